@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -41,6 +43,92 @@ const (
 	CompositionModePipeline CompositionMode = "Pipeline"
 )
 
+// A RolloutStrategy determines how composite resources with an Automatic
+// composition update policy adopt a new CompositionRevision.
+type RolloutStrategy string
+
+const (
+	// RolloutStrategyImmediate indicates that composite resources adopt a
+	// new CompositionRevision as soon as it's created.
+	RolloutStrategyImmediate RolloutStrategy = "Immediate"
+
+	// RolloutStrategyStaged indicates that composite resources stay on their
+	// current CompositionRevision until the new revision is approved, or
+	// until it succeeds for a canary percentage of composite resources.
+	RolloutStrategyStaged RolloutStrategy = "Staged"
+
+	// RolloutStrategyProgressive indicates that composite resources adopt a
+	// new CompositionRevision in waves. Each wave starts once the composite
+	// resources in earlier waves have adopted the revision.
+	RolloutStrategyProgressive RolloutStrategy = "Progressive"
+)
+
+// A RevisionRollout configures how composite resources adopt a new
+// CompositionRevision.
+type RevisionRollout struct {
+	// Strategy determines how composite resources adopt a new
+	// CompositionRevision. Composite resources using the Staged strategy
+	// report what the new revision would change in their status while they
+	// wait to adopt it. Annotate a revision with
+	// crossplane.io/rollout-approved: "true" to approve it.
+	// +optional
+	// +kubebuilder:validation:Enum=Immediate;Staged;Progressive
+	// +kubebuilder:default=Immediate
+	Strategy RolloutStrategy `json:"strategy,omitempty"`
+
+	// CanaryPercentage is the percentage of composite resources that adopt a
+	// staged revision before it's approved. Once every canary composite
+	// resource has adopted the revision and is ready, the revision rolls out
+	// to all composite resources. Composite resources are assigned to the
+	// canary using a hash of their UID.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	CanaryPercentage *int32 `json:"canaryPercentage,omitempty"`
+
+	// Waves of a Progressive rollout, in order. A composite resource belongs
+	// to the first wave that selects it. Composite resources that no wave
+	// selects adopt the revision after the last wave.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Waves []RolloutWave `json:"waves,omitempty"`
+
+	// PauseOnFailure pauses a Progressive rollout while any composite
+	// resource that adopted the revision failed to become ready. A composite
+	// resource fails if it isn't ready for any reason other than that it's
+	// being created, or if it isn't ready for longer than the failure timeout.
+	// A wave doesn't complete until its composite resources are ready.
+	// +optional
+	PauseOnFailure bool `json:"pauseOnFailure,omitempty"`
+
+	// FailureTimeout is how long a composite resource that adopted the
+	// revision may take to become ready before it's considered to have
+	// failed. Defaults to 10m.
+	// +optional
+	FailureTimeout *metav1.Duration `json:"failureTimeout,omitempty"`
+}
+
+// A RolloutWave selects composite resources that adopt a CompositionRevision
+// together during a Progressive rollout.
+type RolloutWave struct {
+	// Name of the wave.
+	Name string `json:"name"`
+
+	// Percentage of composite resources to select. Composite resources are
+	// selected using a hash of their UID, so a wave with a larger percentage
+	// selects every composite resource a wave with a smaller percentage
+	// selects. Use increasing percentages for successive waves.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
+
+	// MatchLabels selects composite resources with these labels.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
 // TypeReference is used to refer to a type for declaring compatibility.
 type TypeReference struct {
 	// APIVersion of the type.
@@ -84,8 +172,68 @@ type PipelineStep struct {
 	// request them first.
 	// +optional
 	Requirements *FunctionRequirements `json:"requirements,omitempty"`
+
+	// Stage groups this step with adjacent steps that don't depend on each
+	// other. Consecutive steps with the same stage run concurrently. Each
+	// step in a stage is sent the desired state and context produced by the
+	// previous stage, and their results are merged in pipeline order. Steps
+	// in a stage may not make different changes to the same desired
+	// resource, or to the same context key. Steps without a stage run one
+	// at a time.
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	Stage string `json:"stage,omitempty"`
+
+	// When is an optional CEL expression that determines whether this step
+	// runs. The step is skipped entirely when the expression evaluates to
+	// false. The expression may refer to the observed and desired state and
+	// pipeline context the step would be sent, as observed, desired, and
+	// context. Fields use their RunFunctionRequest protobuf names, for
+	// example observed.composite.resource.spec.replicas > 1.
+	// +optional
+	When string `json:"when,omitempty"`
+
+	// Timeout for each call to this step's function. Defaults to the time
+	// remaining before the reconcile deadline.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retry configures whether and how calls to this step's function are
+	// retried when they fail. Calls aren't retried by default.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+}
+
+// A RetryPolicy configures how a pipeline step's function call is retried
+// when it fails.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times to call the function,
+	// including the first call. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// Backoff is how long to wait before the first retry. The wait doubles
+	// for each subsequent retry. Defaults to 1s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// MaxBackoff is the longest time to wait between retries. Defaults to
+	// 30s.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// Codes are the gRPC status codes that should be retried. Defaults to
+	// Unavailable, DeadlineExceeded, and ResourceExhausted.
+	// +optional
+	// +listType=set
+	Codes []GRPCCode `json:"codes,omitempty"`
 }
 
+// A GRPCCode is the name of a gRPC status code.
+// +kubebuilder:validation:Enum=Canceled;Unknown;InvalidArgument;DeadlineExceeded;NotFound;AlreadyExists;PermissionDenied;ResourceExhausted;FailedPrecondition;Aborted;OutOfRange;Unimplemented;Internal;Unavailable;DataLoss;Unauthenticated
+type GRPCCode string
+
 // A FunctionReference references a function that may be used in a
 // Composition pipeline.
 type FunctionReference struct {
@@ -96,19 +244,37 @@ type FunctionReference struct {
 // FunctionCredentials are optional credentials that a function
 // needs to run.
 //
-// +kubebuilder:validation:XValidation:rule="self.source == 'Secret' && has(self.secretRef)",message="the Secret source requires a secretRef"
+// +kubebuilder:validation:XValidation:rule="self.source != 'Secret' || has(self.secretRef)",message="the Secret source requires a secretRef"
+// +kubebuilder:validation:XValidation:rule="self.source != 'ServiceAccountToken' || has(self.serviceAccountToken)",message="the ServiceAccountToken source requires a serviceAccountToken"
+// +kubebuilder:validation:XValidation:rule="self.source != 'ConfigMap' || has(self.configMapRef)",message="the ConfigMap source requires a configMapRef"
+// +kubebuilder:validation:XValidation:rule="self.source != 'CompositeSecretRef' || has(self.compositeSecretRef)",message="the CompositeSecretRef source requires a compositeSecretRef"
 type FunctionCredentials struct {
 	// Name of this set of credentials.
 	Name string `json:"name"`
 
 	// Source of the function credentials.
-	// +kubebuilder:validation:Enum=None;Secret
+	// +kubebuilder:validation:Enum=None;Secret;ServiceAccountToken;ConfigMap;CompositeSecretRef
 	Source FunctionCredentialsSource `json:"source"`
 
 	// A SecretRef is a reference to a secret containing credentials that should
 	// be supplied to the function.
 	// +optional
 	SecretRef *xpv1.SecretReference `json:"secretRef,omitempty"`
+
+	// ServiceAccountToken configures a short-lived, projected ServiceAccount
+	// token that should be supplied to the function under the token key.
+	// +optional
+	ServiceAccountToken *ServiceAccountTokenSource `json:"serviceAccountToken,omitempty"`
+
+	// A ConfigMapRef is a reference to a ConfigMap containing credentials that
+	// should be supplied to the function.
+	// +optional
+	ConfigMapRef *ConfigMapReference `json:"configMapRef,omitempty"`
+
+	// A CompositeSecretRef reads a reference to a secret containing
+	// credentials from the composite resource.
+	// +optional
+	CompositeSecretRef *CompositeSecretReference `json:"compositeSecretRef,omitempty"`
 }
 
 // A FunctionCredentialsSource is a source from which function
@@ -123,8 +289,90 @@ const (
 	// FunctionCredentialsSourceSecret indicates that a function should acquire
 	// credentials from a secret.
 	FunctionCredentialsSourceSecret FunctionCredentialsSource = "Secret"
+
+	// FunctionCredentialsSourceServiceAccountToken indicates that a function
+	// should acquire credentials from a short-lived ServiceAccount token. It
+	// requires the --enable-function-service-account-tokens feature flag.
+	FunctionCredentialsSourceServiceAccountToken FunctionCredentialsSource = "ServiceAccountToken"
+
+	// FunctionCredentialsSourceConfigMap indicates that a function should
+	// acquire credentials from a ConfigMap.
+	FunctionCredentialsSourceConfigMap FunctionCredentialsSource = "ConfigMap"
+
+	// FunctionCredentialsSourceCompositeSecretRef indicates that a function
+	// should acquire credentials from a secret referenced by the composite
+	// resource.
+	FunctionCredentialsSourceCompositeSecretRef FunctionCredentialsSource = "CompositeSecretRef"
 )
 
+// A ServiceAccountTokenSource configures a projected ServiceAccount token.
+type ServiceAccountTokenSource struct {
+	// ServiceAccountRef references the ServiceAccount to request a token for.
+	ServiceAccountRef ServiceAccountReference `json:"serviceAccountRef"`
+
+	// Audiences are the intended audiences of the token. At least one is
+	// required. The token is only valid for the Kubernetes API if one of the
+	// audiences is the API server's, which must be requested explicitly.
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	Audiences []string `json:"audiences"`
+
+	// ExpirationSeconds is the requested lifetime of the token. Crossplane
+	// requests a new token before the old one expires.
+	// +optional
+	// +kubebuilder:validation:Minimum=600
+	// +kubebuilder:default=3600
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+
+	// AllowedNamespaces are the namespaces a cluster scoped composite
+	// resource may request a token from, in addition to the namespace of its
+	// claim. A namespaced composite resource may only request a token from
+	// its own namespace.
+	// +optional
+	// +listType=set
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// A ServiceAccountReference references a ServiceAccount.
+type ServiceAccountReference struct {
+	// Name of the ServiceAccount.
+	Name string `json:"name"`
+
+	// Namespace of the ServiceAccount. Defaults to the namespace of the
+	// composite resource, or of its claim if it's cluster scoped.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// A ConfigMapReference references a ConfigMap.
+type ConfigMapReference struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+}
+
+// A CompositeSecretReference reads a reference to a secret from a field of
+// the composite resource.
+type CompositeSecretReference struct {
+	// FieldPath of the composite resource field containing the reference,
+	// for example spec.credentialsRef. The field must be an object with a
+	// name, and optionally a namespace. The namespace defaults to the
+	// namespace of the composite resource, or of its claim if it's cluster
+	// scoped. A namespaced composite resource may only reference secrets in
+	// its own namespace.
+	// +kubebuilder:validation:MinLength=1
+	FieldPath string `json:"fieldPath"`
+
+	// AllowedNamespaces are the namespaces a cluster scoped composite
+	// resource may reference secrets in, in addition to the namespace of its
+	// claim.
+	// +optional
+	// +listType=set
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
 // FunctionRequirements define requirements that a function may need to
 // satisfy.
 type FunctionRequirements struct {
@@ -163,3 +411,129 @@ type RequiredResourceSelector struct {
 	// +optional
 	MatchLabels map[string]string `json:"matchLabels,omitempty"`
 }
+
+// ComposedReadinessChecks determine whether a composed resource is ready.
+type ComposedReadinessChecks struct {
+	// ResourceName is the name of the composed resource in the function
+	// pipeline's desired state.
+	ResourceName string `json:"resourceName"`
+
+	// Checks that must all pass for the composed resource to be ready.
+	// +kubebuilder:validation:MinItems=1
+	Checks []ReadinessCheck `json:"checks"`
+}
+
+// A ReadinessCheckType is a type of readiness check.
+type ReadinessCheckType string
+
+// Readiness check types.
+const (
+	// ReadinessCheckTypeNonEmpty passes if the field at FieldPath is set and
+	// isn't empty.
+	ReadinessCheckTypeNonEmpty ReadinessCheckType = "NonEmpty"
+
+	// ReadinessCheckTypeMatchString passes if the field at FieldPath is the
+	// string MatchString.
+	ReadinessCheckTypeMatchString ReadinessCheckType = "MatchString"
+
+	// ReadinessCheckTypeMatchInteger passes if the field at FieldPath is the
+	// integer MatchInteger.
+	ReadinessCheckTypeMatchInteger ReadinessCheckType = "MatchInteger"
+
+	// ReadinessCheckTypeMatchTrue passes if the field at FieldPath is true.
+	ReadinessCheckTypeMatchTrue ReadinessCheckType = "MatchTrue"
+
+	// ReadinessCheckTypeMatchFalse passes if the field at FieldPath is false.
+	ReadinessCheckTypeMatchFalse ReadinessCheckType = "MatchFalse"
+
+	// ReadinessCheckTypeMatchCondition passes if the composed resource has
+	// the status condition MatchCondition.
+	ReadinessCheckTypeMatchCondition ReadinessCheckType = "MatchCondition"
+
+	// ReadinessCheckTypeExpression passes if the CEL Expression evaluates to
+	// true.
+	ReadinessCheckTypeExpression ReadinessCheckType = "Expression"
+)
+
+// A ReadinessCheck determines whether a composed resource is ready.
+//
+// +kubebuilder:validation:XValidation:rule="!(self.type in ['NonEmpty', 'MatchString', 'MatchInteger', 'MatchTrue', 'MatchFalse']) || has(self.fieldPath)",message="fieldPath is required for this type of readiness check"
+// +kubebuilder:validation:XValidation:rule="self.type != 'MatchString' || has(self.matchString)",message="matchString is required for MatchString readiness checks"
+// +kubebuilder:validation:XValidation:rule="self.type != 'MatchInteger' || has(self.matchInteger)",message="matchInteger is required for MatchInteger readiness checks"
+// +kubebuilder:validation:XValidation:rule="self.type != 'Expression' || has(self.expression)",message="expression is required for Expression readiness checks"
+type ReadinessCheck struct {
+	// Type of readiness check.
+	// +kubebuilder:validation:Enum=NonEmpty;MatchString;MatchInteger;MatchTrue;MatchFalse;MatchCondition;Expression
+	Type ReadinessCheckType `json:"type"`
+
+	// FieldPath of the composed resource field to check.
+	// +optional
+	FieldPath *string `json:"fieldPath,omitempty"`
+
+	// MatchString is the string the field must match.
+	// +optional
+	MatchString *string `json:"matchString,omitempty"`
+
+	// MatchInteger is the integer the field must match.
+	// +optional
+	MatchInteger *int64 `json:"matchInteger,omitempty"`
+
+	// MatchCondition is the status condition the composed resource must
+	// have. Defaults to Ready: True.
+	// +optional
+	MatchCondition *MatchConditionReadinessCheck `json:"matchCondition,omitempty"`
+
+	// Expression is a CEL expression that must evaluate to true. It may
+	// refer to the observed composed resource as resource, for example
+	// "resource.status.atProvider.state == 'available'".
+	// +optional
+	Expression *string `json:"expression,omitempty"`
+}
+
+// MatchConditionReadinessCheck is a status condition a composed resource
+// must have to be ready.
+type MatchConditionReadinessCheck struct {
+	// Type of the status condition.
+	// +kubebuilder:default="Ready"
+	Type xpv1.ConditionType `json:"type"`
+
+	// Status of the status condition.
+	// +kubebuilder:default="True"
+	Status corev1.ConditionStatus `json:"status"`
+}
+
+// A ComposedDeletePolicy determines what happens to a composed resource when
+// it's no longer composed.
+type ComposedDeletePolicy string
+
+// Composed resource delete policies.
+const (
+	// ComposedDeletePolicyDelete deletes the composed resource when it's
+	// removed from the desired state, or when its composite resource is
+	// deleted. This is the default.
+	ComposedDeletePolicyDelete ComposedDeletePolicy = "Delete"
+
+	// ComposedDeletePolicyOrphan orphans the composed resource when its
+	// composite resource is deleted, but deletes it when it's removed from
+	// the desired state.
+	ComposedDeletePolicyOrphan ComposedDeletePolicy = "Orphan"
+
+	// ComposedDeletePolicyRetain orphans the composed resource when it's
+	// removed from the desired state, or when its composite resource is
+	// deleted.
+	ComposedDeletePolicyRetain ComposedDeletePolicy = "Retain"
+)
+
+// A ComposedResourceDeletePolicy determines what happens to a composed
+// resource when it's no longer composed.
+type ComposedResourceDeletePolicy struct {
+	// ResourceName is the name of the composed resource in the function
+	// pipeline's desired state.
+	ResourceName string `json:"resourceName"`
+
+	// Policy determines what happens to the composed resource when it's no
+	// longer composed. Orphaned composed resources aren't deleted; Crossplane
+	// removes the composite resource's owner reference from them instead.
+	// +kubebuilder:validation:Enum=Delete;Orphan;Retain
+	Policy ComposedDeletePolicy `json:"policy"`
+}
@@ -31,6 +31,12 @@ const (
 	// and spec used to create this CompositionRevision. Used to identify
 	// identical revisions.
 	LabelCompositionHash = "crossplane.io/composition-hash"
+
+	// AnnotationKeyRolloutApproved approves a CompositionRevision for rollout
+	// to all composite resources when its Composition uses the Staged or
+	// Progressive rollout strategy. Crossplane approves a revision itself once
+	// its canary succeeds, or once its Progressive rollout is complete.
+	AnnotationKeyRolloutApproved = "crossplane.io/rollout-approved"
 )
 
 // CompositionRevisionSpec specifies the desired state of the composition
@@ -68,6 +74,32 @@ type CompositionRevisionSpec struct {
 	// +optional
 	WriteConnectionSecretsToNamespace *string `json:"writeConnectionSecretsToNamespace,omitempty"`
 
+	// Rollout configures how composite resources with an Automatic
+	// composition update policy adopt new revisions of this composition.
+	// +optional
+	Rollout *RevisionRollout `json:"rollout,omitempty"`
+
+	// ReadinessChecks determine whether composed resources are ready. They're
+	// evaluated against each observed composed resource after it's applied,
+	// unless the function pipeline explicitly set its readiness. This is an
+	// alpha feature; it's ignored unless composition readiness checks are
+	// enabled.
+	// +optional
+	// +listType=map
+	// +listMapKey=resourceName
+	ReadinessChecks []ComposedReadinessChecks `json:"readinessChecks,omitempty"`
+
+	// DeletePolicies determine what happens to composed resources when
+	// they're removed from the desired state, or when their composite
+	// resource is deleted. A function may override the policy of a composed
+	// resource using the crossplane.io/composed-delete-policy annotation.
+	// This is an alpha feature; it's ignored unless composed resource delete
+	// policies are enabled.
+	// +optional
+	// +listType=map
+	// +listMapKey=resourceName
+	DeletePolicies []ComposedResourceDeletePolicy `json:"deletePolicies,omitempty"`
+
 	// Revision number. Newer revisions have larger numbers.
 	//
 	// This number can change. When a Composition transitions from state A
@@ -58,6 +58,114 @@ type CompositionSpec struct {
 	// this composition will be created.
 	// +optional
 	WriteConnectionSecretsToNamespace *string `json:"writeConnectionSecretsToNamespace,omitempty"`
+
+	// Rollout configures how composite resources with an Automatic
+	// composition update policy adopt new revisions of this composition.
+	// +optional
+	Rollout *RevisionRollout `json:"rollout,omitempty"`
+
+	// ReadinessChecks determine whether composed resources are ready. They're
+	// evaluated against each observed composed resource after it's applied,
+	// unless the function pipeline explicitly set its readiness. This is an
+	// alpha feature; it's ignored unless composition readiness checks are
+	// enabled.
+	// +optional
+	// +listType=map
+	// +listMapKey=resourceName
+	ReadinessChecks []ComposedReadinessChecks `json:"readinessChecks,omitempty"`
+
+	// DeletePolicies determine what happens to composed resources when
+	// they're removed from the desired state, or when their composite
+	// resource is deleted. A function may override the policy of a composed
+	// resource using the crossplane.io/composed-delete-policy annotation.
+	// This is an alpha feature; it's ignored unless composed resource delete
+	// policies are enabled.
+	// +optional
+	// +listType=map
+	// +listMapKey=resourceName
+	DeletePolicies []ComposedResourceDeletePolicy `json:"deletePolicies,omitempty"`
+
+	// Selection configures when this composition is selected for composite
+	// resources that don't reference a composition. This is an alpha
+	// feature; it's ignored unless composition selection policies are
+	// enabled.
+	// +optional
+	Selection *CompositionSelectionPolicy `json:"selection,omitempty"`
+}
+
+// A CompositionSelectionPolicy configures when a composition is selected for
+// composite resources that don't reference a composition.
+type CompositionSelectionPolicy struct {
+	// Expression is a CEL expression that must evaluate to true for this
+	// composition to be selected. It may refer to the composite resource's
+	// spec, and to its name, namespace, and labels under metadata, for
+	// example "spec.region.startsWith('eu-')" or
+	// "metadata.labels['tier'] == 'gold'". A
+	// composition without an expression may be selected for any composite
+	// resource.
+	// +optional
+	Expression string `json:"expression,omitempty"`
+
+	// Weight of this composition when one is selected at random from all
+	// compositions that match a composite resource. A composition with a
+	// weight of 0 is never selected. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// A RolloutPhase is the phase of a Progressive rollout.
+type RolloutPhase string
+
+// Rollout phases.
+const (
+	// RolloutPhaseProgressing indicates composite resources are adopting the
+	// revision.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+
+	// RolloutPhasePaused indicates the rollout is paused because a composite
+	// resource that adopted the revision failed to become ready.
+	RolloutPhasePaused RolloutPhase = "Paused"
+
+	// RolloutPhaseComplete indicates every composite resource has adopted
+	// the revision.
+	RolloutPhaseComplete RolloutPhase = "Complete"
+)
+
+// A RolloutStatus reports the progress of a Progressive rollout.
+type RolloutStatus struct {
+	// Revision being rolled out.
+	Revision string `json:"revision"`
+
+	// Phase of the rollout.
+	Phase RolloutPhase `json:"phase"`
+
+	// CurrentWave is the name of the wave that's rolling out. It's empty
+	// once the composite resources no wave selects are rolling out.
+	// +optional
+	CurrentWave string `json:"currentWave,omitempty"`
+
+	// CompletedWaves is the number of waves that have completed.
+	CompletedWaves int32 `json:"completedWaves"`
+
+	// Total number of composite resources using the Composition.
+	Total int32 `json:"total"`
+
+	// Updated is the number of composite resources that have adopted the
+	// revision.
+	Updated int32 `json:"updated"`
+
+	// Ready is the number of composite resources that have adopted the
+	// revision and are ready.
+	Ready int32 `json:"ready"`
+}
+
+// CompositionStatus is the observed state of a Composition.
+type CompositionStatus struct {
+	// Rollout reports the progress of a Progressive rollout of the latest
+	// revision of this Composition.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -73,12 +181,14 @@ type CompositionSpec struct {
 // +kubebuilder:printcolumn:name="XR-KIND",type="string",JSONPath=".spec.compositeTypeRef.kind"
 // +kubebuilder:printcolumn:name="XR-APIVERSION",type="string",JSONPath=".spec.compositeTypeRef.apiVersion"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories=crossplane,shortName=comp
 type Composition struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec CompositionSpec `json:"spec,omitempty"`
+	Spec   CompositionSpec   `json:"spec,omitempty"`
+	Status CompositionStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -36,6 +36,10 @@ const (
 	// A TypeValidPipeline CompositionRevision has a valid function
 	// pipeline.
 	TypeValidPipeline xpv1.ConditionType = "ValidPipeline"
+
+	// A TypeStorageVersionMigrated XRD's composite resources and claims are
+	// all stored at its referenceable version.
+	TypeStorageVersionMigrated xpv1.ConditionType = "StorageVersionMigrated"
 )
 
 // Reasons a resource is or is not established or offered.
@@ -48,6 +52,10 @@ const (
 
 	ReasonValidPipeline       xpv1.ConditionReason = "ValidPipeline"
 	ReasonMissingCapabilities xpv1.ConditionReason = "MissingCapabilities"
+
+	ReasonStorageVersionMigrated  xpv1.ConditionReason = "StorageVersionMigrated"
+	ReasonMigratingStorageVersion xpv1.ConditionReason = "MigratingStorageVersion"
+	ReasonMigrationFailed         xpv1.ConditionReason = "MigrationFailed"
 )
 
 // WatchingComposite indicates that Crossplane has defined and is watching for a
@@ -116,3 +124,38 @@ func MissingCapabilities(message string) xpv1.Condition {
 		Message:            message,
 	}
 }
+
+// StorageVersionMigrated indicates that all of an XRD's composite resources
+// and claims are stored at its referenceable version.
+func StorageVersionMigrated() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeStorageVersionMigrated,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonStorageVersionMigrated,
+	}
+}
+
+// MigratingStorageVersion indicates that Crossplane is migrating an XRD's
+// composite resources and claims to its referenceable version.
+func MigratingStorageVersion(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeStorageVersionMigrated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMigratingStorageVersion,
+		Message:            message,
+	}
+}
+
+// MigrationFailed indicates that Crossplane couldn't migrate an XRD's
+// composite resources and claims to its referenceable version.
+func MigrationFailed(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeStorageVersionMigrated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMigrationFailed,
+		Message:            err.Error(),
+	}
+}
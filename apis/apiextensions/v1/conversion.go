@@ -33,6 +33,7 @@ import (
 type RevisionSpecConverter interface {
 	// goverter:ignore Revision
 	ToRevisionSpec(in CompositionSpec) CompositionRevisionSpec
+	// goverter:ignore Selection
 	FromRevisionSpec(in CompositionRevisionSpec) CompositionSpec
 }
 
@@ -38,6 +38,9 @@ const (
 // CompositeResourceDefinitionSpec specifies the desired state of the definition.
 // +kubebuilder:validation:XValidation:rule="self.scope == 'LegacyCluster' || !has(self.claimNames)",message="Only LegacyCluster composite resources can offer claims"
 // +kubebuilder:validation:XValidation:rule="self.scope == 'LegacyCluster' || !has(self.connectionSecretKeys)",message="Only LegacyCluster composite resources support connection secrets"
+// +kubebuilder:validation:XValidation:rule="has(self.claimNames) || !has(self.claimFieldMappings)",message="Only composite resources that offer claims can map claim fields"
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.claimFieldMappings) == has(self.claimFieldMappings)",message="Claim field mappings can't be added or removed"
+// +kubebuilder:validation:XValidation:rule="has(self.claimNames) || !has(self.claimPolicy)",message="Only composite resources that offer claims can have a claim policy"
 type CompositeResourceDefinitionSpec struct {
 	// Group specifies the API group of the defined composite resource.
 	// Composite resources are served under `/apis/<group>/...`. Must match the
@@ -77,6 +80,27 @@ type CompositeResourceDefinitionSpec struct {
 	// +kubebuilder:validation:XValidation:rule="!has(self.singular) || self.singular == self.singular.lowerAscii()",message="Singular name must be lowercase"
 	ClaimNames *extv1.CustomResourceDefinitionNames `json:"claimNames,omitempty"`
 
+	// ClaimFieldMappings customize which fields Crossplane propagates between
+	// a claim and its composite resource. By default Crossplane propagates the
+	// claim's entire spec to the composite resource, and the composite
+	// resource's entire status to the claim. Mappings don't change the
+	// schema of the claim or the composite resource. Both use the schema of
+	// each version, so it must define the fields of both, and fields only
+	// one of them sets mustn't be required. Claim field mappings can't be
+	// added, changed, or removed once the CompositeResourceDefinition is
+	// created. This is an alpha feature; it's ignored unless claim field
+	// mappings are enabled.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	ClaimFieldMappings *ClaimFieldMappings `json:"claimFieldMappings,omitempty"`
+
+	// ClaimPolicy restricts how each namespace may use claims. Crossplane
+	// won't create or update the composite resource of a claim that violates
+	// the policy. This is an alpha feature; it's ignored unless claim policies
+	// are enabled.
+	// +optional
+	ClaimPolicy *ClaimPolicy `json:"claimPolicy,omitempty"`
+
 	// ConnectionSecretKeys is the list of connection secret keys the
 	// defined XR can publish. If the list is empty, all keys will be
 	// published. If the list isn't empty, any connection secret keys that
@@ -126,6 +150,33 @@ type CompositeResourceDefinitionSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self.strategy == 'Webhook' && has(self.webhook)",message="Webhook configuration is required when conversion strategy is Webhook"
 	Conversion *extv1.CustomResourceConversion `json:"conversion,omitempty"`
 
+	// Converter configures a conversion webhook that Crossplane serves to
+	// convert the defined composite resource (and claim) between versions.
+	// Crossplane configures the generated CRDs to use this webhook, ignoring
+	// Conversion. This is an alpha feature; it's ignored unless composite
+	// resource conversion is enabled.
+	// +optional
+	Converter *CompositeResourceConverter `json:"converter,omitempty"`
+
+	// ValidationRules are CEL validation rules Crossplane adds to the root of
+	// the schema of every version of the defined composite resource. Unlike
+	// rules in a version's schema they may reference fields that Crossplane
+	// injects into the schema, like spec.crossplane.compositionRef. This is
+	// an alpha feature; it's ignored unless XR validation rules are enabled.
+	// +optional
+	ValidationRules []extv1.ValidationRule `json:"validationRules,omitempty"`
+
+	// ImmutableFields are paths of fields, like spec.parameters.region, that
+	// can't be changed or removed once they're set. Crossplane translates them
+	// into CEL transition rules in the schema of every version of the defined
+	// composite resource (and claim) that defines the field. Fields within
+	// arrays aren't supported, so paths may only select object fields, for
+	// example spec.parameters[example.org/zone]. This is an alpha feature;
+	// it's ignored unless XR validation rules are enabled.
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z_][a-zA-Z0-9_-]*(\.[a-zA-Z_][a-zA-Z0-9_-]*|\[[a-zA-Z_./-][a-zA-Z0-9_./-]*\])*$`
+	ImmutableFields []string `json:"immutableFields,omitempty"`
+
 	// Metadata specifies the desired metadata for the defined composite resource and claim CRD's.
 	// +optional
 	Metadata *CompositeResourceDefinitionSpecMetadata `json:"metadata,omitempty"`
@@ -137,6 +188,108 @@ type CompositionReference struct {
 	Name string `json:"name"`
 }
 
+// ClaimFieldMappings customize which fields Crossplane propagates between a
+// claim and its composite resource. A claim and its composite resource share
+// one schema, so mapping a claim's spec.size to a composite resource's
+// spec.parameters.size requires the schema to define both fields as optional.
+type ClaimFieldMappings struct {
+	// Spec mappings copy fields from the claim to the composite resource.
+	// FromFieldPath is a field of the claim, for example metadata.namespace.
+	// ToFieldPath must be a field of the composite resource's spec. When spec
+	// mappings are set Crossplane only propagates mapped fields and the fields
+	// it uses to select a Composition, and no longer propagates the composite
+	// resource's spec back to the claim.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.all(m, m.toFieldPath.startsWith('spec.'))",message="Spec mappings must map to a field of the composite resource's spec"
+	Spec []ClaimFieldMapping `json:"spec,omitempty"`
+
+	// Status mappings copy fields from the composite resource to the claim.
+	// FromFieldPath is a field of the composite resource. ToFieldPath must be
+	// a field of the claim's status. When status mappings are set Crossplane
+	// only propagates mapped status fields.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.all(m, m.toFieldPath.startsWith('status.'))",message="Status mappings must map to a field of the claim's status"
+	Status []ClaimFieldMapping `json:"status,omitempty"`
+}
+
+// A ClaimPolicy restricts how each namespace may use claims.
+type ClaimPolicy struct {
+	// MaxPerNamespace is the maximum number of claims each namespace may bind
+	// to composite resources. Claims over the limit aren't bound until another
+	// claim in the namespace is deleted. The limit is best-effort. Crossplane
+	// counts a namespace's bound claims from its cache, so claims that are
+	// created at the same time may briefly exceed it.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxPerNamespace *int64 `json:"maxPerNamespace,omitempty"`
+
+	// CompositionSelector restricts which Compositions claims may use. A claim
+	// that references a Composition must reference one whose labels match the
+	// selector. A claim that selects Compositions by label may only select
+	// Compositions whose labels match the selector. A claim that neither
+	// references nor selects a Composition is denied unless the default
+	// Composition matches the selector. The enforced Composition, if any, must
+	// always match the selector.
+	// +optional
+	CompositionSelector *metav1.LabelSelector `json:"compositionSelector,omitempty"`
+
+	// RequiredLabels are the keys of labels every claim must have.
+	// +optional
+	RequiredLabels []string `json:"requiredLabels,omitempty"`
+}
+
+// A ClaimFieldMapping copies a field between a claim and its composite
+// resource.
+type ClaimFieldMapping struct {
+	// FromFieldPath is the path of the field to copy.
+	FromFieldPath string `json:"fromFieldPath"`
+
+	// ToFieldPath is the path the field is copied to.
+	ToFieldPath string `json:"toFieldPath"`
+}
+
+// A CompositeResourceConverter configures how Crossplane converts composite
+// resources and claims between versions.
+type CompositeResourceConverter struct {
+	// FieldMappings move fields between versions. Crossplane copies fields
+	// that aren't mapped unchanged.
+	// +optional
+	FieldMappings []ConversionFieldMapping `json:"fieldMappings,omitempty"`
+
+	// FunctionRef references a function that converts composite resources and
+	// claims. Crossplane calls the function after applying any field mappings.
+	// The function is sent the resource to convert as its observed composite
+	// resource, and the result of the field mappings as its desired composite
+	// resource. It must return the converted resource as its desired
+	// composite resource.
+	// +optional
+	FunctionRef *ConversionFunctionReference `json:"functionRef,omitempty"`
+}
+
+// A ConversionFieldMapping moves a field when converting between two
+// versions. Mappings apply in both directions; converting from ToVersion to
+// FromVersion moves the field from ToFieldPath to FromFieldPath.
+type ConversionFieldMapping struct {
+	// FromVersion is the version to convert from.
+	FromVersion string `json:"fromVersion"`
+
+	// FromFieldPath is the path of the field to move in FromVersion.
+	FromFieldPath string `json:"fromFieldPath"`
+
+	// ToVersion is the version to convert to.
+	ToVersion string `json:"toVersion"`
+
+	// ToFieldPath is the path the field is moved to in ToVersion.
+	ToFieldPath string `json:"toFieldPath"`
+}
+
+// A ConversionFunctionReference references a function that converts
+// composite resources and claims.
+type ConversionFunctionReference struct {
+	// Name of the referenced Function.
+	Name string `json:"name"`
+}
+
 // CompositeResourceDefinitionSpecMetadata specifies the desired metadata of the defined composite resource and claim CRD's.
 type CompositeResourceDefinitionSpecMetadata struct {
 	// Map of string keys and values that can be used to organize and categorize
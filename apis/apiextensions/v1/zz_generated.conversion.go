@@ -5,6 +5,7 @@ package v1
 
 import (
 	common "github.com/crossplane/crossplane-runtime/v2/apis/common"
+	v11 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -24,6 +25,19 @@ func (c *GeneratedRevisionSpecConverter) FromRevisionSpec(source CompositionRevi
 		xstring := *source.WriteConnectionSecretsToNamespace
 		v1CompositionSpec.WriteConnectionSecretsToNamespace = &xstring
 	}
+	v1CompositionSpec.Rollout = c.pV1RevisionRolloutToPV1RevisionRollout(source.Rollout)
+	if source.ReadinessChecks != nil {
+		v1CompositionSpec.ReadinessChecks = make([]ComposedReadinessChecks, len(source.ReadinessChecks))
+		for i := 0; i < len(source.ReadinessChecks); i++ {
+			v1CompositionSpec.ReadinessChecks[i] = c.v1ComposedReadinessChecksToV1ComposedReadinessChecks(source.ReadinessChecks[i])
+		}
+	}
+	if source.DeletePolicies != nil {
+		v1CompositionSpec.DeletePolicies = make([]ComposedResourceDeletePolicy, len(source.DeletePolicies))
+		for i := 0; i < len(source.DeletePolicies); i++ {
+			v1CompositionSpec.DeletePolicies[i] = c.v1ComposedResourceDeletePolicyToV1ComposedResourceDeletePolicy(source.DeletePolicies[i])
+		}
+	}
 	return v1CompositionSpec
 }
 func (c *GeneratedRevisionSpecConverter) ToRevisionSpec(source CompositionSpec) CompositionRevisionSpec {
@@ -40,6 +54,19 @@ func (c *GeneratedRevisionSpecConverter) ToRevisionSpec(source CompositionSpec)
 		xstring := *source.WriteConnectionSecretsToNamespace
 		v1CompositionRevisionSpec.WriteConnectionSecretsToNamespace = &xstring
 	}
+	v1CompositionRevisionSpec.Rollout = c.pV1RevisionRolloutToPV1RevisionRollout(source.Rollout)
+	if source.ReadinessChecks != nil {
+		v1CompositionRevisionSpec.ReadinessChecks = make([]ComposedReadinessChecks, len(source.ReadinessChecks))
+		for i := 0; i < len(source.ReadinessChecks); i++ {
+			v1CompositionRevisionSpec.ReadinessChecks[i] = c.v1ComposedReadinessChecksToV1ComposedReadinessChecks(source.ReadinessChecks[i])
+		}
+	}
+	if source.DeletePolicies != nil {
+		v1CompositionRevisionSpec.DeletePolicies = make([]ComposedResourceDeletePolicy, len(source.DeletePolicies))
+		for i := 0; i < len(source.DeletePolicies); i++ {
+			v1CompositionRevisionSpec.DeletePolicies[i] = c.v1ComposedResourceDeletePolicyToV1ComposedResourceDeletePolicy(source.DeletePolicies[i])
+		}
+	}
 	return v1CompositionRevisionSpec
 }
 func (c *GeneratedRevisionSpecConverter) commonSecretReferenceToCommonSecretReference(source common.SecretReference) common.SecretReference {
@@ -56,6 +83,31 @@ func (c *GeneratedRevisionSpecConverter) pCommonSecretReferenceToPCommonSecretRe
 	}
 	return pCommonSecretReference
 }
+func (c *GeneratedRevisionSpecConverter) pV1CompositeSecretReferenceToPV1CompositeSecretReference(source *CompositeSecretReference) *CompositeSecretReference {
+	var pV1CompositeSecretReference *CompositeSecretReference
+	if source != nil {
+		var v1CompositeSecretReference CompositeSecretReference
+		v1CompositeSecretReference.FieldPath = (*source).FieldPath
+		if (*source).AllowedNamespaces != nil {
+			v1CompositeSecretReference.AllowedNamespaces = make([]string, len((*source).AllowedNamespaces))
+			for i := 0; i < len((*source).AllowedNamespaces); i++ {
+				v1CompositeSecretReference.AllowedNamespaces[i] = (*source).AllowedNamespaces[i]
+			}
+		}
+		pV1CompositeSecretReference = &v1CompositeSecretReference
+	}
+	return pV1CompositeSecretReference
+}
+func (c *GeneratedRevisionSpecConverter) pV1ConfigMapReferenceToPV1ConfigMapReference(source *ConfigMapReference) *ConfigMapReference {
+	var pV1ConfigMapReference *ConfigMapReference
+	if source != nil {
+		var v1ConfigMapReference ConfigMapReference
+		v1ConfigMapReference.Name = (*source).Name
+		v1ConfigMapReference.Namespace = (*source).Namespace
+		pV1ConfigMapReference = &v1ConfigMapReference
+	}
+	return pV1ConfigMapReference
+}
 func (c *GeneratedRevisionSpecConverter) pRuntimeRawExtensionToPRuntimeRawExtension(source *runtime.RawExtension) *runtime.RawExtension {
 	var pRuntimeRawExtension *runtime.RawExtension
 	if source != nil {
@@ -64,6 +116,15 @@ func (c *GeneratedRevisionSpecConverter) pRuntimeRawExtensionToPRuntimeRawExtens
 	}
 	return pRuntimeRawExtension
 }
+func (c *GeneratedRevisionSpecConverter) pV1DurationToPV1Duration(source *v11.Duration) *v11.Duration {
+	var pV1Duration *v11.Duration
+	if source != nil {
+		var v1Duration v11.Duration
+		v1Duration.Duration = (*source).Duration
+		pV1Duration = &v1Duration
+	}
+	return pV1Duration
+}
 func (c *GeneratedRevisionSpecConverter) pV1FunctionRequirementsToPV1FunctionRequirements(source *FunctionRequirements) *FunctionRequirements {
 	var pV1FunctionRequirements *FunctionRequirements
 	if source != nil {
@@ -78,6 +139,112 @@ func (c *GeneratedRevisionSpecConverter) pV1FunctionRequirementsToPV1FunctionReq
 	}
 	return pV1FunctionRequirements
 }
+func (c *GeneratedRevisionSpecConverter) pV1MatchConditionReadinessCheckToPV1MatchConditionReadinessCheck(source *MatchConditionReadinessCheck) *MatchConditionReadinessCheck {
+	var pV1MatchConditionReadinessCheck *MatchConditionReadinessCheck
+	if source != nil {
+		var v1MatchConditionReadinessCheck MatchConditionReadinessCheck
+		v1MatchConditionReadinessCheck.Type = (*source).Type
+		v1MatchConditionReadinessCheck.Status = (*source).Status
+		pV1MatchConditionReadinessCheck = &v1MatchConditionReadinessCheck
+	}
+	return pV1MatchConditionReadinessCheck
+}
+func (c *GeneratedRevisionSpecConverter) pV1RetryPolicyToPV1RetryPolicy(source *RetryPolicy) *RetryPolicy {
+	var pV1RetryPolicy *RetryPolicy
+	if source != nil {
+		var v1RetryPolicy RetryPolicy
+		if (*source).MaxAttempts != nil {
+			xint32 := *(*source).MaxAttempts
+			v1RetryPolicy.MaxAttempts = &xint32
+		}
+		v1RetryPolicy.Backoff = c.pV1DurationToPV1Duration((*source).Backoff)
+		v1RetryPolicy.MaxBackoff = c.pV1DurationToPV1Duration((*source).MaxBackoff)
+		if (*source).Codes != nil {
+			v1RetryPolicy.Codes = make([]GRPCCode, len((*source).Codes))
+			for i := 0; i < len((*source).Codes); i++ {
+				v1RetryPolicy.Codes[i] = GRPCCode((*source).Codes[i])
+			}
+		}
+		pV1RetryPolicy = &v1RetryPolicy
+	}
+	return pV1RetryPolicy
+}
+func (c *GeneratedRevisionSpecConverter) pV1RevisionRolloutToPV1RevisionRollout(source *RevisionRollout) *RevisionRollout {
+	var pV1RevisionRollout *RevisionRollout
+	if source != nil {
+		var v1RevisionRollout RevisionRollout
+		v1RevisionRollout.Strategy = c.v1RolloutStrategyToV1RolloutStrategy((*source).Strategy)
+		if (*source).CanaryPercentage != nil {
+			xint32 := *(*source).CanaryPercentage
+			v1RevisionRollout.CanaryPercentage = &xint32
+		}
+		if (*source).Waves != nil {
+			v1RevisionRollout.Waves = make([]RolloutWave, len((*source).Waves))
+			for i := 0; i < len((*source).Waves); i++ {
+				v1RevisionRollout.Waves[i] = c.v1RolloutWaveToV1RolloutWave((*source).Waves[i])
+			}
+		}
+		v1RevisionRollout.PauseOnFailure = (*source).PauseOnFailure
+		v1RevisionRollout.FailureTimeout = c.pV1DurationToPV1Duration((*source).FailureTimeout)
+		pV1RevisionRollout = &v1RevisionRollout
+	}
+	return pV1RevisionRollout
+}
+func (c *GeneratedRevisionSpecConverter) pV1ServiceAccountTokenSourceToPV1ServiceAccountTokenSource(source *ServiceAccountTokenSource) *ServiceAccountTokenSource {
+	var pV1ServiceAccountTokenSource *ServiceAccountTokenSource
+	if source != nil {
+		var v1ServiceAccountTokenSource ServiceAccountTokenSource
+		v1ServiceAccountTokenSource.ServiceAccountRef = c.v1ServiceAccountReferenceToV1ServiceAccountReference((*source).ServiceAccountRef)
+		if (*source).Audiences != nil {
+			v1ServiceAccountTokenSource.Audiences = make([]string, len((*source).Audiences))
+			for i := 0; i < len((*source).Audiences); i++ {
+				v1ServiceAccountTokenSource.Audiences[i] = (*source).Audiences[i]
+			}
+		}
+		if (*source).ExpirationSeconds != nil {
+			xint64 := *(*source).ExpirationSeconds
+			v1ServiceAccountTokenSource.ExpirationSeconds = &xint64
+		}
+		if (*source).AllowedNamespaces != nil {
+			v1ServiceAccountTokenSource.AllowedNamespaces = make([]string, len((*source).AllowedNamespaces))
+			for i := 0; i < len((*source).AllowedNamespaces); i++ {
+				v1ServiceAccountTokenSource.AllowedNamespaces[i] = (*source).AllowedNamespaces[i]
+			}
+		}
+		pV1ServiceAccountTokenSource = &v1ServiceAccountTokenSource
+	}
+	return pV1ServiceAccountTokenSource
+}
+func (c *GeneratedRevisionSpecConverter) v1ComposedReadinessChecksToV1ComposedReadinessChecks(source ComposedReadinessChecks) ComposedReadinessChecks {
+	var v1ComposedReadinessChecks ComposedReadinessChecks
+	v1ComposedReadinessChecks.ResourceName = source.ResourceName
+	if source.Checks != nil {
+		v1ComposedReadinessChecks.Checks = make([]ReadinessCheck, len(source.Checks))
+		for i := 0; i < len(source.Checks); i++ {
+			v1ComposedReadinessChecks.Checks[i] = c.v1ReadinessCheckToV1ReadinessCheck(source.Checks[i])
+		}
+	}
+	return v1ComposedReadinessChecks
+}
+func (c *GeneratedRevisionSpecConverter) v1ComposedDeletePolicyToV1ComposedDeletePolicy(source ComposedDeletePolicy) ComposedDeletePolicy {
+	var v1ComposedDeletePolicy ComposedDeletePolicy
+	switch source {
+	case ComposedDeletePolicyDelete:
+		v1ComposedDeletePolicy = ComposedDeletePolicyDelete
+	case ComposedDeletePolicyOrphan:
+		v1ComposedDeletePolicy = ComposedDeletePolicyOrphan
+	case ComposedDeletePolicyRetain:
+		v1ComposedDeletePolicy = ComposedDeletePolicyRetain
+	default: // ignored
+	}
+	return v1ComposedDeletePolicy
+}
+func (c *GeneratedRevisionSpecConverter) v1ComposedResourceDeletePolicyToV1ComposedResourceDeletePolicy(source ComposedResourceDeletePolicy) ComposedResourceDeletePolicy {
+	var v1ComposedResourceDeletePolicy ComposedResourceDeletePolicy
+	v1ComposedResourceDeletePolicy.ResourceName = source.ResourceName
+	v1ComposedResourceDeletePolicy.Policy = c.v1ComposedDeletePolicyToV1ComposedDeletePolicy(source.Policy)
+	return v1ComposedResourceDeletePolicy
+}
 func (c *GeneratedRevisionSpecConverter) v1CompositionModeToV1CompositionMode(source CompositionMode) CompositionMode {
 	var v1CompositionMode CompositionMode
 	switch source {
@@ -94,6 +261,12 @@ func (c *GeneratedRevisionSpecConverter) v1FunctionCredentialsSourceToV1Function
 		v1FunctionCredentialsSource = FunctionCredentialsSourceNone
 	case FunctionCredentialsSourceSecret:
 		v1FunctionCredentialsSource = FunctionCredentialsSourceSecret
+	case FunctionCredentialsSourceServiceAccountToken:
+		v1FunctionCredentialsSource = FunctionCredentialsSourceServiceAccountToken
+	case FunctionCredentialsSourceConfigMap:
+		v1FunctionCredentialsSource = FunctionCredentialsSourceConfigMap
+	case FunctionCredentialsSourceCompositeSecretRef:
+		v1FunctionCredentialsSource = FunctionCredentialsSourceCompositeSecretRef
 	default: // ignored
 	}
 	return v1FunctionCredentialsSource
@@ -103,6 +276,9 @@ func (c *GeneratedRevisionSpecConverter) v1FunctionCredentialsToV1FunctionCreden
 	v1FunctionCredentials.Name = source.Name
 	v1FunctionCredentials.Source = c.v1FunctionCredentialsSourceToV1FunctionCredentialsSource(source.Source)
 	v1FunctionCredentials.SecretRef = c.pCommonSecretReferenceToPCommonSecretReference(source.SecretRef)
+	v1FunctionCredentials.ServiceAccountToken = c.pV1ServiceAccountTokenSourceToPV1ServiceAccountTokenSource(source.ServiceAccountToken)
+	v1FunctionCredentials.ConfigMapRef = c.pV1ConfigMapReferenceToPV1ConfigMapReference(source.ConfigMapRef)
+	v1FunctionCredentials.CompositeSecretRef = c.pV1CompositeSecretReferenceToPV1CompositeSecretReference(source.CompositeSecretRef)
 	return v1FunctionCredentials
 }
 func (c *GeneratedRevisionSpecConverter) v1FunctionReferenceToV1FunctionReference(source FunctionReference) FunctionReference {
@@ -122,8 +298,55 @@ func (c *GeneratedRevisionSpecConverter) v1PipelineStepToV1PipelineStep(source P
 		}
 	}
 	v1PipelineStep.Requirements = c.pV1FunctionRequirementsToPV1FunctionRequirements(source.Requirements)
+	v1PipelineStep.Stage = source.Stage
+	v1PipelineStep.When = source.When
+	v1PipelineStep.Timeout = c.pV1DurationToPV1Duration(source.Timeout)
+	v1PipelineStep.Retry = c.pV1RetryPolicyToPV1RetryPolicy(source.Retry)
 	return v1PipelineStep
 }
+func (c *GeneratedRevisionSpecConverter) v1ReadinessCheckToV1ReadinessCheck(source ReadinessCheck) ReadinessCheck {
+	var v1ReadinessCheck ReadinessCheck
+	v1ReadinessCheck.Type = c.v1ReadinessCheckTypeToV1ReadinessCheckType(source.Type)
+	if source.FieldPath != nil {
+		xstring := *source.FieldPath
+		v1ReadinessCheck.FieldPath = &xstring
+	}
+	if source.MatchString != nil {
+		xstring2 := *source.MatchString
+		v1ReadinessCheck.MatchString = &xstring2
+	}
+	if source.MatchInteger != nil {
+		xint64 := *source.MatchInteger
+		v1ReadinessCheck.MatchInteger = &xint64
+	}
+	v1ReadinessCheck.MatchCondition = c.pV1MatchConditionReadinessCheckToPV1MatchConditionReadinessCheck(source.MatchCondition)
+	if source.Expression != nil {
+		xstring3 := *source.Expression
+		v1ReadinessCheck.Expression = &xstring3
+	}
+	return v1ReadinessCheck
+}
+func (c *GeneratedRevisionSpecConverter) v1ReadinessCheckTypeToV1ReadinessCheckType(source ReadinessCheckType) ReadinessCheckType {
+	var v1ReadinessCheckType ReadinessCheckType
+	switch source {
+	case ReadinessCheckTypeExpression:
+		v1ReadinessCheckType = ReadinessCheckTypeExpression
+	case ReadinessCheckTypeMatchCondition:
+		v1ReadinessCheckType = ReadinessCheckTypeMatchCondition
+	case ReadinessCheckTypeMatchFalse:
+		v1ReadinessCheckType = ReadinessCheckTypeMatchFalse
+	case ReadinessCheckTypeMatchInteger:
+		v1ReadinessCheckType = ReadinessCheckTypeMatchInteger
+	case ReadinessCheckTypeMatchString:
+		v1ReadinessCheckType = ReadinessCheckTypeMatchString
+	case ReadinessCheckTypeMatchTrue:
+		v1ReadinessCheckType = ReadinessCheckTypeMatchTrue
+	case ReadinessCheckTypeNonEmpty:
+		v1ReadinessCheckType = ReadinessCheckTypeNonEmpty
+	default: // ignored
+	}
+	return v1ReadinessCheckType
+}
 func (c *GeneratedRevisionSpecConverter) v1RequiredResourceSelectorToV1RequiredResourceSelector(source RequiredResourceSelector) RequiredResourceSelector {
 	var v1RequiredResourceSelector RequiredResourceSelector
 	v1RequiredResourceSelector.RequirementName = source.RequirementName
@@ -145,6 +368,40 @@ func (c *GeneratedRevisionSpecConverter) v1RequiredResourceSelectorToV1RequiredR
 	}
 	return v1RequiredResourceSelector
 }
+func (c *GeneratedRevisionSpecConverter) v1RolloutStrategyToV1RolloutStrategy(source RolloutStrategy) RolloutStrategy {
+	var v1RolloutStrategy RolloutStrategy
+	switch source {
+	case RolloutStrategyImmediate:
+		v1RolloutStrategy = RolloutStrategyImmediate
+	case RolloutStrategyStaged:
+		v1RolloutStrategy = RolloutStrategyStaged
+	case RolloutStrategyProgressive:
+		v1RolloutStrategy = RolloutStrategyProgressive
+	default: // ignored
+	}
+	return v1RolloutStrategy
+}
+func (c *GeneratedRevisionSpecConverter) v1RolloutWaveToV1RolloutWave(source RolloutWave) RolloutWave {
+	var v1RolloutWave RolloutWave
+	v1RolloutWave.Name = source.Name
+	if source.Percentage != nil {
+		xint32 := *source.Percentage
+		v1RolloutWave.Percentage = &xint32
+	}
+	if source.MatchLabels != nil {
+		v1RolloutWave.MatchLabels = make(map[string]string, len(source.MatchLabels))
+		for key, value := range source.MatchLabels {
+			v1RolloutWave.MatchLabels[key] = value
+		}
+	}
+	return v1RolloutWave
+}
+func (c *GeneratedRevisionSpecConverter) v1ServiceAccountReferenceToV1ServiceAccountReference(source ServiceAccountReference) ServiceAccountReference {
+	var v1ServiceAccountReference ServiceAccountReference
+	v1ServiceAccountReference.Name = source.Name
+	v1ServiceAccountReference.Namespace = source.Namespace
+	return v1ServiceAccountReference
+}
 func (c *GeneratedRevisionSpecConverter) v1TypeReferenceToV1TypeReference(source TypeReference) TypeReference {
 	var v1TypeReference TypeReference
 	v1TypeReference.APIVersion = source.APIVersion
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+)
+
+// A CompositionPreviewReference references a Composition or
+// CompositionRevision by name.
+type CompositionPreviewReference struct {
+	// Name of the referenced object.
+	Name string `json:"name"`
+}
+
+// CompositionPreviewSpec specifies the composite resource to preview.
+type CompositionPreviewSpec struct {
+	// CompositeResource is the composite resource (XR) to preview. If an XR
+	// with the same name already exists, the preview uses the composed
+	// resources it already references. Otherwise the preview shows what
+	// composing a new XR would produce.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	CompositeResource runtime.RawExtension `json:"compositeResource"`
+
+	// CompositionRef is the Composition to preview. The latest revision of
+	// the Composition is used. Defaults to the Composition referenced by the
+	// composite resource.
+	// +optional
+	CompositionRef *CompositionPreviewReference `json:"compositionRef,omitempty"`
+
+	// CompositionRevisionRef is the CompositionRevision to preview. It takes
+	// precedence over CompositionRef.
+	// +optional
+	CompositionRevisionRef *CompositionPreviewReference `json:"compositionRevisionRef,omitempty"`
+}
+
+// A PreviewAction is what applying a previewed composed resource would do.
+type PreviewAction string
+
+// Preview actions.
+const (
+	// PreviewActionCreate means the composed resource doesn't exist, and
+	// would be created.
+	PreviewActionCreate PreviewAction = "Create"
+
+	// PreviewActionUpdate means the composed resource exists, and would be
+	// updated.
+	PreviewActionUpdate PreviewAction = "Update"
+
+	// PreviewActionNone means the composed resource exists, and wouldn't
+	// change.
+	PreviewActionNone PreviewAction = "None"
+
+	// PreviewActionDelete means the composed resource exists, but is no
+	// longer desired and would be deleted.
+	PreviewActionDelete PreviewAction = "Delete"
+)
+
+// A PreviewedResource is a composed resource the previewed composition would
+// produce, or delete.
+type PreviewedResource struct {
+	// Name of the composed resource in the Composition's pipeline.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Reference to the composed resource.
+	Reference PreviewedResourceReference `json:"reference"`
+
+	// Action that applying the composed resource would take.
+	// +kubebuilder:validation:Enum=Create;Update;None;Delete
+	Action PreviewAction `json:"action"`
+
+	// Diff between the composed resource as it exists and as it would be
+	// after it was applied. Metadata the API server manages is omitted, as is
+	// the data of Secrets.
+	// +optional
+	Diff string `json:"diff,omitempty"`
+
+	// Desired is the composed resource as it would be after it was applied.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	Desired *runtime.RawExtension `json:"desired,omitempty"`
+}
+
+// A PreviewedResourceReference references a composed resource.
+type PreviewedResourceReference struct {
+	// APIVersion of the composed resource.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the composed resource.
+	Kind string `json:"kind"`
+
+	// Name of the composed resource.
+	Name string `json:"name"`
+
+	// Namespace of the composed resource, if it's namespaced.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// A PreviewEvent is an event the previewed composition would emit.
+type PreviewEvent struct {
+	// Type of the event - Normal or Warning.
+	Type string `json:"type"`
+
+	// Reason for the event.
+	Reason string `json:"reason"`
+
+	// Message of the event.
+	Message string `json:"message"`
+}
+
+// CompositionPreviewStatus is the result of a composition preview.
+type CompositionPreviewStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+
+	// ObservedGeneration is the generation of the preview's spec that was
+	// last previewed.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// CompositionRevision that was previewed.
+	// +optional
+	CompositionRevision string `json:"compositionRevision,omitempty"`
+
+	// Resources the previewed composition would create, update, or delete.
+	// +optional
+	// +listType=atomic
+	Resources []PreviewedResource `json:"resources,omitempty"`
+
+	// Events the previewed composition would emit.
+	// +optional
+	// +listType=atomic
+	Events []PreviewEvent `json:"events,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +genclient
+// +genclient:nonNamespaced
+
+// A CompositionPreview runs a Composition's function pipeline against live
+// cluster state, without applying anything. It reports the composed resources
+// the Composition would produce, and how they differ from the composed
+// resources that exist. Previews are computed once per generation; update the
+// preview to compute it again.
+//
+// Previews run with Crossplane's permissions. Anyone who can create a
+// CompositionPreview can see the composed resources any Composition would
+// produce.
+//
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="REVISION",type="string",JSONPath=".status.compositionRevision"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories=crossplane,shortName=cpreview
+type CompositionPreview struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CompositionPreviewSpec   `json:"spec"`
+	Status CompositionPreviewStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this CompositionPreview.
+func (p *CompositionPreview) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return p.Status.GetCondition(ct)
+}
+
+// SetConditions of this CompositionPreview.
+func (p *CompositionPreview) SetConditions(c ...xpv1.Condition) {
+	p.Status.SetConditions(c...)
+}
+
+// +kubebuilder:object:root=true
+
+// CompositionPreviewList contains a list of CompositionPreviews.
+type CompositionPreviewList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []CompositionPreview `json:"items"`
+}
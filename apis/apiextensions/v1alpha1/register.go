@@ -61,8 +61,17 @@ var (
 	ManagedResourceActivationPolicyGroupVersionKind = SchemeGroupVersion.WithKind(ManagedResourceActivationPolicyKind)
 )
 
+// CompositionPreview type metadata.
+var (
+	CompositionPreviewKind             = reflect.TypeOf(CompositionPreview{}).Name()
+	CompositionPreviewGroupKind        = schema.GroupKind{Group: Group, Kind: CompositionPreviewKind}.String()
+	CompositionPreviewKindAPIVersion   = CompositionPreviewKind + "." + SchemeGroupVersion.String()
+	CompositionPreviewGroupVersionKind = SchemeGroupVersion.WithKind(CompositionPreviewKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Usage{}, &UsageList{},
 		&ManagedResourceDefinition{}, &ManagedResourceDefinitionList{},
-		&ManagedResourceActivationPolicy{}, &ManagedResourceActivationPolicyList{})
+		&ManagedResourceActivationPolicy{}, &ManagedResourceActivationPolicyList{},
+		&CompositionPreview{}, &CompositionPreviewList{})
 }
@@ -25,6 +25,134 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositionPreview) DeepCopyInto(out *CompositionPreview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionPreview.
+func (in *CompositionPreview) DeepCopy() *CompositionPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositionPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CompositionPreview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositionPreviewList) DeepCopyInto(out *CompositionPreviewList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CompositionPreview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionPreviewList.
+func (in *CompositionPreviewList) DeepCopy() *CompositionPreviewList {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositionPreviewList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CompositionPreviewList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositionPreviewReference) DeepCopyInto(out *CompositionPreviewReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionPreviewReference.
+func (in *CompositionPreviewReference) DeepCopy() *CompositionPreviewReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositionPreviewReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositionPreviewSpec) DeepCopyInto(out *CompositionPreviewSpec) {
+	*out = *in
+	in.CompositeResource.DeepCopyInto(&out.CompositeResource)
+	if in.CompositionRef != nil {
+		in, out := &in.CompositionRef, &out.CompositionRef
+		*out = new(CompositionPreviewReference)
+		**out = **in
+	}
+	if in.CompositionRevisionRef != nil {
+		in, out := &in.CompositionRevisionRef, &out.CompositionRevisionRef
+		*out = new(CompositionPreviewReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionPreviewSpec.
+func (in *CompositionPreviewSpec) DeepCopy() *CompositionPreviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositionPreviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositionPreviewStatus) DeepCopyInto(out *CompositionPreviewStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PreviewedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]PreviewEvent, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionPreviewStatus.
+func (in *CompositionPreviewStatus) DeepCopy() *CompositionPreviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositionPreviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionDetail) DeepCopyInto(out *ConnectionDetail) {
 	*out = *in
@@ -320,6 +448,57 @@ func (in *ManagedResourceDefinitionStatus) DeepCopy() *ManagedResourceDefinition
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewEvent) DeepCopyInto(out *PreviewEvent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewEvent.
+func (in *PreviewEvent) DeepCopy() *PreviewEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewedResource) DeepCopyInto(out *PreviewedResource) {
+	*out = *in
+	out.Reference = in.Reference
+	if in.Desired != nil {
+		in, out := &in.Desired, &out.Desired
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewedResource.
+func (in *PreviewedResource) DeepCopy() *PreviewedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewedResourceReference) DeepCopyInto(out *PreviewedResourceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewedResourceReference.
+func (in *PreviewedResourceReference) DeepCopy() *PreviewedResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewedResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resource) DeepCopyInto(out *Resource) {
 	*out = *in
@@ -93,6 +93,33 @@ type CompositeResourceDefinitionSpec struct {
 	// +optional
 	Conversion *extv1.CustomResourceConversion `json:"conversion,omitempty"`
 
+	// Converter configures a conversion webhook that Crossplane serves to
+	// convert the defined composite resource (and claim) between versions.
+	// Crossplane configures the generated CRDs to use this webhook, ignoring
+	// Conversion. This is an alpha feature; it's ignored unless composite
+	// resource conversion is enabled.
+	// +optional
+	Converter *CompositeResourceConverter `json:"converter,omitempty"`
+
+	// ValidationRules are CEL validation rules Crossplane adds to the root of
+	// the schema of every version of the defined composite resource. Unlike
+	// rules in a version's schema they may reference fields that Crossplane
+	// injects into the schema, like spec.crossplane.compositionRef. This is
+	// an alpha feature; it's ignored unless XR validation rules are enabled.
+	// +optional
+	ValidationRules []extv1.ValidationRule `json:"validationRules,omitempty"`
+
+	// ImmutableFields are paths of fields, like spec.parameters.region, that
+	// can't be changed or removed once they're set. Crossplane translates them
+	// into CEL transition rules in the schema of every version of the defined
+	// composite resource (and claim) that defines the field. Fields within
+	// arrays aren't supported, so paths may only select object fields, for
+	// example spec.parameters[example.org/zone]. This is an alpha feature;
+	// it's ignored unless XR validation rules are enabled.
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z_][a-zA-Z0-9_-]*(\.[a-zA-Z_][a-zA-Z0-9_-]*|\[[a-zA-Z_./-][a-zA-Z0-9_./-]*\])*$`
+	ImmutableFields []string `json:"immutableFields,omitempty"`
+
 	// Metadata specifies the desired metadata for the defined composite resource and claim CRD's.
 	// +optional
 	Metadata *CompositeResourceDefinitionSpecMetadata `json:"metadata,omitempty"`
@@ -135,6 +162,48 @@ type CompositionReference struct {
 	Name string `json:"name"`
 }
 
+// A CompositeResourceConverter configures how Crossplane converts composite
+// resources and claims between versions.
+type CompositeResourceConverter struct {
+	// FieldMappings move fields between versions. Crossplane copies fields
+	// that aren't mapped unchanged.
+	// +optional
+	FieldMappings []ConversionFieldMapping `json:"fieldMappings,omitempty"`
+
+	// FunctionRef references a function that converts composite resources and
+	// claims. Crossplane calls the function after applying any field mappings.
+	// The function is sent the resource to convert as its observed composite
+	// resource, and the result of the field mappings as its desired composite
+	// resource. It must return the converted resource as its desired
+	// composite resource.
+	// +optional
+	FunctionRef *ConversionFunctionReference `json:"functionRef,omitempty"`
+}
+
+// A ConversionFieldMapping moves a field when converting between two
+// versions. Mappings apply in both directions; converting from ToVersion to
+// FromVersion moves the field from ToFieldPath to FromFieldPath.
+type ConversionFieldMapping struct {
+	// FromVersion is the version to convert from.
+	FromVersion string `json:"fromVersion"`
+
+	// FromFieldPath is the path of the field to move in FromVersion.
+	FromFieldPath string `json:"fromFieldPath"`
+
+	// ToVersion is the version to convert to.
+	ToVersion string `json:"toVersion"`
+
+	// ToFieldPath is the path the field is moved to in ToVersion.
+	ToFieldPath string `json:"toFieldPath"`
+}
+
+// A ConversionFunctionReference references a function that converts
+// composite resources and claims.
+type ConversionFunctionReference struct {
+	// Name of the referenced Function.
+	Name string `json:"name"`
+}
+
 // CompositeResourceDefinitionSpecMetadata specifies the desired metadata of the defined composite resource and claim CRD's.
 type CompositeResourceDefinitionSpecMetadata struct {
 	// Map of string keys and values that can be used to organize and categorize
@@ -26,6 +26,31 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeResourceConverter) DeepCopyInto(out *CompositeResourceConverter) {
+	*out = *in
+	if in.FieldMappings != nil {
+		in, out := &in.FieldMappings, &out.FieldMappings
+		*out = make([]ConversionFieldMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.FunctionRef != nil {
+		in, out := &in.FunctionRef, &out.FunctionRef
+		*out = new(ConversionFunctionReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeResourceConverter.
+func (in *CompositeResourceConverter) DeepCopy() *CompositeResourceConverter {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeResourceConverter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CompositeResourceDefinition) DeepCopyInto(out *CompositeResourceDefinition) {
 	*out = *in
@@ -133,6 +158,23 @@ func (in *CompositeResourceDefinitionSpec) DeepCopyInto(out *CompositeResourceDe
 		*out = new(apiextensionsv1.CustomResourceConversion)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Converter != nil {
+		in, out := &in.Converter, &out.Converter
+		*out = new(CompositeResourceConverter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ValidationRules != nil {
+		in, out := &in.ValidationRules, &out.ValidationRules
+		*out = make([]apiextensionsv1.ValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImmutableFields != nil {
+		in, out := &in.ImmutableFields, &out.ImmutableFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = new(CompositeResourceDefinitionSpecMetadata)
@@ -277,6 +319,36 @@ func (in *CompositionReference) DeepCopy() *CompositionReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionFieldMapping) DeepCopyInto(out *ConversionFieldMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConversionFieldMapping.
+func (in *ConversionFieldMapping) DeepCopy() *ConversionFieldMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ConversionFieldMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionFunctionReference) DeepCopyInto(out *ConversionFunctionReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConversionFunctionReference.
+func (in *ConversionFunctionReference) DeepCopy() *ConversionFunctionReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConversionFunctionReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TypeReference) DeepCopyInto(out *TypeReference) {
 	*out = *in
@@ -87,8 +87,57 @@ type PipelineStep struct {
 	// request them first.
 	// +optional
 	Requirements *FunctionRequirements `json:"requirements,omitempty"`
+
+	// When is an optional CEL expression that determines whether this step
+	// runs. The step is skipped entirely when the expression evaluates to
+	// false. The expression may refer to the desired state and pipeline
+	// context the step would be sent, as desired and context. Fields use
+	// their RunFunctionRequest protobuf names, for example
+	// context.environment == 'production'.
+	// +optional
+	When string `json:"when,omitempty"`
+
+	// Timeout for each call to this step's function. Defaults to the time
+	// remaining before the reconcile deadline.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retry configures whether and how calls to this step's function are
+	// retried when they fail. Calls aren't retried by default.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+}
+
+// A RetryPolicy configures how a pipeline step's function call is retried
+// when it fails.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times to call the function,
+	// including the first call. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// Backoff is how long to wait before the first retry. The wait doubles
+	// for each subsequent retry. Defaults to 1s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// MaxBackoff is the longest time to wait between retries. Defaults to
+	// 30s.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// Codes are the gRPC status codes that should be retried. Defaults to
+	// Unavailable, DeadlineExceeded, and ResourceExhausted.
+	// +optional
+	// +listType=set
+	Codes []GRPCCode `json:"codes,omitempty"`
 }
 
+// A GRPCCode is the name of a gRPC status code.
+// +kubebuilder:validation:Enum=Canceled;Unknown;InvalidArgument;DeadlineExceeded;NotFound;AlreadyExists;PermissionDenied;ResourceExhausted;FailedPrecondition;Aborted;OutOfRange;Unimplemented;Internal;Unavailable;DataLoss;Unauthenticated
+type GRPCCode string
+
 // A FunctionReference references an operation function that may be used in an
 // operation pipeline.
 type FunctionReference struct {
@@ -98,18 +147,32 @@ type FunctionReference struct {
 
 // FunctionCredentials are optional credentials that a function
 // needs to run.
+//
+// +kubebuilder:validation:XValidation:rule="self.source != 'Secret' || has(self.secretRef)",message="the Secret source requires a secretRef"
+// +kubebuilder:validation:XValidation:rule="self.source != 'ServiceAccountToken' || has(self.serviceAccountToken)",message="the ServiceAccountToken source requires a serviceAccountToken"
+// +kubebuilder:validation:XValidation:rule="self.source != 'ConfigMap' || has(self.configMapRef)",message="the ConfigMap source requires a configMapRef"
 type FunctionCredentials struct {
 	// Name of this set of credentials.
 	Name string `json:"name"`
 
 	// Source of the function credentials.
-	// +kubebuilder:validation:Enum=None;Secret
+	// +kubebuilder:validation:Enum=None;Secret;ServiceAccountToken;ConfigMap
 	Source FunctionCredentialsSource `json:"source"`
 
 	// A SecretRef is a reference to a secret containing credentials that should
 	// be supplied to the function.
 	// +optional
 	SecretRef *xpv1.SecretReference `json:"secretRef,omitempty"`
+
+	// ServiceAccountToken configures a short-lived, projected ServiceAccount
+	// token that should be supplied to the function under the token key.
+	// +optional
+	ServiceAccountToken *ServiceAccountTokenSource `json:"serviceAccountToken,omitempty"`
+
+	// A ConfigMapRef is a reference to a ConfigMap containing credentials that
+	// should be supplied to the function.
+	// +optional
+	ConfigMapRef *ConfigMapReference `json:"configMapRef,omitempty"`
 }
 
 // A FunctionCredentialsSource is a source from which function
@@ -124,8 +187,57 @@ const (
 	// FunctionCredentialsSourceSecret indicates that a function should acquire
 	// credentials from a secret.
 	FunctionCredentialsSourceSecret FunctionCredentialsSource = "Secret"
+
+	// FunctionCredentialsSourceServiceAccountToken indicates that a function
+	// should acquire credentials from a short-lived ServiceAccount token. It
+	// requires the --enable-function-service-account-tokens feature flag.
+	FunctionCredentialsSourceServiceAccountToken FunctionCredentialsSource = "ServiceAccountToken"
+
+	// FunctionCredentialsSourceConfigMap indicates that a function should
+	// acquire credentials from a ConfigMap.
+	FunctionCredentialsSourceConfigMap FunctionCredentialsSource = "ConfigMap"
 )
 
+// A ServiceAccountTokenSource configures a projected ServiceAccount token.
+// Crossplane only requests tokens for ServiceAccounts in the namespaces listed
+// by its --operation-service-account-token-namespaces flag.
+type ServiceAccountTokenSource struct {
+	// ServiceAccountRef references the ServiceAccount to request a token for.
+	ServiceAccountRef ServiceAccountReference `json:"serviceAccountRef"`
+
+	// Audiences are the intended audiences of the token. At least one is
+	// required. The token is only valid for the Kubernetes API if one of the
+	// audiences is the API server's, which must be requested explicitly.
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	Audiences []string `json:"audiences"`
+
+	// ExpirationSeconds is the requested lifetime of the token. Crossplane
+	// requests a new token before the old one expires.
+	// +optional
+	// +kubebuilder:validation:Minimum=600
+	// +kubebuilder:default=3600
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// A ServiceAccountReference references a ServiceAccount.
+type ServiceAccountReference struct {
+	// Name of the ServiceAccount.
+	Name string `json:"name"`
+
+	// Namespace of the ServiceAccount.
+	Namespace string `json:"namespace"`
+}
+
+// A ConfigMapReference references a ConfigMap.
+type ConfigMapReference struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+}
+
 // FunctionRequirements specifies resource requirements for a pipeline step.
 type FunctionRequirements struct {
 	// RequiredResources that will be fetched before this pipeline step
@@ -188,6 +300,27 @@ type PipelineStepStatus struct {
 	// Output of this step.
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Output *runtime.RawExtension `json:"output,omitempty"`
+
+	// Progress of this step, if its function streams progress updates.
+	// +optional
+	Progress *PipelineStepProgress `json:"progress,omitempty"`
+}
+
+// PipelineStepProgress represents the most recent progress update streamed by
+// a pipeline step's function.
+type PipelineStepProgress struct {
+	// Message describing the step's progress.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// PercentComplete is the function's estimate of how complete the step is.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	PercentComplete *int32 `json:"percentComplete,omitempty"`
+
+	// LastUpdateTime is when the function last reported progress.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
 }
 
 // An AppliedResourceRef is a reference to a resource an Operation applied.
@@ -33,6 +33,11 @@ const (
 	// used in an operation.
 	FunctionCapabilityOperation = "operation"
 
+	// FunctionCapabilityStreaming is a capability key for a function that
+	// implements the RunFunctionStream RPC, and can stream progress updates
+	// while it runs.
+	FunctionCapabilityStreaming = "streaming"
+
 	// ProviderCapabilitySafeStart is a capability key for a provider that
 	// supports "safe" starting of its controller gated on the existence of
 	// dependent kinds in the cluster.
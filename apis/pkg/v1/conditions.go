@@ -42,6 +42,10 @@ const (
 	// A TypeVerified indicates whether a package's signature is verified.
 	// It could be either successful or skipped to be marked as complete.
 	TypeVerified xpv1.ConditionType = "Verified"
+
+	// A TypeFunctionResponsive indicates whether calls to a function revision
+	// are succeeding, or failing fast because its circuit breaker is open.
+	TypeFunctionResponsive xpv1.ConditionType = "FunctionResponsive"
 )
 
 // Reasons a package is or is not installed.
@@ -55,6 +59,12 @@ const (
 	ReasonUnknownHealth        xpv1.ConditionReason = "UnknownPackageRevisionHealth"
 )
 
+// Reasons a function is or is not responsive.
+const (
+	ReasonCircuitClosed xpv1.ConditionReason = "CircuitBreakerClosed"
+	ReasonCircuitOpen   xpv1.ConditionReason = "CircuitBreakerOpen"
+)
+
 // Reasons a package's signature is or is not verified.
 const (
 	// ReasonVerificationIncomplete indicates that signature verification is
@@ -206,6 +216,29 @@ func RuntimeUnknownHealth() xpv1.Condition {
 	}
 }
 
+// FunctionResponsive indicates that calls to a function revision are
+// succeeding.
+func FunctionResponsive() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeFunctionResponsive,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCircuitClosed,
+	}
+}
+
+// FunctionUnresponsive indicates that calls to a function revision are
+// failing fast, because too many consecutive calls failed.
+func FunctionUnresponsive(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeFunctionResponsive,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCircuitOpen,
+		Message:            msg,
+	}
+}
+
 // VerificationSucceeded returns a condition indicating that a package's
 // signature has been successfully verified using the supplied image config.
 func VerificationSucceeded(imageConfig string) xpv1.Condition {
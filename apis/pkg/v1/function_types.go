@@ -51,6 +51,37 @@ type FunctionSpec struct {
 	PackageSpec `json:",inline"`
 
 	PackageRuntimeSpec `json:",inline"`
+
+	// ResponseCache configures how Crossplane caches the Function's
+	// responses. It has no effect unless the function response cache is
+	// enabled.
+	// +optional
+	ResponseCache *FunctionResponseCache `json:"responseCache,omitempty"`
+}
+
+// FunctionResponseCache configures how Crossplane caches a Function's
+// responses.
+//
+// Crossplane only uses a cached response if the Function's request is
+// identical to the request that produced it. Fields that change often without
+// affecting the Function's response, like a resource's resourceVersion, can
+// make it unlikely requests are identical. Crossplane can ignore these fields
+// when it compares requests.
+type FunctionResponseCache struct {
+	// IgnoreVolatileFields ignores resource metadata fields that change
+	// without a meaningful change to the resource. These are
+	// metadata.resourceVersion, metadata.generation, metadata.managedFields,
+	// and the lastTransitionTime of each status condition.
+	// +optional
+	IgnoreVolatileFields bool `json:"ignoreVolatileFields,omitempty"`
+
+	// IgnoreFields is a list of field paths to ignore in each observed,
+	// desired, and required resource, e.g. metadata.labels[example.org/hash].
+	// Use [*] to match every element of an array or object, e.g.
+	// status.conditions[*].lastTransitionTime.
+	// +optional
+	// +listType=atomic
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
 }
 
 // FunctionStatus represents the observed state of a Function.
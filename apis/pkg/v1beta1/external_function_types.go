@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+)
+
+// ExternalFunctionTLSMode determines how Crossplane secures its connection to
+// an ExternalFunction.
+type ExternalFunctionTLSMode string
+
+const (
+	// ExternalFunctionTLSModeTLS connects to the function using TLS.
+	ExternalFunctionTLSModeTLS ExternalFunctionTLSMode = "TLS"
+
+	// ExternalFunctionTLSModeInsecure connects to the function without TLS,
+	// for example because a service mesh secures the connection.
+	ExternalFunctionTLSModeInsecure ExternalFunctionTLSMode = "Insecure"
+)
+
+// Keys of the Secrets referenced by an ExternalFunction's TLS configuration.
+const (
+	// ExternalFunctionSecretKeyCACert is the key of the CA certificate
+	// bundle in the Secret referenced by caSecretRef.
+	ExternalFunctionSecretKeyCACert = "ca.crt"
+)
+
+// ExternalFunctionTLS configures how Crossplane secures its connection to an
+// ExternalFunction.
+type ExternalFunctionTLS struct {
+	// Mode determines whether Crossplane uses TLS to connect to the function.
+	// +optional
+	// +kubebuilder:validation:Enum=TLS;Insecure
+	// +kubebuilder:default=TLS
+	Mode ExternalFunctionTLSMode `json:"mode,omitempty"`
+
+	// ServerName overrides the name Crossplane uses to verify the function's
+	// server certificate. Defaults to the host of the endpoint.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// CASecretRef references a Secret in the Crossplane namespace. Crossplane
+	// uses the CA certificate bundle under its ca.crt key to verify the
+	// function's server certificate. Crossplane uses the system's root CA
+	// certificates if this is omitted.
+	// +optional
+	CASecretRef *xpv1.LocalSecretReference `json:"caSecretRef,omitempty"`
+
+	// ClientCertSecretRef references a kubernetes.io/tls Secret in the
+	// Crossplane namespace. Crossplane presents the certificate under its
+	// tls.crt and tls.key keys to the function. Crossplane doesn't present a
+	// client certificate if this is omitted.
+	// +optional
+	ClientCertSecretRef *xpv1.LocalSecretReference `json:"clientCertSecretRef,omitempty"`
+}
+
+// ExternalFunctionSpec specifies how to call an ExternalFunction.
+type ExternalFunctionSpec struct {
+	// Endpoint is the gRPC target Crossplane uses to call the function, for
+	// example dns:///function.example.org:9443.
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+
+	// TLS configures how Crossplane secures its connection to the function.
+	// Crossplane connects using TLS, verified by the system's root CA
+	// certificates, if this is omitted.
+	// +optional
+	TLS *ExternalFunctionTLS `json:"tls,omitempty"`
+
+	// Capabilities of the function, for example composition, operation, or
+	// streaming. Crossplane assumes a function has the composition
+	// capability if this is omitted.
+	// +optional
+	// +listType=set
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// ExternalFunctionStatus represents the observed state of an ExternalFunction.
+type ExternalFunctionStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +genclient
+// +genclient:nonNamespaced
+
+// An ExternalFunction registers a composition function that Crossplane doesn't
+// install or run, for example a function server running outside the cluster
+// or in a shared service mesh. Reference an ExternalFunction by name from a
+// pipeline step, like a Function. A Function takes precedence over an
+// ExternalFunction of the same name.
+//
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ENDPOINT",type="string",JSONPath=".spec.endpoint"
+// +kubebuilder:printcolumn:name="RESPONSIVE",type="string",JSONPath=".status.conditions[?(@.type=='FunctionResponsive')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,pkg}
+type ExternalFunction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExternalFunctionSpec   `json:"spec"`
+	Status ExternalFunctionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ExternalFunctionList contains a list of ExternalFunction.
+type ExternalFunctionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ExternalFunction `json:"items"`
+}
+
+// GetCondition of this ExternalFunction.
+func (f *ExternalFunction) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return f.Status.GetCondition(ct)
+}
+
+// SetConditions of this ExternalFunction.
+func (f *ExternalFunction) SetConditions(c ...xpv1.Condition) {
+	f.Status.SetConditions(c...)
+}
+
+// GetCapabilities of this ExternalFunction. Like a Function package, an
+// ExternalFunction that doesn't specify any capabilities is assumed to be a
+// composition function.
+func (f *ExternalFunction) GetCapabilities() []string {
+	if len(f.Spec.Capabilities) == 0 {
+		return []string{pkgmetav1.FunctionCapabilityComposition}
+	}
+
+	return f.Spec.Capabilities
+}
@@ -80,10 +80,19 @@ var (
 	ImageConfigGroupVersionKind = SchemeGroupVersion.WithKind(ImageConfigKind)
 )
 
+// ExternalFunction type metadata.
+var (
+	ExternalFunctionKind             = reflect.TypeOf(ExternalFunction{}).Name()
+	ExternalFunctionGroupKind        = schema.GroupKind{Group: Group, Kind: ExternalFunctionKind}.String()
+	ExternalFunctionKindAPIVersion   = ExternalFunctionKind + "." + SchemeGroupVersion.String()
+	ExternalFunctionGroupVersionKind = SchemeGroupVersion.WithKind(ExternalFunctionKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Lock{}, &LockList{})
 	SchemeBuilder.Register(&Function{}, &FunctionList{})
 	SchemeBuilder.Register(&FunctionRevision{}, &FunctionRevisionList{})
 	SchemeBuilder.Register(&DeploymentRuntimeConfig{}, &DeploymentRuntimeConfigList{})
 	SchemeBuilder.Register(&ImageConfig{}, &ImageConfigList{})
+	SchemeBuilder.Register(&ExternalFunction{}, &ExternalFunctionList{})
 }
@@ -112,7 +112,7 @@ func (r *defaultPkgPrinterRow) String() string {
 }
 
 func getHeaders(gk schema.GroupKind, wide bool) (headers fmt.Stringer, isPackageOrPackageRevision bool) {
-	if xpkg.IsPackageType(gk) || xpkg.IsPackageRevisionType(gk) {
+	if xpkg.IsPackageType(gk) || xpkg.IsPackageRevisionType(gk) || xpkg.IsExternalFunctionType(gk) {
 		return &defaultPkgPrinterRow{
 			wide: wide,
 
@@ -333,6 +333,17 @@ func getPkgResourceStatus(r *resource.Resource, name string, wide bool) fmt.Stri
 		if packageImg, err = fieldpath.Pave(r.Unstructured.Object).GetString("spec.image"); err != nil {
 			state = err.Error()
 		}
+	case xpkg.IsExternalFunctionType(gk):
+		// ExternalFunctions aren't installed. Whether they're healthy is
+		// determined by whether they respond to calls.
+		healthyCond = r.GetCondition(pkgv1.TypeFunctionResponsive)
+		status = string(healthyCond.Reason)
+		m = healthyCond.Message
+
+		// Show the endpoint in place of the package.
+		if packageImg, err = fieldpath.Pave(r.Unstructured.Object).GetString("spec.endpoint"); err != nil {
+			state = err.Error()
+		}
 	case xpkg.IsPackageRuntimeConfigType(gk):
 		// nothing to do here
 	default:
@@ -353,7 +364,7 @@ func getPkgResourceStatus(r *resource.Resource, name string, wide bool) fmt.Stri
 	// Parse the image reference extracting the tag, we'll leave it empty if we
 	// couldn't parse it and leave the whole thing as package instead.
 	var packageImgTag string
-	if tag, err := gcrname.NewTag(packageImg, gcrname.StrictValidation); err == nil {
+	if tag, err := gcrname.NewTag(packageImg, gcrname.StrictValidation); err == nil && !xpkg.IsExternalFunctionType(gk) {
 		packageImgTag = tag.TagStr()
 
 		packageImg = tag.RepositoryStr()
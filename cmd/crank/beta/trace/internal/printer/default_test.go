@@ -25,6 +25,8 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
 	"github.com/crossplane/crossplane/v2/cmd/crank/common/resource"
 )
 
@@ -134,6 +136,24 @@ Configuration/platform-ref-aws
 				err: nil,
 			},
 		},
+		"ExternalFunction": {
+			reason: "Should print an ExternalFunction like a Function, with its endpoint in place of its package.",
+			args: args{
+				resource: &resource.Resource{
+					Unstructured: DummyPackage(pkgv1beta1.ExternalFunctionGroupVersionKind, "function-remote",
+						WithEndpoint("dns:///function-remote.example.org:9443"),
+						WithConditions(v1.FunctionUnresponsive("boom"))),
+				},
+				wide: true,
+			},
+			want: want{
+				// Note: Use spaces instead of tabs for indentation
+				output: `
+NAME                               PACKAGE                                   VERSION   INSTALLED   HEALTHY   STATE   STATUS                     
+ExternalFunction/function-remote   dns:///function-remote.example.org:9443             -           False     -       CircuitBreakerOpen: boom   
+`,
+			},
+		},
 	}
 
 	for name, tc := range cases {
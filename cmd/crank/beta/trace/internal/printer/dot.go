@@ -150,6 +150,20 @@ func (p *DotPrinter) Print(w io.Writer, root *resource.Resource) error {
 				l.error = err.Error()
 			}
 
+			label = l
+		case xpkg.IsExternalFunctionType(gk):
+			endpoint, err := fieldpath.Pave(item.resource.Unstructured.Object).GetString("spec.endpoint")
+
+			l := &dotPackageLabel{
+				apiVersion: item.resource.Unstructured.GroupVersionKind().GroupVersion().String(),
+				name:       item.resource.Unstructured.GetName(),
+				pkg:        endpoint,
+				healthy:    string(item.resource.GetCondition(v1.TypeFunctionResponsive).Status),
+			}
+			if err != nil {
+				l.error = err.Error()
+			}
+
 			label = l
 		default:
 			label = &dotLabel{
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
+)
+
+const (
+	errWritePipelineTrace = "cannot write pipeline trace"
+)
+
+// PrintPipelineTrace prints a table of the steps of the supplied composition
+// function pipeline trace. In wide mode it also prints the results each step
+// returned, and how each step changed the desired state.
+func PrintPipelineTrace(w io.Writer, t *composite.PipelineTrace, wide bool) error {
+	if _, err := fmt.Fprintf(w, "\nPipeline trace of revision %s, started %s, took %s\n", t.Revision, t.StartTime.UTC().Format(time.RFC3339), t.Duration.Round(time.Millisecond)); err != nil {
+		return errors.Wrap(err, errWritePipelineTrace)
+	}
+
+	tw := printers.GetNewTabWriter(w)
+
+	if _, err := fmt.Fprintln(tw, strings.Join([]string{"STEP", "FUNCTION", "STAGE", "ITERATIONS", "DURATION", "RESULTS", "STATUS"}, "\t")); err != nil {
+		return errors.Wrap(err, errWriteHeader)
+	}
+
+	for _, st := range t.Steps {
+		row := []string{st.Step, st.Function, orDash(st.Stage), "-", "-", results(st.Results), stepStatus(st)}
+		if !st.Skipped {
+			row[3] = fmt.Sprintf("%d", st.Iterations)
+			row[4] = st.Duration.Round(time.Millisecond).String()
+		}
+
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return errors.Wrap(err, errWriteRow)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, errFlushTabWriter)
+	}
+
+	if t.Error != "" {
+		if _, err := fmt.Fprintf(w, "Pipeline error: %s\n", t.Error); err != nil {
+			return errors.Wrap(err, errWritePipelineTrace)
+		}
+	}
+
+	if !wide {
+		return nil
+	}
+
+	for _, st := range t.Steps {
+		if len(st.Results) == 0 && st.Diff == "" && len(st.Requirements) == 0 {
+			continue
+		}
+
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "\nStep %q:\n", st.Step)
+
+		if len(st.Requirements) > 0 {
+			fmt.Fprintf(&b, "  Required resources: %s\n", strings.Join(st.Requirements, ", "))
+		}
+
+		for _, r := range st.Results {
+			fmt.Fprintf(&b, "  %s %s: %s\n", r.Severity, orDash(r.Reason), r.Message)
+		}
+
+		if st.Diff != "" {
+			fmt.Fprintln(&b, "  Desired state (-sent, +returned):")
+			for _, l := range strings.Split(strings.TrimRight(st.Diff, "\n"), "\n") {
+				fmt.Fprintf(&b, "  %s\n", l)
+			}
+		}
+
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return errors.Wrap(err, errWritePipelineTrace)
+		}
+	}
+
+	if t.Truncated {
+		if _, err := fmt.Fprintln(w, "\nSome desired state diffs were omitted to bound the size of the trace."); err != nil {
+			return errors.Wrap(err, errWritePipelineTrace)
+		}
+	}
+
+	return nil
+}
+
+func results(rs []composite.ResultTrace) string {
+	if len(rs) == 0 {
+		return "-"
+	}
+
+	counts := map[string]int{}
+	order := []string{}
+
+	for _, r := range rs {
+		s := strings.ToLower(r.Severity)
+		if counts[s] == 0 {
+			order = append(order, s)
+		}
+
+		counts[s]++
+	}
+
+	out := make([]string, len(order))
+	for i, s := range order {
+		out[i] = fmt.Sprintf("%d %s", counts[s], s)
+	}
+
+	return strings.Join(out, ", ")
+}
+
+func stepStatus(st *composite.StepTrace) string {
+	switch {
+	case st.Skipped:
+		return "Skipped"
+	case st.Error != "":
+		return "Error: " + st.Error
+	default:
+		return "Ran"
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
+)
+
+func TestPrintPipelineTrace(t *testing.T) {
+	trace := func() *composite.PipelineTrace {
+		return &composite.PipelineTrace{
+			Revision:  "cool-revision",
+			StartTime: metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Duration:  metav1.Duration{Duration: 1500 * time.Millisecond},
+			Steps: []*composite.StepTrace{
+				{
+					Step:         "first",
+					Function:     "function-first",
+					Iterations:   2,
+					Duration:     metav1.Duration{Duration: time.Second},
+					Requirements: []string{"cool-resource"},
+					Results: []composite.ResultTrace{
+						{Severity: "WARNING", Reason: "Cool", Message: "a warning"},
+						{Severity: "NORMAL", Message: "all good"},
+					},
+					Diff: "+ added\n",
+				},
+				{
+					Step:     "second",
+					Function: "function-second",
+					Skipped:  true,
+				},
+				{
+					Step:       "third",
+					Function:   "function-third",
+					Iterations: 1,
+					Duration:   metav1.Duration{Duration: 500 * time.Millisecond},
+					Error:      "boom",
+				},
+			},
+			Error: "boom",
+		}
+	}
+
+	type args struct {
+		trace *composite.PipelineTrace
+		wide  bool
+	}
+
+	type want struct {
+		output string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Default": {
+			reason: "Should print a table of pipeline steps.",
+			args: args{
+				trace: trace(),
+			},
+			want: want{
+				output: `
+Pipeline trace of revision cool-revision, started 2025-01-01T00:00:00Z, took 1.5s
+STEP     FUNCTION          STAGE   ITERATIONS   DURATION   RESULTS               STATUS
+first    function-first    -       2            1s         1 warning, 1 normal   Ran
+second   function-second   -       -            -          -                     Skipped
+third    function-third    -       1            500ms      -                     Error: boom
+Pipeline error: boom
+`,
+			},
+		},
+		"Wide": {
+			reason: "Should print the results, requirements, and diff of each step in wide mode.",
+			args: args{
+				trace: trace(),
+				wide:  true,
+			},
+			want: want{
+				output: `
+Pipeline trace of revision cool-revision, started 2025-01-01T00:00:00Z, took 1.5s
+STEP     FUNCTION          STAGE   ITERATIONS   DURATION   RESULTS               STATUS
+first    function-first    -       2            1s         1 warning, 1 normal   Ran
+second   function-second   -       -            -          -                     Skipped
+third    function-third    -       1            500ms      -                     Error: boom
+Pipeline error: boom
+
+Step "first":
+  Required resources: cool-resource
+  WARNING Cool: a warning
+  NORMAL -: all good
+  Desired state (-sent, +returned):
+  + added
+`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := PrintPipelineTrace(&buf, tc.args.trace, tc.args.wide)
+
+			if diff := cmp.Diff(tc.want.output, buf.String()); diff != "" {
+				t.Errorf("\n%s\nPrintPipelineTrace(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPrintPipelineTrace(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -93,6 +93,13 @@ func WithPackage(pkg string) DummyManifestOpt {
 	}
 }
 
+// WithEndpoint sets the endpoint of the manifest.
+func WithEndpoint(endpoint string) DummyManifestOpt {
+	return func(m *unstructured.Unstructured) {
+		fieldpath.Pave(m.Object).SetValue("spec.endpoint", endpoint)
+	}
+}
+
 // WithDesiredState sets the desired state of the manifest.
 func WithDesiredState(state v1.PackageRevisionDesiredState) DummyManifestOpt {
 	return func(m *unstructured.Unstructured) {
@@ -19,11 +19,14 @@ package trace
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 
 	"github.com/alecthomas/kong"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -33,6 +36,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/claim"
 
 	"github.com/crossplane/crossplane/v2/apis/pkg"
 	"github.com/crossplane/crossplane/v2/cmd/crank/beta/trace/internal/printer"
@@ -40,6 +44,7 @@ import (
 	"github.com/crossplane/crossplane/v2/cmd/crank/common/resource/xpkg"
 	"github.com/crossplane/crossplane/v2/cmd/crank/common/resource/xrm"
 	"github.com/crossplane/crossplane/v2/cmd/crank/internal"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
 )
 
 const (
@@ -55,6 +60,10 @@ const (
 	errNameDoubled            = "name provided twice, must be provided separately 'TYPE[.VERSION][.GROUP] [NAME]' or in the 'TYPE[.VERSION][.GROUP][/NAME]' format"
 	errInvalidResource        = "invalid resource, must be provided in the 'TYPE[.VERSION][.GROUP][/NAME]' format"
 	errInvalidResourceAndName = "invalid resource and name"
+	errGetPipelineTrace       = "cannot get pipeline trace"
+	errParsePipelineTrace     = "cannot parse pipeline trace"
+	errPipelineTraceOutput    = "--show-pipeline-trace is only supported with the default and wide output formats"
+	errFmtNoPipelineTrace     = "%s %q has no pipeline trace; annotate it or its Composition with %s: %s to record one"
 )
 
 // Cmd builds the trace tree for a Crossplane resource.
@@ -71,6 +80,8 @@ type Cmd struct {
 	ShowPackageRevisions      string `default:"active"                              enum:"active,all,none"                             help:"Show package revisions in the output. One of: active, all, none."    name:"show-package-revisions"`
 	ShowPackageRuntimeConfigs bool   `default:"false"                               help:"Show package runtime configs in the output." name:"show-package-runtime-configs"`
 	Concurrency               int    `default:"5"                                   help:"load concurrency"                            name:"concurrency"`
+	ShowPipelineTrace         bool   `help:"Show the composition function pipeline trace of the XR, if it has one." name:"show-pipeline-trace"`
+	CrossplaneNamespace       string `default:"crossplane-system"                   help:"Namespace Crossplane is installed in. Used to find the pipeline traces of cluster scoped XRs." name:"crossplane-namespace"`
 }
 
 // Help returns help message for the trace command.
@@ -102,6 +113,12 @@ Examples:
 
   # Output debug logs to stderr while redirecting a dot formatted graph to dot
   crossplane beta trace mykind my-res -n my-ns -o dot --verbose | dot -Tpng -o output.png
+
+  # Show what each step of the XR's composition function pipeline did the last
+  # time it ran. Requires the XR or its Composition to be annotated with
+  # crossplane.io/pipeline-trace: Enabled. Use -o wide to show how each step
+  # changed the desired state.
+  crossplane beta trace mykind my-res -n my-ns --show-pipeline-trace -o wide
 `
 }
 
@@ -116,6 +133,10 @@ func (c *Cmd) Run(k *kong.Context, logger logging.Logger) error {
 		return errors.Wrap(err, errInitPrinter)
 	}
 
+	if c.ShowPipelineTrace && printer.Type(c.Output) != printer.TypeDefault && printer.Type(c.Output) != printer.TypeWide {
+		return errors.New(errPipelineTraceOutput)
+	}
+
 	logger.Debug("Built printer", "output", c.Output)
 
 	clientconfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
@@ -203,7 +224,7 @@ func (c *Cmd) Run(k *kong.Context, logger logging.Logger) error {
 	var treeClient resource.TreeClient
 
 	switch {
-	case xpkg.IsPackageType(mapping.GroupVersionKind.GroupKind()):
+	case xpkg.IsPackageType(mapping.GroupVersionKind.GroupKind()), xpkg.IsExternalFunctionType(mapping.GroupVersionKind.GroupKind()):
 		logger.Debug("Requested resource is an Package")
 
 		treeClient, err = xpkg.NewClient(client,
@@ -241,7 +262,49 @@ func (c *Cmd) Run(k *kong.Context, logger logging.Logger) error {
 		return errors.Wrap(err, errCliOutput)
 	}
 
-	return nil
+	if !c.ShowPipelineTrace {
+		return nil
+	}
+
+	t, err := GetPipelineTrace(ctx, client, root, c.CrossplaneNamespace)
+	if err != nil {
+		return errors.Wrap(err, errGetPipelineTrace)
+	}
+
+	return errors.Wrap(printer.PrintPipelineTrace(k.Stdout, t, printer.Type(c.Output) == printer.TypeWide), errCliOutput)
+}
+
+// GetPipelineTrace gets the composition function pipeline trace of the XR at
+// the root of the supplied tree. If the root is a claim it gets the trace of
+// the claim's XR. Traces of cluster scoped XRs are read from the supplied
+// namespace.
+func GetPipelineTrace(ctx context.Context, c client.Reader, root *resource.Resource, namespace string) (*composite.PipelineTrace, error) {
+	xr := root
+
+	cm := claim.Unstructured{Unstructured: root.Unstructured}
+	if cm.GetResourceReference() != nil && len(root.Children) > 0 {
+		xr = root.Children[0]
+	}
+
+	cfg := &v1.ConfigMap{}
+	key := types.NamespacedName{
+		Namespace: composite.PipelineTraceNamespace(&xr.Unstructured, namespace),
+		Name:      composite.PipelineTraceName(xr.Unstructured.GetUID()),
+	}
+
+	if err := c.Get(ctx, key, cfg); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, errors.Errorf(errFmtNoPipelineTrace, xr.Unstructured.GetKind(), xr.Unstructured.GetName(), composite.AnnotationKeyPipelineTrace, composite.PipelineTraceEnabled)
+		}
+		return nil, err
+	}
+
+	t := &composite.PipelineTrace{}
+	if err := json.Unmarshal([]byte(cfg.Data[composite.PipelineTraceKey]), t); err != nil {
+		return nil, errors.Wrap(err, errParsePipelineTrace)
+	}
+
+	return t, nil
 }
 
 func (c *Cmd) getResourceAndName() (string, string, error) {
@@ -487,6 +487,18 @@ func TestConvertToCRDs(t *testing.T) {
 																},
 															},
 														},
+														"pendingCompositionRevision": {
+															Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+															Type:        "object",
+															Required:    []string{"name"},
+															Properties: map[string]extv1.JSONSchemaProps{
+																"name":             {Type: "string"},
+																"resourcesAdded":   {Type: "integer", Format: "int64"},
+																"resourcesRemoved": {Type: "integer", Format: "int64"},
+																"resourcesChanged": {Type: "integer", Format: "int64"},
+																"previewTime":      {Type: "string", Format: "date-time"},
+															},
+														},
 														"claimConditionTypes": {
 															Type:      "array",
 															XListType: ptr.To("set"),
@@ -795,6 +807,18 @@ func TestConvertToCRDs(t *testing.T) {
 																},
 															},
 														},
+														"pendingCompositionRevision": {
+															Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+															Type:        "object",
+															Required:    []string{"name"},
+															Properties: map[string]extv1.JSONSchemaProps{
+																"name":             {Type: "string"},
+																"resourcesAdded":   {Type: "integer", Format: "int64"},
+																"resourcesRemoved": {Type: "integer", Format: "int64"},
+																"resourcesChanged": {Type: "integer", Format: "int64"},
+																"previewTime":      {Type: "string", Format: "date-time"},
+															},
+														},
 														"claimConditionTypes": {
 															Type:      "array",
 															XListType: ptr.To("set"),
@@ -1011,6 +1035,18 @@ func TestConvertToCRDs(t *testing.T) {
 																},
 															},
 														},
+														"pendingCompositionRevision": {
+															Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+															Type:        "object",
+															Required:    []string{"name"},
+															Properties: map[string]extv1.JSONSchemaProps{
+																"name":             {Type: "string"},
+																"resourcesAdded":   {Type: "integer", Format: "int64"},
+																"resourcesRemoved": {Type: "integer", Format: "int64"},
+																"resourcesChanged": {Type: "integer", Format: "int64"},
+																"previewTime":      {Type: "string", Format: "date-time"},
+															},
+														},
 														"claimConditionTypes": {
 															Type:      "array",
 															XListType: ptr.To("set"),
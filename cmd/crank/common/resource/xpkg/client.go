@@ -91,6 +91,11 @@ func NewClient(in client.Client, opts ...ClientOption) (*Client, error) {
 func (kc *Client) GetResourceTree(ctx context.Context, root *resource.Resource) (*resource.Resource, error) {
 	var err error
 
+	// An ExternalFunction has no revisions or dependencies.
+	if IsExternalFunctionType(root.Unstructured.GroupVersionKind().GroupKind()) {
+		return root, nil
+	}
+
 	if !IsPackageType(root.Unstructured.GroupVersionKind().GroupKind()) {
 		return nil, errors.Errorf("resource %s is not a package", root.Unstructured.GetName())
 	}
@@ -64,6 +64,13 @@ func IsPackageRevisionType(gk schema.GroupKind) bool {
 		gk == pkgv1.FunctionRevisionGroupVersionKind.GroupKind()
 }
 
+// IsExternalFunctionType returns true if the GroupKind is a Crossplane
+// ExternalFunction type. An ExternalFunction isn't a package, but it's
+// otherwise treated like a Function package.
+func IsExternalFunctionType(gk schema.GroupKind) bool {
+	return gk == pkgv1beta1.ExternalFunctionGroupVersionKind.GroupKind()
+}
+
 // IsPackageRuntimeConfigType returns true if the GroupKind is a Crossplane runtime
 // config type.
 func IsPackageRuntimeConfigType(gk schema.GroupKind) bool {
@@ -252,3 +252,46 @@ func TestIsPackageRuntimeConfigType(t *testing.T) {
 		})
 	}
 }
+
+func TestIsExternalFunctionType(t *testing.T) {
+	type args struct {
+		gk schema.GroupKind
+	}
+
+	type want struct {
+		ok bool
+	}
+
+	tests := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"V1Beta1ExternalFunctionOK": {
+			reason: "Should return true for a v1beta1 ExternalFunction",
+			args: args{
+				gk: pkgv1beta1.ExternalFunctionGroupVersionKind.GroupKind(),
+			},
+			want: want{
+				ok: true,
+			},
+		},
+		"V1FunctionKO": {
+			reason: "Should return false for a v1 Function",
+			args: args{
+				gk: pkgv1.FunctionGroupVersionKind.GroupKind(),
+			},
+			want: want{
+				ok: false,
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := IsExternalFunctionType(tc.args.gk)
+			if got != tc.want.ok {
+				t.Errorf("%s\nIsExternalFunctionType() = %v, want %v", tc.reason, got, tc.want.ok)
+			}
+		})
+	}
+}
@@ -53,7 +53,7 @@ type Cmd struct {
 	ExtraResources         string            `help:"A YAML file or directory of YAML files specifying required resources (deprecated, use --required-resources)."                              placeholder:"PATH" predictor:"yaml_file_or_directory" type:"path"`
 	RequiredResources      string            `help:"A YAML file or directory of YAML files specifying required resources to pass to the Function pipeline."                                    placeholder:"PATH" predictor:"yaml_file_or_directory" short:"e"   type:"path"`
 	IncludeContext         bool              `help:"Include the context in the rendered output as a resource of kind: Context."                                                                short:"c"`
-	FunctionCredentials    string            `help:"A YAML file or directory of YAML files specifying credentials to use for Functions to render the XR."                                      placeholder:"PATH" predictor:"yaml_file_or_directory" type:"path"`
+	FunctionCredentials    string            `help:"A YAML file or directory of YAML files specifying Secrets and ConfigMaps to use as credentials for Functions to render the XR."            placeholder:"PATH" predictor:"yaml_file_or_directory" type:"path"`
 
 	Timeout time.Duration `default:"1m"                                                                                                     help:"How long to run before timing out."`
 	XRD     string        `help:"A YAML file specifying the CompositeResourceDefinition (XRD) that defines the XR's schema and properties." optional:""                               placeholder:"PATH" type:"existingfile"`
@@ -101,6 +101,11 @@ Use the standard DOCKER_HOST, DOCKER_API_VERSION, DOCKER_CERT_PATH, and
 DOCKER_TLS_VERIFY environment variables to configure how this command connects
 to the Docker daemon.
 
+Use --function-credentials to supply the Secrets and ConfigMaps that pipeline
+steps read credentials from. Render can't request ServiceAccount tokens. To
+supply credentials for the ServiceAccountToken source, include a Secret of type
+kubernetes.io/service-account-token annotated with the ServiceAccount's name.
+
 Examples:
 
   # Simulate creating a new XR.
@@ -200,10 +205,11 @@ func (c *Cmd) Run(k *kong.Context, log logging.Logger) error { //nolint:gocognit
 	}
 
 	fcreds := []corev1.Secret{}
+	fcms := []corev1.ConfigMap{}
 	if c.FunctionCredentials != "" {
-		fcreds, err = LoadCredentials(c.fs, c.FunctionCredentials)
+		fcreds, fcms, err = LoadCredentials(c.fs, c.FunctionCredentials)
 		if err != nil {
-			return errors.Wrapf(err, "cannot load secrets from %q", c.FunctionCredentials)
+			return errors.Wrapf(err, "cannot load credentials from %q", c.FunctionCredentials)
 		}
 	}
 
@@ -254,6 +260,7 @@ func (c *Cmd) Run(k *kong.Context, log logging.Logger) error { //nolint:gocognit
 		Composition:         comp,
 		Functions:           fns,
 		FunctionCredentials: fcreds,
+		FunctionConfigMaps:  fcms,
 		ObservedResources:   ors,
 		ExtraResources:      ers,
 		RequiredResources:   rrs,
@@ -112,24 +112,42 @@ func LoadFunctions(filesys afero.Fs, file string) ([]pkgv1.Function, error) {
 	return functions, nil
 }
 
-// LoadCredentials from a stream of YAML manifests.
-func LoadCredentials(fs afero.Fs, file string) ([]corev1.Secret, error) {
+// LoadCredentials from a stream of YAML manifests. Manifests of kind ConfigMap
+// are loaded as ConfigMaps; all others are loaded as Secrets.
+func LoadCredentials(fs afero.Fs, file string) ([]corev1.Secret, []corev1.ConfigMap, error) {
 	stream, err := LoadYAMLStream(fs, file)
 	if err != nil {
-		return nil, errors.Wrap(err, "cannot load YAML stream from file")
+		return nil, nil, errors.Wrap(err, "cannot load YAML stream from file")
 	}
 
 	secrets := make([]corev1.Secret, 0, len(stream))
+	cms := make([]corev1.ConfigMap, 0)
 	for _, y := range stream {
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(y, u); err != nil {
+			return nil, nil, errors.Wrap(err, "cannot parse YAML credentials manifest")
+		}
+
+		if u.GetKind() == "ConfigMap" {
+			cm := &corev1.ConfigMap{}
+			if err := yaml.Unmarshal(y, cm); err != nil {
+				return nil, nil, errors.Wrap(err, "cannot parse YAML configmap manifest")
+			}
+
+			cms = append(cms, *cm)
+
+			continue
+		}
+
 		s := &corev1.Secret{}
 		if err := yaml.Unmarshal(y, s); err != nil {
-			return nil, errors.Wrap(err, "cannot parse YAML secret manifest")
+			return nil, nil, errors.Wrap(err, "cannot parse YAML secret manifest")
 		}
 
 		secrets = append(secrets, *s)
 	}
 
-	return secrets, nil
+	return secrets, cms, nil
 }
 
 // LoadRequiredResources from a stream of YAML manifests.
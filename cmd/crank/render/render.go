@@ -26,6 +26,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -71,6 +72,7 @@ type Inputs struct {
 	Composition         *apiextensionsv1.Composition
 	Functions           []pkgv1.Function
 	FunctionCredentials []corev1.Secret
+	FunctionConfigMaps  []corev1.ConfigMap
 	ObservedResources   []composed.Unstructured
 	ExtraResources      []unstructured.Unstructured
 	RequiredResources   []unstructured.Unstructured
@@ -185,6 +187,93 @@ func getSecret(name string, nameSpace string, secrets []corev1.Secret) (*corev1.
 	return nil, errors.Errorf("secret %q not found", name)
 }
 
+// getConfigMap retrieves the ConfigMap with the specified name and namespace
+// from the provided list of ConfigMaps.
+func getConfigMap(name string, namespace string, cms []corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	for _, cm := range cms {
+		if cm.GetName() == name && cm.GetNamespace() == namespace {
+			return &cm, nil
+		}
+	}
+
+	return nil, errors.Errorf("configmap %q not found", name)
+}
+
+// getServiceAccountToken retrieves the token of the specified ServiceAccount
+// from a legacy ServiceAccount token secret in the provided list of secrets.
+// We can't request a short-lived token when rendering offline, so users
+// supply one this way instead.
+func getServiceAccountToken(name string, namespace string, secrets []corev1.Secret) ([]byte, error) {
+	for _, s := range secrets {
+		if s.Type != corev1.SecretTypeServiceAccountToken || s.GetNamespace() != namespace {
+			continue
+		}
+		if s.GetAnnotations()[corev1.ServiceAccountNameKey] != name {
+			continue
+		}
+		return s.Data[corev1.ServiceAccountTokenKey], nil
+	}
+
+	return nil, errors.Errorf("token secret for service account %q not found", name)
+}
+
+// getCredentials returns the supplied pipeline step credentials, loaded from
+// the provided secrets and ConfigMaps. It returns nil credentials for the None
+// source.
+func getCredentials(cs apiextensionsv1.FunctionCredentials, xr *ucomposite.Unstructured, in Inputs) (*fnv1.Credentials, error) {
+	switch cs.Source {
+	case apiextensionsv1.FunctionCredentialsSourceNone:
+		return nil, nil
+	case apiextensionsv1.FunctionCredentialsSourceSecret:
+		if cs.SecretRef == nil {
+			return nil, errors.New("the Secret source requires a secretRef")
+		}
+		s, err := getSecret(cs.SecretRef.Name, cs.SecretRef.Namespace, in.FunctionCredentials)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get credentials from secret %q", cs.SecretRef.Name)
+		}
+		return xfn.CredentialsFromData(s.Data), nil
+	case apiextensionsv1.FunctionCredentialsSourceConfigMap:
+		if cs.ConfigMapRef == nil {
+			return nil, errors.New("the ConfigMap source requires a configMapRef")
+		}
+		cm, err := getConfigMap(cs.ConfigMapRef.Name, cs.ConfigMapRef.Namespace, in.FunctionConfigMaps)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get credentials from configmap %q", cs.ConfigMapRef.Name)
+		}
+		return xfn.CredentialsFromConfigMap(cm), nil
+	case apiextensionsv1.FunctionCredentialsSourceServiceAccountToken:
+		if cs.ServiceAccountToken == nil {
+			return nil, errors.New("the ServiceAccountToken source requires a serviceAccountToken")
+		}
+		ref := cs.ServiceAccountToken.ServiceAccountRef
+		ns, err := composite.CredentialsNamespace(xr.UnstructuredContent(), ref.Namespace, cs.ServiceAccountToken.AllowedNamespaces)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid reference to service account %q", ref.Name)
+		}
+		t, err := getServiceAccountToken(ref.Name, ns, in.FunctionCredentials)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get credentials from service account %q", ref.Name)
+		}
+		return xfn.CredentialsFromData(map[string][]byte{xfn.CredentialsKeyToken: t}), nil
+	case apiextensionsv1.FunctionCredentialsSourceCompositeSecretRef:
+		if cs.CompositeSecretRef == nil {
+			return nil, errors.New("the CompositeSecretRef source requires a compositeSecretRef")
+		}
+		ref, err := composite.CompositeSecretRef(xr.UnstructuredContent(), cs.CompositeSecretRef.FieldPath, cs.CompositeSecretRef.AllowedNamespaces)
+		if err != nil {
+			return nil, err
+		}
+		s, err := getSecret(ref.Name, ref.Namespace, in.FunctionCredentials)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get credentials from secret %q", ref.Name)
+		}
+		return xfn.CredentialsFromData(s.Data), nil
+	}
+
+	return nil, errors.Errorf("unknown credentials source %q", cs.Source)
+}
+
 // Render the desired XR and composed resources, sorted by resource name, given the supplied inputs.
 func Render(ctx context.Context, log logging.Logger, in Inputs) (Outputs, error) { //nolint:gocognit // TODO(negz): Should we refactor to break this up a bit?
 	runtimes, err := NewRuntimeFunctionRunner(ctx, log, in.Functions)
@@ -250,102 +339,141 @@ func Render(ctx context.Context, log logging.Logger, in Inputs) (Outputs, error)
 
 	// Run any Composition Functions in the pipeline. Each Function may mutate
 	// the desired state returned by the last, and each Function may produce
-	// results.
-	for _, fn := range in.Composition.Spec.Pipeline {
-		// The request to send to the function, will be updated at each iteration if needed.
-		req := &fnv1.RunFunctionRequest{Observed: o, Desired: d, Context: fctx}
-
-		if fn.Input != nil {
-			in := &structpb.Struct{}
-			if err := in.UnmarshalJSON(fn.Input.Raw); err != nil {
-				return Outputs{}, errors.Wrapf(err, "cannot unmarshal input for Composition pipeline step %q", fn.Step)
-			}
+	// results. Crossplane runs the Functions in a stage concurrently. We run
+	// them one at a time, but merge their desired state and context the same
+	// way Crossplane does.
+	for _, stage := range composite.PipelineStages(in.Composition.Spec.Pipeline) {
+		s, err := composite.SkipSteps(stage, o, d, fctx)
+		if err != nil {
+			return Outputs{}, err
+		}
 
-			req.Input = in
+		// Every step in this stage was skipped.
+		if len(s.Steps) == 0 {
+			continue
 		}
 
-		req.Credentials = map[string]*fnv1.Credentials{}
-		for _, cs := range fn.Credentials {
-			// For now we only support loading credentials from secrets.
-			if cs.Source != apiextensionsv1.FunctionCredentialsSourceSecret || cs.SecretRef == nil {
-				continue
+		rsps := make([]*fnv1.RunFunctionResponse, len(s.Steps))
+
+		for i, fn := range s.Steps {
+			sd, sctx := d, fctx
+
+			// Each step in a stage is sent the same desired state and
+			// context.
+			if len(s.Steps) > 1 {
+				sd = proto.CloneOf(d)
+				sctx = proto.CloneOf(fctx)
 			}
 
-			s, err := getSecret(cs.SecretRef.Name, cs.SecretRef.Namespace, in.FunctionCredentials)
-			if err != nil {
-				return Outputs{}, errors.Wrapf(err, "cannot get credentials from secret %q", cs.SecretRef.Name)
+			// The request to send to the function, will be updated at each iteration if needed.
+			req := &fnv1.RunFunctionRequest{Observed: o, Desired: sd, Context: sctx}
+
+			if fn.Input != nil {
+				in := &structpb.Struct{}
+				if err := in.UnmarshalJSON(fn.Input.Raw); err != nil {
+					return Outputs{}, errors.Wrapf(err, "cannot unmarshal input for Composition pipeline step %q", fn.Step)
+				}
+
+				req.Input = in
 			}
 
-			req.Credentials[cs.Name] = &fnv1.Credentials{
-				Source: &fnv1.Credentials_CredentialData{
-					CredentialData: &fnv1.CredentialData{
-						Data: s.Data,
-					},
-				},
+			req.Credentials = map[string]*fnv1.Credentials{}
+			for _, cs := range fn.Credentials {
+				creds, err := getCredentials(cs, in.CompositeResource, in)
+				if err != nil {
+					return Outputs{}, errors.Wrapf(err, "cannot get credential %q for pipeline step %q", cs.Name, fn.Step)
+				}
+
+				if creds != nil {
+					req.Credentials[cs.Name] = creds
+				}
 			}
-		}
 
-		rsp, err := runner.RunFunction(ctx, fn.FunctionRef.Name, req)
-		if err != nil {
-			return Outputs{}, errors.Wrapf(err, "cannot run pipeline step %q", fn.Step)
-		}
+			rsp, err := runner.RunFunction(ctx, fn.FunctionRef.Name, req)
+			if err != nil {
+				return Outputs{}, errors.Wrapf(err, "cannot run pipeline step %q", fn.Step)
+			}
 
-		// Pass the desired state returned by this Function to the next one.
-		d = rsp.GetDesired()
+			rsps[i] = rsp
 
-		// Pass the Function context returned by this Function to the next one.
-		// We intentionally discard/ignore this after the last Function runs.
-		fctx = rsp.GetContext()
+			for _, c := range rsp.GetConditions() {
+				var status corev1.ConditionStatus
 
-		for _, c := range rsp.GetConditions() {
-			var status corev1.ConditionStatus
+				switch c.GetStatus() {
+				case fnv1.Status_STATUS_CONDITION_TRUE:
+					status = corev1.ConditionTrue
+				case fnv1.Status_STATUS_CONDITION_FALSE:
+					status = corev1.ConditionFalse
+				case fnv1.Status_STATUS_CONDITION_UNKNOWN, fnv1.Status_STATUS_CONDITION_UNSPECIFIED:
+					status = corev1.ConditionUnknown
+				}
+
+				conditions = append(conditions, xpv1.Condition{
+					Type:               xpv1.ConditionType(c.GetType()),
+					Status:             status,
+					LastTransitionTime: conditionTime(),
+					Reason:             xpv1.ConditionReason(c.GetReason()),
+					Message:            c.GetMessage(),
+				})
+			}
 
-			switch c.GetStatus() {
-			case fnv1.Status_STATUS_CONDITION_TRUE:
-				status = corev1.ConditionTrue
-			case fnv1.Status_STATUS_CONDITION_FALSE:
-				status = corev1.ConditionFalse
-			case fnv1.Status_STATUS_CONDITION_UNKNOWN, fnv1.Status_STATUS_CONDITION_UNSPECIFIED:
-				status = corev1.ConditionUnknown
+			if rsp.GetRequirements() != nil {
+				requirements[fn.Step] = *rsp.GetRequirements()
 			}
 
-			conditions = append(conditions, xpv1.Condition{
-				Type:               xpv1.ConditionType(c.GetType()),
-				Status:             status,
-				LastTransitionTime: conditionTime(),
-				Reason:             xpv1.ConditionReason(c.GetReason()),
-				Message:            c.GetMessage(),
-			})
-		}
-
-		if rsp.GetRequirements() != nil {
-			requirements[fn.Step] = *rsp.GetRequirements()
-		}
-
-		// Results of fatal severity stop the Composition process.
-		for _, rs := range rsp.GetResults() {
-			switch rs.GetSeverity() { //nolint:exhaustive // We intentionally have a broad default case.
-			case fnv1.Severity_SEVERITY_FATAL:
-				// Even in the fatal case, return requirements if they exist, so that the caller can try to satisfy them
-				return Outputs{Requirements: requirements}, errors.Errorf("pipeline step %q returned a fatal result: %s", fn.Step, rs.GetMessage())
-			default:
-				results = append(results, unstructured.Unstructured{Object: map[string]any{
-					"apiVersion": "render.crossplane.io/v1beta1",
-					"kind":       "Result",
-					"step":       fn.Step,
-					"severity":   rs.GetSeverity().String(),
-					"message":    rs.GetMessage(),
-				}})
+			// Results of fatal severity stop the Composition process.
+			for _, rs := range rsp.GetResults() {
+				switch rs.GetSeverity() { //nolint:exhaustive // We intentionally have a broad default case.
+				case fnv1.Severity_SEVERITY_FATAL:
+					// Even in the fatal case, return requirements if they exist, so that the caller can try to satisfy them
+					return Outputs{Requirements: requirements}, errors.Errorf("pipeline step %q returned a fatal result: %s", fn.Step, rs.GetMessage())
+				default:
+					results = append(results, unstructured.Unstructured{Object: map[string]any{
+						"apiVersion": "render.crossplane.io/v1beta1",
+						"kind":       "Result",
+						"step":       fn.Step,
+						"severity":   rs.GetSeverity().String(),
+						"message":    rs.GetMessage(),
+					}})
+				}
 			}
 		}
+
+		// Pass the desired state and Function context returned by this
+		// stage to the next one. We intentionally discard/ignore the
+		// context after the last stage runs.
+		d, fctx, err = composite.MergeStage(s, d, fctx, rsps)
+		if err != nil {
+			return Outputs{Requirements: requirements}, err
+		}
 	}
 
 	desired := make([]composed.Unstructured, 0, len(d.GetResources()))
 
 	var unready []string
 
+	checks := map[string][]apiextensionsv1.ReadinessCheck{}
+	for _, rc := range in.Composition.Spec.ReadinessChecks {
+		checks[rc.ResourceName] = rc.Checks
+	}
+
 	for name, dr := range d.GetResources() {
-		if dr.GetReady() != fnv1.Ready_READY_TRUE {
+		switch {
+		case dr.GetReady() == fnv1.Ready_READY_TRUE:
+			// The Function pipeline said this resource is ready.
+		case dr.GetReady() == fnv1.Ready_READY_UNSPECIFIED && len(checks[name]) > 0:
+			// The Composition's readiness checks determine whether this
+			// resource is ready. We can only check observed resources.
+			or, ok := observed[composite.ResourceName(name)]
+			if !ok {
+				unready = append(unready, fmt.Sprintf("%s (not yet observed)", name))
+				break
+			}
+
+			if ready, why := composite.IsReady(ctx, or.Resource, checks[name]); !ready {
+				unready = append(unready, fmt.Sprintf("%s (%s)", name, why))
+			}
+		default:
 			unready = append(unready, name)
 		}
 
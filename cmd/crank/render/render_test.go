@@ -27,6 +27,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/structpb"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -1298,6 +1299,201 @@ func TestRender(t *testing.T) {
 				},
 			},
 		},
+		"ReadinessChecks": {
+			reason: "When a composed resource's Ready is unspecified the Composition's readiness checks should determine whether it's ready, and why not",
+			args: args{
+				ctx: context.Background(),
+				in: Inputs{
+					CompositeResource: &ucomposite.Unstructured{
+						Unstructured: unstructured.Unstructured{
+							Object: MustLoadJSON(`{
+								"apiVersion": "nop.example.org/v1alpha1",
+								"kind": "XNopResource",
+								"metadata": {
+									"name": "test-render"
+								}
+							}`),
+						},
+					},
+					Composition: &apiextensionsv1.Composition{
+						Spec: apiextensionsv1.CompositionSpec{
+							Mode: apiextensionsv1.CompositionModePipeline,
+							Pipeline: []apiextensionsv1.PipelineStep{
+								{
+									Step:        "test",
+									FunctionRef: apiextensionsv1.FunctionReference{Name: "function-test"},
+								},
+							},
+							ReadinessChecks: []apiextensionsv1.ComposedReadinessChecks{
+								{
+									ResourceName: "a-cool-resource",
+									Checks: []apiextensionsv1.ReadinessCheck{{
+										Type:        apiextensionsv1.ReadinessCheckTypeMatchString,
+										FieldPath:   ptr.To("status.phase"),
+										MatchString: ptr.To("Running"),
+									}},
+								},
+								{
+									ResourceName: "b-cool-resource",
+									Checks: []apiextensionsv1.ReadinessCheck{{
+										Type:       apiextensionsv1.ReadinessCheckTypeExpression,
+										Expression: ptr.To("resource.status.phase == 'Running'"),
+									}},
+								},
+							},
+						},
+					},
+					ObservedResources: []composed.Unstructured{
+						{
+							Unstructured: unstructured.Unstructured{
+								Object: MustLoadJSON(`{
+									"apiVersion": "atest.crossplane.io/v1",
+									"kind": "AComposed",
+									"metadata": {
+										"name": "test-render-a",
+										"annotations": {
+											"crossplane.io/composition-resource-name": "a-cool-resource"
+										}
+									},
+									"status": {
+										"phase": "Pending"
+									}
+								}`),
+							},
+						},
+						{
+							Unstructured: unstructured.Unstructured{
+								Object: MustLoadJSON(`{
+									"apiVersion": "btest.crossplane.io/v1",
+									"kind": "BComposed",
+									"metadata": {
+										"name": "test-render-b",
+										"annotations": {
+											"crossplane.io/composition-resource-name": "b-cool-resource"
+										}
+									},
+									"status": {
+										"phase": "Running"
+									}
+								}`),
+							},
+						},
+					},
+					Functions: []pkgv1.Function{
+						func() pkgv1.Function {
+							lis := NewFunction(t, &fnv1.RunFunctionResponse{
+								Desired: &fnv1.State{
+									Composite: &fnv1.Resource{
+										Resource: MustStructJSON(`{}`),
+									},
+									Resources: map[string]*fnv1.Resource{
+										"a-cool-resource": {
+											Resource: MustStructJSON(`{
+												"apiVersion": "atest.crossplane.io/v1",
+												"kind": "AComposed"
+											}`),
+										},
+										"b-cool-resource": {
+											Resource: MustStructJSON(`{
+												"apiVersion": "btest.crossplane.io/v1",
+												"kind": "BComposed"
+											}`),
+										},
+									},
+								},
+							})
+							listeners = append(listeners, lis)
+
+							return pkgv1.Function{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "function-test",
+									Annotations: map[string]string{
+										AnnotationKeyRuntime:                  string(AnnotationValueRuntimeDevelopment),
+										AnnotationKeyRuntimeDevelopmentTarget: lis.Addr().String(),
+									},
+								},
+							}
+						}(),
+					},
+				},
+			},
+			want: want{
+				out: Outputs{
+					CompositeResource: &ucomposite.Unstructured{
+						Unstructured: unstructured.Unstructured{
+							Object: MustLoadJSON(`{
+								"apiVersion": "nop.example.org/v1alpha1",
+								"kind": "XNopResource",
+								"metadata": {
+									"name": "test-render"
+								},
+								"status": {
+									"conditions": [{
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+										"type": "Ready",
+										"status": "False",
+										"reason": "Creating",
+										"message": "Unready resources: a-cool-resource (status.phase is \"Pending\", not \"Running\")"
+									}]
+								}
+							}`),
+						},
+					},
+					ComposedResources: []composed.Unstructured{
+						{
+							Unstructured: unstructured.Unstructured{
+								Object: MustLoadJSON(`{
+									"apiVersion": "atest.crossplane.io/v1",
+									"kind": "AComposed",
+									"metadata": {
+										"name": "test-render-a",
+										"labels": {
+											"crossplane.io/composite": "test-render"
+										},
+										"annotations": {
+											"crossplane.io/composition-resource-name": "a-cool-resource"
+										},
+										"ownerReferences": [{
+											"apiVersion": "nop.example.org/v1alpha1",
+											"kind": "XNopResource",
+											"name": "test-render",
+											"blockOwnerDeletion": true,
+											"controller": true,
+											"uid": ""
+										}]
+									}
+								}`),
+							},
+						},
+						{
+							Unstructured: unstructured.Unstructured{
+								Object: MustLoadJSON(`{
+									"apiVersion": "btest.crossplane.io/v1",
+									"kind": "BComposed",
+									"metadata": {
+										"name": "test-render-b",
+										"labels": {
+											"crossplane.io/composite": "test-render"
+										},
+										"annotations": {
+											"crossplane.io/composition-resource-name": "b-cool-resource"
+										},
+										"ownerReferences": [{
+											"apiVersion": "nop.example.org/v1alpha1",
+											"kind": "XNopResource",
+											"name": "test-render",
+											"blockOwnerDeletion": true,
+											"controller": true,
+											"uid": ""
+										}]
+									}
+								}`),
+							},
+						},
+					},
+				},
+			},
+		},
 		"CompositeReadyUnspecifiedWithUnreadyResources": {
 			reason: "When composite resource Ready is unspecified and there are unready resources, XR condition should be Creating with message",
 			args: args{
@@ -1913,6 +2109,121 @@ func TestGetSecret(t *testing.T) {
 	}
 }
 
+func TestGetCredentials(t *testing.T) {
+	in := Inputs{
+		FunctionCredentials: []corev1.Secret{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "cool-secret", Namespace: "cool-ns"},
+				Data:       map[string][]byte{"password": []byte("hunter2")},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cool-sa-token",
+					Namespace:   "cool-ns",
+					Annotations: map[string]string{corev1.ServiceAccountNameKey: "cool-sa"},
+				},
+				Type: corev1.SecretTypeServiceAccountToken,
+				Data: map[string][]byte{corev1.ServiceAccountTokenKey: []byte("cool-token")},
+			},
+		},
+		FunctionConfigMaps: []corev1.ConfigMap{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "cool-cm", Namespace: "cool-ns"},
+				Data:       map[string]string{"url": "https://example.org"},
+			},
+		},
+	}
+
+	xr := ucomposite.New()
+	xr.SetNamespace("cool-ns")
+	xr.Object["spec"] = map[string]any{"credentialsRef": map[string]any{"name": "cool-secret"}}
+
+	type want struct {
+		creds *fnv1.Credentials
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cs     apiextensionsv1.FunctionCredentials
+		want   want
+	}{
+		"None": {
+			reason: "We should return no credentials for the None source.",
+			cs:     apiextensionsv1.FunctionCredentials{Name: "none", Source: apiextensionsv1.FunctionCredentialsSourceNone},
+		},
+		"ConfigMap": {
+			reason: "We should return credentials from a ConfigMap.",
+			cs: apiextensionsv1.FunctionCredentials{
+				Name:         "cm",
+				Source:       apiextensionsv1.FunctionCredentialsSourceConfigMap,
+				ConfigMapRef: &apiextensionsv1.ConfigMapReference{Name: "cool-cm", Namespace: "cool-ns"},
+			},
+			want: want{
+				creds: xfn.CredentialsFromData(map[string][]byte{"url": []byte("https://example.org")}),
+			},
+		},
+		"ServiceAccountToken": {
+			reason: "We should return a ServiceAccount token from a ServiceAccount token secret.",
+			cs: apiextensionsv1.FunctionCredentials{
+				Name:   "token",
+				Source: apiextensionsv1.FunctionCredentialsSourceServiceAccountToken,
+				ServiceAccountToken: &apiextensionsv1.ServiceAccountTokenSource{
+					ServiceAccountRef: apiextensionsv1.ServiceAccountReference{Name: "cool-sa", Namespace: "cool-ns"},
+				},
+			},
+			want: want{
+				creds: xfn.CredentialsFromData(map[string][]byte{xfn.CredentialsKeyToken: []byte("cool-token")}),
+			},
+		},
+		"ServiceAccountTokenOtherNamespace": {
+			reason: "We should return an error if a namespaced XR references a ServiceAccount in another namespace.",
+			cs: apiextensionsv1.FunctionCredentials{
+				Name:   "token",
+				Source: apiextensionsv1.FunctionCredentialsSourceServiceAccountToken,
+				ServiceAccountToken: &apiextensionsv1.ServiceAccountTokenSource{
+					ServiceAccountRef: apiextensionsv1.ServiceAccountReference{Name: "cool-sa", Namespace: "other-ns"},
+				},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"CompositeSecretRef": {
+			reason: "We should return credentials from a secret referenced by the XR.",
+			cs: apiextensionsv1.FunctionCredentials{
+				Name:               "xr",
+				Source:             apiextensionsv1.FunctionCredentialsSourceCompositeSecretRef,
+				CompositeSecretRef: &apiextensionsv1.CompositeSecretReference{FieldPath: "spec.credentialsRef"},
+			},
+			want: want{
+				creds: xfn.CredentialsFromData(map[string][]byte{"password": []byte("hunter2")}),
+			},
+		},
+		"UnknownSource": {
+			reason: "We should return an error for an unknown source.",
+			cs:     apiextensionsv1.FunctionCredentials{Name: "unknown", Source: "Cool"},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := getCredentials(tc.cs, xr, in)
+
+			if diff := cmp.Diff(tc.want.creds, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\ngetCredentials(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ngetCredentials(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func MustStructJSON(j string) *structpb.Struct {
 	s := &structpb.Struct{}
 	if err := protojson.Unmarshal([]byte(j), s); err != nil {
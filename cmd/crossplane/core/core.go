@@ -28,12 +28,16 @@ import (
 
 	"github.com/alecthomas/kong"
 	"github.com/spf13/afero"
+	"go.opentelemetry.io/otel"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	kcache "k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -62,7 +66,10 @@ import (
 	"github.com/crossplane/crossplane/v2/internal/initializer"
 	"github.com/crossplane/crossplane/v2/internal/metrics"
 	"github.com/crossplane/crossplane/v2/internal/protection/usage"
+	"github.com/crossplane/crossplane/v2/internal/tracing"
 	"github.com/crossplane/crossplane/v2/internal/transport"
+	"github.com/crossplane/crossplane/v2/internal/version"
+	xrconversion "github.com/crossplane/crossplane/v2/internal/webhook/conversion"
 	usagehook "github.com/crossplane/crossplane/v2/internal/webhook/protection/usage"
 	"github.com/crossplane/crossplane/v2/internal/xfn"
 	"github.com/crossplane/crossplane/v2/internal/xfn/cached"
@@ -73,6 +80,8 @@ import (
 type Command struct {
 	Start startCommand `cmd:"" help:"Start Crossplane controllers."`
 	Init  initCommand  `cmd:"" help:"Make cluster ready for Crossplane controllers."`
+
+	XfnCache xfnCacheCommand `cmd:"" help:"Inspect and purge the function response cache." name:"xfn-cache"`
 }
 
 // KongVars represent the kong variables associated with the CLI parser
@@ -112,20 +121,59 @@ type startCommand struct {
 	MetricsPort     int `default:"8080" env:"METRICS_PORT"      help:"The port the metrics server listens on."`
 	HealthProbePort int `default:"8081" env:"HEALTH_PROBE_PORT" help:"The port the health probe endpoint listens on."`
 
+	WebhookServiceName      string `env:"WEBHOOK_SERVICE_NAME"      help:"The name of the Service that exposes the webhook server. Required by --enable-composite-resource-conversion."`
+	WebhookServiceNamespace string `env:"WEBHOOK_SERVICE_NAMESPACE" help:"The namespace of the Service that exposes the webhook server. Defaults to --namespace."`
+	WebhookServicePort      int32  `default:"9443"                  env:"WEBHOOK_SERVICE_PORT" help:"The port of the Service that exposes the webhook server."`
+
+	TracingExporter    string  `default:"none" enum:"none,otlp,stdout" env:"TRACING_EXPORTER"     help:"Where to export OpenTelemetry traces. Use otlp to export to an OTLP collector over gRPC, or stdout for debugging."`
+	TracingEndpoint    string  `env:"TRACING_ENDPOINT"                                                 help:"The OTLP collector endpoint (host:port) to export traces to. Defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable."`
+	TracingInsecure    bool    `env:"TRACING_INSECURE"                                                 help:"Export traces to the OTLP collector without TLS."`
+	TracingSampleRatio float64 `default:"1.0"                          env:"TRACING_SAMPLE_RATIO" help:"The fraction of reconciles to trace, between 0 and 1. Reconciles with a traced parent are always traced."`
+
 	TLSServerSecretName string `env:"TLS_SERVER_SECRET_NAME" help:"The name of the TLS Secret that will store Crossplane's server certificate."`
 	TLSServerCertsDir   string `env:"TLS_SERVER_CERTS_DIR"   help:"The path of the folder which will store TLS server certificate of Crossplane."`
 	TLSClientSecretName string `env:"TLS_CLIENT_SECRET_NAME" help:"The name of the TLS Secret that will be store Crossplane's client certificate."`
 	TLSClientCertsDir   string `env:"TLS_CLIENT_CERTS_DIR"   help:"The path of the folder which will store TLS client certificate of Crossplane."`
 
-	EnableDependencyVersionUpgrades   bool `group:"Alpha Features:" help:"Enable support for upgrading dependency versions when the parent package is updated."`
-	EnableDependencyVersionDowngrades bool `group:"Alpha Features:" help:"Enable support for upgrading and downgrading dependency versions when a dependent package is updated."`
-	EnableSignatureVerification       bool `group:"Alpha Features:" help:"Enable support for package signature verification via ImageConfig API."`
-	EnableFunctionResponseCache       bool `group:"Alpha Features:" help:"Enable support for caching composition function responses."`
-	EnableOperations                  bool `group:"Alpha Features:" help:"Enable support for Operations."`
+	EnableDependencyVersionUpgrades    bool `group:"Alpha Features:" help:"Enable support for upgrading dependency versions when the parent package is updated."`
+	EnableDependencyVersionDowngrades  bool `group:"Alpha Features:" help:"Enable support for upgrading and downgrading dependency versions when a dependent package is updated."`
+	EnableSignatureVerification        bool `group:"Alpha Features:" help:"Enable support for package signature verification via ImageConfig API."`
+	EnableFunctionResponseCache        bool `group:"Alpha Features:" help:"Enable support for caching composition function responses."`
+	EnableOperations                   bool `group:"Alpha Features:" help:"Enable support for Operations."`
+	EnableExternalFunctions            bool `group:"Alpha Features:" help:"Enable support for ExternalFunctions, i.e. composition functions that run outside of Crossplane's control."`
+	EnablePipelineTraces               bool `group:"Alpha Features:" help:"Enable support for recording composition function pipeline traces of XRs annotated with crossplane.io/pipeline-trace: Enabled."`
+	EnableCompositionPreviews          bool `group:"Alpha Features:" help:"Enable support for CompositionPreviews, which dry-run a Composition against live cluster state."`
+	EnableStagedRollouts               bool `group:"Alpha Features:" help:"Enable support for Compositions that roll out new revisions in stages, gated by approval, a canary, or progressive waves."`
+	EnableCompositionSelectionPolicies bool `group:"Alpha Features:" help:"Enable support for selecting Compositions by CEL expression and weight."`
+	EnableReadinessChecks              bool `group:"Alpha Features:" help:"Enable support for readiness checks of composed resources defined in the Composition."`
+	EnableComposedResourceOrdering     bool `group:"Alpha Features:" help:"Enable ordering the creation and deletion of composed resources using the crossplane.io/depends-on annotation."`
+	EnableComposedDeletePolicies       bool `group:"Alpha Features:" help:"Enable delete policies that orphan composed resources when they're removed from the desired state, or when their composite resource is deleted."`
+	EnableCompositeResourceConversion  bool `group:"Alpha Features:" help:"Enable a conversion webhook that converts composite resources and claims between the versions of XRDs that configure a converter."`
+	EnableStorageVersionMigration      bool `group:"Alpha Features:" help:"Enable migrating composite resources and claims to their XRD's referenceable version when it changes."`
+	EnableCompositeResourceValidation  bool `group:"Alpha Features:" help:"Enable adding XRDs' validation rules and immutable fields to the schemas of composite resources and claims."`
+	EnableClaimFieldMappings           bool `group:"Alpha Features:" help:"Enable customizing which fields propagate between claims and composite resources."`
+	EnableClaimPolicies                bool `group:"Alpha Features:" help:"Enable XRD claim policies that limit how many claims each namespace may create and which compositions they may use."`
+	EnableFunctionEndpointSlices       bool `group:"Alpha Features:" help:"Enable resolving the endpoints of function packages by reading their Service's EndpointSlices, rather than using DNS. This spreads calls across function replicas as soon as they're ready."`
+	EnableFunctionCircuitBreaker       bool `group:"Alpha Features:" help:"Enable a circuit breaker per function that fails calls fast while the function appears to be unhealthy, and reflects its state in the function's status."`
+	EnableFunctionServiceAccountTokens bool `group:"Alpha Features:" help:"Enable supplying functions with short-lived ServiceAccount tokens using the ServiceAccountToken credentials source. Crossplane needs RBAC to create tokens for any ServiceAccount."`
+
+	OperationServiceAccountTokenNamespaces []string `env:"OPERATION_SERVICE_ACCOUNT_TOKEN_NAMESPACES" group:"Alpha Features:" help:"A comma-separated list of namespaces whose ServiceAccounts Operations may request tokens for. Operations may not request tokens unless this is set. Requires --enable-function-service-account-tokens." placeholder:"NAMESPACE"`
+
+	XfnCircuitBreakerThreshold    int           `default:"5"   env:"XFN_CIRCUIT_BREAKER_THRESHOLD"     group:"Alpha Features:" help:"Number of consecutive failed calls to a function that open its circuit breaker, causing further calls to fail fast. Requires --enable-function-circuit-breaker."`
+	XfnCircuitBreakerOpenDuration time.Duration `default:"30s" env:"XFN_CIRCUIT_BREAKER_OPEN_DURATION" group:"Alpha Features:" help:"How long a function's circuit breaker stays open before a call is let through to probe whether the function has recovered. Requires --enable-function-circuit-breaker."`
 
 	XfnCacheDir    string        `default:"/cache/xfn" env:"XFN_CACHE_DIR"     group:"Alpha Features:" help:"Directory used for caching function responses. Requires --enable-function-response-cache."`
 	XfnCacheMaxTTL time.Duration `default:"24h"        env:"XFN_CACHE_MAX_TTL" group:"Alpha Features:" help:"Maximum TTL for cached function responses. Set to 0 to disable. Requires --enable-function-response-cache."`
 
+	XfnCacheBackend             string `default:"filesystem" enum:"filesystem,memory,redis" env:"XFN_CACHE_BACKEND"        group:"Alpha Features:" help:"Where to cache function responses. Use redis to share cached responses between Crossplane replicas. Requires --enable-function-response-cache."`
+	XfnCacheMaxBytes            int64  `default:"268435456" env:"XFN_CACHE_MAX_BYTES"              group:"Alpha Features:" help:"Maximum total size in bytes of cached function responses. Least recently used responses are evicted to stay within this size. Set to 0 for no limit. Requires --enable-function-response-cache."`
+	XfnCacheMaxBytesPerFunction int64  `default:"0"         env:"XFN_CACHE_MAX_BYTES_PER_FUNCTION" group:"Alpha Features:" help:"Maximum size in bytes of cached responses for each function. Set to 0 for no per-function limit. Requires --enable-function-response-cache."`
+	XfnCacheRedisAddress        string `env:"XFN_CACHE_REDIS_ADDRESS"                       group:"Alpha Features:"        help:"Address (host:port) of the Redis server used to cache function responses. Requires --xfn-cache-backend=redis."`
+	XfnCacheRedisPassword       string `env:"XFN_CACHE_REDIS_PASSWORD"                      group:"Alpha Features:"        help:"Password used to authenticate to the Redis server used to cache function responses. Requires --xfn-cache-backend=redis."`
+	XfnCacheRedisDB             int    `env:"XFN_CACHE_REDIS_DB"                            group:"Alpha Features:"        help:"Redis logical database used to cache function responses. Requires --xfn-cache-backend=redis."`
+	XfnCacheRedisTLS            bool   `env:"XFN_CACHE_REDIS_TLS"                           group:"Alpha Features:"        help:"Use TLS to connect to the Redis server used to cache function responses. Requires --xfn-cache-backend=redis."`
+	XfnCacheRedisCABundlePath   string `env:"XFN_CACHE_REDIS_CA_BUNDLE_PATH"                group:"Alpha Features:"        help:"Additional CA bundle to use when verifying the Redis server's TLS certificate. Requires --xfn-cache-redis-tls."`
+
 	EnableDeploymentRuntimeConfigs          bool `default:"true" group:"Beta Features:" help:"Enable support for Deployment Runtime Configs."`
 	EnableUsages                            bool `default:"true" group:"Beta Features:" help:"Enable support for deletion ordering and resource protection with Usages."`
 	EnableSSAClaims                         bool `default:"true" group:"Beta Features:" help:"Enable support for using Kubernetes server-side apply to sync claims with composite resources (XRs)."`
@@ -160,6 +208,28 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	tp, err := tracing.NewTracerProvider(ctx, tracing.ProviderOptions{
+		Exporter:    c.TracingExporter,
+		Endpoint:    c.TracingEndpoint,
+		Insecure:    c.TracingInsecure,
+		SampleRatio: c.TracingSampleRatio,
+		Version:     version.New().GetVersionString(),
+		Writer:      os.Stdout,
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot create tracer provider")
+	}
+
+	if tp != nil {
+		otel.SetTracerProvider(tp)
+		otel.SetTextMapPropagator(tracing.Propagator())
+
+		// Flush any buffered spans when we exit.
+		defer func() { _ = tp.Shutdown(context.Background()) }()
+
+		log.Info("Exporting traces", "exporter", c.TracingExporter)
+	}
+
 	cfg, err := ctrl.GetConfig()
 	if err != nil {
 		return errors.Wrap(err, "cannot get config")
@@ -178,6 +248,13 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		Scheme: s,
 		Cache: cache.Options{
 			SyncPeriod: &c.SyncInterval,
+			ByObject: map[client.Object]cache.ByObject{
+				// We only read the EndpointSlices of function Services,
+				// which are in our namespace.
+				&discoveryv1.EndpointSlice{}: {
+					Namespaces: map[string]cache.Config{c.Namespace: {}},
+				},
+			},
 		},
 		WebhookServer: webhook.NewServer(webhook.Options{
 			CertDir: c.TLSServerCertsDir,
@@ -247,18 +324,49 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 	pfrm := xfn.NewPrometheusMetrics()
 	metrics.Registry.MustRegister(pfrm)
 
-	// We want all XR controllers to share the same gRPC clients.
-	pfr := xfn.NewPackagedFunctionRunner(mgr.GetClient(),
+	pfro := []xfn.PackagedFunctionRunnerOption{
 		xfn.WithLogger(log),
 		xfn.WithTLSConfig(clienttls),
 		xfn.WithInterceptorCreators(pfrm),
-	)
+		xfn.WithStepMetrics(pfrm),
+	}
+
+	if c.EnableFunctionEndpointSlices {
+		o.Features.Enable(features.EnableAlphaFunctionEndpointSlices)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaFunctionEndpointSlices)
+
+		pfro = append(pfro, xfn.WithEndpointSliceResolver(xfn.NewEndpointSliceResolverBuilder(mgr.GetClient(), xfn.WithResolverLogger(log))))
+	}
+
+	if c.EnableExternalFunctions {
+		o.Features.Enable(features.EnableAlphaExternalFunctions)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaExternalFunctions)
+
+		pfro = append(pfro, xfn.WithExternalFunctions(c.Namespace))
+	}
+
+	// We want all XR controllers to share the same gRPC clients.
+	pfr := xfn.NewPackagedFunctionRunner(mgr.GetClient(), pfro...)
 
 	// Periodically remove clients for Functions that no longer exist.
 	go pfr.GarbageCollectConnections(ctx, 10*time.Minute)
 
 	var runner xfn.FunctionRunner = pfr
 
+	if c.EnableFunctionCircuitBreaker {
+		o.Features.Enable(features.EnableAlphaFunctionCircuitBreaker)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaFunctionCircuitBreaker)
+
+		// Fail fast when calling Functions that appear to be unhealthy.
+		runner = xfn.NewCircuitBreakingFunctionRunner(pfr,
+			xfn.WithFailureThreshold(c.XfnCircuitBreakerThreshold),
+			xfn.WithOpenDuration(c.XfnCircuitBreakerOpenDuration),
+			xfn.WithCircuitMetrics(pfrm),
+			xfn.WithCircuitObserver(xfn.NewRevisionCircuitObserver(mgr.GetClient(), log)),
+			xfn.WithCircuitLogger(log),
+		)
+	}
+
 	if c.EnableFunctionResponseCache {
 		o.Features.Enable(features.EnableAlphaFunctionResponseCache)
 		log.Info("Alpha feature enabled", "flag", features.EnableAlphaFunctionResponseCache)
@@ -266,11 +374,51 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		cfrm := cached.NewPrometheusMetrics()
 		metrics.Registry.MustRegister(cfrm)
 
-		// Wrap the packaged function runner with a caching one.
-		cfr := cached.NewFileBackedRunner(pfr, c.XfnCacheDir,
+		co := []cached.FileBackedRunnerOption{
 			cached.WithLogger(log),
 			cached.WithMetrics(cfrm),
-		)
+			cached.WithCanonicalizer(cached.NewFunctionCanonicalizer(mgr.GetClient())),
+		}
+
+		switch c.XfnCacheBackend {
+		case "filesystem":
+			co = append(co, cached.WithCache(cached.NewFileCache(afero.NewBasePathFs(afero.NewOsFs(), c.XfnCacheDir),
+				cached.WithFileCacheLogger(log),
+				cached.WithFileCacheMetrics(cfrm),
+				cached.WithFileCacheMaxBytes(c.XfnCacheMaxBytes),
+				cached.WithFileCacheMaxFunctionBytes(c.XfnCacheMaxBytesPerFunction),
+			)))
+		case "memory":
+			co = append(co, cached.WithCache(cached.NewLRUCache(c.XfnCacheMaxBytes,
+				cached.WithLRUCacheMetrics(cfrm),
+				cached.WithLRUCacheMaxFunctionBytes(c.XfnCacheMaxBytesPerFunction),
+			)))
+		case "redis":
+			if c.XfnCacheRedisAddress == "" {
+				return errors.New("--xfn-cache-redis-address is required when --xfn-cache-backend=redis")
+			}
+			ro := []cached.RedisCacheOption{
+				cached.WithRedisPassword(c.XfnCacheRedisPassword),
+				cached.WithRedisDB(c.XfnCacheRedisDB),
+			}
+			if c.XfnCacheRedisTLS {
+				cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+				if c.XfnCacheRedisCABundlePath != "" {
+					rootCAs, err := ParseCertificatesFromPath(c.XfnCacheRedisCABundlePath)
+					if err != nil {
+						return errors.Wrap(err, "cannot parse Redis CA bundle")
+					}
+					cfg.RootCAs = rootCAs
+				}
+				ro = append(ro, cached.WithRedisTLSConfig(cfg))
+			}
+			co = append(co, cached.WithCache(cached.NewRedisCache(c.XfnCacheRedisAddress, ro...)))
+		}
+
+		log.Info("Caching function responses", "backend", c.XfnCacheBackend)
+
+		// Wrap the function runner with a caching one.
+		cfr := cached.NewFileBackedRunner(runner, c.XfnCacheDir, co...)
 
 		// Periodically delete expired cache entries.
 		go cfr.GarbageCollectFiles(ctx, 1*time.Minute)
@@ -323,6 +471,71 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		log.Info("Alpha feature enabled", "flag", features.EnableAlphaOperations)
 	}
 
+	if c.EnablePipelineTraces {
+		o.Features.Enable(features.EnableAlphaPipelineTraces)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaPipelineTraces)
+	}
+
+	if c.EnableCompositionPreviews {
+		o.Features.Enable(features.EnableAlphaCompositionPreviews)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaCompositionPreviews)
+	}
+
+	if c.EnableStagedRollouts {
+		o.Features.Enable(features.EnableAlphaStagedRollouts)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaStagedRollouts)
+	}
+
+	if c.EnableCompositionSelectionPolicies {
+		o.Features.Enable(features.EnableAlphaCompositionSelectionPolicies)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaCompositionSelectionPolicies)
+	}
+
+	if c.EnableReadinessChecks {
+		o.Features.Enable(features.EnableAlphaReadinessChecks)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaReadinessChecks)
+	}
+
+	if c.EnableComposedResourceOrdering {
+		o.Features.Enable(features.EnableAlphaComposedResourceOrdering)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaComposedResourceOrdering)
+	}
+
+	if c.EnableComposedDeletePolicies {
+		o.Features.Enable(features.EnableAlphaComposedResourceDeletePolicies)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaComposedResourceDeletePolicies)
+	}
+
+	if c.EnableCompositeResourceConversion {
+		o.Features.Enable(features.EnableAlphaCompositeResourceConversion)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaCompositeResourceConversion)
+	}
+
+	if c.EnableStorageVersionMigration {
+		o.Features.Enable(features.EnableAlphaStorageVersionMigration)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaStorageVersionMigration)
+	}
+
+	if c.EnableCompositeResourceValidation {
+		o.Features.Enable(features.EnableAlphaCompositeResourceValidationRules)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaCompositeResourceValidationRules)
+	}
+
+	if c.EnableClaimFieldMappings {
+		o.Features.Enable(features.EnableAlphaClaimFieldMappings)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaClaimFieldMappings)
+	}
+
+	if c.EnableClaimPolicies {
+		o.Features.Enable(features.EnableAlphaClaimPolicies)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaClaimPolicies)
+	}
+
+	if c.EnableFunctionServiceAccountTokens {
+		o.Features.Enable(features.EnableAlphaFunctionServiceAccountTokens)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaFunctionServiceAccountTokens)
+	}
+
 	// Claim and XR controllers are started and stopped dynamically by the
 	// ControllerEngine below. When realtime compositions are enabled, they also
 	// start and stop their watches (e.g. of composed resources) dynamically. To
@@ -427,6 +640,16 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		Options:          o,
 		ControllerEngine: ce,
 		FunctionRunner:   runner,
+		Namespace:        c.Namespace,
+	}
+
+	if c.EnableWebhooks && o.Features.Enabled(features.EnableAlphaCompositeResourceConversion) {
+		cc, err := c.ConversionWebhookClientConfig()
+		if err != nil {
+			return errors.Wrap(err, "cannot configure composite resource conversion webhook")
+		}
+
+		ao.ConversionWebhook = cc
 	}
 
 	if err := apiextensions.Setup(mgr, ao); err != nil {
@@ -435,9 +658,10 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 
 	if o.Features.Enabled(features.EnableAlphaOperations) {
 		oo := opscontroller.Options{
-			Options:          o,
-			FunctionRunner:   runner,
-			ControllerEngine: ce,
+			Options:                       o,
+			FunctionRunner:                runner,
+			ControllerEngine:              ce,
+			ServiceAccountTokenNamespaces: c.OperationServiceAccountTokenNamespaces,
 		}
 		if err := ops.Setup(mgr, oo); err != nil {
 			return errors.Wrap(err, "cannot setup ops controllers")
@@ -515,6 +739,10 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		usagehook.SetupWebhookWithManager(mgr, f, o)
 	}
 
+	if c.EnableWebhooks && o.Features.Enabled(features.EnableAlphaCompositeResourceConversion) {
+		xrconversion.SetupWebhookWithManager(mgr, runner, o)
+	}
+
 	if err := c.SetupProbes(mgr); err != nil {
 		return errors.Wrap(err, "cannot setup probes")
 	}
@@ -522,6 +750,35 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 	return errors.Wrap(mgr.Start(ctrl.SetupSignalHandler()), "cannot start controller manager")
 }
 
+// ConversionWebhookClientConfig returns how the API server should call the
+// composite resource conversion webhook.
+func (c *startCommand) ConversionWebhookClientConfig() (*extv1.WebhookClientConfig, error) {
+	if c.WebhookServiceName == "" {
+		return nil, errors.New("--webhook-service-name is required when --enable-composite-resource-conversion is set")
+	}
+
+	ns := c.WebhookServiceNamespace
+	if ns == "" {
+		ns = c.Namespace
+	}
+
+	// The API server trusts the webhook server's certificate.
+	caBundle, err := os.ReadFile(filepath.Join(c.TLSServerCertsDir, corev1.TLSCertKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read webhook server certificate")
+	}
+
+	return &extv1.WebhookClientConfig{
+		Service: &extv1.ServiceReference{
+			Name:      c.WebhookServiceName,
+			Namespace: ns,
+			Path:      ptr.To(xrconversion.Path),
+			Port:      ptr.To(c.WebhookServicePort),
+		},
+		CABundle: caBundle,
+	}, nil
+}
+
 // SetupProbes sets up the health and readiness probes.
 func (c *startCommand) SetupProbes(mgr ctrl.Manager) error {
 	// Add default readiness probe
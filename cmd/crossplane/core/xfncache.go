@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/spf13/afero"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/crossplane/v2/internal/xfn/cached"
+)
+
+// xfnCacheCommand inspects and purges the function response cache. The cache
+// lives on the filesystem of the Crossplane pod, so run it there, e.g. using
+// kubectl exec.
+type xfnCacheCommand struct {
+	List  xfnCacheListCommand  `cmd:"" help:"List cached function responses."`
+	Purge xfnCachePurgeCommand `cmd:"" help:"Delete cached function responses."`
+}
+
+type xfnCacheListCommand struct {
+	Function string `help:"Only list cached responses of the named function." short:"f"`
+	CacheDir string `default:"/cache/xfn" env:"XFN_CACHE_DIR" help:"Directory used for caching function responses."`
+}
+
+// Run lists cached function responses.
+func (c *xfnCacheListCommand) Run(k *kong.Context) error {
+	fc := cached.NewFileCache(afero.NewBasePathFs(afero.NewOsFs(), c.CacheDir))
+
+	entries, err := fc.List(context.Background(), c.Function)
+	if err != nil {
+		return errors.Wrap(err, "cannot list cached function responses")
+	}
+
+	w := tabwriter.NewWriter(k.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "FUNCTION\tTAG\tBYTES\tEXPIRES\tLAST USED")
+
+	total := int64(0)
+	for _, e := range entries {
+		total += e.Bytes
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", e.Function, e.Tag, e.Bytes, e.Deadline.Format(time.RFC3339), e.LastUsed.Format(time.RFC3339))
+	}
+
+	if err := w.Flush(); err != nil {
+		return errors.Wrap(err, "cannot write cached function responses")
+	}
+
+	_, _ = fmt.Fprintf(k.Stdout, "\n%d cached responses, %d bytes total\n", len(entries), total)
+
+	return nil
+}
+
+type xfnCachePurgeCommand struct {
+	Function string `help:"Only delete cached responses of the named function." short:"f"`
+	All      bool   `help:"Delete all cached responses."`
+	CacheDir string `default:"/cache/xfn" env:"XFN_CACHE_DIR" help:"Directory used for caching function responses."`
+}
+
+// Run deletes cached function responses.
+func (c *xfnCachePurgeCommand) Run(k *kong.Context, log logging.Logger) error {
+	if c.Function == "" && !c.All {
+		return errors.New("specify a function to purge using --function, or purge all functions using --all")
+	}
+
+	fc := cached.NewFileCache(afero.NewBasePathFs(afero.NewOsFs(), c.CacheDir), cached.WithFileCacheLogger(log))
+
+	purged, err := fc.Purge(context.Background(), c.Function)
+	if err != nil {
+		return errors.Wrap(err, "cannot purge cached function responses")
+	}
+
+	_, _ = fmt.Fprintf(k.Stdout, "Deleted %d cached responses\n", purged)
+
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	authv1 "k8s.io/api/authorization/v1"
 	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
@@ -120,6 +121,7 @@ func main() {
 	ctx.FatalIfErrorf(extv1.AddToScheme(s), "cannot add apiextensions v1 Kubernetes API types to scheme")
 	ctx.FatalIfErrorf(extv1beta1.AddToScheme(s), "cannot add apiextensions v1beta1 Kubernetes API types to scheme")
 	ctx.FatalIfErrorf(admv1.AddToScheme(s), "cannot add admissionregistration v1 Kubernetes API types to scheme")
+	ctx.FatalIfErrorf(discoveryv1.AddToScheme(s), "cannot add discovery v1 Kubernetes API types to scheme")
 	ctx.FatalIfErrorf(apis.AddToScheme(s), "cannot add Crossplane API types to scheme")
 	ctx.FatalIfErrorf(ctx.Run(s))
 }
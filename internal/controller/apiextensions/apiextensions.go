@@ -26,7 +26,10 @@ import (
 	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/definition"
 	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/managed"
 	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/offered"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/preview"
 	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/revision"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/rollout"
+	"github.com/crossplane/crossplane/v2/internal/features"
 )
 
 // Setup API extensions controllers.
@@ -51,5 +54,17 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		return err
 	}
 
+	if o.Features.Enabled(features.EnableAlphaCompositionPreviews) {
+		if err := preview.Setup(mgr, o); err != nil {
+			return err
+		}
+	}
+
+	if o.Features.Enabled(features.EnableAlphaStagedRollouts) {
+		if err := rollout.Setup(mgr, o); err != nil {
+			return err
+		}
+	}
+
 	return offered.Setup(mgr, o)
 }
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package claim
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+const (
+	errMapClaimSpec       = "cannot map claim spec to composite resource"
+	errMapCompositeStatus = "cannot map composite resource status to claim"
+	errFmtGetMappedField  = "cannot get field %q"
+	errFmtSetMappedField  = "cannot set field %q"
+)
+
+type compositeSyncerOptions struct {
+	mappings v1.ClaimFieldMappings
+}
+
+// A CompositeSyncerOption configures a CompositeSyncer.
+type CompositeSyncerOption func(o *compositeSyncerOptions)
+
+// WithClaimFieldMappings configures a CompositeSyncer to propagate only the
+// supplied fields between claims and composite resources. Spec mappings
+// replace propagation of the claim's spec, and status mappings replace
+// propagation of the composite resource's status.
+func WithClaimFieldMappings(m v1.ClaimFieldMappings) CompositeSyncerOption {
+	return func(o *compositeSyncerOptions) {
+		o.mappings = m
+	}
+}
+
+func newCompositeSyncerOptions(opts ...CompositeSyncerOption) compositeSyncerOptions {
+	o := compositeSyncerOptions{}
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	return o
+}
+
+// mapSpec returns the spec of a composite resource with the supplied claim's
+// mapped fields. The supplied spec is the claim's spec, minus fields that are
+// unique to claims. Only the fields of it that Crossplane uses to select a
+// composition are kept.
+func mapSpec(from map[string]any, spec map[string]any, mappings []v1.ClaimFieldMapping) (map[string]any, error) {
+	to := map[string]any{"spec": onlyKeys(spec, xcrd.GetPropFields(xcrd.CompositeResourceClaimSpecProps(nil))...)}
+	if err := mapFields(from, to, mappings); err != nil {
+		return nil, err
+	}
+
+	out, _ := to["spec"].(map[string]any)
+
+	return out, nil
+}
+
+// mapStatus returns the status of a claim with the supplied composite
+// resource's mapped fields.
+func mapStatus(from map[string]any, mappings []v1.ClaimFieldMapping) (map[string]any, error) {
+	to := map[string]any{"status": map[string]any{}}
+	if err := mapFields(from, to, mappings); err != nil {
+		return nil, err
+	}
+
+	out, _ := to["status"].(map[string]any)
+
+	return out, nil
+}
+
+// mapFields copies the mapped fields of the from object to the to object.
+// Fields that aren't set in the from object are skipped.
+func mapFields(from, to map[string]any, mappings []v1.ClaimFieldMapping) error {
+	src := fieldpath.Pave(from)
+	dst := fieldpath.Pave(to)
+
+	for _, m := range mappings {
+		v, err := src.GetValue(m.FromFieldPath)
+		if fieldpath.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, errFmtGetMappedField, m.FromFieldPath)
+		}
+
+		if err := dst.SetValue(m.ToFieldPath, runtime.DeepCopyJSONValue(v)); err != nil {
+			return errors.Wrapf(err, errFmtSetMappedField, m.ToFieldPath)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package claim
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+func TestMapSpec(t *testing.T) {
+	type args struct {
+		from     map[string]any
+		spec     map[string]any
+		mappings []v1.ClaimFieldMapping
+	}
+
+	type want struct {
+		spec map[string]any
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"MappedFields": {
+			reason: "We should propagate only mapped fields and the fields Crossplane uses to select a composition.",
+			args: args{
+				from: map[string]any{
+					"metadata": map[string]any{"namespace": "team-a"},
+					"spec": map[string]any{
+						"size":           "large",
+						"unmapped":       "value",
+						"compositionRef": map[string]any{"name": "cool-composition"},
+					},
+				},
+				spec: map[string]any{
+					"size":           "large",
+					"unmapped":       "value",
+					"compositionRef": map[string]any{"name": "cool-composition"},
+				},
+				mappings: []v1.ClaimFieldMapping{
+					{FromFieldPath: "spec.size", ToFieldPath: "spec.parameters.instanceSize"},
+					{FromFieldPath: "metadata.namespace", ToFieldPath: "spec.parameters.team"},
+					{FromFieldPath: "spec.missing", ToFieldPath: "spec.parameters.missing"},
+				},
+			},
+			want: want{
+				spec: map[string]any{
+					"compositionRef": map[string]any{"name": "cool-composition"},
+					"parameters": map[string]any{
+						"instanceSize": "large",
+						"team":         "team-a",
+					},
+				},
+			},
+		},
+		"InvalidFromFieldPath": {
+			reason: "We should return an error if a field can't be read.",
+			args: args{
+				from: map[string]any{"spec": map[string]any{}},
+				spec: map[string]any{},
+				mappings: []v1.ClaimFieldMapping{
+					{FromFieldPath: "spec[", ToFieldPath: "spec.size"},
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errors.New("cannot parse path \"spec[\": unterminated '[' at position 4"), errFmtGetMappedField, "spec["),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := mapSpec(tc.args.from, tc.args.spec, tc.args.mappings)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nmapSpec(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.spec, got); diff != "" {
+				t.Errorf("\n%s\nmapSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMapStatus(t *testing.T) {
+	type args struct {
+		from     map[string]any
+		mappings []v1.ClaimFieldMapping
+	}
+
+	type want struct {
+		status map[string]any
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"MappedFields": {
+			reason: "We should propagate only mapped fields.",
+			args: args{
+				from: map[string]any{
+					"status": map[string]any{
+						"atProvider": map[string]any{"endpoint": "example.org"},
+						"unmapped":   "value",
+					},
+				},
+				mappings: []v1.ClaimFieldMapping{
+					{FromFieldPath: "status.atProvider.endpoint", ToFieldPath: "status.address"},
+				},
+			},
+			want: want{
+				status: map[string]any{"address": "example.org"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := mapStatus(tc.args.from, tc.args.mappings)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nmapStatus(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.status, got); diff != "" {
+				t.Errorf("\n%s\nmapStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -59,6 +59,18 @@ func withoutKeys(in map[string]any, keys ...string) map[string]any {
 	return out
 }
 
+func onlyKeys(in map[string]any, keys ...string) map[string]any {
+	out := map[string]any{}
+
+	for _, k := range keys {
+		if v, ok := in[k]; ok {
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
 type mergeConfig struct {
 	mergeOptions []func(*mergo.Config)
 	srcfilter    []string
@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package claim
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/claim"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+const (
+	errGetPolicyXRD        = "cannot get CompositeResourceDefinition"
+	errParsePolicySelector = "cannot parse claim policy composition selector"
+	errParseClaimSelector  = "cannot parse claim composition selector"
+	errGetPolicyComp       = "cannot get Composition"
+	errListPolicyComps     = "cannot list Compositions"
+	errListPolicyClaims    = "cannot list claims"
+
+	fmtDenyMissingLabel       = "claim policy requires label %q"
+	fmtDenyComposition        = "claim policy doesn't allow Composition %q"
+	fmtDenyCompositionSelect  = "claim policy doesn't allow Composition %q, which composition selector %q selects"
+	fmtDenyNoComposition      = "claim policy requires a composition reference or selector"
+	fmtDenyMaxClaimsNamespace = "claim policy allows at most %d %s claims in namespace %q"
+)
+
+// ReasonPolicyDenied indicates a claim violates its claim policy.
+const ReasonPolicyDenied xpv1.ConditionReason = "PolicyDenied"
+
+// A PolicyEnforcer enforces a policy that restricts how claims may be used.
+type PolicyEnforcer interface {
+	// Enforce the policy. It returns a message explaining why the supplied
+	// claim is denied, or an empty string if it's allowed.
+	Enforce(ctx context.Context, cm *claim.Unstructured) (string, error)
+}
+
+// A PolicyEnforcerFn enforces a policy that restricts how claims may be used.
+type PolicyEnforcerFn func(ctx context.Context, cm *claim.Unstructured) (string, error)
+
+// Enforce the policy.
+func (fn PolicyEnforcerFn) Enforce(ctx context.Context, cm *claim.Unstructured) (string, error) {
+	return fn(ctx, cm)
+}
+
+// A NopPolicyEnforcer allows all claims.
+type NopPolicyEnforcer struct{}
+
+// Enforce allows all claims.
+func (e *NopPolicyEnforcer) Enforce(_ context.Context, _ *claim.Unstructured) (string, error) {
+	return "", nil
+}
+
+// An APIPolicyEnforcer enforces the claim policy of a
+// CompositeResourceDefinition. It reads the policy each time it's called, so
+// policy changes take effect without restarting the claim controller.
+type APIPolicyEnforcer struct {
+	client client.Reader
+	xrd    string
+}
+
+// NewAPIPolicyEnforcer returns a PolicyEnforcer that enforces the claim policy
+// of the named CompositeResourceDefinition.
+func NewAPIPolicyEnforcer(c client.Reader, xrdName string) *APIPolicyEnforcer {
+	return &APIPolicyEnforcer{client: c, xrd: xrdName}
+}
+
+// Enforce the CompositeResourceDefinition's claim policy.
+func (e *APIPolicyEnforcer) Enforce(ctx context.Context, cm *claim.Unstructured) (string, error) {
+	xrd := &v1.CompositeResourceDefinition{}
+	if err := e.client.Get(ctx, types.NamespacedName{Name: e.xrd}, xrd); err != nil {
+		return "", errors.Wrap(err, errGetPolicyXRD)
+	}
+
+	p := xrd.Spec.ClaimPolicy
+	if p == nil {
+		return "", nil
+	}
+
+	for _, k := range p.RequiredLabels {
+		if _, ok := cm.GetLabels()[k]; !ok {
+			return fmt.Sprintf(fmtDenyMissingLabel, k), nil
+		}
+	}
+
+	if p.CompositionSelector != nil {
+		denial, err := e.enforceComposition(ctx, xrd, cm)
+		if err != nil || denial != "" {
+			return denial, err
+		}
+	}
+
+	// Claims that are already bound count towards the limit; they're never
+	// denied because of it.
+	if p.MaxPerNamespace != nil && cm.GetResourceReference() == nil {
+		return e.enforceMaxPerNamespaceBestEffort(ctx, *p.MaxPerNamespace, cm)
+	}
+
+	return "", nil
+}
+
+// enforceComposition denies the supplied claim if its composite resource could
+// use a Composition the policy's selector doesn't match. The XRD's enforced
+// Composition takes precedence over the claim's, and its default Composition
+// applies only if the claim neither references nor selects one.
+func (e *APIPolicyEnforcer) enforceComposition(ctx context.Context, xrd *v1.CompositeResourceDefinition, cm *claim.Unstructured) (string, error) {
+	sel, err := metav1.LabelSelectorAsSelector(xrd.Spec.ClaimPolicy.CompositionSelector)
+	if err != nil {
+		return "", errors.Wrap(err, errParsePolicySelector)
+	}
+
+	switch {
+	case xrd.Spec.EnforcedCompositionRef != nil:
+		return e.enforceCompositionRef(ctx, sel, xrd.Spec.EnforcedCompositionRef.Name)
+	case cm.GetCompositionReference() != nil:
+		return e.enforceCompositionRef(ctx, sel, cm.GetCompositionReference().Name)
+	case cm.GetCompositionSelector() != nil:
+		return e.enforceCompositionSelector(ctx, sel, xrd, cm.GetCompositionSelector())
+	case xrd.Spec.DefaultCompositionRef != nil:
+		return e.enforceCompositionRef(ctx, sel, xrd.Spec.DefaultCompositionRef.Name)
+	}
+
+	return fmtDenyNoComposition, nil
+}
+
+func (e *APIPolicyEnforcer) enforceCompositionRef(ctx context.Context, sel labels.Selector, name string) (string, error) {
+	comp := &v1.Composition{}
+	if err := e.client.Get(ctx, types.NamespacedName{Name: name}, comp); err != nil {
+		return "", errors.Wrap(err, errGetPolicyComp)
+	}
+
+	if !sel.Matches(labels.Set(comp.GetLabels())) {
+		return fmt.Sprintf(fmtDenyComposition, name), nil
+	}
+
+	return "", nil
+}
+
+// enforceCompositionSelector denies the supplied claim selector if it selects
+// any Composition of the XRD's composite resource that the policy's selector
+// doesn't match.
+func (e *APIPolicyEnforcer) enforceCompositionSelector(ctx context.Context, sel labels.Selector, xrd *v1.CompositeResourceDefinition, ls *metav1.LabelSelector) (string, error) {
+	cs, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return "", errors.Wrap(err, errParseClaimSelector)
+	}
+
+	l := &v1.CompositionList{}
+	if err := e.client.List(ctx, l, client.MatchingLabelsSelector{Selector: cs}); err != nil {
+		return "", errors.Wrap(err, errListPolicyComps)
+	}
+
+	apiVersion, kind := xrd.GetCompositeGroupVersionKind().ToAPIVersionAndKind()
+
+	for _, comp := range l.Items {
+		if comp.Spec.CompositeTypeRef.APIVersion != apiVersion || comp.Spec.CompositeTypeRef.Kind != kind {
+			continue
+		}
+
+		if !sel.Matches(labels.Set(comp.GetLabels())) {
+			return fmt.Sprintf(fmtDenyCompositionSelect, comp.GetName(), metav1.FormatLabelSelector(ls)), nil
+		}
+	}
+
+	return "", nil
+}
+
+// enforceMaxPerNamespaceBestEffort denies the supplied claim if its namespace
+// already has the maximum number of bound claims. It's best-effort, not
+// atomic. Claims are counted from a cache and bound after they're counted, so
+// two claims reconciled at the same time, or before the cache observes the
+// other being bound, may both be allowed.
+func (e *APIPolicyEnforcer) enforceMaxPerNamespaceBestEffort(ctx context.Context, maxClaims int64, cm *claim.Unstructured) (string, error) {
+	gvk := cm.GetObjectKind().GroupVersionKind()
+
+	l := &kunstructured.UnstructuredList{}
+	l.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	if err := e.client.List(ctx, l, client.InNamespace(cm.GetNamespace())); err != nil {
+		return "", errors.Wrap(err, errListPolicyClaims)
+	}
+
+	var bound int64
+
+	for _, o := range l.Items {
+		if o.GetUID() == cm.GetUID() {
+			continue
+		}
+
+		if _, err := fieldpath.Pave(o.Object).GetValue("spec.resourceRef"); err == nil {
+			bound++
+		}
+	}
+
+	if bound >= maxClaims {
+		return fmt.Sprintf(fmtDenyMaxClaimsNamespace, maxClaims, gvk.Kind, cm.GetNamespace()), nil
+	}
+
+	return "", nil
+}
+
+// PolicyDenied returns a condition that indicates the claim violates its claim
+// policy.
+func PolicyDenied(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPolicyDenied,
+		Message:            message,
+	}
+}
@@ -0,0 +1,345 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package claim
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/claim"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/reference"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+func TestAPIPolicyEnforcerEnforce(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	gvk := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Database"}
+
+	cm := func(m ...ClaimModifier) *claim.Unstructured {
+		c := claim.New(claim.WithGroupVersionKind(gvk))
+		c.SetNamespace("team-a")
+		c.SetName("cool-claim")
+		c.SetUID(types.UID("cool-claim"))
+		c.SetLabels(map[string]string{"team": "a"})
+
+		for _, fn := range m {
+			fn(c)
+		}
+
+		return c
+	}
+
+	xrdSpec := func(p *v1.ClaimPolicy) v1.CompositeResourceDefinitionSpec {
+		return v1.CompositeResourceDefinitionSpec{
+			Group:       "example.org",
+			Names:       extv1.CustomResourceDefinitionNames{Kind: "XDatabase"},
+			Versions:    []v1.CompositeResourceDefinitionVersion{{Name: "v1", Referenceable: true}},
+			ClaimPolicy: p,
+		}
+	}
+
+	getXRD := func(s v1.CompositeResourceDefinitionSpec, compLabels map[string]string) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1.CompositeResourceDefinition:
+				o.Spec = s
+			case *v1.Composition:
+				o.SetLabels(compLabels)
+			}
+
+			return nil
+		}
+	}
+
+	get := func(p *v1.ClaimPolicy, compLabels map[string]string) test.MockGetFn {
+		return getXRD(xrdSpec(p), compLabels)
+	}
+
+	comp := func(name, kind string, compLabels map[string]string) v1.Composition {
+		c := v1.Composition{Spec: v1.CompositionSpec{
+			CompositeTypeRef: v1.TypeReference{APIVersion: "example.org/v1", Kind: kind},
+		}}
+		c.SetName(name)
+		c.SetLabels(compLabels)
+
+		return c
+	}
+
+	listComps := func(comps ...v1.Composition) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			obj.(*v1.CompositionList).Items = comps
+			return nil
+		}
+	}
+
+	list := func(bound int) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			l := obj.(*kunstructured.UnstructuredList)
+
+			// The claim being enforced shouldn't count towards the limit.
+			l.Items = append(l.Items, cm().Unstructured)
+
+			for i := range bound {
+				c := claim.New(claim.WithGroupVersionKind(gvk))
+				c.SetUID(types.UID(fmt.Sprintf("bound-%d", i)))
+				c.SetResourceReference(&reference.Composite{Name: fmt.Sprintf("xr-%d", i)})
+				l.Items = append(l.Items, c.Unstructured)
+			}
+
+			// Unbound claims shouldn't count towards the limit.
+			unbound := claim.New(claim.WithGroupVersionKind(gvk))
+			unbound.SetUID(types.UID("unbound"))
+			l.Items = append(l.Items, unbound.Unstructured)
+
+			return nil
+		}
+	}
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "dev"}}
+
+	type args struct {
+		client client.Reader
+		cm     *claim.Unstructured
+	}
+
+	type want struct {
+		denial string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"GetXRDError": {
+			reason: "We should return any error encountered getting the XRD.",
+			args: args{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				cm:     cm(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetPolicyXRD),
+			},
+		},
+		"NoPolicy": {
+			reason: "We should allow all claims if the XRD doesn't have a claim policy.",
+			args: args{
+				client: &test.MockClient{MockGet: get(nil, nil)},
+				cm:     cm(),
+			},
+		},
+		"MissingLabel": {
+			reason: "We should deny claims that don't have a required label.",
+			args: args{
+				client: &test.MockClient{MockGet: get(&v1.ClaimPolicy{RequiredLabels: []string{"team", "cost-center"}}, nil)},
+				cm:     cm(),
+			},
+			want: want{
+				denial: fmt.Sprintf(fmtDenyMissingLabel, "cost-center"),
+			},
+		},
+		"CompositionNotAllowed": {
+			reason: "We should deny claims that reference a Composition the policy doesn't allow.",
+			args: args{
+				client: &test.MockClient{MockGet: get(&v1.ClaimPolicy{CompositionSelector: selector}, map[string]string{"tier": "prod"})},
+				cm: cm(func(c *claim.Unstructured) {
+					c.SetCompositionReference(&corev1.ObjectReference{Name: "prod-composition"})
+				}),
+			},
+			want: want{
+				denial: fmt.Sprintf(fmtDenyComposition, "prod-composition"),
+			},
+		},
+		"CompositionAllowed": {
+			reason: "We should allow claims that reference a Composition the policy allows.",
+			args: args{
+				client: &test.MockClient{MockGet: get(&v1.ClaimPolicy{CompositionSelector: selector}, map[string]string{"tier": "dev"})},
+				cm: cm(func(c *claim.Unstructured) {
+					c.SetCompositionReference(&corev1.ObjectReference{Name: "dev-composition"})
+				}),
+			},
+		},
+		"CompositionSelectorNotAllowed": {
+			reason: "We should deny claims that select a Composition the policy doesn't allow.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: get(&v1.ClaimPolicy{CompositionSelector: selector}, nil),
+					MockList: listComps(
+						comp("dev-composition", "XDatabase", map[string]string{"tier": "dev"}),
+						comp("prod-composition", "XDatabase", map[string]string{"tier": "prod"}),
+					),
+				},
+				cm: cm(func(c *claim.Unstructured) {
+					c.SetCompositionSelector(&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "tier", Operator: metav1.LabelSelectorOpExists},
+					}})
+				}),
+			},
+			want: want{
+				denial: fmt.Sprintf(fmtDenyCompositionSelect, "prod-composition", "tier"),
+			},
+		},
+		"CompositionSelectorAllowed": {
+			reason: "We should allow claims that only select Compositions of their composite resource that the policy allows.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: get(&v1.ClaimPolicy{CompositionSelector: selector}, nil),
+					MockList: listComps(
+						comp("dev-composition", "XDatabase", map[string]string{"tier": "dev"}),
+						comp("other-composition", "XBucket", map[string]string{"tier": "prod"}),
+					),
+				},
+				cm: cm(func(c *claim.Unstructured) {
+					c.SetCompositionSelector(&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "tier", Operator: metav1.LabelSelectorOpExists},
+					}})
+				}),
+			},
+		},
+		"ListCompositionsError": {
+			reason: "We should return any error encountered listing the Compositions a claim selects.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  get(&v1.ClaimPolicy{CompositionSelector: selector}, nil),
+					MockList: test.NewMockListFn(errBoom),
+				},
+				cm: cm(func(c *claim.Unstructured) {
+					c.SetCompositionSelector(selector)
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListPolicyComps),
+			},
+		},
+		"EnforcedCompositionNotAllowed": {
+			reason: "We should deny claims if the XRD enforces a Composition the policy doesn't allow, regardless of the claim's Composition.",
+			args: args{
+				client: &test.MockClient{MockGet: getXRD(func() v1.CompositeResourceDefinitionSpec {
+					s := xrdSpec(&v1.ClaimPolicy{CompositionSelector: selector})
+					s.EnforcedCompositionRef = &v1.CompositionReference{Name: "prod-composition"}
+					return s
+				}(), map[string]string{"tier": "prod"})},
+				cm: cm(func(c *claim.Unstructured) {
+					c.SetCompositionSelector(selector)
+				}),
+			},
+			want: want{
+				denial: fmt.Sprintf(fmtDenyComposition, "prod-composition"),
+			},
+		},
+		"DefaultCompositionAllowed": {
+			reason: "We should allow claims that neither reference nor select a Composition if the XRD's default Composition is allowed.",
+			args: args{
+				client: &test.MockClient{MockGet: getXRD(func() v1.CompositeResourceDefinitionSpec {
+					s := xrdSpec(&v1.ClaimPolicy{CompositionSelector: selector})
+					s.DefaultCompositionRef = &v1.CompositionReference{Name: "dev-composition"}
+					return s
+				}(), map[string]string{"tier": "dev"})},
+				cm: cm(),
+			},
+		},
+		"NoCompositionDenied": {
+			reason: "We should deny claims that neither reference nor select a Composition if the XRD has no default Composition.",
+			args: args{
+				client: &test.MockClient{MockGet: get(&v1.ClaimPolicy{CompositionSelector: selector}, nil)},
+				cm:     cm(),
+			},
+			want: want{
+				denial: fmtDenyNoComposition,
+			},
+		},
+		"ListClaimsError": {
+			reason: "We should return any error encountered listing claims.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  get(&v1.ClaimPolicy{MaxPerNamespace: ptr.To[int64](2)}, nil),
+					MockList: test.NewMockListFn(errBoom),
+				},
+				cm: cm(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListPolicyClaims),
+			},
+		},
+		"MaxPerNamespaceExceeded": {
+			reason: "We should deny unbound claims if the namespace already has the maximum number of bound claims.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  get(&v1.ClaimPolicy{MaxPerNamespace: ptr.To[int64](2)}, nil),
+					MockList: list(2),
+				},
+				cm: cm(),
+			},
+			want: want{
+				denial: fmt.Sprintf(fmtDenyMaxClaimsNamespace, 2, "Database", "team-a"),
+			},
+		},
+		"MaxPerNamespaceNotExceeded": {
+			reason: "We should allow unbound claims if the namespace has fewer than the maximum number of bound claims.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  get(&v1.ClaimPolicy{MaxPerNamespace: ptr.To[int64](2)}, nil),
+					MockList: list(1),
+				},
+				cm: cm(),
+			},
+		},
+		"AlreadyBound": {
+			reason: "We shouldn't deny claims that are already bound because their namespace is at the limit.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  get(&v1.ClaimPolicy{MaxPerNamespace: ptr.To[int64](0)}, nil),
+					MockList: test.NewMockListFn(errBoom),
+				},
+				cm: cm(func(c *claim.Unstructured) {
+					c.SetResourceReference(&reference.Composite{Name: "cool-xr"})
+				}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := NewAPIPolicyEnforcer(tc.args.client, "databases.example.org")
+
+			denial, err := e.Enforce(context.Background(), tc.args.cm)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nEnforce(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.denial, denial); diff != "" {
+				t.Errorf("\n%s\nEnforce(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
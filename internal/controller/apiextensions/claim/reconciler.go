@@ -60,6 +60,7 @@ const (
 	errSync                 = "cannot bind and sync claim with composite resource"
 	errPropagateCDs         = "cannot propagate connection details from composite resource"
 	errUpdateClaimStatus    = "cannot update claim status"
+	errEnforcePolicy        = "cannot enforce claim policy"
 
 	errFmtUnbound = "refusing to operate on composite resource %q that is not bound to this claim: bound to claim %q"
 )
@@ -72,6 +73,7 @@ const (
 	reasonDelete    event.Reason = "DeleteCompositeResource"
 	reasonPropagate event.Reason = "PropagateConnectionSecret"
 	reasonPaused    event.Reason = "ReconciliationPaused"
+	reasonPolicy    event.Reason = "EnforceClaimPolicy"
 )
 
 // ControllerName returns the recommended name for controllers that use this
@@ -210,11 +212,13 @@ func defaultCRComposite(c client.Client) crComposite {
 
 type crClaim struct {
 	resource.Finalizer
+	PolicyEnforcer
 }
 
 func defaultCRClaim(c client.Client) crClaim {
 	return crClaim{
-		Finalizer: resource.NewAPIFinalizer(c, finalizer),
+		Finalizer:      resource.NewAPIFinalizer(c, finalizer),
+		PolicyEnforcer: &NopPolicyEnforcer{},
 	}
 }
 
@@ -254,6 +258,14 @@ func WithClaimFinalizer(f resource.Finalizer) ReconcilerOption {
 	}
 }
 
+// WithPolicyEnforcer specifies how the Reconciler should enforce the policy
+// that restricts how claims may be used.
+func WithPolicyEnforcer(e PolicyEnforcer) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.claim.PolicyEnforcer = e
+	}
+}
+
 // WithLogger specifies how the Reconciler should log messages.
 func WithLogger(l logging.Logger) ReconcilerOption {
 	return func(r *Reconciler) {
@@ -443,6 +455,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, cm), errUpdateClaimStatus)
 	}
 
+	denial, err := r.claim.Enforce(ctx, cm)
+	if err != nil {
+		err = errors.Wrap(err, errEnforcePolicy)
+		record.Event(cm, event.Warning(reasonPolicy, err))
+		status.MarkConditions(xpv1.ReconcileError(err))
+
+		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, cm), errUpdateClaimStatus)
+	}
+
+	// Don't create or update the XR of a claim that violates policy. We
+	// requeue because the claim may be allowed later, for example once
+	// another claim in its namespace is deleted.
+	if denial != "" {
+		log.Debug("Claim violates claim policy", "denial", denial)
+		record.Event(cm, event.Warning(reasonPolicy, errors.New(denial)))
+		status.MarkConditions(PolicyDenied(denial))
+
+		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, cm), errUpdateClaimStatus)
+	}
+
 	// The XR's claim reference before syncing. Used to determine if we bind it.
 	before := xr.GetClaimReference()
 
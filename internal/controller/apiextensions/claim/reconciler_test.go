@@ -374,6 +374,52 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{Requeue: true},
 			},
 		},
+		"EnforcePolicyError": {
+			reason: "We should fail the reconcile if we can't enforce the claim policy",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockStatusUpdate: WantClaim(t, NewClaim(func(cm *claim.Unstructured) {
+						// Check that we set our status condition.
+						cm.SetConditions(xpv1.ReconcileError(errors.Wrap(errBoom, errEnforcePolicy)))
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithClaimFinalizer(resource.FinalizerFns{
+						AddFinalizerFn: func(_ context.Context, _ resource.Object) error { return nil },
+					}),
+					WithPolicyEnforcer(PolicyEnforcerFn(func(_ context.Context, _ *claim.Unstructured) (string, error) { return "", errBoom })),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: true},
+			},
+		},
+		"PolicyDenied": {
+			reason: "We shouldn't sync the claim with a composite resource if it violates the claim policy",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockStatusUpdate: WantClaim(t, NewClaim(func(cm *claim.Unstructured) {
+						// Check that we set our status condition.
+						cm.SetConditions(PolicyDenied("nope"))
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithClaimFinalizer(resource.FinalizerFns{
+						AddFinalizerFn: func(_ context.Context, _ resource.Object) error { return nil },
+					}),
+					WithPolicyEnforcer(PolicyEnforcerFn(func(_ context.Context, _ *claim.Unstructured) (string, error) { return "nope", nil })),
+					WithCompositeSyncer(CompositeSyncerFn(func(_ context.Context, _ *claim.Unstructured, _ *composite.Unstructured) error {
+						t.Error("We shouldn't sync a claim that violates the claim policy")
+						return nil
+					})),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: true},
+			},
+		},
 		"SyncCompositeError": {
 			reason: "We should fail the reconcile if we can't bind and sync the claim with a composite resource",
 			args: args{
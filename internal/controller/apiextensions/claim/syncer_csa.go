@@ -50,19 +50,23 @@ const (
 // A ClientSideCompositeSyncer binds and syncs a claim with a composite resource
 // (XR). It uses client-side apply to update the claim and the composite.
 type ClientSideCompositeSyncer struct {
-	client resource.ClientApplicator
-	names  names.NameGenerator
+	client   resource.ClientApplicator
+	names    names.NameGenerator
+	mappings v1.ClaimFieldMappings
 }
 
 // NewClientSideCompositeSyncer returns a CompositeSyncer that uses client-side
 // apply to sync a claim with a composite resource.
-func NewClientSideCompositeSyncer(c client.Client, ng names.NameGenerator) *ClientSideCompositeSyncer {
+func NewClientSideCompositeSyncer(c client.Client, ng names.NameGenerator, opts ...CompositeSyncerOption) *ClientSideCompositeSyncer {
+	o := newCompositeSyncerOptions(opts...)
+
 	return &ClientSideCompositeSyncer{
 		client: resource.ClientApplicator{
 			Client:     c,
 			Applicator: resource.NewAPIPatchingApplicator(c),
 		},
-		names: ng,
+		names:    ng,
+		mappings: o.mappings,
 	}
 }
 
@@ -119,7 +123,17 @@ func (s *ClientSideCompositeSyncer) Sync(ctx context.Context, cm *claim.Unstruct
 	}
 
 	// Propagate the claim's spec (minus well known fields) to the XR's spec.
-	xr.Object["spec"] = withoutKeys(cmSpec, xcrd.GetPropFields(wellKnownClaimFields)...)
+	xrSpec := withoutKeys(cmSpec, xcrd.GetPropFields(wellKnownClaimFields)...)
+
+	// If the XRD maps claim fields, propagate only the mapped fields.
+	if len(s.mappings.Spec) > 0 {
+		var err error
+		if xrSpec, err = mapSpec(cm.Object, xrSpec, s.mappings.Spec); err != nil {
+			return errors.Wrap(err, errMapClaimSpec)
+		}
+	}
+
+	xr.Object["spec"] = xrSpec
 
 	// We overwrite the entire XR spec above, so we wait until this point to set
 	// the claim reference.
@@ -169,8 +183,18 @@ func (s *ClientSideCompositeSyncer) Sync(ctx context.Context, cm *claim.Unstruct
 
 	// Below this point we're syncing XR status -> claim status.
 
+	xrStatus := xr.Object["status"]
+
+	// If the XRD maps status fields, propagate only the mapped fields.
+	if len(s.mappings.Status) > 0 {
+		var err error
+		if xrStatus, err = mapStatus(xr.Object, s.mappings.Status); err != nil {
+			return errors.Wrap(err, errMapCompositeStatus)
+		}
+	}
+
 	// Merge the XR's status into the claim's status.
-	if err := merge(cm.Object["status"], xr.Object["status"],
+	if err := merge(cm.Object["status"], xrStatus,
 		// XR status fields overwrite non-empty claim fields.
 		withMergeOptions(mergo.WithOverride),
 		// Don't sync XR machinery (i.e. status conditions, connection details).
@@ -210,8 +234,16 @@ func (s *ClientSideCompositeSyncer) Sync(ctx context.Context, cm *claim.Unstruct
 		cm.SetCompositionRevisionReference(xr.GetCompositionRevisionReference())
 	}
 
+	// If the XRD maps claim fields the XR's spec doesn't share the claim's
+	// schema. Only propagate the fields Crossplane uses to select a
+	// composition.
+	src := xr.Object["spec"]
+	if m, ok := src.(map[string]any); ok && len(s.mappings.Spec) > 0 {
+		src = onlyKeys(m, xcrd.PropagateSpecProps...)
+	}
+
 	// Propagate the XR's spec (minus well known fields) to the claim's spec.
-	if err := merge(cm.Object["spec"], xr.Object["spec"],
+	if err := merge(cm.Object["spec"], src,
 		withSrcFilter(xcrd.GetPropFields(wellKnownXRFields)...)); err != nil {
 		return errors.Wrap(err, errMergeClaimSpec)
 	}
@@ -152,14 +152,16 @@ func (u *PatchingManagedFieldsUpgrader) Upgrade(ctx context.Context, obj client.
 // A ServerSideCompositeSyncer binds and syncs a claim with a composite resource
 // (XR). It uses server-side apply to update the XR.
 type ServerSideCompositeSyncer struct {
-	client client.Client
-	names  names.NameGenerator
+	client   client.Client
+	names    names.NameGenerator
+	mappings v1.ClaimFieldMappings
 }
 
 // NewServerSideCompositeSyncer returns a CompositeSyncer that uses server-side
 // apply to sync a claim with a composite resource.
-func NewServerSideCompositeSyncer(c client.Client, ng names.NameGenerator) *ServerSideCompositeSyncer {
-	return &ServerSideCompositeSyncer{client: c, names: ng}
+func NewServerSideCompositeSyncer(c client.Client, ng names.NameGenerator, opts ...CompositeSyncerOption) *ServerSideCompositeSyncer {
+	o := newCompositeSyncerOptions(opts...)
+	return &ServerSideCompositeSyncer{client: c, names: ng, mappings: o.mappings}
 }
 
 // Sync the supplied claim with the supplied composite resource (XR). Syncing
@@ -240,7 +242,17 @@ func (s *ServerSideCompositeSyncer) Sync(ctx context.Context, cm *claim.Unstruct
 	}
 
 	// Propagate the claim's spec (minus well known fields) to the XR's spec.
-	xrPatch.Object["spec"] = withoutKeys(cmSpec, xcrd.GetPropFields(wellKnownClaimFields)...)
+	xrSpec := withoutKeys(cmSpec, xcrd.GetPropFields(wellKnownClaimFields)...)
+
+	// If the XRD maps claim fields, propagate only the mapped fields.
+	if len(s.mappings.Spec) > 0 {
+		var err error
+		if xrSpec, err = mapSpec(cm.Object, xrSpec, s.mappings.Spec); err != nil {
+			return errors.Wrap(err, errMapClaimSpec)
+		}
+	}
+
+	xrPatch.Object["spec"] = xrSpec
 
 	// We overwrite the entire XR spec above, so we wait until this point to set
 	// the claim reference.
@@ -325,7 +337,17 @@ func (s *ServerSideCompositeSyncer) Sync(ctx context.Context, cm *claim.Unstruct
 	pub := cm.GetConnectionDetailsLastPublishedTime()
 
 	// Update the claim's user-defined status fields to match the XRs.
-	cm.Object["status"] = withoutKeys(xrStatus, xcrd.GetPropFields(xcrd.CompositeResourceStatusProps(v1.CompositeResourceScopeLegacyCluster))...)
+	cmStatus := withoutKeys(xrStatus, xcrd.GetPropFields(xcrd.CompositeResourceStatusProps(v1.CompositeResourceScopeLegacyCluster))...)
+
+	// If the XRD maps status fields, propagate only the mapped fields.
+	if len(s.mappings.Status) > 0 {
+		var err error
+		if cmStatus, err = mapStatus(xr.Object, s.mappings.Status); err != nil {
+			return errors.Wrap(err, errMapCompositeStatus)
+		}
+	}
+
+	cm.Object["status"] = cmStatus
 
 	if cmcs.Conditions != nil {
 		cm.SetConditions(cmcs.Conditions...)
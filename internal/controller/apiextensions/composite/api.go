@@ -18,6 +18,7 @@ package composite
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"time"
 
@@ -55,6 +56,8 @@ const (
 	errCompositionNotCompatible        = "referenced composition is not compatible with this composite resource"
 	errGetXRD                          = "cannot get composite resource definition"
 	errFetchCompositionRevision        = "cannot fetch composition revision"
+
+	errFmtNoApprovedCompositionRevision = "no approved CompositionRevisions found, and CompositionRevision %s isn't approved for rollout yet"
 )
 
 // Event reasons.
@@ -143,12 +146,35 @@ func ConnectionSecretFor(o ConnectionSecretOwner, kind schema.GroupVersionKind)
 // are in alpha.
 type APIRevisionFetcher struct {
 	client client.Client
+	staged bool
+}
+
+// An APIRevisionFetcherOption configures an APIRevisionFetcher.
+type APIRevisionFetcherOption func(f *APIRevisionFetcher)
+
+// WithStagedRollouts configures an APIRevisionFetcher to honor the rollout
+// strategy of CompositionRevisions. Composite resources with an Automatic
+// composition update policy don't adopt a new revision that uses the Staged
+// strategy until it's approved, either explicitly or by the rollout controller
+// once it succeeds for a canary percentage of composite resources. They adopt
+// a new revision that uses the Progressive strategy once the rollout
+// controller reports that their wave has started.
+func WithStagedRollouts() APIRevisionFetcherOption {
+	return func(f *APIRevisionFetcher) {
+		f.staged = true
+	}
 }
 
 // NewAPIRevisionFetcher returns a RevisionFetcher that fetches the
 // Revision referenced by a composite resource.
-func NewAPIRevisionFetcher(c client.Client) *APIRevisionFetcher {
-	return &APIRevisionFetcher{client: c}
+func NewAPIRevisionFetcher(c client.Client, o ...APIRevisionFetcherOption) *APIRevisionFetcher {
+	f := &APIRevisionFetcher{client: c}
+
+	for _, fn := range o {
+		fn(f)
+	}
+
+	return f
 }
 
 // Fetch the appropriate CompositionRevision for the supplied XR. Panics if the
@@ -185,6 +211,16 @@ func (f *APIRevisionFetcher) Fetch(ctx context.Context, cr resource.Composite) (
 		return nil, errors.New(errNoCompatibleCompositionRevision)
 	}
 
+	// The latest revision is being rolled out in stages. Stay on the current
+	// revision, or start on the last approved revision, until we're allowed
+	// to adopt the latest one.
+	if f.staged && (current == nil || current.Name != latest.GetName()) {
+		rev, err := f.hold(ctx, cr, comp, current, latest, rl.Items)
+		if err != nil || rev != nil {
+			return rev, err
+		}
+	}
+
 	if current == nil || current.Name != latest.GetName() {
 		cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: latest.GetName()})
 
@@ -193,9 +229,48 @@ func (f *APIRevisionFetcher) Fetch(ctx context.Context, cr resource.Composite) (
 		}
 	}
 
+	adoptRevision(cr)
+
 	return latest, nil
 }
 
+// hold returns the revision the supplied composite resource should use if it
+// may not adopt the latest revision yet. That's its current revision, or the
+// last approved revision if it doesn't have one. The current revision is only
+// kept if it's one of the supplied revisions of the supplied Composition, so
+// a composite resource that switches to another Composition, or whose current
+// revision no longer matches its revision selector, doesn't stay on it. It
+// returns nil if the composite resource may adopt the latest revision.
+func (f *APIRevisionFetcher) hold(ctx context.Context, cr resource.Composite, comp *v1.Composition, current *corev1.LocalObjectReference, latest *v1.CompositionRevision, revs []v1.CompositionRevision) (*v1.CompositionRevision, error) {
+	if mayAdopt(cr, comp, latest) {
+		return nil, nil
+	}
+
+	if current != nil {
+		for i := range revs {
+			if rev := &revs[i]; rev.GetName() == current.Name && metav1.IsControlledBy(rev, comp) {
+				holdRevision(cr, latest.GetName())
+				return rev, nil
+			}
+		}
+	}
+
+	rev := lastApprovedRevision(comp, latest, revs)
+	if rev == nil {
+		return nil, errors.Errorf(errFmtNoApprovedCompositionRevision, latest.GetName())
+	}
+
+	cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: rev.GetName()})
+
+	if err := f.client.Update(ctx, cr); err != nil {
+		return nil, errors.Wrap(err, errUpdate)
+	}
+
+	holdRevision(cr, latest.GetName())
+
+	return rev, nil
+}
+
 func (f *APIRevisionFetcher) getCompositionRevisionList(ctx context.Context, cr resource.Composite, comp *v1.Composition) (*v1.CompositionRevisionList, error) {
 	rl := &v1.CompositionRevisionList{}
 	ml := client.MatchingLabels{}
@@ -235,15 +310,44 @@ func (r *CompositionSelectorChain) SelectComposition(ctx context.Context, cp res
 	return nil
 }
 
+// An APILabelSelectorResolverOption configures an APILabelSelectorResolver.
+type APILabelSelectorResolverOption func(r *APILabelSelectorResolver)
+
+// WithSelectionPolicies configures an APILabelSelectorResolver to honor the
+// selection policies of Compositions. A Composition is only selected for a
+// composite resource that matches its selection expression, and is selected
+// at random weighted by its selection weight.
+func WithSelectionPolicies() APILabelSelectorResolverOption {
+	return func(r *APILabelSelectorResolver) {
+		r.policies = true
+	}
+}
+
+// WithSelectionRecorder specifies how an APILabelSelectorResolver should
+// record which Composition it selected, and why.
+func WithSelectionRecorder(er event.Recorder) APILabelSelectorResolverOption {
+	return func(r *APILabelSelectorResolver) {
+		r.recorder = er
+	}
+}
+
 // NewAPILabelSelectorResolver returns a SelectorResolver for composite resource.
-func NewAPILabelSelectorResolver(c client.Client) *APILabelSelectorResolver {
-	return &APILabelSelectorResolver{client: c}
+func NewAPILabelSelectorResolver(c client.Client, o ...APILabelSelectorResolverOption) *APILabelSelectorResolver {
+	r := &APILabelSelectorResolver{client: c, recorder: event.NewNopRecorder()}
+
+	for _, fn := range o {
+		fn(r)
+	}
+
+	return r
 }
 
 // APILabelSelectorResolver is used to resolve the composition selector on the instance
 // to composition reference.
 type APILabelSelectorResolver struct {
-	client client.Client
+	client   client.Client
+	recorder event.Recorder
+	policies bool
 }
 
 // SelectComposition resolves selector to a reference if it doesn't exist.
@@ -269,14 +373,27 @@ func (r *APILabelSelectorResolver) SelectComposition(ctx context.Context, cp res
 		return errors.Wrap(err, errListCompositions)
 	}
 
-	candidates := make([]string, 0, len(list.Items))
+	candidates := make([]v1.Composition, 0, len(list.Items))
 	v, k := cp.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
 
 	for _, comp := range list.Items {
-		if comp.Spec.CompositeTypeRef.APIVersion == v && comp.Spec.CompositeTypeRef.Kind == k {
-			// This composition is compatible with our composite resource.
-			candidates = append(candidates, comp.Name)
+		if comp.Spec.CompositeTypeRef.APIVersion != v || comp.Spec.CompositeTypeRef.Kind != k {
+			continue
 		}
+
+		// This composition is compatible with our composite resource.
+		if r.policies {
+			ok, err := SelectionMatches(comp.Spec.Selection, cp)
+			if err != nil {
+				return errors.Wrapf(err, errFmtSelectionExpression, comp.GetName())
+			}
+
+			if !ok {
+				continue
+			}
+		}
+
+		candidates = append(candidates, comp)
 	}
 
 	if len(candidates) == 0 {
@@ -284,10 +401,26 @@ func (r *APILabelSelectorResolver) SelectComposition(ctx context.Context, cp res
 	}
 
 	random := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec // We don't need this to be cryptographically random.
-	selected := candidates[random.Intn(len(candidates))]
-	cp.SetCompositionReference(&corev1.ObjectReference{Name: selected})
 
-	return errors.Wrap(r.client.Update(ctx, cp), errUpdateComposite)
+	selected := &candidates[random.Intn(len(candidates))]
+	why := fmt.Sprintf("selected at random from %d compatible compositions", len(candidates))
+
+	if r.policies {
+		var err error
+		if selected, why, err = selectWeighted(random, candidates); err != nil {
+			return err
+		}
+	}
+
+	cp.SetCompositionReference(&corev1.ObjectReference{Name: selected.GetName()})
+
+	if err := r.client.Update(ctx, cp); err != nil {
+		return errors.Wrap(err, errUpdateComposite)
+	}
+
+	r.recorder.Event(cp, event.Normal(reasonCompositionSelection, fmt.Sprintf("Composition %q has been %s", selected.GetName(), why)))
+
+	return nil
 }
 
 // NewAPIDefaultCompositionSelector returns a APIDefaultCompositionSelector.
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
@@ -215,9 +216,14 @@ func TestFetchRevision(t *testing.T) {
 		err error
 	}
 
+	// The latest revision, rolled out in stages and not yet approved.
+	staged := rev2.DeepCopy()
+	staged.Spec.Rollout = &v1.RevisionRollout{Strategy: v1.RolloutStrategyStaged}
+
 	cases := map[string]struct {
 		reason string
 		client client.Client
+		o      []APIRevisionFetcherOption
 		args   args
 		want   want
 	}{
@@ -446,6 +452,62 @@ func TestFetchRevision(t *testing.T) {
 				rev: rev2,
 			},
 		},
+		"StagedCurrentRevisionOfOtherComposition": {
+			reason: "We shouldn't keep a revision of another Composition while the latest revision is staged. We should start on the last approved revision.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					switch o := obj.(type) {
+					case *v1.Composition:
+						*o = *comp
+					case *v1.CompositionRevision:
+						// The XR's current revision exists, but belongs
+						// to the Composition it used to reference.
+						*o = *rev3
+					}
+					return nil
+				}),
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					*obj.(*v1.CompositionRevisionList) = v1.CompositionRevisionList{
+						Items: []v1.CompositionRevision{*staged, *rev1},
+					}
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+					// Ensure we were updated to reference the last approved
+					// CompositionRevision.
+					want := &fake.Composite{
+						CompositionReferencer: fake.CompositionReferencer{
+							Ref: &corev1.ObjectReference{Name: comp.GetName()},
+						},
+						CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+							Ref: &corev1.LocalObjectReference{
+								Name: rev1.GetName(),
+							},
+						},
+					}
+					if diff := cmp.Diff(want, obj); diff != "" {
+						t.Errorf("Apply(): -want, +got: %s", diff)
+					}
+					return nil
+				}),
+			},
+			o: []APIRevisionFetcherOption{WithStagedRollouts()},
+			args: args{
+				cr: &fake.Composite{
+					CompositionReferencer: fake.CompositionReferencer{
+						Ref: &corev1.ObjectReference{Name: comp.GetName()},
+					},
+					CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+						Ref: &corev1.LocalObjectReference{
+							Name: rev3.GetName(),
+						},
+					},
+				},
+			},
+			want: want{
+				rev: rev1,
+			},
+		},
 		"SetRevisionError": {
 			reason: "We should return the latest revision and update our reference if none is set.",
 			client: &test.MockClient{
@@ -480,7 +542,7 @@ func TestFetchRevision(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			f := NewAPIRevisionFetcher(tc.client)
+			f := NewAPIRevisionFetcher(tc.client, tc.o...)
 
 			got, err := f.Fetch(tc.args.ctx, tc.args.cr)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -621,9 +683,34 @@ func TestSelectorResolver(t *testing.T) {
 	}
 	sel := &metav1.LabelSelector{MatchLabels: map[string]string{"select": "me"}}
 
+	// A client that lists the supplied compositions.
+	listComps := func(comps ...v1.Composition) *test.MockClient {
+		return &test.MockClient{
+			MockUpdate: test.NewMockUpdateFn(nil),
+			MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+				obj.(*v1.CompositionList).Items = comps
+				return nil
+			}),
+		}
+	}
+
+	// A compatible composition with the supplied selection policy.
+	policy := func(name, expr string, weight *int32) v1.Composition {
+		return v1.Composition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.CompositionSpec{
+				CompositeTypeRef: tref,
+				Selection:        &v1.CompositionSelectionPolicy{Expression: expr, Weight: weight},
+			},
+		}
+	}
+
+	eu := &fake.Composite{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"region": "eu-west-1"}}}
+
 	type args struct {
 		kube client.Client
 		cp   resource.Composite
+		o    []APILabelSelectorResolverOption
 	}
 
 	type want struct {
@@ -710,10 +797,80 @@ func TestSelectorResolver(t *testing.T) {
 				},
 			},
 		},
+		"SelectionExpression": {
+			reason: "Should select the composition whose selection expression matches",
+			args: args{
+				kube: listComps(
+					policy("us", "metadata.labels['region'].startsWith('us-')", nil),
+					policy("eu", "metadata.labels['region'].startsWith('eu-')", nil),
+				),
+				cp: eu.DeepCopyObject().(*fake.Composite),
+				o:  []APILabelSelectorResolverOption{WithSelectionPolicies()},
+			},
+			want: want{
+				cp: &fake.Composite{
+					ObjectMeta:            eu.ObjectMeta,
+					CompositionReferencer: fake.CompositionReferencer{Ref: &corev1.ObjectReference{Name: "eu"}},
+				},
+			},
+		},
+		"SelectionWeight": {
+			reason: "Should never select a composition with a selection weight of 0",
+			args: args{
+				kube: listComps(
+					policy("never", "", ptr.To[int32](0)),
+					policy("always", "", ptr.To[int32](5)),
+				),
+				cp: &fake.Composite{},
+				o:  []APILabelSelectorResolverOption{WithSelectionPolicies()},
+			},
+			want: want{
+				cp: &fake.Composite{
+					CompositionReferencer: fake.CompositionReferencer{Ref: &corev1.ObjectReference{Name: "always"}},
+				},
+			},
+		},
+		"NoneMatchSelectionExpression": {
+			reason: "Should fail if no compatible composition's selection expression matches",
+			args: args{
+				kube: listComps(policy("us", "metadata.labels['region'].startsWith('us-')", nil)),
+				cp:   eu.DeepCopyObject().(*fake.Composite),
+				o:    []APILabelSelectorResolverOption{WithSelectionPolicies()},
+			},
+			want: want{
+				cp:  eu.DeepCopyObject().(*fake.Composite),
+				err: errors.New(errNoCompatibleComposition),
+			},
+		},
+		"SelectionPoliciesDisabled": {
+			reason: "Should ignore selection expressions unless selection policies are enabled",
+			args: args{
+				kube: listComps(policy("us", "metadata.labels['region'].startsWith('us-')", nil)),
+				cp:   eu.DeepCopyObject().(*fake.Composite),
+			},
+			want: want{
+				cp: &fake.Composite{
+					ObjectMeta:            eu.ObjectMeta,
+					CompositionReferencer: fake.CompositionReferencer{Ref: &corev1.ObjectReference{Name: "us"}},
+				},
+			},
+		},
+		"InvalidSelectionExpression": {
+			reason: "Should fail if a selection expression doesn't evaluate to a bool",
+			args: args{
+				kube: listComps(policy("us", "metadata.labels", nil)),
+				cp:   &fake.Composite{},
+				o:    []APILabelSelectorResolverOption{WithSelectionPolicies()},
+			},
+			want: want{
+				cp:  &fake.Composite{},
+				err: errors.Wrapf(errors.Wrap(errors.New("expression must evaluate to a bool"), errEvalSelection), errFmtSelectionExpression, "us"),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			c := NewAPILabelSelectorResolver(tc.kube)
+			c := NewAPILabelSelectorResolver(tc.kube, tc.o...)
 
 			err := c.SelectComposition(context.Background(), tc.args.cp)
 			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
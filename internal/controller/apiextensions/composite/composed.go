@@ -37,6 +37,9 @@ type ComposedResource struct {
 	// XR to be marked as not ready.
 	Ready bool
 
+	// Message explains why this composed resource isn't ready, if known.
+	Message string
+
 	// Synced indicates whether the composition process was able to sync the
 	// composed resource with its desired state. Setting it to false will cause
 	// the XR to be marked as not synced.
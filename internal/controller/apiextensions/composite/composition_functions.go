@@ -20,6 +20,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -36,6 +38,7 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
@@ -44,6 +47,7 @@ import (
 
 	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
 	"github.com/crossplane/crossplane/v2/internal/names"
+	"github.com/crossplane/crossplane/v2/internal/tracing"
 	"github.com/crossplane/crossplane/v2/internal/xcrd"
 	"github.com/crossplane/crossplane/v2/internal/xerrors"
 	"github.com/crossplane/crossplane/v2/internal/xfn"
@@ -65,10 +69,27 @@ const (
 	errGetComposed              = "cannot get composed resource"
 	errMarshalJSON              = "cannot marshal to JSON"
 
+	errMissingSecretRef           = "the Secret source requires a secretRef"
+	errMissingConfigMapRef        = "the ConfigMap source requires a configMapRef"
+	errMissingServiceAccountToken = "the ServiceAccountToken source requires a serviceAccountToken"
+	errMissingCompositeSecretRef  = "the CompositeSecretRef source requires a compositeSecretRef"
+
+	errServiceAccountTokensDisabled = "the ServiceAccountToken source requires the --enable-function-service-account-tokens feature flag"
+
+	errCredentialsNamespace = "a namespace is required, because the composite resource is cluster scoped and isn't bound to a claim"
+
+	errFmtUnknownCredentialsSource       = "unknown credentials source %q"
+	errFmtGetCompositeSecretRef          = "cannot get secret reference from composite resource field %q"
+	errFmtCompositeSecretRefName         = "secret reference in composite resource field %q must have a name"
+	errFmtCompositeSecretRefNamespace    = "invalid secret reference in composite resource field %q"
+	errFmtServiceAccountNamespace        = "invalid reference to ServiceAccount %q"
+	errFmtCredentialsOtherNamespace      = "cannot use credentials in namespace %q; a namespaced composite resource may only use credentials in its own namespace"
+	errFmtCredentialsNamespaceNotAllowed = "cannot use credentials in namespace %q; a cluster scoped composite resource may only use credentials in its claim's namespace or an allowed namespace"
+
 	errFmtApplyCD                     = "cannot apply composed resource %q"
 	errFmtFetchCDConnectionDetails    = "cannot fetch connection details for composed resource %q (a %s named %s)"
 	errFmtUnmarshalPipelineStepInput  = "cannot unmarshal input for Composition pipeline step %q"
-	errFmtGetCredentialsFromSecret    = "cannot get Composition pipeline step %q credential %q from Secret"
+	errFmtGetCredentials              = "cannot get Composition pipeline step %q credential %q"
 	errFmtRunPipelineStep             = "cannot run Composition pipeline step %q"
 	errFmtControllerMismatch          = "refusing to delete composed resource %q that is controlled by %s %q"
 	errFmtCleanupLabelsCD             = "cannot cleanup composed resource labels of resource %q (a %s named %s)"
@@ -104,10 +125,16 @@ const (
 // A FunctionComposer supports composing resources using a pipeline of
 // Composition Functions. It ignores the P&T resources array.
 type FunctionComposer struct {
-	client    client.Client
-	composite xr
-	pipeline  FunctionRunner
-	resources xfn.RequiredResourcesFetcher
+	client      client.Client
+	composite   xr
+	pipeline    FunctionRunner
+	resources   xfn.RequiredResourcesFetcher
+	credentials xfn.CredentialsFetcher
+	traces      PipelineTraceWriter
+	readiness   ReadinessChecker
+	ordered     bool
+	policies    bool
+	tokens      bool
 }
 
 type xr struct {
@@ -225,6 +252,62 @@ func WithRequiredResourcesFetcher(f xfn.RequiredResourcesFetcher) FunctionCompos
 	}
 }
 
+// WithCredentialsFetcher configures how the FunctionComposer should fetch
+// credentials for composition functions.
+func WithCredentialsFetcher(f xfn.CredentialsFetcher) FunctionComposerOption {
+	return func(p *FunctionComposer) {
+		p.credentials = f
+	}
+}
+
+// WithPipelineTraceWriter configures how the FunctionComposer should write
+// pipeline traces. Pipelines are only traced if a trace writer is configured,
+// and the XR or its Composition enables tracing.
+func WithPipelineTraceWriter(w PipelineTraceWriter) FunctionComposerOption {
+	return func(p *FunctionComposer) {
+		p.traces = w
+	}
+}
+
+// WithReadinessChecker configures how the FunctionComposer should check the
+// readiness of composed resources using the readiness checks of their
+// Composition. Readiness checks are ignored if no ReadinessChecker is
+// configured.
+func WithReadinessChecker(rc ReadinessChecker) FunctionComposerOption {
+	return func(p *FunctionComposer) {
+		p.readiness = rc
+	}
+}
+
+// WithComposedResourceOrdering configures the FunctionComposer to honor the
+// dependencies composed resources declare using the crossplane.io/depends-on
+// annotation. A composed resource isn't created until the composed resources
+// it depends on are ready, and isn't deleted until the composed resources that
+// depend on it are gone.
+func WithComposedResourceOrdering() FunctionComposerOption {
+	return func(p *FunctionComposer) {
+		p.ordered = true
+	}
+}
+
+// WithComposedResourceDeletePolicies configures the FunctionComposer to
+// annotate composed resources with the delete policies their Composition
+// declares, unless the function pipeline set one.
+func WithComposedResourceDeletePolicies() FunctionComposerOption {
+	return func(p *FunctionComposer) {
+		p.policies = true
+	}
+}
+
+// WithServiceAccountTokenCredentials configures the FunctionComposer to supply
+// functions with ServiceAccount tokens using the ServiceAccountToken
+// credentials source. Without it, pipeline steps that use the source fail.
+func WithServiceAccountTokenCredentials() FunctionComposerOption {
+	return func(p *FunctionComposer) {
+		p.tokens = true
+	}
+}
+
 // NewFunctionComposer returns a new Composer that supports composing resources using
 // both Patch and Transform (P&T) logic and a pipeline of Composition Functions.
 func NewFunctionComposer(cached, uncached client.Client, r FunctionRunner, o ...FunctionComposerOption) *FunctionComposer {
@@ -243,6 +326,10 @@ func NewFunctionComposer(cached, uncached client.Client, r FunctionRunner, o ...
 
 		pipeline:  r,
 		resources: xfn.NewExistingRequiredResourcesFetcher(cached),
+
+		// TokenRequests are a create-only subresource, so they can't be
+		// served from the cache.
+		credentials: xfn.NewExistingCredentialsFetcher(cached, uncached),
 	}
 
 	for _, fn := range o {
@@ -252,8 +339,37 @@ func NewFunctionComposer(cached, uncached client.Client, r FunctionRunner, o ...
 	return c
 }
 
-// Compose resources using the Functions pipeline.
-func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructured, req CompositionRequest) (CompositionResult, error) { //nolint:gocognit // We probably don't want any further abstraction for the sake of reduced complexity.
+// Compose resources using the Functions pipeline. If the XR or its
+// Composition enables pipeline tracing, Compose records what each step of the
+// pipeline did.
+func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructured, req CompositionRequest) (CompositionResult, error) {
+	trace, err := c.tracing(ctx, xr, req.Revision)
+	if err != nil {
+		return CompositionResult{}, err
+	}
+
+	if !trace {
+		return c.compose(ctx, xr, req, c.pipeline, nil)
+	}
+
+	t := NewPipelineTrace(req.Revision)
+	res, err := c.compose(ctx, xr, req, NewTracingFunctionRunner(c.pipeline, t, req.Revision.Spec.Pipeline), t)
+	t.Finish(err)
+
+	// Failing to write a trace shouldn't break composition.
+	if werr := c.traces.WriteTrace(ctx, xr, t); werr != nil {
+		res.Events = append(res.Events, TargetedEvent{
+			Event:  event.Warning(reasonCompose, errors.Wrap(werr, errWriteTrace)),
+			Target: CompositionTargetComposite,
+		})
+	}
+
+	return res, err
+}
+
+// compose resources using the supplied Functions pipeline runner, recording
+// what each step did to the supplied trace. The trace may be nil.
+func (c *FunctionComposer) compose(ctx context.Context, xr *composite.Unstructured, req CompositionRequest, runner FunctionRunner, t *PipelineTrace) (CompositionResult, error) { //nolint:gocognit // We probably don't want any further abstraction for the sake of reduced complexity.
 	// Observe our existing composed resources. We need to do this before we
 	// render any P&T templates, so that we can make sure we use the same
 	// composed resource names (as in, metadata.name) every time. We know what
@@ -297,135 +413,130 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 
 	// Run any Composition Functions in the pipeline. Each Function may mutate
 	// the desired state returned by the last, and each Function may produce
-	// results that will be emitted as events.
-	for _, fn := range req.Revision.Spec.Pipeline {
-		req := &fnv1.RunFunctionRequest{Observed: o, Desired: d, Context: fctx}
-
-		if fn.Input != nil {
-			in := &structpb.Struct{}
-			if err := in.UnmarshalJSON(fn.Input.Raw); err != nil {
-				return CompositionResult{}, errors.Wrapf(err, errFmtUnmarshalPipelineStepInput, fn.Step)
-			}
+	// results that will be emitted as events. Functions in the same stage run
+	// concurrently, and their desired state and context are merged.
+	for _, stage := range PipelineStages(req.Revision.Spec.Pipeline) {
+		s, err := SkipSteps(stage, o, d, fctx)
+		if err != nil {
+			return CompositionResult{}, err
+		}
+
+		t.StartStage(stage, s)
 
-			req.Input = in
+		// Every step in this stage was skipped.
+		if len(s.Steps) == 0 {
+			continue
 		}
 
-		req.Credentials = map[string]*fnv1.Credentials{}
-		for _, cs := range fn.Credentials {
-			// For now, we only support loading credentials from secrets.
-			if cs.Source != v1.FunctionCredentialsSourceSecret || cs.SecretRef == nil {
-				continue
-			}
+		reqs := make([]*fnv1.RunFunctionRequest, len(s.Steps))
+		for i, fn := range s.Steps {
+			sd, sctx := d, fctx
 
-			s := &corev1.Secret{}
-			if err := c.client.Get(ctx, client.ObjectKey{Namespace: cs.SecretRef.Namespace, Name: cs.SecretRef.Name}, s); err != nil {
-				return CompositionResult{}, errors.Wrapf(err, errFmtGetCredentialsFromSecret, fn.Step, cs.Name)
+			// Steps in a stage run concurrently, so each needs its own
+			// copy of the desired state and context.
+			if len(s.Steps) > 1 {
+				sd = proto.CloneOf(d)
+				sctx = proto.CloneOf(fctx)
 			}
 
-			req.Credentials[cs.Name] = &fnv1.Credentials{
-				Source: &fnv1.Credentials_CredentialData{
-					CredentialData: &fnv1.CredentialData{
-						Data: s.Data,
-					},
-				},
+			req, err := c.newRunFunctionRequest(ctx, fn, o, sd, sctx)
+			if err != nil {
+				return CompositionResult{}, err
 			}
-		}
 
-		// Pre-populate bootstrap requirements
-		if fn.Requirements != nil {
-			// Bootstrap requirements were introduced alongside the new field names,
-			// so we only need to support the new required_resources field.
-			req.RequiredResources = map[string]*fnv1.Resources{}
-			for _, sel := range fn.Requirements.RequiredResources {
-				resources, err := c.resources.Fetch(ctx, ToProtobufResourceSelector(sel))
-				if err != nil {
-					return CompositionResult{}, errors.Wrapf(err, errFmtFetchBootstrapRequirements, sel.RequirementName)
-				}
-				req.RequiredResources[sel.RequirementName] = resources
-			}
+			reqs[i] = req
 		}
 
-		req.Meta = &fnv1.RequestMeta{Tag: Tag(req)}
-
-		rsp, err := c.pipeline.RunFunction(ctx, fn.FunctionRef.Name, req)
+		rsps, err := RunStage(ctx, runner, s, reqs)
 		if err != nil {
-			return CompositionResult{}, errors.Wrapf(err, errFmtRunPipelineStep, fn.Step)
+			return CompositionResult{}, err
 		}
 
-		// If this Function specified a non-zero TTL that's less than
-		// the current recorded TTL for the pipeline, it's the new TTL
-		// for the pipeline.
-		if d := rsp.GetMeta().GetTtl().AsDuration(); d > 0 && (ttl == 0 || d < ttl) {
-			ttl = d
-		}
+		for i, fn := range s.Steps {
+			rsp := rsps[i]
 
-		// Pass the desired state returned by this Function to the next one.
-		d = rsp.GetDesired()
+			t.RecordStep(fn, reqs[i].GetDesired(), rsp)
 
-		// Pass the Function context returned by this Function to the next one.
-		// We intentionally discard/ignore this after the last Function runs.
-		fctx = rsp.GetContext()
+			// If this Function specified a non-zero TTL that's less than
+			// the current recorded TTL for the pipeline, it's the new TTL
+			// for the pipeline.
+			if d := rsp.GetMeta().GetTtl().AsDuration(); d > 0 && (ttl == 0 || d < ttl) {
+				ttl = d
+			}
 
-		for _, c := range rsp.GetConditions() {
-			var status corev1.ConditionStatus
+			for _, c := range rsp.GetConditions() {
+				var status corev1.ConditionStatus
 
-			switch c.GetStatus() {
-			case fnv1.Status_STATUS_CONDITION_TRUE:
-				status = corev1.ConditionTrue
-			case fnv1.Status_STATUS_CONDITION_FALSE:
-				status = corev1.ConditionFalse
-			case fnv1.Status_STATUS_CONDITION_UNKNOWN, fnv1.Status_STATUS_CONDITION_UNSPECIFIED:
-				status = corev1.ConditionUnknown
-			}
+				switch c.GetStatus() {
+				case fnv1.Status_STATUS_CONDITION_TRUE:
+					status = corev1.ConditionTrue
+				case fnv1.Status_STATUS_CONDITION_FALSE:
+					status = corev1.ConditionFalse
+				case fnv1.Status_STATUS_CONDITION_UNKNOWN, fnv1.Status_STATUS_CONDITION_UNSPECIFIED:
+					status = corev1.ConditionUnknown
+				}
 
-			conditions = append(conditions, TargetedCondition{
-				Condition: xpv1.Condition{
-					Type:               xpv1.ConditionType(c.GetType()),
-					Status:             status,
-					LastTransitionTime: metav1.Now(),
-					Reason:             xpv1.ConditionReason(c.GetReason()),
-					Message:            c.GetMessage(),
-				},
-				Target: convertTarget(c.GetTarget()),
-			})
-		}
-
-		// Results of fatal severity stop the Composition process. Other results
-		// are accumulated to be emitted as events by the Reconciler.
-		for _, rs := range rsp.GetResults() {
-			reason := event.Reason(rs.GetReason())
-			if reason == "" {
-				reason = reasonCompose
+				conditions = append(conditions, TargetedCondition{
+					Condition: xpv1.Condition{
+						Type:               xpv1.ConditionType(c.GetType()),
+						Status:             status,
+						LastTransitionTime: metav1.Now(),
+						Reason:             xpv1.ConditionReason(c.GetReason()),
+						Message:            c.GetMessage(),
+					},
+					Target: convertTarget(c.GetTarget()),
+				})
 			}
 
-			e := TargetedEvent{Target: convertTarget(rs.GetTarget())}
-
-			switch rs.GetSeverity() {
-			case fnv1.Severity_SEVERITY_FATAL:
-				return CompositionResult{Events: events, Conditions: conditions}, errors.Errorf(errFmtFatalResult, fn.Step, rs.GetMessage())
-			case fnv1.Severity_SEVERITY_WARNING:
-				e.Event = event.Warning(reason, errors.New(rs.GetMessage()))
-				e.Detail = fmt.Sprintf("Pipeline step %q", fn.Step)
-			case fnv1.Severity_SEVERITY_NORMAL:
-				e.Event = event.Normal(reason, rs.GetMessage())
-				e.Detail = fmt.Sprintf("Pipeline step %q", fn.Step)
-			case fnv1.Severity_SEVERITY_UNSPECIFIED:
-				// We could hit this case if a Function was built against a newer
-				// protobuf than this build of Crossplane, and the new protobuf
-				// introduced a severity that we don't know about.
-				e.Event = event.Warning(reason, errors.Errorf("Pipeline step %q returned a result of unknown severity (assuming warning): %s", fn.Step, rs.GetMessage()))
-				// Explicitly target only the XR, since we're including information
-				// about an exceptional, unexpected state.
-				e.Target = CompositionTargetComposite
+			// Results of fatal severity stop the Composition process. Other results
+			// are accumulated to be emitted as events by the Reconciler.
+			for _, rs := range rsp.GetResults() {
+				reason := event.Reason(rs.GetReason())
+				if reason == "" {
+					reason = reasonCompose
+				}
+
+				e := TargetedEvent{Target: convertTarget(rs.GetTarget())}
+
+				switch rs.GetSeverity() {
+				case fnv1.Severity_SEVERITY_FATAL:
+					return CompositionResult{Events: events, Conditions: conditions}, errors.Errorf(errFmtFatalResult, fn.Step, rs.GetMessage())
+				case fnv1.Severity_SEVERITY_WARNING:
+					e.Event = event.Warning(reason, errors.New(rs.GetMessage()))
+					e.Detail = fmt.Sprintf("Pipeline step %q", fn.Step)
+				case fnv1.Severity_SEVERITY_NORMAL:
+					e.Event = event.Normal(reason, rs.GetMessage())
+					e.Detail = fmt.Sprintf("Pipeline step %q", fn.Step)
+				case fnv1.Severity_SEVERITY_UNSPECIFIED:
+					// We could hit this case if a Function was built against a newer
+					// protobuf than this build of Crossplane, and the new protobuf
+					// introduced a severity that we don't know about.
+					e.Event = event.Warning(reason, errors.Errorf("Pipeline step %q returned a result of unknown severity (assuming warning): %s", fn.Step, rs.GetMessage()))
+					// Explicitly target only the XR, since we're including information
+					// about an exceptional, unexpected state.
+					e.Target = CompositionTargetComposite
+				}
+
+				events = append(events, e)
 			}
+		}
 
-			events = append(events, e)
+		// Pass the desired state and Function context returned by this
+		// stage to the next one. We intentionally discard/ignore the
+		// context after the last stage runs.
+		d, fctx, err = MergeStage(s, d, fctx, rsps)
+		if err != nil {
+			return CompositionResult{Events: events, Conditions: conditions}, err
 		}
 	}
 
 	// Load our desired composed resources from the Function pipeline.
 	desired := ComposedResourceStates{}
 
+	// Readiness checks for composed resources whose readiness wasn't set by
+	// the Function pipeline.
+	checks := map[ResourceName][]v1.ReadinessCheck{}
+
 	for name, dr := range d.GetResources() {
 		cd := composed.New()
 		if err := xfn.FromStruct(cd, dr.GetResource()); err != nil {
@@ -464,6 +575,10 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 			return CompositionResult{}, errors.Wrapf(err, errFmtRenderMetadata, name)
 		}
 
+		if c.policies {
+			RenderComposedDeletePolicy(cd, req.Revision, ResourceName(name))
+		}
+
 		// Generate a name. We want to allocate this name before we actually
 		// create the resource so that we can persist a resourceRef to it.
 		// This ensures we don't leak composed resources - see
@@ -495,16 +610,68 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 			ConnectionDetails: dr.GetConnectionDetails(),
 			Ready:             dr.GetReady() == fnv1.Ready_READY_TRUE,
 		}
+
+		if rc := ComposedReadinessChecksFor(req.Revision, ResourceName(name)); c.readiness != nil && len(rc) > 0 && dr.GetReady() == fnv1.Ready_READY_UNSPECIFIED {
+			checks[ResourceName(name)] = rc
+		}
 	}
 
+	// Composed resources that we're not creating yet, because they depend on
+	// composed resources that aren't ready.
+	deferred := map[ResourceName][]ResourceName{}
+
+	// Composed resources that are no longer desired, but that we must keep
+	// referencing until they're deleted in dependency order.
+	blocked, deleting := ComposedResourceStates{}, ComposedResourceStates{}
+
+	if c.ordered {
+		if cycle := DependencyCycle(desired); cycle != nil {
+			return CompositionResult{}, errors.Errorf(errFmtDependencyCycle, joinNames(cycle))
+		}
+
+		deferred = DeferComposedResources(desired, observed, func(name ResourceName) bool {
+			return c.dependencyReady(ctx, name, desired, observed, req.Revision)
+		})
+
+		for name := range deferred {
+			delete(desired, name)
+		}
+
+		blocked, deleting = RetainComposedResources(desired, observed)
+	}
+
+	c.unblockOwnerDeletion(xr, desired)
+
+	// Blocked composed resources aren't desired, but mustn't be deleted yet.
+	keep := ComposedResourceStates{}
+	maps.Copy(keep, desired)
+	maps.Copy(keep, blocked)
+
 	// Garbage collect any observed resources that aren't part of our final
 	// desired state. We must do this before we update the XR's resource
 	// references to ensure that we don't forget and leak them if a delete
 	// fails.
-	if err := c.composite.GarbageCollectComposedResources(ctx, xr, observed, desired); err != nil {
+	if err := c.composite.GarbageCollectComposedResources(ctx, xr, observed, keep); err != nil {
 		return CompositionResult{}, errors.Wrap(err, errGarbageCollectCDs)
 	}
 
+	for name := range blocked {
+		events = append(events, TargetedEvent{
+			Event:  event.Normal(reasonCompose, fmt.Sprintf("Waiting for composed resources that depend on %q to be deleted before deleting it: %s", name, joinNames(dependents(name, observed)))),
+			Target: CompositionTargetComposite,
+		})
+	}
+
+	// Deleting composed resources stay referenced until they're gone.
+	// Retained composed resources were orphaned, not deleted.
+	for name, cd := range deleting {
+		if c.policies && DeletePolicyOf(cd.Resource) == v1.ComposedDeletePolicyRetain {
+			continue
+		}
+
+		keep[name] = cd
+	}
+
 	// Record references to all desired composed resources. We need to do this
 	// before we apply the composed resources in order to avoid potentially
 	// leaking them. For example if we create three composed resources with
@@ -514,7 +681,7 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 	refs := composite.New(composite.WithSchema(xr.Schema), composite.WithGroupVersionKind(xr.GroupVersionKind()))
 	refs.SetNamespace(xr.GetNamespace())
 	refs.SetName(xr.GetName())
-	UpdateResourceRefs(refs, desired)
+	UpdateResourceRefs(refs, keep)
 
 	// Persist our updated composed resource references. We want this to be an
 	// atomic replace of the entire array. Note that we're relying on the status
@@ -543,7 +710,11 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 
 	// Produce our array of resources to return to the Reconciler. The
 	// Reconciler uses this array to determine whether the XR is ready.
-	resources := make([]ComposedResource, 0, len(desired))
+	resources := make([]ComposedResource, 0, len(desired)+len(deferred))
+
+	for name, deps := range deferred {
+		resources = append(resources, ComposedResource{ResourceName: name, Ready: false, Message: fmt.Sprintf("waiting for %s to be ready", joinNames(deps)), Synced: true})
+	}
 
 	// We apply all of our desired resources before we observe them in the loop
 	// below. This ensures that issues observing and processing one composed
@@ -557,7 +728,11 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 		// NOTE(phisco): We need to set a field owner unique for each XR here,
 		// this prevents multiple XRs composing the same resource to be
 		// continuously alternated as controllers.
-		if err := c.client.Patch(ctx, cd.Resource, client.Apply, client.ForceOwnership, client.FieldOwner(ComposedFieldOwnerName(xr))); err != nil {
+		actx, span := tracing.Start(ctx, "ApplyComposedResource", tracing.AttrKeyResource.String(string(name)), tracing.AttrKeyKind.String(cd.Resource.GetObjectKind().GroupVersionKind().Kind))
+		err := c.client.Patch(actx, cd.Resource, client.Apply, client.ForceOwnership, client.FieldOwner(ComposedFieldOwnerName(xr)))
+		tracing.End(span, err)
+
+		if err != nil {
 			if kerrors.IsInvalid(err) {
 				// We tried applying an invalid resource, we can't tell whether
 				// this means the resource will never be valid or it will if we
@@ -575,7 +750,8 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 				// p&t composer, as we respect the readiness reported by
 				// functions, while there we defaulted to also set ready false
 				// in case of apply errors.
-				resources = append(resources, ComposedResource{ResourceName: name, Ready: cd.Ready, Synced: false})
+				ready, msg := c.isReady(ctx, cd, checks[name])
+				resources = append(resources, ComposedResource{ResourceName: name, Ready: ready, Message: msg, Synced: false})
 
 				continue
 			}
@@ -586,7 +762,10 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 				Err:      err,
 			}
 		}
-		resources = append(resources, ComposedResource{ResourceName: name, Ready: cd.Ready, Synced: true})
+		// Applying the composed resource loaded its observed state, so we
+		// can check whether it's ready.
+		ready, msg := c.isReady(ctx, cd, checks[name])
+		resources = append(resources, ComposedResource{ResourceName: name, Ready: ready, Message: msg, Synced: true})
 	}
 
 	// Our goal here is to patch our XR's status using server-side apply. We
@@ -613,7 +792,11 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 
 	// NOTE(phisco): Here we are fine using a hardcoded field owner as there is
 	// no risk of conflict between different XRs.
-	if err := c.client.Status().Patch(ctx, xr, client.Apply, client.ForceOwnership, client.FieldOwner(FieldOwnerXR)); err != nil {
+	actx, span := tracing.Start(ctx, "ApplyCompositeStatus", tracing.AttrKeyKind.String(k), tracing.AttrKeyName.String(n))
+	err = c.client.Status().Patch(actx, xr, client.Apply, client.ForceOwnership, client.FieldOwner(FieldOwnerXR))
+	tracing.End(span, err)
+
+	if err != nil {
 		// Note(phisco): here we are fine with this error being terminal, as
 		// there is no other resource to apply that might eventually resolve
 		// this issue.
@@ -641,6 +824,200 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 	}, nil
 }
 
+// isReady returns whether the supplied composed resource is ready, and why
+// not. Composed resources without readiness checks are ready if the Function
+// pipeline said so.
+func (c *FunctionComposer) isReady(ctx context.Context, cd ComposedResourceState, checks []v1.ReadinessCheck) (bool, string) {
+	if len(checks) == 0 {
+		return cd.Ready, ""
+	}
+
+	return c.readiness.IsReady(ctx, cd.Resource, checks)
+}
+
+// newRunFunctionRequest builds the RunFunctionRequest for the supplied
+// pipeline step, given the observed state, and the desired state and context
+// produced by the previous step.
+func (c *FunctionComposer) newRunFunctionRequest(ctx context.Context, fn v1.PipelineStep, o, d *fnv1.State, fctx *structpb.Struct) (*fnv1.RunFunctionRequest, error) {
+	req := &fnv1.RunFunctionRequest{Observed: o, Desired: d, Context: fctx}
+
+	if fn.Input != nil {
+		in := &structpb.Struct{}
+		if err := in.UnmarshalJSON(fn.Input.Raw); err != nil {
+			return nil, errors.Wrapf(err, errFmtUnmarshalPipelineStepInput, fn.Step)
+		}
+
+		req.Input = in
+	}
+
+	req.Credentials = map[string]*fnv1.Credentials{}
+	for _, cs := range fn.Credentials {
+		creds, err := c.fetchCredentials(ctx, o.GetComposite().GetResource(), cs)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtGetCredentials, fn.Step, cs.Name)
+		}
+
+		if creds != nil {
+			req.Credentials[cs.Name] = creds
+		}
+	}
+
+	// Pre-populate bootstrap requirements
+	if fn.Requirements != nil {
+		// Bootstrap requirements were introduced alongside the new field names,
+		// so we only need to support the new required_resources field.
+		req.RequiredResources = map[string]*fnv1.Resources{}
+		for _, sel := range fn.Requirements.RequiredResources {
+			resources, err := c.resources.Fetch(ctx, ToProtobufResourceSelector(sel))
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtFetchBootstrapRequirements, sel.RequirementName)
+			}
+			req.RequiredResources[sel.RequirementName] = resources
+		}
+	}
+
+	req.Meta = &fnv1.RequestMeta{Tag: Tag(req)}
+
+	return req, nil
+}
+
+// fetchCredentials fetches the supplied pipeline step credentials. It returns
+// nil credentials for the None source.
+func (c *FunctionComposer) fetchCredentials(ctx context.Context, xr *structpb.Struct, cs v1.FunctionCredentials) (*fnv1.Credentials, error) {
+	switch cs.Source {
+	case v1.FunctionCredentialsSourceNone:
+		return nil, nil
+	case v1.FunctionCredentialsSourceSecret:
+		if cs.SecretRef == nil {
+			return nil, errors.New(errMissingSecretRef)
+		}
+		return c.credentials.FetchSecret(ctx, types.NamespacedName{Namespace: cs.SecretRef.Namespace, Name: cs.SecretRef.Name})
+	case v1.FunctionCredentialsSourceConfigMap:
+		if cs.ConfigMapRef == nil {
+			return nil, errors.New(errMissingConfigMapRef)
+		}
+		return c.credentials.FetchConfigMap(ctx, types.NamespacedName{Namespace: cs.ConfigMapRef.Namespace, Name: cs.ConfigMapRef.Name})
+	case v1.FunctionCredentialsSourceServiceAccountToken:
+		if !c.tokens {
+			return nil, errors.New(errServiceAccountTokensDisabled)
+		}
+		sat := cs.ServiceAccountToken
+		if sat == nil {
+			return nil, errors.New(errMissingServiceAccountToken)
+		}
+		ns, err := CredentialsNamespace(xr.AsMap(), sat.ServiceAccountRef.Namespace, sat.AllowedNamespaces)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtServiceAccountNamespace, sat.ServiceAccountRef.Name)
+		}
+		ref := types.NamespacedName{Namespace: ns, Name: sat.ServiceAccountRef.Name}
+		return c.credentials.FetchServiceAccountToken(ctx, ref, sat.Audiences, ptr.Deref(sat.ExpirationSeconds, 0))
+	case v1.FunctionCredentialsSourceCompositeSecretRef:
+		if cs.CompositeSecretRef == nil {
+			return nil, errors.New(errMissingCompositeSecretRef)
+		}
+		ref, err := CompositeSecretRef(xr.AsMap(), cs.CompositeSecretRef.FieldPath, cs.CompositeSecretRef.AllowedNamespaces)
+		if err != nil {
+			return nil, err
+		}
+		return c.credentials.FetchSecret(ctx, ref)
+	}
+
+	return nil, errors.Errorf(errFmtUnknownCredentialsSource, cs.Source)
+}
+
+// CompositeSecretRef returns the Secret referenced by the supplied field path
+// of the supplied composite resource. The field must be an object with a name,
+// and optionally a namespace. See CredentialsNamespace for the namespaces a
+// composite resource may reference.
+func CompositeSecretRef(xr map[string]any, path string, allowed []string) (types.NamespacedName, error) {
+	p := fieldpath.Pave(xr)
+
+	ref := struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	}{}
+	if err := p.GetValueInto(path, &ref); err != nil {
+		return types.NamespacedName{}, errors.Wrapf(err, errFmtGetCompositeSecretRef, path)
+	}
+
+	if ref.Name == "" {
+		return types.NamespacedName{}, errors.Errorf(errFmtCompositeSecretRefName, path)
+	}
+
+	ns, err := CredentialsNamespace(xr, ref.Namespace, allowed)
+	if err != nil {
+		return types.NamespacedName{}, errors.Wrapf(err, errFmtCompositeSecretRefNamespace, path)
+	}
+
+	return types.NamespacedName{Namespace: ns, Name: ref.Name}, nil
+}
+
+// CredentialsNamespace returns the namespace the supplied composite resource
+// may use credentials from, given the namespace a credentials source
+// references. Composite resources can't read credentials from arbitrary
+// namespaces, because Crossplane reads them on their behalf. A namespaced
+// composite resource may only use credentials in its own namespace, which is
+// the default. A cluster scoped composite resource may use credentials in the
+// namespace of its claim, which is the default, or in one of the supplied
+// allowed namespaces.
+func CredentialsNamespace(xr map[string]any, ns string, allowed []string) (string, error) {
+	p := fieldpath.Pave(xr)
+
+	if xrns, _ := p.GetString("metadata.namespace"); xrns != "" {
+		if ns != "" && ns != xrns {
+			return "", errors.Errorf(errFmtCredentialsOtherNamespace, ns)
+		}
+
+		return xrns, nil
+	}
+
+	claimns, _ := p.GetString("metadata.labels[" + xcrd.LabelKeyClaimNamespace + "]")
+
+	switch {
+	case ns == "" && claimns == "":
+		return "", errors.New(errCredentialsNamespace)
+	case ns == "":
+		return claimns, nil
+	case ns == claimns, slices.Contains(allowed, ns):
+		return ns, nil
+	}
+
+	return "", errors.Errorf(errFmtCredentialsNamespaceNotAllowed, ns)
+}
+
+// ToStepPolicy converts the timeout and retry policy of the supplied API
+// PipelineStep to an xfn StepPolicy.
+func ToStepPolicy(fn v1.PipelineStep) xfn.StepPolicy {
+	p := xfn.StepPolicy{Step: fn.Step}
+
+	if fn.Timeout != nil {
+		p.Timeout = fn.Timeout.Duration
+	}
+
+	if fn.Retry == nil {
+		return p
+	}
+
+	p.Retry.MaxAttempts = int(ptr.Deref(fn.Retry.MaxAttempts, xfn.DefaultRetryMaxAttempts))
+
+	if fn.Retry.Backoff != nil {
+		p.Retry.Backoff = fn.Retry.Backoff.Duration
+	}
+
+	if fn.Retry.MaxBackoff != nil {
+		p.Retry.MaxBackoff = fn.Retry.MaxBackoff.Duration
+	}
+
+	if fn.Retry.Codes != nil {
+		p.Retry.Codes = make([]string, len(fn.Retry.Codes))
+		for i, c := range fn.Retry.Codes {
+			p.Retry.Codes[i] = string(c)
+		}
+	}
+
+	return p
+}
+
 // ToProtobufResourceSelector converts API RequiredResourceSelector to protobuf ResourceSelector.
 func ToProtobufResourceSelector(r v1.RequiredResourceSelector) *fnv1.ResourceSelector {
 	selector := &fnv1.ResourceSelector{
@@ -819,13 +1196,32 @@ func AsState(xr resource.Composite, xc managed.ConnectionDetails, rs ComposedRes
 // An DeletingComposedResourceGarbageCollector deletes undesired composed resources from
 // the API server.
 type DeletingComposedResourceGarbageCollector struct {
-	client client.Writer
+	client   client.Writer
+	policies bool
+}
+
+// A DeletingComposedResourceGarbageCollectorOption configures a
+// DeletingComposedResourceGarbageCollector.
+type DeletingComposedResourceGarbageCollectorOption func(gc *DeletingComposedResourceGarbageCollector)
+
+// WithComposedDeletePolicies configures the garbage collector to orphan,
+// rather than delete, undesired composed resources whose delete policy is
+// Retain.
+func WithComposedDeletePolicies() DeletingComposedResourceGarbageCollectorOption {
+	return func(gc *DeletingComposedResourceGarbageCollector) {
+		gc.policies = true
+	}
 }
 
 // NewDeletingComposedResourceGarbageCollector returns a ComposedResourceDeleter that
 // deletes undesired composed resources from the API server.
-func NewDeletingComposedResourceGarbageCollector(c client.Writer) *DeletingComposedResourceGarbageCollector {
-	return &DeletingComposedResourceGarbageCollector{client: c}
+func NewDeletingComposedResourceGarbageCollector(c client.Writer, o ...DeletingComposedResourceGarbageCollectorOption) *DeletingComposedResourceGarbageCollector {
+	gc := &DeletingComposedResourceGarbageCollector{client: c}
+	for _, fn := range o {
+		fn(gc)
+	}
+
+	return gc
 }
 
 // GarbageCollectComposedResources deletes any composed resource that didn't
@@ -854,6 +1250,19 @@ func (d *DeletingComposedResourceGarbageCollector) GarbageCollectComposedResourc
 			return errors.Errorf(errFmtControllerMismatch, name, c.Kind, c.Name)
 		}
 
+		// Orphan composed resources we must retain, rather than deleting them.
+		if d.policies && DeletePolicyOf(cd.Resource) == v1.ComposedDeletePolicyRetain {
+			if !orphan(cd.Resource, owner.GetUID()) {
+				continue
+			}
+
+			if err := d.client.Update(ctx, cd.Resource); resource.IgnoreNotFound(err) != nil {
+				return errors.Wrapf(err, errFmtOrphanCD, name, cd.Resource.GetObjectKind().GroupVersionKind().Kind, cd.Resource.GetName())
+			}
+
+			continue
+		}
+
 		// Remove the labels that indicate this resource was owned by a
 		// Composition. This helps differentiate whether a resource was deleted
 		// due to garbage collection or because its owning composite was deleted.
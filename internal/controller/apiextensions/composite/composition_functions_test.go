@@ -99,6 +99,7 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
+				ctx: context.Background(),
 				xr:  composite.New(),
 				req: CompositionRequest{Revision: &v1.CompositionRevision{}},
 			},
@@ -119,6 +120,7 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
+				ctx: context.Background(),
 				xr:  composite.New(),
 				req: CompositionRequest{Revision: &v1.CompositionRevision{}},
 			},
@@ -139,7 +141,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: composite.New(),
+				ctx: context.Background(),
+				xr:  composite.New(),
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -179,7 +182,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: composite.New(),
+				ctx: context.Background(),
+				xr:  composite.New(),
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -204,7 +208,98 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			want: want{
-				err: errors.Wrapf(errBoom, errFmtGetCredentialsFromSecret, "run-cool-function", "cool-secret"),
+				err: errors.Wrapf(errors.Wrap(errBoom, "cannot get credentials Secret"), errFmtGetCredentials, "run-cool-function", "cool-secret"),
+			},
+		},
+		"ServiceAccountTokensDisabled": {
+			reason: "We should return an error if a Composition uses the ServiceAccountToken source without it being enabled",
+			params: params{
+				o: []FunctionComposerOption{
+					WithCompositeConnectionDetailsFetcher(ConnectionDetailsFetcherFn(func(_ context.Context, _ ConnectionSecretOwner) (managed.ConnectionDetails, error) {
+						return nil, nil
+					})),
+					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+						return nil, nil
+					})),
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				xr: func() *composite.Unstructured {
+					xr := composite.New()
+					xr.SetLabels(map[string]string{xcrd.LabelKeyClaimNamespace: "claim-ns"})
+					return xr
+				}(),
+				req: CompositionRequest{
+					Revision: &v1.CompositionRevision{
+						Spec: v1.CompositionRevisionSpec{
+							Pipeline: []v1.PipelineStep{
+								{
+									Step:        "run-cool-function",
+									FunctionRef: v1.FunctionReference{Name: "cool-function"},
+									Credentials: []v1.FunctionCredentials{
+										{
+											Name:   "cool-token",
+											Source: v1.FunctionCredentialsSourceServiceAccountToken,
+											ServiceAccountToken: &v1.ServiceAccountTokenSource{
+												ServiceAccountRef: v1.ServiceAccountReference{Namespace: "other-ns", Name: "cool-sa"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errors.New(errServiceAccountTokensDisabled), errFmtGetCredentials, "run-cool-function", "cool-token"),
+			},
+		},
+		"ServiceAccountTokenNamespaceNotAllowed": {
+			reason: "We should return an error if a cluster scoped XR's Composition references a ServiceAccount outside its claim's namespace",
+			params: params{
+				o: []FunctionComposerOption{
+					WithServiceAccountTokenCredentials(),
+					WithCompositeConnectionDetailsFetcher(ConnectionDetailsFetcherFn(func(_ context.Context, _ ConnectionSecretOwner) (managed.ConnectionDetails, error) {
+						return nil, nil
+					})),
+					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+						return nil, nil
+					})),
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				xr: func() *composite.Unstructured {
+					xr := composite.New()
+					xr.SetLabels(map[string]string{xcrd.LabelKeyClaimNamespace: "claim-ns"})
+					return xr
+				}(),
+				req: CompositionRequest{
+					Revision: &v1.CompositionRevision{
+						Spec: v1.CompositionRevisionSpec{
+							Pipeline: []v1.PipelineStep{
+								{
+									Step:        "run-cool-function",
+									FunctionRef: v1.FunctionReference{Name: "cool-function"},
+									Credentials: []v1.FunctionCredentials{
+										{
+											Name:   "cool-token",
+											Source: v1.FunctionCredentialsSourceServiceAccountToken,
+											ServiceAccountToken: &v1.ServiceAccountTokenSource{
+												ServiceAccountRef: v1.ServiceAccountReference{Namespace: "other-ns", Name: "cool-sa"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errors.Wrapf(errors.Errorf(errFmtCredentialsNamespaceNotAllowed, "other-ns"), errFmtServiceAccountNamespace, "cool-sa"), errFmtGetCredentials, "run-cool-function", "cool-token"),
 			},
 		},
 		"RunFunctionError": {
@@ -223,7 +318,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: composite.New(),
+				ctx: context.Background(),
+				xr:  composite.New(),
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -304,7 +400,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: composite.New(),
+				ctx: context.Background(),
+				xr:  composite.New(),
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -396,6 +493,7 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
+				ctx: context.Background(),
 				// Missing labels required by RenderComposedResourceMetadata.
 				xr: composite.New(),
 				req: CompositionRequest{
@@ -448,7 +546,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: WithParentLabel(),
+				ctx: context.Background(),
+				xr:  WithParentLabel(),
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -501,7 +600,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: WithParentLabel(),
+				ctx: context.Background(),
+				xr:  WithParentLabel(),
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -559,7 +659,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: WithParentLabel(),
+				ctx: context.Background(),
+				xr:  WithParentLabel(),
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -617,6 +718,7 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
+				ctx: context.Background(),
 				xr: func() *composite.Unstructured {
 					xr := WithParentLabel()
 					xr.SetNamespace("test-namespace") // Make the XR namespaced
@@ -679,6 +781,7 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
+				ctx: context.Background(),
 				xr: func() *composite.Unstructured {
 					xr := WithParentLabel()
 					xr.SetNamespace("test-namespace") // Make the XR namespaced
@@ -744,7 +847,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: WithParentLabel(), // Cluster-scoped XR (no namespace)
+				ctx: context.Background(),
+				xr:  WithParentLabel(), // Cluster-scoped XR (no namespace)
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -764,6 +868,242 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 		},
+		"ReadinessChecks": {
+			reason: "The Composition's readiness checks should determine whether composed resources whose readiness the pipeline didn't set are ready",
+			params: params{
+				c: &test.MockClient{
+					MockGet:                test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "ClusterComposed"}, "")), // all names are available
+					MockPatch:              test.NewMockPatchFn(nil),
+					MockStatusPatch:        test.NewMockSubResourcePatchFn(nil),
+					MockIsObjectNamespaced: test.NewMockIsObjectNamespacedFn(errBoom, false),
+				},
+				uc: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				r: FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (rsp *fnv1.RunFunctionResponse, err error) {
+					d := &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"checked": {
+								Resource: MustStruct(map[string]any{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind":       "ClusterComposed",
+									"metadata": map[string]any{
+										"name": "checked",
+									},
+								}),
+							},
+							"explicit": {
+								Resource: MustStruct(map[string]any{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind":       "ClusterComposed",
+									"metadata": map[string]any{
+										"name": "explicit",
+									},
+								}),
+								Ready: fnv1.Ready_READY_TRUE,
+							},
+						},
+					}
+					return &fnv1.RunFunctionResponse{Desired: d}, nil
+				}),
+				o: []FunctionComposerOption{
+					WithCompositeConnectionDetailsFetcher(ConnectionDetailsFetcherFn(func(_ context.Context, _ ConnectionSecretOwner) (managed.ConnectionDetails, error) {
+						return nil, nil
+					})),
+					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+						return nil, nil
+					})),
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+						return nil
+					})),
+					WithReadinessChecker(ReadinessCheckerFn(func(_ context.Context, _ resource.Composed, _ []v1.ReadinessCheck) (bool, string) {
+						return false, "status.phase is not set"
+					})),
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				xr:  WithParentLabel(),
+				req: CompositionRequest{
+					Revision: &v1.CompositionRevision{
+						Spec: v1.CompositionRevisionSpec{
+							Pipeline: []v1.PipelineStep{
+								{
+									Step:        "run-cool-function",
+									FunctionRef: v1.FunctionReference{Name: "cool-function"},
+								},
+							},
+							ReadinessChecks: []v1.ComposedReadinessChecks{
+								{ResourceName: "checked", Checks: []v1.ReadinessCheck{{Type: v1.ReadinessCheckTypeNonEmpty, FieldPath: ptr.To("status.phase")}}},
+								{ResourceName: "explicit", Checks: []v1.ReadinessCheck{{Type: v1.ReadinessCheckTypeNonEmpty, FieldPath: ptr.To("status.phase")}}},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				res: CompositionResult{
+					Composed: []ComposedResource{
+						{ResourceName: "checked", Ready: false, Message: "status.phase is not set", Synced: true},
+						{ResourceName: "explicit", Ready: true, Synced: true},
+					},
+				},
+			},
+		},
+		"DeferComposedResources": {
+			reason: "Composed resources shouldn't be created until the composed resources they depend on are ready",
+			params: params{
+				c: &test.MockClient{
+					MockGet:                test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "ClusterComposed"}, "")), // all names are available
+					MockPatch:              test.NewMockPatchFn(nil),
+					MockStatusPatch:        test.NewMockSubResourcePatchFn(nil),
+					MockIsObjectNamespaced: test.NewMockIsObjectNamespacedFn(errBoom, false),
+				},
+				uc: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				r: FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (rsp *fnv1.RunFunctionResponse, err error) {
+					d := &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"database": {
+								Resource: MustStruct(map[string]any{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind":       "ClusterComposed",
+									"metadata": map[string]any{
+										"name": "database",
+									},
+								}),
+							},
+							"app": {
+								Resource: MustStruct(map[string]any{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind":       "ClusterComposed",
+									"metadata": map[string]any{
+										"name": "app",
+										"annotations": map[string]any{
+											"crossplane.io/depends-on": "database",
+										},
+									},
+								}),
+							},
+						},
+					}
+					return &fnv1.RunFunctionResponse{Desired: d}, nil
+				}),
+				o: []FunctionComposerOption{
+					WithCompositeConnectionDetailsFetcher(ConnectionDetailsFetcherFn(func(_ context.Context, _ ConnectionSecretOwner) (managed.ConnectionDetails, error) {
+						return nil, nil
+					})),
+					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+						return nil, nil
+					})),
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+						return nil
+					})),
+					WithComposedResourceOrdering(),
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				xr:  WithParentLabel(),
+				req: CompositionRequest{
+					Revision: &v1.CompositionRevision{
+						Spec: v1.CompositionRevisionSpec{
+							Pipeline: []v1.PipelineStep{
+								{
+									Step:        "run-cool-function",
+									FunctionRef: v1.FunctionReference{Name: "cool-function"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				res: CompositionResult{
+					Composed: []ComposedResource{
+						{ResourceName: "app", Ready: false, Message: "waiting for database to be ready", Synced: true},
+						{ResourceName: "database", Ready: false, Synced: true},
+					},
+				},
+			},
+		},
+		"DependencyCycleError": {
+			reason: "We should return an error if composed resources depend on each other",
+			params: params{
+				c: &test.MockClient{
+					MockGet:                test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "ClusterComposed"}, "")), // all names are available
+					MockPatch:              test.NewMockPatchFn(nil),
+					MockStatusPatch:        test.NewMockSubResourcePatchFn(nil),
+					MockIsObjectNamespaced: test.NewMockIsObjectNamespacedFn(errBoom, false),
+				},
+				uc: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				r: FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (rsp *fnv1.RunFunctionResponse, err error) {
+					d := &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"database": {
+								Resource: MustStruct(map[string]any{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind":       "ClusterComposed",
+									"metadata": map[string]any{
+										"name": "database",
+										"annotations": map[string]any{
+											"crossplane.io/depends-on": "app",
+										},
+									},
+								}),
+							},
+							"app": {
+								Resource: MustStruct(map[string]any{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind":       "ClusterComposed",
+									"metadata": map[string]any{
+										"name": "app",
+										"annotations": map[string]any{
+											"crossplane.io/depends-on": "database",
+										},
+									},
+								}),
+							},
+						},
+					}
+					return &fnv1.RunFunctionResponse{Desired: d}, nil
+				}),
+				o: []FunctionComposerOption{
+					WithCompositeConnectionDetailsFetcher(ConnectionDetailsFetcherFn(func(_ context.Context, _ ConnectionSecretOwner) (managed.ConnectionDetails, error) {
+						return nil, nil
+					})),
+					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+						return nil, nil
+					})),
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+						return nil
+					})),
+					WithComposedResourceOrdering(),
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				xr:  WithParentLabel(),
+				req: CompositionRequest{
+					Revision: &v1.CompositionRevision{
+						Spec: v1.CompositionRevisionSpec{
+							Pipeline: []v1.PipelineStep{
+								{
+									Step:        "run-cool-function",
+									FunctionRef: v1.FunctionReference{Name: "cool-function"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtDependencyCycle, "app, database, app"),
+			},
+		},
 		"ApplyXRResourceReferencesError": {
 			reason: "We should return any error we encounter when applying the composite resource's resource references",
 			params: params{
@@ -798,7 +1138,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: composite.New(),
+				ctx: context.Background(),
+				xr:  composite.New(),
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -852,7 +1193,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: composite.New(),
+				ctx: context.Background(),
+				xr:  composite.New(),
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -917,7 +1259,8 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
-				xr: WithParentLabel(),
+				ctx: context.Background(),
+				xr:  WithParentLabel(),
 				req: CompositionRequest{
 					Revision: &v1.CompositionRevision{
 						Spec: v1.CompositionRevisionSpec{
@@ -1107,6 +1450,7 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
+				ctx: context.Background(),
 				xr: func() *composite.Unstructured {
 					// Our XR needs a GVK to survive round-tripping through a
 					// protobuf struct (which involves using the Kubernetes-aware
@@ -1274,6 +1618,7 @@ func TestFunctionCompose(t *testing.T) {
 				},
 			},
 			args: args{
+				ctx: context.Background(),
 				xr: func() *composite.Unstructured {
 					xr := composite.New(composite.WithGroupVersionKind(schema.GroupVersionKind{
 						Group:   "test.crossplane.io",
@@ -1470,6 +1815,157 @@ func WithParentLabel() *composite.Unstructured {
 	return xr
 }
 
+func TestCompositeSecretRef(t *testing.T) {
+	type args struct {
+		xr      map[string]any
+		path    string
+		allowed []string
+	}
+
+	type want struct {
+		ref types.NamespacedName
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"MissingField": {
+			reason: "We should return an error if the field doesn't exist.",
+			args: args{
+				xr:   map[string]any{"spec": map[string]any{}},
+				path: "spec.credentialsRef",
+			},
+			want: want{
+				err: errors.Wrapf(errors.New("spec.credentialsRef: no such field"), errFmtGetCompositeSecretRef, "spec.credentialsRef"),
+			},
+		},
+		"MissingName": {
+			reason: "We should return an error if the reference doesn't have a name.",
+			args: args{
+				xr:   map[string]any{"spec": map[string]any{"credentialsRef": map[string]any{"namespace": "default"}}},
+				path: "spec.credentialsRef",
+			},
+			want: want{
+				err: errors.Errorf(errFmtCompositeSecretRefName, "spec.credentialsRef"),
+			},
+		},
+		"ClusterScopedMissingNamespace": {
+			reason: "We should return an error if a cluster scoped XR without a claim doesn't reference a namespace.",
+			args: args{
+				xr:   map[string]any{"spec": map[string]any{"credentialsRef": map[string]any{"name": "cool-secret"}}},
+				path: "spec.credentialsRef",
+			},
+			want: want{
+				err: errors.Wrapf(errors.New(errCredentialsNamespace), errFmtCompositeSecretRefNamespace, "spec.credentialsRef"),
+			},
+		},
+		"ClusterScopedNamespaceNotAllowed": {
+			reason: "A cluster scoped XR shouldn't be able to reference a secret in a namespace that isn't allowed.",
+			args: args{
+				xr:   map[string]any{"spec": map[string]any{"credentialsRef": map[string]any{"name": "cool-secret", "namespace": "kube-system"}}},
+				path: "spec.credentialsRef",
+			},
+			want: want{
+				err: errors.Wrapf(errors.Errorf(errFmtCredentialsNamespaceNotAllowed, "kube-system"), errFmtCompositeSecretRefNamespace, "spec.credentialsRef"),
+			},
+		},
+		"ClusterScopedOtherNamespaceThanClaim": {
+			reason: "A cluster scoped XR shouldn't be able to reference a secret outside its claim's namespace.",
+			args: args{
+				xr: map[string]any{
+					"metadata": map[string]any{"labels": map[string]any{xcrd.LabelKeyClaimNamespace: "claim-ns"}},
+					"spec":     map[string]any{"credentialsRef": map[string]any{"name": "cool-secret", "namespace": "other-ns"}},
+				},
+				path: "spec.credentialsRef",
+			},
+			want: want{
+				err: errors.Wrapf(errors.Errorf(errFmtCredentialsNamespaceNotAllowed, "other-ns"), errFmtCompositeSecretRefNamespace, "spec.credentialsRef"),
+			},
+		},
+		"ClusterScopedClaimDefaultNamespace": {
+			reason: "A cluster scoped XR's reference should default to its claim's namespace.",
+			args: args{
+				xr: map[string]any{
+					"metadata": map[string]any{"labels": map[string]any{xcrd.LabelKeyClaimNamespace: "claim-ns"}},
+					"spec":     map[string]any{"credentialsRef": map[string]any{"name": "cool-secret"}},
+				},
+				path: "spec.credentialsRef",
+			},
+			want: want{
+				ref: types.NamespacedName{Namespace: "claim-ns", Name: "cool-secret"},
+			},
+		},
+		"ClusterScopedClaimNamespace": {
+			reason: "A cluster scoped XR should be able to reference a secret in its claim's namespace.",
+			args: args{
+				xr: map[string]any{
+					"metadata": map[string]any{"labels": map[string]any{xcrd.LabelKeyClaimNamespace: "claim-ns"}},
+					"spec":     map[string]any{"credentialsRef": map[string]any{"name": "cool-secret", "namespace": "claim-ns"}},
+				},
+				path: "spec.credentialsRef",
+			},
+			want: want{
+				ref: types.NamespacedName{Namespace: "claim-ns", Name: "cool-secret"},
+			},
+		},
+		"ClusterScopedAllowedNamespace": {
+			reason: "A cluster scoped XR should be able to reference a secret in an allowed namespace.",
+			args: args{
+				xr:      map[string]any{"spec": map[string]any{"credentialsRef": map[string]any{"name": "cool-secret", "namespace": "cool-ns"}}},
+				path:    "spec.credentialsRef",
+				allowed: []string{"cool-ns"},
+			},
+			want: want{
+				ref: types.NamespacedName{Namespace: "cool-ns", Name: "cool-secret"},
+			},
+		},
+		"NamespacedDefaultNamespace": {
+			reason: "A namespaced XR's reference should default to the XR's namespace.",
+			args: args{
+				xr: map[string]any{
+					"metadata": map[string]any{"namespace": "cool-ns"},
+					"spec":     map[string]any{"credentialsRef": map[string]any{"name": "cool-secret"}},
+				},
+				path: "spec.credentialsRef",
+			},
+			want: want{
+				ref: types.NamespacedName{Namespace: "cool-ns", Name: "cool-secret"},
+			},
+		},
+		"NamespacedOtherNamespace": {
+			reason: "A namespaced XR shouldn't be able to reference a secret in another namespace, even an allowed one.",
+			args: args{
+				xr: map[string]any{
+					"metadata": map[string]any{"namespace": "cool-ns"},
+					"spec":     map[string]any{"credentialsRef": map[string]any{"name": "cool-secret", "namespace": "other-ns"}},
+				},
+				path:    "spec.credentialsRef",
+				allowed: []string{"other-ns"},
+			},
+			want: want{
+				err: errors.Wrapf(errors.Errorf(errFmtCredentialsOtherNamespace, "other-ns"), errFmtCompositeSecretRefNamespace, "spec.credentialsRef"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ref, err := CompositeSecretRef(tc.args.xr, tc.args.path, tc.args.allowed)
+
+			if diff := cmp.Diff(tc.want.ref, ref); diff != "" {
+				t.Errorf("\n%s\nCompositeSecretRef(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCompositeSecretRef(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestGetComposedResources(t *testing.T) {
 	errBoom := errors.New("boom")
 	details := managed.ConnectionDetails{"a": []byte("b")}
@@ -1855,6 +2351,7 @@ func TestGarbageCollectComposedResources(t *testing.T) {
 
 	type params struct {
 		client client.Writer
+		opts   []DeletingComposedResourceGarbageCollectorOption
 	}
 
 	type args struct {
@@ -2013,6 +2510,53 @@ func TestGarbageCollectComposedResources(t *testing.T) {
 				err: nil,
 			},
 		},
+		"RetainedResource": {
+			reason: "We should orphan, rather than delete, an undesired resource whose delete policy is Retain.",
+			params: params{
+				client: &test.MockClient{
+					MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+						if len(obj.GetOwnerReferences()) != 0 {
+							return errors.New("resource still has owner references")
+						}
+						if obj.GetLabels()[xcrd.LabelKeyNamePrefixForComposed] != "" {
+							return errors.New("resource still has composed resource labels")
+						}
+						return nil
+					},
+					// We know Delete wasn't called because it's nil and would
+					// panic if it was.
+				},
+				opts: []DeletingComposedResourceGarbageCollectorOption{WithComposedDeletePolicies()},
+			},
+			args: args{
+				owner: &fake.Composite{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "cool-xr",
+					},
+				},
+				observed: ComposedResourceStates{
+					"undesired-resource": ComposedResourceState{
+						Resource: &fake.Composed{
+							ObjectMeta: metav1.ObjectMeta{
+								OwnerReferences: []metav1.OwnerReference{{
+									Controller: ptr.To(true),
+									UID:        "cool-xr",
+								}},
+								Labels: map[string]string{
+									xcrd.LabelKeyNamePrefixForComposed: "cool-xr",
+								},
+								Annotations: map[string]string{
+									xcrd.AnnotationKeyComposedDeletePolicy: string(v1.ComposedDeletePolicyRetain),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"SuccessfulNoop": {
 			reason: "We should not delete an observed resource from the API server if it is desired.",
 			params: params{
@@ -2053,7 +2597,7 @@ func TestGarbageCollectComposedResources(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			d := NewDeletingComposedResourceGarbageCollector(tc.params.client)
+			d := NewDeletingComposedResourceGarbageCollector(tc.params.client, tc.params.opts...)
 
 			err := d.GarbageCollectComposedResources(tc.args.ctx, tc.args.owner, tc.args.observed, tc.args.desired)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
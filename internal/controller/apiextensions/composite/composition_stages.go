@@ -0,0 +1,269 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/tracing"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// Error strings.
+const (
+	errFmtConflictingComposite = "pipeline steps %q and %q in stage %q made conflicting changes to the desired composite resource"
+	errFmtConflictingResource  = "pipeline steps %q and %q in stage %q made conflicting changes to desired composed resource %q"
+	errFmtConflictingContext   = "pipeline steps %q and %q in stage %q made conflicting changes to context key %q"
+	errFmtEvaluateCondition    = "cannot evaluate when condition of Composition pipeline step %q"
+)
+
+// A PipelineStage is a group of pipeline steps that run concurrently.
+type PipelineStage struct {
+	// Name of the stage. Empty for a step that isn't part of a stage.
+	Name string
+
+	// Steps in this stage, in pipeline order.
+	Steps []v1.PipelineStep
+}
+
+// PipelineStages groups the supplied pipeline into stages. Consecutive steps
+// that specify the same stage are grouped together. Each step that doesn't
+// specify a stage is a stage of its own.
+func PipelineStages(pipeline []v1.PipelineStep) []PipelineStage {
+	stages := make([]PipelineStage, 0, len(pipeline))
+
+	for _, fn := range pipeline {
+		if i := len(stages) - 1; fn.Stage != "" && i >= 0 && stages[i].Name == fn.Stage {
+			stages[i].Steps = append(stages[i].Steps, fn)
+			continue
+		}
+
+		stages = append(stages, PipelineStage{Name: fn.Stage, Steps: []v1.PipelineStep{fn}})
+	}
+
+	return stages
+}
+
+// SkipSteps returns the supplied stage without any steps whose when condition
+// isn't met. Conditions are evaluated against the observed state, and the
+// desired state and context that would be sent to the stage.
+func SkipSteps(s PipelineStage, o, d *fnv1.State, fctx *structpb.Struct) (PipelineStage, error) {
+	out := PipelineStage{Name: s.Name, Steps: make([]v1.PipelineStep, 0, len(s.Steps))}
+
+	for _, fn := range s.Steps {
+		run, err := xfn.EvaluateCondition(fn.When, o, d, fctx)
+		if err != nil {
+			return PipelineStage{}, errors.Wrapf(err, errFmtEvaluateCondition, fn.Step)
+		}
+
+		if run {
+			out.Steps = append(out.Steps, fn)
+		}
+	}
+
+	return out, nil
+}
+
+// RunStage runs the supplied requests concurrently - one per step of the
+// supplied stage. Each step's timeout and retry policy is passed to the
+// function runner. It returns responses in pipeline order. If more than one
+// step fails it returns the error of the first failed step in pipeline order.
+func RunStage(ctx context.Context, r FunctionRunner, s PipelineStage, reqs []*fnv1.RunFunctionRequest) ([]*fnv1.RunFunctionResponse, error) {
+	rsps := make([]*fnv1.RunFunctionResponse, len(s.Steps))
+
+	// Don't bother with a goroutine if there's only one step.
+	if len(s.Steps) == 1 {
+		rsp, err := runStep(ctx, r, s.Steps[0], reqs[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtRunPipelineStep, s.Steps[0].Step)
+		}
+
+		rsps[0] = rsp
+
+		return rsps, nil
+	}
+
+	errs := make([]error, len(s.Steps))
+
+	wg := sync.WaitGroup{}
+	for i, fn := range s.Steps {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			rsps[i], errs[i] = runStep(ctx, r, fn, reqs[i])
+		}()
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtRunPipelineStep, s.Steps[i].Step)
+		}
+	}
+
+	return rsps, nil
+}
+
+// runStep runs the supplied pipeline step in its own span.
+func runStep(ctx context.Context, r FunctionRunner, fn v1.PipelineStep, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	ctx, span := tracing.Start(ctx, "RunPipelineStep", tracing.AttrKeyStep.String(fn.Step), tracing.AttrKeyFunction.String(fn.FunctionRef.Name))
+	rsp, err := r.RunFunction(xfn.WithStepPolicy(ctx, ToStepPolicy(fn)), fn.FunctionRef.Name, req)
+	tracing.End(span, err)
+
+	return rsp, err
+}
+
+// MergeStage merges the desired state and context returned by each step of
+// the supplied stage. The desired state and context sent to each step of the
+// stage are supplied as d and fctx. A step changes the desired composite
+// resource, a desired composed resource, or a context key if what it returns
+// differs from what it was sent. Only one step in a stage may change each of
+// these - unless all steps that change it make the same change. Removing a
+// desired composed resource or context key counts as a change.
+func MergeStage(s PipelineStage, d *fnv1.State, fctx *structpb.Struct, rsps []*fnv1.RunFunctionResponse) (*fnv1.State, *structpb.Struct, error) {
+	// A stage of one step is just a regular pipeline step. Pass through
+	// exactly what it returned.
+	if len(s.Steps) == 1 {
+		return rsps[0].GetDesired(), rsps[0].GetContext(), nil
+	}
+
+	out := &fnv1.State{Composite: d.GetComposite(), Resources: map[string]*fnv1.Resource{}}
+	for name, r := range d.GetResources() {
+		out.Resources[name] = r
+	}
+
+	octx := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for k, v := range fctx.GetFields() {
+		octx.Fields[k] = v
+	}
+
+	// Track which step changed what, so we can detect conflicts.
+	compositeChangedBy := ""
+	resourceChangedBy := map[string]string{}
+	contextChangedBy := map[string]string{}
+
+	for i, rsp := range rsps {
+		step := s.Steps[i].Step
+
+		if c := rsp.GetDesired().GetComposite(); !proto.Equal(c, d.GetComposite()) {
+			if compositeChangedBy != "" && !proto.Equal(c, out.GetComposite()) {
+				return nil, nil, errors.Errorf(errFmtConflictingComposite, compositeChangedBy, step, s.Name)
+			}
+
+			compositeChangedBy = step
+			out.Composite = c
+		}
+
+		for _, name := range changedResources(d.GetResources(), rsp.GetDesired().GetResources()) {
+			r, exists := rsp.GetDesired().GetResources()[name]
+
+			if by, ok := resourceChangedBy[name]; ok {
+				cur, curExists := out.GetResources()[name]
+				if exists != curExists || !proto.Equal(r, cur) {
+					return nil, nil, errors.Errorf(errFmtConflictingResource, by, step, s.Name, name)
+				}
+			}
+
+			resourceChangedBy[name] = step
+
+			if !exists {
+				delete(out.Resources, name)
+				continue
+			}
+
+			out.Resources[name] = r
+		}
+
+		for _, k := range changedContext(fctx.GetFields(), rsp.GetContext().GetFields()) {
+			v, exists := rsp.GetContext().GetFields()[k]
+
+			if by, ok := contextChangedBy[k]; ok {
+				cur, curExists := octx.GetFields()[k]
+				if exists != curExists || !proto.Equal(v, cur) {
+					return nil, nil, errors.Errorf(errFmtConflictingContext, by, step, s.Name, k)
+				}
+			}
+
+			contextChangedBy[k] = step
+
+			if !exists {
+				delete(octx.Fields, k)
+				continue
+			}
+
+			octx.Fields[k] = v
+		}
+	}
+
+	return out, octx, nil
+}
+
+// changedResources returns the names of resources that were added, removed,
+// or updated between before and after.
+func changedResources(before, after map[string]*fnv1.Resource) []string {
+	changed := make([]string, 0)
+
+	for name, a := range after {
+		if b, ok := before[name]; !ok || !proto.Equal(a, b) {
+			changed = append(changed, name)
+		}
+	}
+
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+
+	// Sort so that we report conflicts deterministically.
+	sort.Strings(changed)
+
+	return changed
+}
+
+// changedContext returns the keys of context fields that were added, removed,
+// or updated between before and after.
+func changedContext(before, after map[string]*structpb.Value) []string {
+	changed := make([]string, 0)
+
+	for k, a := range after {
+		if b, ok := before[k]; !ok || !proto.Equal(a, b) {
+			changed = append(changed, k)
+		}
+	}
+
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+
+	sort.Strings(changed)
+
+	return changed
+}
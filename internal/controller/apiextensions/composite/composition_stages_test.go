@@ -0,0 +1,439 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestPipelineStages(t *testing.T) {
+	type args struct {
+		pipeline []v1.PipelineStep
+	}
+
+	type want struct {
+		stages []PipelineStage
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoStages": {
+			reason: "Each step without a stage should be a stage of its own.",
+			args: args{
+				pipeline: []v1.PipelineStep{{Step: "a"}, {Step: "b"}},
+			},
+			want: want{
+				stages: []PipelineStage{
+					{Steps: []v1.PipelineStep{{Step: "a"}}},
+					{Steps: []v1.PipelineStep{{Step: "b"}}},
+				},
+			},
+		},
+		"ConsecutiveSteps": {
+			reason: "Consecutive steps with the same stage should be grouped together.",
+			args: args{
+				pipeline: []v1.PipelineStep{
+					{Step: "a"},
+					{Step: "b", Stage: "render"},
+					{Step: "c", Stage: "render"},
+					{Step: "d"},
+				},
+			},
+			want: want{
+				stages: []PipelineStage{
+					{Steps: []v1.PipelineStep{{Step: "a"}}},
+					{Name: "render", Steps: []v1.PipelineStep{{Step: "b", Stage: "render"}, {Step: "c", Stage: "render"}}},
+					{Steps: []v1.PipelineStep{{Step: "d"}}},
+				},
+			},
+		},
+		"NonConsecutiveSteps": {
+			reason: "Steps with the same stage that aren't consecutive should be separate stages.",
+			args: args{
+				pipeline: []v1.PipelineStep{
+					{Step: "a", Stage: "render"},
+					{Step: "b"},
+					{Step: "c", Stage: "render"},
+				},
+			},
+			want: want{
+				stages: []PipelineStage{
+					{Name: "render", Steps: []v1.PipelineStep{{Step: "a", Stage: "render"}}},
+					{Steps: []v1.PipelineStep{{Step: "b"}}},
+					{Name: "render", Steps: []v1.PipelineStep{{Step: "c", Stage: "render"}}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := PipelineStages(tc.args.pipeline)
+			if diff := cmp.Diff(tc.want.stages, got); diff != "" {
+				t.Errorf("\n%s\nPipelineStages(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSkipSteps(t *testing.T) {
+	o := &fnv1.State{
+		Composite: &fnv1.Resource{
+			Resource: MustStruct(map[string]any{
+				"apiVersion": "example.org/v1",
+				"kind":       "XR",
+				"spec":       map[string]any{"tier": "premium"},
+			}),
+		},
+	}
+
+	type args struct {
+		s    PipelineStage
+		o    *fnv1.State
+		d    *fnv1.State
+		fctx *structpb.Struct
+	}
+
+	type want struct {
+		s   PipelineStage
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoConditions": {
+			reason: "Steps without a condition should never be skipped.",
+			args: args{
+				s: PipelineStage{Steps: []v1.PipelineStep{{Step: "a"}}},
+			},
+			want: want{
+				s: PipelineStage{Steps: []v1.PipelineStep{{Step: "a"}}},
+			},
+		},
+		"SkipUnmetConditions": {
+			reason: "Steps whose condition isn't met should be skipped.",
+			args: args{
+				s: PipelineStage{Name: "render", Steps: []v1.PipelineStep{
+					{Step: "a", When: "observed.composite.resource.spec.tier == 'premium'"},
+					{Step: "b", When: "observed.composite.resource.spec.tier == 'basic'"},
+					{Step: "c", When: "context.enabled"},
+				}},
+				o:    o,
+				fctx: MustStruct(map[string]any{"enabled": true}),
+			},
+			want: want{
+				s: PipelineStage{Name: "render", Steps: []v1.PipelineStep{
+					{Step: "a", When: "observed.composite.resource.spec.tier == 'premium'"},
+					{Step: "c", When: "context.enabled"},
+				}},
+			},
+		},
+		"InvalidCondition": {
+			reason: "We should return an error if a condition can't be evaluated.",
+			args: args{
+				s: PipelineStage{Steps: []v1.PipelineStep{{Step: "a", When: "observed."}}},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := SkipSteps(tc.args.s, tc.args.o, tc.args.d, tc.args.fctx)
+			if diff := cmp.Diff(tc.want.s, s); diff != "" {
+				t.Errorf("\n%s\nSkipSteps(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSkipSteps(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRunStage(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		r    FunctionRunner
+		s    PipelineStage
+		reqs []*fnv1.RunFunctionRequest
+	}
+
+	type want struct {
+		rsps []*fnv1.RunFunctionResponse
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"RunFunctionError": {
+			reason: "We should return the error of the first failed step in pipeline order.",
+			args: args{
+				r: FunctionRunnerFn(func(_ context.Context, name string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return nil, errors.New(name)
+				}),
+				s: PipelineStage{
+					Name: "render",
+					Steps: []v1.PipelineStep{
+						{Step: "a", FunctionRef: v1.FunctionReference{Name: "fn-a"}},
+						{Step: "b", FunctionRef: v1.FunctionReference{Name: "fn-b"}},
+					},
+				},
+				reqs: []*fnv1.RunFunctionRequest{{}, {}},
+			},
+			want: want{
+				err: errors.Wrapf(errors.New("fn-a"), errFmtRunPipelineStep, "a"),
+			},
+		},
+		"SingleStepError": {
+			reason: "We should return the error of a stage with a single step.",
+			args: args{
+				r: FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return nil, errBoom
+				}),
+				s: PipelineStage{
+					Steps: []v1.PipelineStep{{Step: "a"}},
+				},
+				reqs: []*fnv1.RunFunctionRequest{{}},
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtRunPipelineStep, "a"),
+			},
+		},
+		"Success": {
+			reason: "We should return responses in pipeline order.",
+			args: args{
+				r: FunctionRunnerFn(func(_ context.Context, name string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return &fnv1.RunFunctionResponse{Meta: &fnv1.ResponseMeta{Tag: name}}, nil
+				}),
+				s: PipelineStage{
+					Name: "render",
+					Steps: []v1.PipelineStep{
+						{Step: "a", FunctionRef: v1.FunctionReference{Name: "fn-a"}},
+						{Step: "b", FunctionRef: v1.FunctionReference{Name: "fn-b"}},
+						{Step: "c", FunctionRef: v1.FunctionReference{Name: "fn-c"}},
+					},
+				},
+				reqs: []*fnv1.RunFunctionRequest{{}, {}, {}},
+			},
+			want: want{
+				rsps: []*fnv1.RunFunctionResponse{
+					{Meta: &fnv1.ResponseMeta{Tag: "fn-a"}},
+					{Meta: &fnv1.ResponseMeta{Tag: "fn-b"}},
+					{Meta: &fnv1.ResponseMeta{Tag: "fn-c"}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rsps, err := RunStage(context.Background(), tc.args.r, tc.args.s, tc.args.reqs)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRunStage(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.rsps, rsps, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nRunStage(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMergeStage(t *testing.T) {
+	stage := PipelineStage{Name: "render", Steps: []v1.PipelineStep{{Step: "a"}, {Step: "b"}}}
+
+	xr := &fnv1.Resource{Resource: MustStruct(map[string]any{"apiVersion": "example.org/v1", "kind": "XR"})}
+
+	type args struct {
+		s    PipelineStage
+		d    *fnv1.State
+		fctx *structpb.Struct
+		rsps []*fnv1.RunFunctionResponse
+	}
+
+	type want struct {
+		d    *fnv1.State
+		fctx *structpb.Struct
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SingleStep": {
+			reason: "A stage with a single step should pass through exactly what the step returned.",
+			args: args{
+				s:    PipelineStage{Steps: []v1.PipelineStep{{Step: "a"}}},
+				d:    &fnv1.State{Resources: map[string]*fnv1.Resource{"removed": {}}},
+				fctx: MustStruct(map[string]any{"removed": true}),
+				rsps: []*fnv1.RunFunctionResponse{
+					{Desired: &fnv1.State{Composite: xr}, Context: MustStruct(map[string]any{"added": true})},
+				},
+			},
+			want: want{
+				d:    &fnv1.State{Composite: xr},
+				fctx: MustStruct(map[string]any{"added": true}),
+			},
+		},
+		"MergeIndependentChanges": {
+			reason: "Changes that different steps make to different resources and context keys should be merged.",
+			args: args{
+				s: stage,
+				d: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"existing": {Ready: fnv1.Ready_READY_FALSE},
+						"removed":  {},
+					},
+				},
+				fctx: MustStruct(map[string]any{"existing": "yes"}),
+				rsps: []*fnv1.RunFunctionResponse{
+					{
+						Desired: &fnv1.State{
+							Composite: xr,
+							Resources: map[string]*fnv1.Resource{
+								"existing": {Ready: fnv1.Ready_READY_FALSE},
+								"a":        {Ready: fnv1.Ready_READY_TRUE},
+							},
+						},
+						Context: MustStruct(map[string]any{"existing": "yes", "a": "yes"}),
+					},
+					{
+						Desired: &fnv1.State{
+							Resources: map[string]*fnv1.Resource{
+								"existing": {Ready: fnv1.Ready_READY_TRUE},
+								"removed":  {},
+								"b":        {Ready: fnv1.Ready_READY_TRUE},
+							},
+						},
+						Context: MustStruct(map[string]any{"existing": "yes", "b": "yes"}),
+					},
+				},
+			},
+			want: want{
+				d: &fnv1.State{
+					Composite: xr,
+					Resources: map[string]*fnv1.Resource{
+						"existing": {Ready: fnv1.Ready_READY_TRUE},
+						"a":        {Ready: fnv1.Ready_READY_TRUE},
+						"b":        {Ready: fnv1.Ready_READY_TRUE},
+					},
+				},
+				fctx: MustStruct(map[string]any{"existing": "yes", "a": "yes", "b": "yes"}),
+			},
+		},
+		"IdenticalChanges": {
+			reason: "Steps that make identical changes don't conflict.",
+			args: args{
+				s: stage,
+				d: &fnv1.State{},
+				rsps: []*fnv1.RunFunctionResponse{
+					{Desired: &fnv1.State{Composite: xr, Resources: map[string]*fnv1.Resource{"a": {}}}},
+					{Desired: &fnv1.State{Composite: xr, Resources: map[string]*fnv1.Resource{"a": {}}}},
+				},
+			},
+			want: want{
+				d:    &fnv1.State{Composite: xr, Resources: map[string]*fnv1.Resource{"a": {}}},
+				fctx: &structpb.Struct{Fields: map[string]*structpb.Value{}},
+			},
+		},
+		"ConflictingComposite": {
+			reason: "We should return an error if two steps make different changes to the desired XR.",
+			args: args{
+				s: stage,
+				d: &fnv1.State{},
+				rsps: []*fnv1.RunFunctionResponse{
+					{Desired: &fnv1.State{Composite: xr}},
+					{Desired: &fnv1.State{Composite: &fnv1.Resource{Ready: fnv1.Ready_READY_TRUE}}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtConflictingComposite, "a", "b", "render"),
+			},
+		},
+		"ConflictingResource": {
+			reason: "We should return an error if one step updates a desired resource that another removes.",
+			args: args{
+				s: stage,
+				d: &fnv1.State{Resources: map[string]*fnv1.Resource{"a": {}}},
+				rsps: []*fnv1.RunFunctionResponse{
+					{Desired: &fnv1.State{Resources: map[string]*fnv1.Resource{"a": {Ready: fnv1.Ready_READY_TRUE}}}},
+					{Desired: &fnv1.State{}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtConflictingResource, "a", "b", "render", "a"),
+			},
+		},
+		"ConflictingContext": {
+			reason: "We should return an error if two steps set the same context key to different values.",
+			args: args{
+				s: stage,
+				d: &fnv1.State{},
+				rsps: []*fnv1.RunFunctionResponse{
+					{Context: MustStruct(map[string]any{"key": "a"})},
+					{Context: MustStruct(map[string]any{"key": "b"})},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtConflictingContext, "a", "b", "render", "key"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, fctx, err := MergeStage(tc.args.s, tc.args.d, tc.args.fctx, tc.args.rsps)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nMergeStage(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.d, d, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nMergeStage(...): -want desired, +got desired:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.fctx, fctx, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nMergeStage(...): -want context, +got context:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,441 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+const (
+	// AnnotationKeyPipelineTrace enables pipeline tracing when set to
+	// PipelineTraceEnabled on a composite resource or its Composition.
+	AnnotationKeyPipelineTrace = "crossplane.io/pipeline-trace"
+
+	// PipelineTraceEnabled enables pipeline tracing.
+	PipelineTraceEnabled = "Enabled"
+
+	// PipelineTraceKey is the key of the ConfigMap data that contains a
+	// pipeline trace, encoded as JSON.
+	PipelineTraceKey = "trace.json"
+
+	// LabelKeyPipelineTraceFor labels a pipeline trace ConfigMap with the UID
+	// of the composite resource it traces.
+	LabelKeyPipelineTraceFor = "crossplane.io/pipeline-trace-for"
+)
+
+// Pipeline traces are stored in a ConfigMap, which can be at most 1MiB. We
+// bound a trace well below that.
+const (
+	maxStepDiffBytes = 16 * 1024
+	maxTraceBytes    = 512 * 1024
+)
+
+const (
+	errMarshalTrace       = "cannot marshal pipeline trace"
+	errApplyTrace         = "cannot apply pipeline trace ConfigMap"
+	errWriteTrace         = "cannot write pipeline trace"
+	errNoTraceNamespace   = "cannot write pipeline trace of a cluster scoped composite resource: no namespace configured for traces"
+	traceDiffTruncatedMsg = "\n... (truncated)"
+)
+
+// A PipelineTrace records what each step of a composition function pipeline
+// did the last time the pipeline ran.
+type PipelineTrace struct {
+	// Revision is the name of the CompositionRevision that was run.
+	Revision string `json:"revision"`
+
+	// StartTime is when the pipeline started running.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Duration is how long the pipeline took to run.
+	Duration metav1.Duration `json:"duration"`
+
+	// Steps records each step of the pipeline, in pipeline order.
+	Steps []*StepTrace `json:"steps"`
+
+	// Error is the error returned by the pipeline, if any.
+	Error string `json:"error,omitempty"`
+
+	// Truncated is true if some step diffs were dropped to bound the size of
+	// the trace.
+	Truncated bool `json:"truncated,omitempty"`
+
+	mx sync.Mutex
+}
+
+// A StepTrace records what a pipeline step did.
+type StepTrace struct {
+	// Step is the name of the pipeline step.
+	Step string `json:"step"`
+
+	// Function is the name of the function the step called.
+	Function string `json:"function"`
+
+	// Stage is the stage the step ran in, if any.
+	Stage string `json:"stage,omitempty"`
+
+	// Skipped is true if the step didn't run because its when condition
+	// wasn't met.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// Iterations is how many times the function was called before its
+	// requirements stabilized.
+	Iterations int `json:"iterations,omitempty"`
+
+	// Duration is how long the step took to run, including all iterations.
+	Duration metav1.Duration `json:"duration,omitempty"`
+
+	// Requirements are the names of the resources the function required.
+	Requirements []string `json:"requirements,omitempty"`
+
+	// Results returned by the function.
+	Results []ResultTrace `json:"results,omitempty"`
+
+	// Diff between the desired state sent to the function and the desired
+	// state it returned.
+	Diff string `json:"diff,omitempty"`
+
+	// Error returned when running the step, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// A ResultTrace records a result returned by a function.
+type ResultTrace struct {
+	Severity string `json:"severity"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message"`
+}
+
+// NewPipelineTrace starts a trace of the supplied CompositionRevision's
+// pipeline.
+func NewPipelineTrace(rev *v1.CompositionRevision) *PipelineTrace {
+	return &PipelineTrace{Revision: rev.GetName(), StartTime: metav1.Now(), Steps: []*StepTrace{}}
+}
+
+// step returns the trace of the named step, adding it if needed.
+func (t *PipelineTrace) step(fn v1.PipelineStep) *StepTrace {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	for _, st := range t.Steps {
+		if st.Step == fn.Step {
+			return st
+		}
+	}
+
+	st := &StepTrace{Step: fn.Step, Function: fn.FunctionRef.Name, Stage: fn.Stage}
+	t.Steps = append(t.Steps, st)
+
+	return st
+}
+
+// StartStage records that the supplied stage is about to run. Steps that
+// aren't in the supplied run stage were skipped. It's a no-op on a nil trace.
+func (t *PipelineTrace) StartStage(stage, run PipelineStage) {
+	if t == nil {
+		return
+	}
+
+	for _, fn := range stage.Steps {
+		st := t.step(fn)
+		st.Skipped = !slices.ContainsFunc(run.Steps, func(s v1.PipelineStep) bool { return s.Step == fn.Step })
+	}
+}
+
+// RecordStep records the results returned by a step, and how it changed the
+// supplied desired state. It's a no-op on a nil trace.
+func (t *PipelineTrace) RecordStep(fn v1.PipelineStep, d *fnv1.State, rsp *fnv1.RunFunctionResponse) {
+	if t == nil {
+		return
+	}
+
+	st := t.step(fn)
+
+	for _, r := range rsp.GetResults() {
+		st.Results = append(st.Results, ResultTrace{
+			Severity: strings.TrimPrefix(r.GetSeverity().String(), "SEVERITY_"),
+			Reason:   r.GetReason(),
+			Message:  r.GetMessage(),
+		})
+	}
+
+	diff := cmp.Diff(stateAsMap(d), stateAsMap(rsp.GetDesired()))
+	if len(diff) > maxStepDiffBytes {
+		diff = diff[:maxStepDiffBytes] + traceDiffTruncatedMsg
+	}
+
+	st.Diff = diff
+}
+
+// Finish records the supplied error, if any, and how long the pipeline took
+// to run. It's a no-op on a nil trace.
+func (t *PipelineTrace) Finish(err error) {
+	if t == nil {
+		return
+	}
+
+	t.Duration = metav1.Duration{Duration: time.Since(t.StartTime.Time)}
+	if err != nil {
+		t.Error = err.Error()
+	}
+}
+
+// Marshal the trace to JSON. Step diffs are dropped, starting with the first
+// step, until the trace fits within its size limit.
+func (t *PipelineTrace) Marshal() ([]byte, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	for i := 0; ; i++ {
+		b, err := json.Marshal(t)
+		if err != nil || len(b) <= maxTraceBytes || i >= len(t.Steps) {
+			return b, err
+		}
+
+		if t.Steps[i].Diff != "" {
+			t.Steps[i].Diff = ""
+			t.Truncated = true
+		}
+	}
+}
+
+// stateAsMap returns the supplied state as a map, for diffing. Traces are
+// stored in ConfigMaps, so the map omits connection details and redacts the
+// data of composed Secrets.
+func stateAsMap(s *fnv1.State) map[string]any {
+	out := map[string]any{}
+	if c := s.GetComposite().GetResource(); c != nil {
+		out["composite"] = c.AsMap()
+	}
+
+	rs := map[string]any{}
+	for name, r := range s.GetResources() {
+		res := r.GetResource().AsMap()
+		RedactSecretData(res)
+
+		rs[name] = map[string]any{
+			"resource": res,
+			"ready":    r.GetReady().String(),
+		}
+	}
+
+	if len(rs) > 0 {
+		out["resources"] = rs
+	}
+
+	return out
+}
+
+// A TracingFunctionRunner records how long each step of a traced pipeline
+// took to run, and how many times its function was called.
+type TracingFunctionRunner struct {
+	wrapped FunctionRunner
+	trace   *PipelineTrace
+	steps   map[string]v1.PipelineStep
+}
+
+// NewTracingFunctionRunner returns a FunctionRunner that records each call
+// of the supplied pipeline's steps to the supplied trace.
+func NewTracingFunctionRunner(r FunctionRunner, t *PipelineTrace, pipeline []v1.PipelineStep) *TracingFunctionRunner {
+	steps := make(map[string]v1.PipelineStep, len(pipeline))
+	for _, fn := range pipeline {
+		steps[fn.Step] = fn
+	}
+
+	return &TracingFunctionRunner{wrapped: r, trace: t, steps: steps}
+}
+
+// RunFunction runs the named function, recording the call to the trace of
+// the pipeline step in the supplied context.
+func (r *TracingFunctionRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	p, ok := xfn.GetStepPolicy(ctx)
+	fn, known := r.steps[p.Step]
+
+	if !ok || !known {
+		return r.wrapped.RunFunction(ctx, name, req)
+	}
+
+	// Each step in a stage runs in its own goroutine, but only writes to
+	// its own step trace.
+	st := r.trace.step(fn)
+
+	ctx = xfn.WithRunObserver(ctx, func(_ *fnv1.RunFunctionRequest, rsp *fnv1.RunFunctionResponse) {
+		st.Iterations++
+
+		for name := range rsp.GetRequirements().GetResources() {
+			if !slices.Contains(st.Requirements, name) {
+				st.Requirements = append(st.Requirements, name)
+			}
+		}
+	})
+
+	start := time.Now()
+	rsp, err := r.wrapped.RunFunction(ctx, name, req)
+	st.Duration = metav1.Duration{Duration: time.Since(start)}
+
+	slices.Sort(st.Requirements)
+
+	if err != nil {
+		st.Error = err.Error()
+		return rsp, err
+	}
+
+	// The wrapped runner doesn't fetch requirements, so it called the
+	// function once.
+	if st.Iterations == 0 {
+		st.Iterations = 1
+	}
+
+	return rsp, nil
+}
+
+// A PipelineTraceWriter writes the trace of a composite resource's pipeline.
+type PipelineTraceWriter interface {
+	WriteTrace(ctx context.Context, xr resource.Composite, t *PipelineTrace) error
+}
+
+// A PipelineTraceWriterFn writes a pipeline trace.
+type PipelineTraceWriterFn func(ctx context.Context, xr resource.Composite, t *PipelineTrace) error
+
+// WriteTrace writes the supplied trace.
+func (fn PipelineTraceWriterFn) WriteTrace(ctx context.Context, xr resource.Composite, t *PipelineTrace) error {
+	return fn(ctx, xr, t)
+}
+
+// PipelineTraceName returns the name of the ConfigMap that contains the
+// pipeline trace of the composite resource with the supplied UID.
+func PipelineTraceName(uid types.UID) string {
+	return "pipeline-trace-" + string(uid)
+}
+
+// PipelineTraceNamespace returns the namespace of the ConfigMap that contains
+// the pipeline trace of the supplied composite resource. Traces of cluster
+// scoped composite resources are written to the supplied namespace.
+func PipelineTraceNamespace(xr metav1.Object, namespace string) string {
+	if ns := xr.GetNamespace(); ns != "" {
+		return ns
+	}
+
+	return namespace
+}
+
+// A ConfigMapPipelineTraceWriter writes pipeline traces to a ConfigMap owned
+// by the traced composite resource. The ConfigMap is in the composite
+// resource's namespace, or in the supplied namespace if the composite
+// resource is cluster scoped.
+type ConfigMapPipelineTraceWriter struct {
+	client    resource.Applicator
+	namespace string
+}
+
+// NewConfigMapPipelineTraceWriter returns a PipelineTraceWriter that writes
+// traces to ConfigMaps.
+func NewConfigMapPipelineTraceWriter(c client.Client, namespace string) *ConfigMapPipelineTraceWriter {
+	return &ConfigMapPipelineTraceWriter{client: resource.NewAPIUpdatingApplicator(c), namespace: namespace}
+}
+
+// WriteTrace writes the supplied trace to a ConfigMap.
+func (w *ConfigMapPipelineTraceWriter) WriteTrace(ctx context.Context, xr resource.Composite, t *PipelineTrace) error {
+	ns := PipelineTraceNamespace(xr, w.namespace)
+	if ns == "" {
+		return errors.New(errNoTraceNamespace)
+	}
+
+	b, err := t.Marshal()
+	if err != nil {
+		return errors.Wrap(err, errMarshalTrace)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PipelineTraceName(xr.GetUID()),
+			Namespace: ns,
+			Labels:    map[string]string{LabelKeyPipelineTraceFor: string(xr.GetUID())},
+		},
+		Data: map[string]string{PipelineTraceKey: string(b)},
+	}
+
+	meta.AddOwnerReference(cm, meta.AsOwner(meta.TypedReferenceTo(xr, xr.GetObjectKind().GroupVersionKind())))
+
+	return errors.Wrap(w.client.Apply(ctx, cm), errApplyTrace)
+}
+
+// tracing returns true if the supplied composite resource, or the Composition
+// of the supplied revision, enables pipeline tracing.
+func (c *FunctionComposer) tracing(ctx context.Context, xr resource.Composite, rev *v1.CompositionRevision) (bool, error) {
+	if c.traces == nil {
+		return false, nil
+	}
+
+	if xr.GetAnnotations()[AnnotationKeyPipelineTrace] == PipelineTraceEnabled {
+		return true, nil
+	}
+
+	name := rev.GetLabels()[v1.LabelCompositionName]
+	if name == "" {
+		return false, nil
+	}
+
+	comp := &v1.Composition{}
+	if err := c.client.Get(ctx, types.NamespacedName{Name: name}, comp); err != nil {
+		return false, errors.Wrap(resource.IgnoreNotFound(err), errGetComposition)
+	}
+
+	return comp.GetAnnotations()[AnnotationKeyPipelineTrace] == PipelineTraceEnabled, nil
+}
+
+// RedactedSecretValue replaces the values of Secret data that Crossplane
+// records outside the Secret, for example in a pipeline trace.
+const RedactedSecretValue = "(redacted)"
+
+// RedactSecretData replaces the values of the data and stringData of the
+// supplied object with RedactedSecretValue, if it's a Secret.
+func RedactSecretData(obj map[string]any) {
+	if obj["apiVersion"] != "v1" || obj["kind"] != "Secret" {
+		return
+	}
+
+	for _, f := range []string{"data", "stringData"} {
+		data, ok := obj[f].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for k := range data {
+			data[k] = RedactedSecretValue
+		}
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestTracingFunctionRunner(t *testing.T) {
+	pipeline := []v1.PipelineStep{
+		{Step: "first", FunctionRef: v1.FunctionReference{Name: "function-first"}},
+		{Step: "second", FunctionRef: v1.FunctionReference{Name: "function-second"}, When: "false"},
+	}
+
+	// The function requires a resource the first time it's called, then
+	// returns the same requirements, which means they've stabilized.
+	wrapped := xfn.NewFetchingFunctionRunner(FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+		return &fnv1.RunFunctionResponse{
+			Requirements: &fnv1.Requirements{
+				Resources: map[string]*fnv1.ResourceSelector{
+					"cool-resource": {ApiVersion: "example.org/v1", Kind: "Cool"},
+				},
+			},
+			Results: []*fnv1.Result{
+				{Severity: fnv1.Severity_SEVERITY_WARNING, Reason: ptrTo("Cool"), Message: "a warning"},
+			},
+			Desired: &fnv1.State{
+				Resources: map[string]*fnv1.Resource{
+					"cool-resource": {Resource: MustStruct(map[string]any{"apiVersion": "example.org/v1", "kind": "Cool"})},
+				},
+			},
+		}, nil
+	}), xfn.RequiredResourcesFetcherFn(func(_ context.Context, _ *fnv1.ResourceSelector) (*fnv1.Resources, error) {
+		return &fnv1.Resources{}, nil
+	}))
+
+	tr := NewPipelineTrace(&v1.CompositionRevision{ObjectMeta: metav1.ObjectMeta{Name: "cool-revision"}})
+	r := NewTracingFunctionRunner(wrapped, tr, pipeline)
+
+	stage := PipelineStage{Steps: pipeline}
+	run := PipelineStage{Steps: pipeline[:1]}
+	tr.StartStage(stage, run)
+
+	d := &fnv1.State{}
+	rsp, err := r.RunFunction(xfn.WithStepPolicy(context.Background(), ToStepPolicy(pipeline[0])), "function-first", &fnv1.RunFunctionRequest{Desired: d})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr.RecordStep(pipeline[0], d, rsp)
+	tr.Finish(errors.New("boom"))
+
+	want := &PipelineTrace{
+		Revision: "cool-revision",
+		Error:    "boom",
+		Steps: []*StepTrace{
+			{
+				Step:         "first",
+				Function:     "function-first",
+				Iterations:   2,
+				Requirements: []string{"cool-resource"},
+				Results:      []ResultTrace{{Severity: "WARNING", Reason: "Cool", Message: "a warning"}},
+			},
+			{
+				Step:     "second",
+				Function: "function-second",
+				Skipped:  true,
+			},
+		},
+	}
+
+	ignore := cmp.Options{
+		cmpopts.IgnoreUnexported(PipelineTrace{}),
+		cmpopts.IgnoreFields(PipelineTrace{}, "StartTime", "Duration"),
+		cmpopts.IgnoreFields(StepTrace{}, "Duration", "Diff"),
+	}
+
+	if diff := cmp.Diff(want, tr, ignore...); diff != "" {
+		t.Errorf("\nRunFunction(...): -want trace, +got trace:\n%s", diff)
+	}
+
+	if !strings.Contains(tr.Steps[0].Diff, "cool-resource") {
+		t.Errorf("\nRecordStep(...): want diff to include the added resource, got:\n%s", tr.Steps[0].Diff)
+	}
+}
+
+func TestPipelineTraceMarshal(t *testing.T) {
+	big := strings.Repeat("x", maxStepDiffBytes)
+
+	tr := &PipelineTrace{Steps: []*StepTrace{}}
+	for range maxTraceBytes/maxStepDiffBytes + 1 {
+		tr.Steps = append(tr.Steps, &StepTrace{Diff: big})
+	}
+
+	b, err := tr.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(b) > maxTraceBytes {
+		t.Errorf("\nMarshal(): want at most %d bytes, got %d", maxTraceBytes, len(b))
+	}
+
+	got := &PipelineTrace{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Truncated {
+		t.Errorf("\nMarshal(): want truncated trace")
+	}
+
+	// Diffs should be dropped from the start of the pipeline.
+	if got.Steps[0].Diff != "" || got.Steps[len(got.Steps)-1].Diff == "" {
+		t.Errorf("\nMarshal(): want diffs dropped from the first steps")
+	}
+}
+
+func TestStateAsMap(t *testing.T) {
+	s := &fnv1.State{
+		Composite: &fnv1.Resource{
+			Resource:          MustStruct(map[string]any{"apiVersion": "example.org/v1", "kind": "XCool"}),
+			ConnectionDetails: map[string][]byte{"password": []byte("hunter2")},
+		},
+		Resources: map[string]*fnv1.Resource{
+			"cool-secret": {Resource: MustStruct(map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"data":       map[string]any{"password": "aHVudGVyMg=="},
+				"stringData": map[string]any{"username": "admin"},
+			})},
+		},
+	}
+
+	want := map[string]any{
+		"composite": map[string]any{"apiVersion": "example.org/v1", "kind": "XCool"},
+		"resources": map[string]any{
+			"cool-secret": map[string]any{
+				"resource": map[string]any{
+					"apiVersion": "v1",
+					"kind":       "Secret",
+					"data":       map[string]any{"password": RedactedSecretValue},
+					"stringData": map[string]any{"username": RedactedSecretValue},
+				},
+				"ready": fnv1.Ready_READY_UNSPECIFIED.String(),
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, stateAsMap(s)); diff != "" {
+		t.Errorf("\nstateAsMap(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestConfigMapPipelineTraceWriter(t *testing.T) {
+	type args struct {
+		namespace string
+		xr        func() *composite.Unstructured
+	}
+
+	type want struct {
+		namespace string
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Namespaced": {
+			reason: "We should write the trace of a namespaced XR to its namespace.",
+			args: args{
+				namespace: "crossplane-system",
+				xr: func() *composite.Unstructured {
+					xr := composite.New()
+					xr.SetNamespace("cool-ns")
+					xr.SetUID("cool-uid")
+					return xr
+				},
+			},
+			want: want{
+				namespace: "cool-ns",
+			},
+		},
+		"ClusterScoped": {
+			reason: "We should write the trace of a cluster scoped XR to the configured namespace.",
+			args: args{
+				namespace: "crossplane-system",
+				xr: func() *composite.Unstructured {
+					xr := composite.New(composite.WithSchema(composite.SchemaLegacy))
+					xr.SetUID("cool-uid")
+					return xr
+				},
+			},
+			want: want{
+				namespace: "crossplane-system",
+			},
+		},
+		"NoNamespace": {
+			reason: "We should return an error if we don't know where to write the trace of a cluster scoped XR.",
+			args: args{
+				xr: func() *composite.Unstructured {
+					return composite.New(composite.WithSchema(composite.SchemaLegacy))
+				},
+			},
+			want: want{
+				err: errors.New(errNoTraceNamespace),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ""
+			c := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+				MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+					cm := obj.(*corev1.ConfigMap)
+					got = cm.GetNamespace()
+
+					if cm.GetName() != PipelineTraceName("cool-uid") {
+						t.Errorf("\n%s\nWriteTrace(...): unexpected ConfigMap name %q", tc.reason, cm.GetName())
+					}
+					if _, ok := cm.Data[PipelineTraceKey]; !ok {
+						t.Errorf("\n%s\nWriteTrace(...): ConfigMap is missing key %q", tc.reason, PipelineTraceKey)
+					}
+
+					return nil
+				}),
+			}
+
+			w := NewConfigMapPipelineTraceWriter(c, tc.args.namespace)
+			err := w.WriteTrace(context.Background(), tc.args.xr(), &PipelineTrace{})
+
+			if diff := cmp.Diff(tc.want.namespace, got); diff != "" {
+				t.Errorf("\n%s\nWriteTrace(...): -want namespace, +got namespace:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWriteTrace(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func ptrTo(s string) *string { return &s }
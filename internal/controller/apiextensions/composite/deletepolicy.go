@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+// Error strings.
+const (
+	errFmtOrphanCD = "cannot orphan composed resource %q (a %s named %s)"
+)
+
+// ComposedDeletePolicyFor returns the delete policy the supplied
+// CompositionRevision declares for the supplied composed resource, if any.
+func ComposedDeletePolicyFor(rev *v1.CompositionRevision, name ResourceName) v1.ComposedDeletePolicy {
+	if rev == nil {
+		return ""
+	}
+
+	for _, p := range rev.Spec.DeletePolicies {
+		if p.ResourceName == string(name) {
+			return p.Policy
+		}
+	}
+
+	return ""
+}
+
+// RenderComposedDeletePolicy annotates the supplied composed resource with the
+// delete policy the supplied CompositionRevision declares for it. A delete
+// policy set by the function pipeline takes precedence.
+func RenderComposedDeletePolicy(cd resource.Object, rev *v1.CompositionRevision, name ResourceName) {
+	if xcrd.GetComposedDeletePolicy(cd) != "" {
+		return
+	}
+
+	if p := ComposedDeletePolicyFor(rev, name); p != "" {
+		xcrd.SetComposedDeletePolicy(cd, string(p))
+	}
+}
+
+// DeletePolicyOf returns the delete policy of the supplied composed resource.
+// Composed resources without a valid delete policy are deleted.
+func DeletePolicyOf(cd metav1.Object) v1.ComposedDeletePolicy {
+	switch p := v1.ComposedDeletePolicy(xcrd.GetComposedDeletePolicy(cd)); p {
+	case v1.ComposedDeletePolicyDelete, v1.ComposedDeletePolicyOrphan, v1.ComposedDeletePolicyRetain:
+		return p
+	default:
+		return v1.ComposedDeletePolicyDelete
+	}
+}
+
+// Orphan the supplied composed resource, so that it's no longer composed by
+// the supplied owner. It returns false if the composed resource was already
+// orphaned.
+func orphan(cd metav1.Object, owner types.UID) bool {
+	refs := cd.GetOwnerReferences()
+	keep := make([]metav1.OwnerReference, 0, len(refs))
+
+	for _, ref := range refs {
+		if ref.UID != owner {
+			keep = append(keep, ref)
+		}
+	}
+
+	if len(keep) == len(refs) {
+		return false
+	}
+
+	cd.SetOwnerReferences(keep)
+
+	// Remove the labels that indicate this resource was owned by a
+	// Composition, as we do when we garbage collect it.
+	meta.RemoveLabels(cd, xcrd.LabelKeyNamePrefixForComposed, xcrd.LabelKeyClaimName, xcrd.LabelKeyClaimNamespace)
+
+	return true
+}
+
+// unblockOwnerDeletion stops the supplied desired composed resources that the
+// supplied composite resource must orphan, or must delete in dependency order,
+// from blocking its deletion. When a composite resource is deleted in the
+// foreground, for example because its claim's composite delete policy is
+// Foreground, the API server's garbage collector immediately deletes every
+// composed resource that blocks its deletion. It would do so before the
+// composite resource's ComposedResourceDeleters could orphan them, or delete
+// them in order. Composed resources that don't block deletion are only garbage
+// collected once the composite resource is gone, and the composite resource
+// isn't gone until its ComposedResourceDeleters are done.
+func (c *FunctionComposer) unblockOwnerDeletion(xr metav1.Object, desired ComposedResourceStates) {
+	for name, cd := range desired {
+		orphaned := c.policies && DeletePolicyOf(cd.Resource) != v1.ComposedDeletePolicyDelete
+		ordered := c.ordered && len(dependents(name, desired)) > 0
+
+		if !orphaned && !ordered {
+			continue
+		}
+
+		refs := cd.Resource.GetOwnerReferences()
+		for i := range refs {
+			if refs[i].UID == xr.GetUID() {
+				refs[i].BlockOwnerDeletion = ptr.To(false)
+			}
+		}
+
+		cd.Resource.SetOwnerReferences(refs)
+	}
+}
+
+// An OrphaningComposedResourceDeleter orphans the composed resources of a
+// composite resource that's being deleted if their delete policy is Orphan or
+// Retain. Orphaned composed resources aren't deleted by the API server's
+// garbage collector along with the composite resource. Such composed resources
+// don't block their composite resource's deletion, so they're orphaned even if
+// the composite resource is deleted in the foreground.
+type OrphaningComposedResourceDeleter struct {
+	observer ComposedResourceObserver
+	client   client.Writer
+}
+
+// NewOrphaningComposedResourceDeleter returns a ComposedResourceDeleter that
+// observes composed resources using the supplied observer, and orphans them
+// using the supplied client.
+func NewOrphaningComposedResourceDeleter(o ComposedResourceObserver, c client.Writer) *OrphaningComposedResourceDeleter {
+	return &OrphaningComposedResourceDeleter{observer: o, client: c}
+}
+
+// DeleteComposedResources orphans every composed resource whose delete policy
+// is Orphan or Retain. It returns true once there are no composed resources
+// left to orphan.
+func (d *OrphaningComposedResourceDeleter) DeleteComposedResources(ctx context.Context, xr resource.Composite) (bool, error) {
+	observed, err := d.observer.ObserveComposedResources(ctx, xr)
+	if err != nil {
+		return false, errors.Wrap(err, errObserveComposed)
+	}
+
+	done := true
+
+	for name, cd := range observed {
+		if DeletePolicyOf(cd.Resource) == v1.ComposedDeletePolicyDelete {
+			continue
+		}
+
+		if !orphan(cd.Resource, xr.GetUID()) {
+			continue
+		}
+
+		if err := d.client.Update(ctx, cd.Resource); resource.IgnoreNotFound(err) != nil {
+			return false, errors.Wrapf(err, errFmtOrphanCD, name, cd.Resource.GetObjectKind().GroupVersionKind().Kind, cd.Resource.GetName())
+		}
+
+		// We'll return true on a subsequent call, once we observe that the
+		// composed resource was orphaned.
+		done = false
+	}
+
+	return done, nil
+}
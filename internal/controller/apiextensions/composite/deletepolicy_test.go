@@ -0,0 +1,361 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+func withDeletePolicy(name string, p v1.ComposedDeletePolicy) ComposedResourceState {
+	cd := composed.New()
+	cd.SetName(name)
+	cd.SetOwnerReferences([]metav1.OwnerReference{{Controller: ptr.To(true), UID: "cool-xr"}})
+
+	if p != "" {
+		xcrd.SetComposedDeletePolicy(cd, string(p))
+	}
+
+	return ComposedResourceState{Resource: cd}
+}
+
+func TestRenderComposedDeletePolicy(t *testing.T) {
+	rev := &v1.CompositionRevision{
+		Spec: v1.CompositionRevisionSpec{
+			DeletePolicies: []v1.ComposedResourceDeletePolicy{
+				{ResourceName: "db", Policy: v1.ComposedDeletePolicyOrphan},
+			},
+		},
+	}
+
+	type args struct {
+		cd   ComposedResourceState
+		rev  *v1.CompositionRevision
+		name ResourceName
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"NoRevision": {
+			reason: "We shouldn't annotate a composed resource if there's no revision.",
+			args: args{
+				cd:   withDeletePolicy("db", ""),
+				name: "db",
+			},
+			want: "",
+		},
+		"NoPolicy": {
+			reason: "We shouldn't annotate a composed resource the revision declares no policy for.",
+			args: args{
+				cd:   withDeletePolicy("bucket", ""),
+				rev:  rev,
+				name: "bucket",
+			},
+			want: "",
+		},
+		"RevisionPolicy": {
+			reason: "We should annotate a composed resource with the policy the revision declares for it.",
+			args: args{
+				cd:   withDeletePolicy("db", ""),
+				rev:  rev,
+				name: "db",
+			},
+			want: string(v1.ComposedDeletePolicyOrphan),
+		},
+		"FunctionPolicy": {
+			reason: "A policy set by the function pipeline should take precedence over the revision's.",
+			args: args{
+				cd:   withDeletePolicy("db", v1.ComposedDeletePolicyRetain),
+				rev:  rev,
+				name: "db",
+			},
+			want: string(v1.ComposedDeletePolicyRetain),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			RenderComposedDeletePolicy(tc.args.cd.Resource, tc.args.rev, tc.args.name)
+
+			if diff := cmp.Diff(tc.want, xcrd.GetComposedDeletePolicy(tc.args.cd.Resource)); diff != "" {
+				t.Errorf("\n%s\nRenderComposedDeletePolicy(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeletePolicyOf(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cd     ComposedResourceState
+		want   v1.ComposedDeletePolicy
+	}{
+		"Unset": {
+			reason: "Composed resources without a delete policy should be deleted.",
+			cd:     withDeletePolicy("a", ""),
+			want:   v1.ComposedDeletePolicyDelete,
+		},
+		"Invalid": {
+			reason: "Composed resources with an invalid delete policy should be deleted.",
+			cd:     withDeletePolicy("a", "Keep"),
+			want:   v1.ComposedDeletePolicyDelete,
+		},
+		"Orphan": {
+			reason: "We should return a valid delete policy.",
+			cd:     withDeletePolicy("a", v1.ComposedDeletePolicyOrphan),
+			want:   v1.ComposedDeletePolicyOrphan,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DeletePolicyOf(tc.cd.Resource)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDeletePolicyOf(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUnblockOwnerDeletion(t *testing.T) {
+	xr := composite.New()
+	xr.SetUID("cool-xr")
+
+	blocking := func(s ComposedResourceState) ComposedResourceState {
+		refs := s.Resource.GetOwnerReferences()
+		refs[0].BlockOwnerDeletion = ptr.To(true)
+		s.Resource.SetOwnerReferences(refs)
+
+		return s
+	}
+
+	type args struct {
+		policies bool
+		ordered  bool
+		desired  ComposedResourceStates
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   map[ResourceName]bool
+	}{
+		"FeaturesDisabled": {
+			reason: "Every composed resource should block foreground deletion if neither delete policies nor ordering are enabled.",
+			args: args{
+				desired: ComposedResourceStates{
+					"a": blocking(withDeletePolicy("a", v1.ComposedDeletePolicyOrphan)),
+					"b": blocking(dependsOn("b", "a")),
+				},
+			},
+			want: map[ResourceName]bool{"a": true, "b": true},
+		},
+		"DeletePolicies": {
+			reason: "Composed resources that must be orphaned shouldn't block foreground deletion, or the garbage collector would delete them before they could be orphaned.",
+			args: args{
+				policies: true,
+				desired: ComposedResourceStates{
+					"a": blocking(withDeletePolicy("a", "")),
+					"b": blocking(withDeletePolicy("b", v1.ComposedDeletePolicyDelete)),
+					"c": blocking(withDeletePolicy("c", v1.ComposedDeletePolicyOrphan)),
+					"d": blocking(withDeletePolicy("d", v1.ComposedDeletePolicyRetain)),
+				},
+			},
+			want: map[ResourceName]bool{"a": true, "b": true, "c": false, "d": false},
+		},
+		"Ordered": {
+			reason: "Composed resources that others depend on shouldn't block foreground deletion, or the garbage collector would delete them before their dependents.",
+			args: args{
+				ordered: true,
+				desired: ComposedResourceStates{
+					"a": blocking(dependsOn("a", "")),
+					"b": blocking(dependsOn("b", "a")),
+					"c": blocking(dependsOn("c", "")),
+				},
+			},
+			want: map[ResourceName]bool{"a": false, "b": true, "c": true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &FunctionComposer{policies: tc.args.policies, ordered: tc.args.ordered}
+			c.unblockOwnerDeletion(xr, tc.args.desired)
+
+			got := make(map[ResourceName]bool, len(tc.args.desired))
+			for n, s := range tc.args.desired {
+				got[n] = ptr.Deref(s.Resource.GetOwnerReferences()[0].BlockOwnerDeletion, false)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nunblockOwnerDeletion(...): -want BlockOwnerDeletion, +got BlockOwnerDeletion:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOrphaningComposedResourceDeleter(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type params struct {
+		o ComposedResourceObserver
+		c client.Writer
+	}
+
+	type want struct {
+		done     bool
+		orphaned []string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		params params
+		want   want
+	}{
+		"ObserveError": {
+			reason: "We should return any error encountered observing composed resources.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					return nil, errBoom
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errObserveComposed),
+			},
+		},
+		"NothingToOrphan": {
+			reason: "We should leave composed resources to the garbage collector if their delete policy is Delete.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					return ComposedResourceStates{
+						"a": withDeletePolicy("a", ""),
+						"b": withDeletePolicy("b", v1.ComposedDeletePolicyDelete),
+					}, nil
+				}),
+			},
+			want: want{
+				done: true,
+			},
+		},
+		"OrphanResources": {
+			reason: "We should orphan composed resources whose delete policy is Orphan or Retain.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					return ComposedResourceStates{
+						"a": withDeletePolicy("a", ""),
+						"b": withDeletePolicy("b", v1.ComposedDeletePolicyOrphan),
+						"c": withDeletePolicy("c", v1.ComposedDeletePolicyRetain),
+					}, nil
+				}),
+			},
+			want: want{
+				orphaned: []string{"b", "c"},
+			},
+		},
+		"AlreadyOrphaned": {
+			reason: "We should be done once every composed resource that must be orphaned is orphaned.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					b := withDeletePolicy("b", v1.ComposedDeletePolicyOrphan)
+					b.Resource.SetOwnerReferences(nil)
+
+					return ComposedResourceStates{
+						"a": withDeletePolicy("a", ""),
+						"b": b,
+					}, nil
+				}),
+			},
+			want: want{
+				done: true,
+			},
+		},
+		"UpdateError": {
+			reason: "We should return any error encountered orphaning a composed resource.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					return ComposedResourceStates{
+						"b": withDeletePolicy("b", v1.ComposedDeletePolicyOrphan),
+					}, nil
+				}),
+				c: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtOrphanCD, "b", "", "b"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var orphaned []string
+
+			c := tc.params.c
+			if c == nil {
+				c = &test.MockClient{
+					MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+						if len(obj.GetOwnerReferences()) != 0 {
+							return errors.New("resource still has owner references")
+						}
+
+						orphaned = append(orphaned, obj.GetName())
+
+						return nil
+					},
+				}
+			}
+
+			xr := composite.New()
+			xr.SetUID("cool-xr")
+
+			d := NewOrphaningComposedResourceDeleter(tc.params.o, c)
+			done, err := d.DeleteComposedResources(context.Background(), xr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDeleteComposedResources(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.done, done); diff != "" {
+				t.Errorf("\n%s\nDeleteComposedResources(...): -want done, +got done:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.orphaned, orphaned, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nDeleteComposedResources(...): -want orphaned, +got orphaned:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+// Error strings.
+const (
+	errObserveComposed    = "cannot observe composed resources"
+	errFmtDependencyCycle = "composed resources depend on each other: %s"
+)
+
+// A ComposedResourceDeleter deletes the composed resources of a composite
+// resource that's being deleted.
+type ComposedResourceDeleter interface {
+	// DeleteComposedResources deletes the supplied composite resource's
+	// composed resources. It returns true once the composite resource can be
+	// deleted without waiting for any of them.
+	DeleteComposedResources(ctx context.Context, xr resource.Composite) (bool, error)
+}
+
+// A ComposedResourceDeleterFn deletes the composed resources of a composite
+// resource that's being deleted.
+type ComposedResourceDeleterFn func(ctx context.Context, xr resource.Composite) (bool, error)
+
+// DeleteComposedResources deletes the supplied composite resource's composed
+// resources.
+func (fn ComposedResourceDeleterFn) DeleteComposedResources(ctx context.Context, xr resource.Composite) (bool, error) {
+	return fn(ctx, xr)
+}
+
+// A ComposedResourceDeleterChain calls the supplied ComposedResourceDeleters
+// in order. It doesn't call a deleter until all the deleters before it are
+// done.
+type ComposedResourceDeleterChain []ComposedResourceDeleter
+
+// DeleteComposedResources calls each ComposedResourceDeleter in order. It
+// returns true once they're all done.
+func (dc ComposedResourceDeleterChain) DeleteComposedResources(ctx context.Context, xr resource.Composite) (bool, error) {
+	for _, d := range dc {
+		done, err := d.DeleteComposedResources(ctx, xr)
+		if err != nil || !done {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// An OrderedComposedResourceDeleter deletes the composed resources of a
+// composite resource in reverse dependency order. A composed resource isn't
+// deleted until every composed resource that depends on it is gone. Composed
+// resources that others depend on don't block their composite resource's
+// deletion, so they're deleted in order even if the composite resource is
+// deleted in the foreground.
+type OrderedComposedResourceDeleter struct {
+	observer ComposedResourceObserver
+	client   client.Writer
+}
+
+// NewOrderedComposedResourceDeleter returns a ComposedResourceDeleter that
+// observes composed resources using the supplied observer, and deletes them
+// using the supplied client.
+func NewOrderedComposedResourceDeleter(o ComposedResourceObserver, c client.Writer) *OrderedComposedResourceDeleter {
+	return &OrderedComposedResourceDeleter{observer: o, client: c}
+}
+
+// DeleteComposedResources deletes every composed resource that no other
+// composed resource depends on. It returns true once no composed resource
+// depends on another, at which point the API server's garbage collector can
+// delete the remaining composed resources along with the composite resource.
+func (d *OrderedComposedResourceDeleter) DeleteComposedResources(ctx context.Context, xr resource.Composite) (bool, error) {
+	observed, err := d.observer.ObserveComposedResources(ctx, xr)
+	if err != nil {
+		return false, errors.Wrap(err, errObserveComposed)
+	}
+
+	// Composed resources we don't control (e.g. because they were orphaned)
+	// won't be deleted, so nothing should wait for them.
+	for name, cd := range observed {
+		if c := metav1.GetControllerOf(cd.Resource); c == nil || c.UID != xr.GetUID() {
+			delete(observed, name)
+		}
+	}
+
+	ordered := false
+
+	for name := range observed {
+		if len(dependents(name, observed)) > 0 {
+			ordered = true
+			break
+		}
+	}
+
+	if !ordered {
+		return true, nil
+	}
+
+	for name, cd := range observed {
+		if len(dependents(name, observed)) > 0 || meta.WasDeleted(cd.Resource) {
+			continue
+		}
+
+		if err := d.client.Delete(ctx, cd.Resource); resource.IgnoreNotFound(err) != nil {
+			return false, errors.Wrapf(err, errFmtDeleteCD, name, cd.Resource.GetObjectKind().GroupVersionKind().Kind, cd.Resource.GetName())
+		}
+	}
+
+	return false, nil
+}
+
+// dependencies returns the names of the composed resources the supplied
+// composed resource depends on. A composed resource can't depend on itself.
+func dependencies(name ResourceName, cd resource.Composed) []ResourceName {
+	deps := xcrd.GetDependsOn(cd)
+	out := make([]ResourceName, 0, len(deps))
+
+	for _, d := range deps {
+		if ResourceName(d) != name {
+			out = append(out, ResourceName(d))
+		}
+	}
+
+	return out
+}
+
+// dependents returns the names of the supplied composed resources that
+// depend on the named composed resource.
+func dependents(name ResourceName, states ComposedResourceStates) []ResourceName {
+	var out []ResourceName
+
+	for n, s := range states {
+		if slices.Contains(dependencies(n, s.Resource), name) {
+			out = append(out, n)
+		}
+	}
+
+	slices.Sort(out)
+
+	return out
+}
+
+// DependencyCycle returns a cycle of desired composed resources that depend
+// on each other, if any. Composed resources in a cycle could never be
+// created.
+func DependencyCycle(desired ComposedResourceStates) []ResourceName {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[ResourceName]int, len(desired))
+
+	var path []ResourceName
+
+	var visit func(n ResourceName) []ResourceName
+
+	visit = func(n ResourceName) []ResourceName {
+		switch state[n] {
+		case visited:
+			return nil
+		case visiting:
+			i := slices.Index(path, n)
+			return append(slices.Clone(path[i:]), n)
+		}
+
+		s, ok := desired[n]
+		if !ok {
+			return nil
+		}
+
+		state[n] = visiting
+		path = append(path, n)
+
+		for _, d := range dependencies(n, s.Resource) {
+			if c := visit(d); c != nil {
+				return c
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[n] = visited
+
+		return nil
+	}
+
+	// Visit in a stable order so we always report the same cycle.
+	names := make([]ResourceName, 0, len(desired))
+	for n := range desired {
+		names = append(names, n)
+	}
+
+	slices.Sort(names)
+
+	for _, n := range names {
+		if c := visit(n); c != nil {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// DeferComposedResources returns the desired composed resources that
+// shouldn't be created yet, and the dependencies each is waiting for. A
+// composed resource is deferred if it doesn't exist yet, and one or more of
+// the composed resources it depends on isn't ready. Composed resources that
+// already exist are never deferred.
+func DeferComposedResources(desired, observed ComposedResourceStates, ready func(name ResourceName) bool) map[ResourceName][]ResourceName {
+	deferred := map[ResourceName][]ResourceName{}
+
+	for name, dr := range desired {
+		if _, ok := observed[name]; ok {
+			continue
+		}
+
+		var waiting []ResourceName
+
+		for _, d := range dependencies(name, dr.Resource) {
+			if !ready(d) {
+				waiting = append(waiting, d)
+			}
+		}
+
+		if len(waiting) > 0 {
+			slices.Sort(waiting)
+			deferred[name] = waiting
+		}
+	}
+
+	return deferred
+}
+
+// RetainComposedResources returns the observed composed resources that are no
+// longer desired, but that the composite resource must keep referencing.
+// Blocked composed resources mustn't be deleted yet, because other observed
+// composed resources still depend on them. Deleting composed resources
+// depend on other composed resources; they may be deleted but must stay
+// referenced until they're gone, so that their dependencies stay blocked.
+func RetainComposedResources(desired, observed ComposedResourceStates) (blocked, deleting ComposedResourceStates) {
+	blocked = ComposedResourceStates{}
+	deleting = ComposedResourceStates{}
+
+	for name, or := range observed {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+
+		if len(dependents(name, observed)) > 0 {
+			blocked[name] = or
+			continue
+		}
+
+		if len(dependencies(name, or.Resource)) > 0 {
+			deleting[name] = or
+		}
+	}
+
+	return blocked, deleting
+}
+
+// dependencyReady returns true if the named composed resource exists and is
+// ready. It's ready if the function pipeline said so, if it passes its
+// Composition's readiness checks, or otherwise if it has the Ready: True
+// status condition.
+func (c *FunctionComposer) dependencyReady(ctx context.Context, name ResourceName, desired, observed ComposedResourceStates, rev *v1.CompositionRevision) bool {
+	or, ok := observed[name]
+	if !ok {
+		return false
+	}
+
+	if dr, ok := desired[name]; ok && dr.Ready {
+		return true
+	}
+
+	if checks := ComposedReadinessChecksFor(rev, name); c.readiness != nil && len(checks) > 0 {
+		ready, _ := c.readiness.IsReady(ctx, or.Resource, checks)
+		return ready
+	}
+
+	return or.Resource.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue
+}
+
+// joinNames joins the supplied composed resource names.
+func joinNames(names []ResourceName) string {
+	s := make([]string, len(names))
+	for i, n := range names {
+		s[i] = string(n)
+	}
+
+	return strings.Join(s, ", ")
+}
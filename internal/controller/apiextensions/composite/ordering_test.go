@@ -0,0 +1,392 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+func dependsOn(name string, deps string) ComposedResourceState {
+	cd := composed.New()
+	cd.SetName(name)
+	cd.SetOwnerReferences([]metav1.OwnerReference{{Controller: ptr.To(true), UID: "cool-xr"}})
+
+	if deps != "" {
+		cd.SetAnnotations(map[string]string{xcrd.AnnotationKeyDependsOn: deps})
+	}
+
+	return ComposedResourceState{Resource: cd}
+}
+
+func TestDependencyCycle(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		desired ComposedResourceStates
+		want    []ResourceName
+	}{
+		"NoDependencies": {
+			reason: "Composed resources that don't depend on each other don't form a cycle.",
+			desired: ComposedResourceStates{
+				"a": dependsOn("a", ""),
+				"b": dependsOn("b", ""),
+			},
+		},
+		"Chain": {
+			reason: "A chain of dependencies isn't a cycle.",
+			desired: ComposedResourceStates{
+				"a": dependsOn("a", ""),
+				"b": dependsOn("b", "a"),
+				"c": dependsOn("c", "a, b"),
+			},
+		},
+		"SelfDependency": {
+			reason: "A composed resource that depends on itself is ignored.",
+			desired: ComposedResourceStates{
+				"a": dependsOn("a", "a"),
+			},
+		},
+		"UnknownDependency": {
+			reason: "A dependency on a composed resource that isn't desired isn't a cycle.",
+			desired: ComposedResourceStates{
+				"a": dependsOn("a", "unknown"),
+			},
+		},
+		"Cycle": {
+			reason: "We should return the composed resources that form a cycle.",
+			desired: ComposedResourceStates{
+				"a": dependsOn("a", "c"),
+				"b": dependsOn("b", "a"),
+				"c": dependsOn("c", "b"),
+			},
+			want: []ResourceName{"a", "c", "b", "a"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DependencyCycle(tc.desired)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDependencyCycle(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeferComposedResources(t *testing.T) {
+	type args struct {
+		desired  ComposedResourceStates
+		observed ComposedResourceStates
+		ready    func(name ResourceName) bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   map[ResourceName][]ResourceName
+	}{
+		"DependenciesReady": {
+			reason: "We shouldn't defer composed resources whose dependencies are ready.",
+			args: args{
+				desired: ComposedResourceStates{
+					"a": dependsOn("a", ""),
+					"b": dependsOn("b", "a"),
+				},
+				observed: ComposedResourceStates{
+					"a": dependsOn("a", ""),
+				},
+				ready: func(_ ResourceName) bool { return true },
+			},
+			want: map[ResourceName][]ResourceName{},
+		},
+		"DependenciesNotReady": {
+			reason: "We should defer composed resources that don't exist yet until their dependencies are ready.",
+			args: args{
+				desired: ComposedResourceStates{
+					"a": dependsOn("a", ""),
+					"b": dependsOn("b", ""),
+					"c": dependsOn("c", "b,a"),
+				},
+				observed: ComposedResourceStates{
+					"a": dependsOn("a", ""),
+				},
+				ready: func(name ResourceName) bool { return name == "a" },
+			},
+			want: map[ResourceName][]ResourceName{
+				"c": {"b"},
+			},
+		},
+		"AlreadyExists": {
+			reason: "We should never defer composed resources that already exist.",
+			args: args{
+				desired: ComposedResourceStates{
+					"a": dependsOn("a", ""),
+					"b": dependsOn("b", "a"),
+				},
+				observed: ComposedResourceStates{
+					"a": dependsOn("a", ""),
+					"b": dependsOn("b", "a"),
+				},
+				ready: func(_ ResourceName) bool { return false },
+			},
+			want: map[ResourceName][]ResourceName{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DeferComposedResources(tc.args.desired, tc.args.observed, tc.args.ready)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDeferComposedResources(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRetainComposedResources(t *testing.T) {
+	type args struct {
+		desired  ComposedResourceStates
+		observed ComposedResourceStates
+	}
+
+	type want struct {
+		blocked  []ResourceName
+		deleting []ResourceName
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"AllDesired": {
+			reason: "We shouldn't retain composed resources that are still desired.",
+			args: args{
+				desired: ComposedResourceStates{
+					"a": dependsOn("a", ""),
+					"b": dependsOn("b", "a"),
+				},
+				observed: ComposedResourceStates{
+					"a": dependsOn("a", ""),
+					"b": dependsOn("b", "a"),
+				},
+			},
+			want: want{},
+		},
+		"DependedOn": {
+			reason: "We should block deleting undesired composed resources that others depend on, and retain their dependents until they're gone.",
+			args: args{
+				desired: ComposedResourceStates{},
+				observed: ComposedResourceStates{
+					"a": dependsOn("a", ""),
+					"b": dependsOn("b", "a"),
+					"c": dependsOn("c", ""),
+				},
+			},
+			want: want{
+				blocked:  []ResourceName{"a"},
+				deleting: []ResourceName{"b"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			blocked, deleting := RetainComposedResources(tc.args.desired, tc.args.observed)
+
+			if diff := cmp.Diff(tc.want.blocked, resourceNames(blocked), cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b ResourceName) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nRetainComposedResources(...): -want blocked, +got blocked:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.deleting, resourceNames(deleting), cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b ResourceName) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nRetainComposedResources(...): -want deleting, +got deleting:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func resourceNames(s ComposedResourceStates) []ResourceName {
+	out := make([]ResourceName, 0, len(s))
+	for n := range s {
+		out = append(out, n)
+	}
+
+	return out
+}
+
+func TestOrderedComposedResourceDeleter(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	now := metav1.Now()
+	deleting := dependsOn("b", "")
+	deleting.Resource.SetDeletionTimestamp(&now)
+
+	type params struct {
+		o ComposedResourceObserver
+		c client.Writer
+	}
+
+	type want struct {
+		done    bool
+		deleted []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		params params
+		want   want
+	}{
+		"ObserveError": {
+			reason: "We should return any error encountered observing composed resources.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					return nil, errBoom
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errObserveComposed),
+			},
+		},
+		"NoDependencies": {
+			reason: "We should leave composed resources to the garbage collector if none depend on another.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					return ComposedResourceStates{
+						"a": dependsOn("a", ""),
+						"b": dependsOn("b", ""),
+					}, nil
+				}),
+			},
+			want: want{
+				done: true,
+			},
+		},
+		"DeleteDependents": {
+			reason: "We should only delete composed resources that nothing depends on.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					return ComposedResourceStates{
+						"a": dependsOn("a", ""),
+						"b": dependsOn("b", "a"),
+						"c": dependsOn("c", "a"),
+					}, nil
+				}),
+			},
+			want: want{
+				deleted: []string{"b", "c"},
+			},
+		},
+		"Orphaned": {
+			reason: "We shouldn't wait for composed resources we don't control to be deleted.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					a := dependsOn("a", "")
+					a.Resource.SetOwnerReferences(nil)
+
+					return ComposedResourceStates{
+						"a": a,
+						"b": dependsOn("b", "a"),
+					}, nil
+				}),
+			},
+			want: want{
+				done: true,
+			},
+		},
+		"AlreadyDeleting": {
+			reason: "We shouldn't delete composed resources that are already being deleted.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					b := deleting
+					b.Resource.SetAnnotations(map[string]string{xcrd.AnnotationKeyDependsOn: "a"})
+
+					return ComposedResourceStates{
+						"a": dependsOn("a", ""),
+						"b": b,
+					}, nil
+				}),
+			},
+			want: want{},
+		},
+		"DeleteError": {
+			reason: "We should return any error encountered deleting a composed resource.",
+			params: params{
+				o: ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+					return ComposedResourceStates{
+						"a": dependsOn("a", ""),
+						"b": dependsOn("b", "a"),
+					}, nil
+				}),
+				c: &test.MockClient{
+					MockDelete: test.NewMockDeleteFn(errBoom),
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtDeleteCD, "b", "", "b"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+
+			c := tc.params.c
+			if c == nil {
+				c = &test.MockClient{
+					MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+						deleted = append(deleted, obj.GetName())
+						return nil
+					},
+				}
+			}
+
+			xr := composite.New()
+			xr.SetUID("cool-xr")
+
+			d := NewOrderedComposedResourceDeleter(tc.params.o, c)
+			done, err := d.DeleteComposedResources(context.Background(), xr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDeleteComposedResources(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.done, done); diff != "" {
+				t.Errorf("\n%s\nDeleteComposedResources(...): -want done, +got done:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.deleted, deleted, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nDeleteComposedResources(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xcel"
+)
+
+// ReadinessVarResource is the observed composed resource, which may be used
+// in a readiness check expression.
+const ReadinessVarResource = "resource"
+
+const (
+	errEvalReadiness         = "cannot evaluate readiness check expression"
+	errConvertComposed       = "cannot convert composed resource to unstructured"
+	errFmtUnknownCheckType   = "unknown readiness check type %q"
+	errFmtCheckMissingField  = "%s readiness check must specify %s"
+	errFmtReadinessCheckFail = "readiness check %d (%s) failed"
+)
+
+// A ReadinessChecker determines whether a composed resource is ready.
+type ReadinessChecker interface {
+	// IsReady returns true if the supplied composed resource passes all of
+	// the supplied readiness checks. If it doesn't, it returns a human
+	// readable reason the composed resource isn't ready.
+	IsReady(ctx context.Context, cd resource.Composed, checks []v1.ReadinessCheck) (bool, string)
+}
+
+// A ReadinessCheckerFn determines whether a composed resource is ready.
+type ReadinessCheckerFn func(ctx context.Context, cd resource.Composed, checks []v1.ReadinessCheck) (bool, string)
+
+// IsReady returns true if the supplied composed resource is ready.
+func (fn ReadinessCheckerFn) IsReady(ctx context.Context, cd resource.Composed, checks []v1.ReadinessCheck) (bool, string) {
+	return fn(ctx, cd, checks)
+}
+
+// IsReady returns true if the supplied composed resource passes all of the
+// supplied readiness checks. If it doesn't, it returns a human readable
+// reason the composed resource isn't ready. A readiness check that can't be
+// evaluated, for example because its expression is invalid, fails.
+func IsReady(_ context.Context, cd resource.Composed, checks []v1.ReadinessCheck) (bool, string) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cd)
+	if err != nil {
+		return false, errors.Wrap(err, errConvertComposed).Error()
+	}
+
+	p := fieldpath.Pave(u)
+
+	for i, c := range checks {
+		ok, reason, err := check(p, cd, c)
+		if err != nil {
+			return false, errors.Wrapf(err, errFmtReadinessCheckFail, i, c.Type).Error()
+		}
+
+		if !ok {
+			return false, reason
+		}
+	}
+
+	return true, ""
+}
+
+// ComposedReadinessChecksFor returns the readiness checks for the supplied
+// composed resource, if any.
+func ComposedReadinessChecksFor(rev *v1.CompositionRevision, name ResourceName) []v1.ReadinessCheck {
+	if rev == nil {
+		return nil
+	}
+
+	for _, rc := range rev.Spec.ReadinessChecks {
+		if rc.ResourceName == string(name) {
+			return rc.Checks
+		}
+	}
+
+	return nil
+}
+
+func check(p *fieldpath.Paved, cd resource.Composed, c v1.ReadinessCheck) (bool, string, error) { //nolint:gocognit // Only slightly over.
+	fp := ptr.Deref(c.FieldPath, "")
+	if fp == "" && c.Type != v1.ReadinessCheckTypeMatchCondition && c.Type != v1.ReadinessCheckTypeExpression {
+		return false, "", errors.Errorf(errFmtCheckMissingField, c.Type, "fieldPath")
+	}
+
+	switch c.Type {
+	case v1.ReadinessCheckTypeNonEmpty:
+		v, err := p.GetValue(fp)
+		if fieldpath.IsNotFound(err) {
+			return false, fmt.Sprintf("%s is not set", fp), nil
+		}
+
+		if err != nil {
+			return false, "", err
+		}
+
+		if empty(v) {
+			return false, fmt.Sprintf("%s is empty", fp), nil
+		}
+
+		return true, "", nil
+	case v1.ReadinessCheckTypeMatchString:
+		if c.MatchString == nil {
+			return false, "", errors.Errorf(errFmtCheckMissingField, c.Type, "matchString")
+		}
+
+		v, err := p.GetString(fp)
+		if fieldpath.IsNotFound(err) {
+			return false, fmt.Sprintf("%s is not set", fp), nil
+		}
+
+		if err != nil {
+			return false, "", err
+		}
+
+		if v != *c.MatchString {
+			return false, fmt.Sprintf("%s is %q, not %q", fp, v, *c.MatchString), nil
+		}
+
+		return true, "", nil
+	case v1.ReadinessCheckTypeMatchInteger:
+		if c.MatchInteger == nil {
+			return false, "", errors.Errorf(errFmtCheckMissingField, c.Type, "matchInteger")
+		}
+
+		v, err := p.GetInteger(fp)
+		if fieldpath.IsNotFound(err) {
+			return false, fmt.Sprintf("%s is not set", fp), nil
+		}
+
+		if err != nil {
+			return false, "", err
+		}
+
+		if v != *c.MatchInteger {
+			return false, fmt.Sprintf("%s is %d, not %d", fp, v, *c.MatchInteger), nil
+		}
+
+		return true, "", nil
+	case v1.ReadinessCheckTypeMatchTrue, v1.ReadinessCheckTypeMatchFalse:
+		want := c.Type == v1.ReadinessCheckTypeMatchTrue
+
+		v, err := p.GetBool(fp)
+		if fieldpath.IsNotFound(err) {
+			return false, fmt.Sprintf("%s is not set", fp), nil
+		}
+
+		if err != nil {
+			return false, "", err
+		}
+
+		if v != want {
+			return false, fmt.Sprintf("%s is %t, not %t", fp, v, want), nil
+		}
+
+		return true, "", nil
+	case v1.ReadinessCheckTypeMatchCondition:
+		want := v1.MatchConditionReadinessCheck{Type: xpv1.TypeReady, Status: corev1.ConditionTrue}
+		if c.MatchCondition != nil {
+			want = *c.MatchCondition
+		}
+
+		got := cd.GetCondition(want.Type)
+		if got.Status == want.Status {
+			return true, "", nil
+		}
+
+		reason := fmt.Sprintf("condition %s is %s, not %s", want.Type, got.Status, want.Status)
+		if got.Message != "" {
+			reason = fmt.Sprintf("%s: %s", reason, got.Message)
+		}
+
+		return false, reason, nil
+	case v1.ReadinessCheckTypeExpression:
+		if ptr.Deref(c.Expression, "") == "" {
+			return false, "", errors.Errorf(errFmtCheckMissingField, c.Type, "expression")
+		}
+
+		ok, err := evalReadinessExpression(*c.Expression, p.UnstructuredContent())
+		if err != nil {
+			return false, "", err
+		}
+
+		if !ok {
+			return false, fmt.Sprintf("%s is false", *c.Expression), nil
+		}
+
+		return true, "", nil
+	}
+
+	return false, "", errors.Errorf(errFmtUnknownCheckType, c.Type)
+}
+
+// empty returns true if the supplied value is nil, or an empty string, array,
+// or object.
+func empty(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case []any:
+		return len(t) == 0
+	case map[string]any:
+		return len(t) == 0
+	}
+
+	return false
+}
+
+var readinessExpressions = xcel.NewEvaluator([]cel.EnvOption{ //nolint:gochecknoglobals // We want to share compiled expressions.
+	cel.Variable(ReadinessVarResource, cel.MapType(cel.StringType, cel.DynType)),
+})
+
+func evalReadinessExpression(expr string, cd map[string]any) (bool, error) {
+	ok, err := readinessExpressions.EvalBool(expr, map[string]any{ReadinessVarResource: cd})
+	return ok, errors.Wrap(err, errEvalReadiness)
+}
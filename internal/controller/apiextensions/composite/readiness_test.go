@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composed"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+func TestIsReady(t *testing.T) {
+	cd := composed.New()
+	cd.Object["status"] = map[string]any{
+		"phase":    "Pending",
+		"replicas": int64(3),
+		"healthy":  true,
+		"endpoint": "",
+		"atProvider": map[string]any{
+			"arn": "arn:cool",
+		},
+	}
+	cd.SetConditions(xpv1.Condition{Type: xpv1.TypeReady, Status: corev1.ConditionFalse, Message: "still creating"})
+
+	type want struct {
+		ready  bool
+		reason string
+	}
+
+	cases := map[string]struct {
+		reason string
+		checks []v1.ReadinessCheck
+		want   want
+	}{
+		"NoChecks": {
+			reason: "A composed resource with no readiness checks should be ready.",
+			want:   want{ready: true},
+		},
+		"AllPass": {
+			reason: "A composed resource should be ready if all of its readiness checks pass.",
+			checks: []v1.ReadinessCheck{
+				{Type: v1.ReadinessCheckTypeNonEmpty, FieldPath: ptr.To("status.atProvider.arn")},
+				{Type: v1.ReadinessCheckTypeMatchString, FieldPath: ptr.To("status.phase"), MatchString: ptr.To("Pending")},
+				{Type: v1.ReadinessCheckTypeMatchInteger, FieldPath: ptr.To("status.replicas"), MatchInteger: ptr.To[int64](3)},
+				{Type: v1.ReadinessCheckTypeMatchTrue, FieldPath: ptr.To("status.healthy")},
+				{Type: v1.ReadinessCheckTypeMatchCondition, MatchCondition: &v1.MatchConditionReadinessCheck{Type: xpv1.TypeReady, Status: corev1.ConditionFalse}},
+				{Type: v1.ReadinessCheckTypeExpression, Expression: ptr.To("resource.status.replicas > 1")},
+			},
+			want: want{ready: true},
+		},
+		"NotSet": {
+			reason: "A NonEmpty check should fail if the field isn't set.",
+			checks: []v1.ReadinessCheck{{Type: v1.ReadinessCheckTypeNonEmpty, FieldPath: ptr.To("status.atProvider.id")}},
+			want:   want{reason: "status.atProvider.id is not set"},
+		},
+		"Empty": {
+			reason: "A NonEmpty check should fail if the field is empty.",
+			checks: []v1.ReadinessCheck{{Type: v1.ReadinessCheckTypeNonEmpty, FieldPath: ptr.To("status.endpoint")}},
+			want:   want{reason: "status.endpoint is empty"},
+		},
+		"StringMismatch": {
+			reason: "A MatchString check should fail if the field doesn't match.",
+			checks: []v1.ReadinessCheck{{Type: v1.ReadinessCheckTypeMatchString, FieldPath: ptr.To("status.phase"), MatchString: ptr.To("Running")}},
+			want:   want{reason: `status.phase is "Pending", not "Running"`},
+		},
+		"FalseMismatch": {
+			reason: "A MatchFalse check should fail if the field is true.",
+			checks: []v1.ReadinessCheck{{Type: v1.ReadinessCheckTypeMatchFalse, FieldPath: ptr.To("status.healthy")}},
+			want:   want{reason: "status.healthy is true, not false"},
+		},
+		"ConditionMismatch": {
+			reason: "A MatchCondition check should default to Ready: True and explain the observed condition.",
+			checks: []v1.ReadinessCheck{{Type: v1.ReadinessCheckTypeMatchCondition}},
+			want:   want{reason: "condition Ready is False, not True: still creating"},
+		},
+		"ExpressionFalse": {
+			reason: "An Expression check should fail if the expression is false.",
+			checks: []v1.ReadinessCheck{{Type: v1.ReadinessCheckTypeExpression, Expression: ptr.To("resource.status.phase == 'Running'")}},
+			want:   want{reason: "resource.status.phase == 'Running' is false"},
+		},
+		"MissingField": {
+			reason: "A check that's missing a required field should fail.",
+			checks: []v1.ReadinessCheck{
+				{Type: v1.ReadinessCheckTypeMatchTrue, FieldPath: ptr.To("status.healthy")},
+				{Type: v1.ReadinessCheckTypeMatchInteger, FieldPath: ptr.To("status.replicas")},
+			},
+			want: want{reason: "readiness check 1 (MatchInteger) failed: MatchInteger readiness check must specify matchInteger"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ready, reason := IsReady(context.Background(), cd, tc.checks)
+
+			if diff := cmp.Diff(tc.want.ready, ready); diff != "" {
+				t.Errorf("\n%s\nIsReady(...): -want ready, +got ready:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.reason, reason); diff != "" {
+				t.Errorf("\n%s\nIsReady(...): -want reason, +got reason:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
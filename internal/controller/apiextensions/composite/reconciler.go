@@ -49,7 +49,9 @@ import (
 	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
 	"github.com/crossplane/crossplane/v2/internal/engine"
 	"github.com/crossplane/crossplane/v2/internal/features"
+	"github.com/crossplane/crossplane/v2/internal/tracing"
 	"github.com/crossplane/crossplane/v2/internal/xerrors"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
 )
 
 const (
@@ -65,6 +67,7 @@ const (
 	errUpdateStatus     = "cannot update composite resource status"
 	errAddFinalizer     = "cannot add composite resource finalizer"
 	errRemoveFinalizer  = "cannot remove composite resource finalizer"
+	errDeleteComposed   = "cannot delete composed resources"
 	errSelectComp       = "cannot select Composition"
 	errFetchComp        = "cannot fetch Composition"
 	errConfigure        = "cannot configure composite resource"
@@ -76,7 +79,8 @@ const (
 	errGetClaim         = "cannot get referenced claim"
 	errParseClaimRef    = "cannot parse claim reference"
 
-	reconcilePausedMsg = "Reconciliation (including deletion) is paused via the pause annotation"
+	reconcilePausedMsg  = "Reconciliation (including deletion) is paused via the pause annotation"
+	deletingComposedMsg = "Waiting for composed resources to be deleted in dependency order"
 )
 
 // Event reasons.
@@ -93,7 +97,8 @@ const (
 
 // Condition reasons.
 const (
-	reasonFatalError xpv1.ConditionReason = "FatalError"
+	reasonFatalError          xpv1.ConditionReason = "FatalError"
+	reasonFunctionCircuitOpen xpv1.ConditionReason = "FunctionCircuitOpen"
 )
 
 // ControllerName returns the recommended name for controllers that use this
@@ -314,6 +319,15 @@ func WithCompositionRevisionFetcher(f CompositionRevisionFetcher) ReconcilerOpti
 	}
 }
 
+// WithRevisionPreviewer specifies how the Reconciler should preview what
+// adopting a pending CompositionRevision would change. Pending revisions are
+// only previewed if a previewer is configured.
+func WithRevisionPreviewer(p RevisionPreviewer) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.revision.preview = p
+	}
+}
+
 // WithCompositeFinalizer specifies how the composition to be used should be
 // selected.
 // WithCompositeFinalizer specifies which Finalizer should be used to finalize
@@ -324,6 +338,14 @@ func WithCompositeFinalizer(f resource.Finalizer) ReconcilerOption {
 	}
 }
 
+// WithComposedResourceDeleter specifies how the Reconciler should delete
+// composed resources when their composite resource is deleted.
+func WithComposedResourceDeleter(d ComposedResourceDeleter) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.composite.ComposedResourceDeleter = d
+	}
+}
+
 // WithCompositionSelector specifies how the composition to be used should be
 // selected.
 func WithCompositionSelector(s CompositionSelector) ReconcilerOption {
@@ -382,6 +404,8 @@ func WithCompositeSchema(s composite.Schema) ReconcilerOption {
 
 type revision struct {
 	CompositionRevisionFetcher
+
+	preview RevisionPreviewer
 }
 
 // A WatchStarter can start a new watch. XR controllers use this to dynamically
@@ -418,6 +442,7 @@ type compositeResource struct {
 	CompositionSelector
 	Configurator
 	ConnectionPublisher
+	ComposedResourceDeleter
 }
 
 // NewReconciler returns a new Reconciler of composite resources.
@@ -443,6 +468,12 @@ func NewReconciler(cached client.Client, of schema.GroupVersionKind, opts ...Rec
 			ConnectionPublisher: ConnectionPublisherFn(func(_ context.Context, _ ConnectionSecretOwner, _ managed.ConnectionDetails) (bool, error) {
 				return false, nil
 			}),
+
+			// By default we leave deleting composed resources to the
+			// API server's garbage collector.
+			ComposedResourceDeleter: ComposedResourceDeleterFn(func(_ context.Context, _ resource.Composite) (bool, error) {
+				return true, nil
+			}),
 		},
 
 		// We use a nop Composer by default. The real composed is passed in by
@@ -535,6 +566,22 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 		status.MarkConditions(xpv1.Deleting())
 
+		done, err := r.composite.DeleteComposedResources(ctx, xr)
+		if err != nil {
+			err = errors.Wrap(err, errDeleteComposed)
+			r.record.Event(xr, event.Warning(reasonDelete, err))
+			status.MarkConditions(xpv1.ReconcileError(err))
+
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(updateCtx, xr), errUpdateStatus)
+		}
+
+		if !done {
+			log.Debug("Waiting for composed resources to be deleted in dependency order")
+			status.MarkConditions(xpv1.Deleting().WithMessage(deletingComposedMsg), xpv1.ReconcileSuccess())
+
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(updateCtx, xr), errUpdateStatus)
+		}
+
 		if err := r.composite.RemoveFinalizer(ctx, xr); err != nil {
 			if kerrors.IsConflict(err) {
 				return reconcile.Result{Requeue: true}, nil
@@ -566,7 +613,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	}
 
 	orig := xr.GetCompositionReference()
-	if err := r.composite.SelectComposition(ctx, xr); err != nil {
+
+	sctx, span := tracing.Start(ctx, "SelectComposition")
+	err := r.composite.SelectComposition(sctx, xr)
+	tracing.End(span, err)
+
+	if err != nil {
 		if kerrors.IsConflict(err) {
 			return reconcile.Result{Requeue: true}, nil
 		}
@@ -604,6 +656,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		r.record.Event(xr, event.Normal(reasonResolve, fmt.Sprintf("Selected composition revision: %s", rev.Name)))
 	}
 
+	// Composing resources replaces the XR's status with the status the
+	// pipeline returns. Hold on to the XR's pending revision (if any) so we
+	// can restore it before every status update from here on.
+	pending := r.previewPendingRevision(ctx, xr)
+
 	// Check if the CompositionRevision has a valid pipeline before proceeding.
 	// Only proceed if the pipeline is explicitly marked as valid.
 	if c := rev.GetCondition(v1.TypeValidPipeline); c.Status != corev1.ConditionTrue {
@@ -616,6 +673,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 		r.record.Event(xr, event.Warning(reasonCompose, err))
 		status.MarkConditions(xpv1.ReconcileError(err))
+		setPendingRevision(xr, pending)
 
 		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, xr), errUpdateStatus)
 	}
@@ -630,6 +688,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		err = errors.Wrap(err, errConfigure)
 		r.record.Event(xr, event.Warning(reasonCompose, err))
 		status.MarkConditions(xpv1.ReconcileError(err))
+		setPendingRevision(xr, pending)
 
 		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(updateCtx, xr), errUpdateStatus)
 	}
@@ -663,7 +722,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 				}
 			}
 		}
-		status.MarkConditions(xpv1.ReconcileError(err))
+		cond := xpv1.ReconcileError(err)
+		if xfn.IsCircuitOpen(err) {
+			// Make it clear the XR isn't broken - a function it uses is.
+			cond.Reason = reasonFunctionCircuitOpen
+		}
+		status.MarkConditions(cond)
 
 		resultMeta := r.handleCommonCompositionResult(updateCtx, res, xr)
 		// We encountered a fatal error. For any custom status conditions that were
@@ -679,9 +743,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 				status.MarkConditions(c)
 			}
 		}
+		setPendingRevision(xr, pending)
 		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(updateCtx, xr), errUpdateStatus)
 	}
 
+	setPendingRevision(xr, pending)
+
 	ws := make([]engine.Watch, len(xr.GetResourceReferences()))
 	for i, ref := range xr.GetResourceReferences() {
 		cr := &kunstructured.Unstructured{}
@@ -750,9 +817,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		}
 
 		if !cd.Ready {
-			log.Debug("Composed resource is not yet ready", "id", id)
+			log.Debug("Composed resource is not yet ready", "id", id, "reason", cd.Message)
 			unready = append(unready, id)
-			r.record.Event(xr, event.Normal(reasonCompose, fmt.Sprintf("Composed resource %q is not yet ready", id)))
+
+			msg := fmt.Sprintf("Composed resource %q is not yet ready", id)
+			if cd.Message != "" {
+				msg = fmt.Sprintf("%s: %s", msg, cd.Message)
+			}
+			r.record.Event(xr, event.Normal(reasonCompose, msg))
 		}
 	}
 
@@ -93,6 +93,55 @@ func TestReconcile(t *testing.T) {
 				err: errors.Wrap(errBoom, errGet),
 			},
 		},
+		"DeleteComposedResourcesError": {
+			reason: "We should return any error encountered while deleting composed resources.",
+			args: args{
+				c: &test.MockClient{
+					MockGet: WithComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetDeletionTimestamp(&now)
+					})),
+					MockStatusUpdate: WantComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetDeletionTimestamp(&now)
+						cr.SetConditions(xpv1.Deleting(), xpv1.ReconcileError(errors.Wrap(errBoom, errDeleteComposed)))
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithComposedResourceDeleter(ComposedResourceDeleterFn(func(_ context.Context, _ resource.Composite) (bool, error) {
+						return false, errBoom
+					})),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: true},
+			},
+		},
+		"WaitingForComposedResources": {
+			reason: "We should requeue without removing our finalizer while composed resources are deleted in order.",
+			args: args{
+				c: &test.MockClient{
+					MockGet: WithComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetDeletionTimestamp(&now)
+					})),
+					MockStatusUpdate: WantComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetDeletionTimestamp(&now)
+						cr.SetConditions(xpv1.Deleting().WithMessage(deletingComposedMsg), xpv1.ReconcileSuccess())
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithComposedResourceDeleter(ComposedResourceDeleterFn(func(_ context.Context, _ resource.Composite) (bool, error) {
+						return false, nil
+					})),
+					WithCompositeFinalizer(resource.FinalizerFns{
+						RemoveFinalizerFn: func(_ context.Context, _ resource.Object) error {
+							return errors.New("should not be called")
+						},
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: true},
+			},
+		},
 		"RemoveFinalizerError": {
 			reason: "We should return any error encountered while removing finalizer.",
 			args: args{
@@ -322,6 +371,41 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{Requeue: true},
 			},
 		},
+		"ComposeResourcesErrorWithPendingRevision": {
+			reason: "We should keep the composite resource's pending revision if we encounter an error while composing resources.",
+			args: args{
+				c: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockStatusUpdate: WantComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						cr.SetConditions(xpv1.ReconcileError(errors.Wrap(errBoom, errCompose)))
+						setPendingRevision(cr, &PendingRevision{Name: "rev-2"})
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, cr resource.Composite) error {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						return nil
+					})),
+					WithCompositionRevisionFetcher(CompositionRevisionFetcherFn(func(_ context.Context, cr resource.Composite) (*v1.CompositionRevision, error) {
+						holdRevision(cr, "rev-2")
+						return NewCompositionRevision(), nil
+					})),
+					WithConfigurator(ConfiguratorFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) error {
+						return nil
+					})),
+					WithComposer(ComposerFn(func(_ context.Context, xr *composite.Unstructured, _ CompositionRequest) (CompositionResult, error) {
+						// Composing replaces the XR's status.
+						delete(xr.Object, "status")
+						return CompositionResult{}, errBoom
+					})),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: true},
+			},
+		},
 		"PublishConnectionDetailsError": {
 			reason: "We should return any error encountered while publishing connection details.",
 			args: args{
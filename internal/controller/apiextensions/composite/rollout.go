@@ -0,0 +1,523 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+// Error strings.
+const (
+	errListComposites  = "cannot list composite resources"
+	errPreviewRevision = "cannot preview pending composition revision"
+)
+
+// Event reasons.
+const (
+	reasonRollout event.Reason = "RolloutCompositionRevision"
+)
+
+// The status field that records a composite resource's pending revision.
+const fieldPendingRevision = "status.pendingCompositionRevision"
+
+// DefaultRolloutFailureTimeout is how long a composite resource that adopted
+// a Progressive revision may take to become ready before it's considered to
+// have failed, unless the revision's rollout configures a failure timeout.
+const DefaultRolloutFailureTimeout = 10 * time.Minute
+
+// A PendingRevision is a CompositionRevision that a composite resource hasn't
+// adopted yet, because its Composition uses the Staged rollout strategy.
+type PendingRevision struct {
+	// Name of the pending CompositionRevision.
+	Name string `json:"name"`
+
+	// ResourcesAdded is the number of composed resources adopting the
+	// revision would create.
+	ResourcesAdded int64 `json:"resourcesAdded,omitempty"`
+
+	// ResourcesRemoved is the number of composed resources adopting the
+	// revision would delete.
+	ResourcesRemoved int64 `json:"resourcesRemoved,omitempty"`
+
+	// ResourcesChanged is the number of composed resources adopting the
+	// revision would update.
+	ResourcesChanged int64 `json:"resourcesChanged,omitempty"`
+
+	// PreviewTime is when we previewed adopting the revision. It's nil if
+	// we haven't previewed it yet.
+	PreviewTime *metav1.Time `json:"previewTime,omitempty"`
+}
+
+// A RevisionDiff summarizes what composing a composite resource using a
+// CompositionRevision would change.
+type RevisionDiff struct {
+	// Added composed resources.
+	Added int
+
+	// Removed composed resources.
+	Removed int
+
+	// Changed composed resources.
+	Changed int
+}
+
+// A RevisionPreviewer previews what composing a composite resource using a
+// CompositionRevision would change, without changing anything.
+type RevisionPreviewer interface {
+	PreviewRevision(ctx context.Context, xr *composite.Unstructured, rev *v1.CompositionRevision) (RevisionDiff, error)
+}
+
+// A RevisionPreviewerFn previews what composing a composite resource using a
+// CompositionRevision would change.
+type RevisionPreviewerFn func(ctx context.Context, xr *composite.Unstructured, rev *v1.CompositionRevision) (RevisionDiff, error)
+
+// PreviewRevision previews what composing the supplied composite resource
+// using the supplied CompositionRevision would change.
+func (fn RevisionPreviewerFn) PreviewRevision(ctx context.Context, xr *composite.Unstructured, rev *v1.CompositionRevision) (RevisionDiff, error) {
+	return fn(ctx, xr, rev)
+}
+
+// getPendingRevision returns the supplied composite resource's pending
+// revision, or nil if it doesn't have one.
+func getPendingRevision(u runtime.Unstructured) *PendingRevision {
+	p := &PendingRevision{}
+	if err := fieldpath.Pave(u.UnstructuredContent()).GetValueInto(fieldPendingRevision, p); err != nil {
+		return nil
+	}
+
+	if p.Name == "" {
+		return nil
+	}
+
+	return p
+}
+
+// setPendingRevision sets the supplied composite resource's pending revision.
+// Passing nil removes the pending revision.
+func setPendingRevision(u runtime.Unstructured, p *PendingRevision) {
+	if p == nil {
+		if status, ok := u.UnstructuredContent()["status"].(map[string]any); ok {
+			delete(status, "pendingCompositionRevision")
+		}
+
+		return
+	}
+
+	_ = fieldpath.Pave(u.UnstructuredContent()).SetValue(fieldPendingRevision, p)
+}
+
+// holdRevision records that the supplied composite resource hasn't adopted
+// the named revision. It preserves any preview of the revision.
+func holdRevision(cr resource.Composite, name string) {
+	u, ok := cr.(runtime.Unstructured)
+	if !ok {
+		return
+	}
+
+	if p := getPendingRevision(u); p != nil && p.Name == name {
+		return
+	}
+
+	setPendingRevision(u, &PendingRevision{Name: name})
+}
+
+// adoptRevision records that the supplied composite resource has adopted its
+// pending revision, if any.
+func adoptRevision(cr resource.Composite) {
+	if u, ok := cr.(runtime.Unstructured); ok {
+		setPendingRevision(u, nil)
+	}
+}
+
+// inCanary returns true if the composite resource with the supplied UID is
+// one of the supplied percentage of composite resources that adopt a staged
+// revision before it's approved. The same UID is always in the same bucket.
+func inCanary(uid types.UID, pct int32) bool {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(uid))
+
+	return int32(h.Sum32()%100) < pct //nolint:gosec // Can't overflow; the value is less than 100.
+}
+
+// Approved returns true if the supplied CompositionRevision has been approved
+// for rollout to all composite resources.
+func Approved(rev *v1.CompositionRevision) bool {
+	return rev.GetAnnotations()[v1.AnnotationKeyRolloutApproved] == "true"
+}
+
+// released returns true if every composite resource may adopt the supplied
+// CompositionRevision, because it's approved or doesn't use a gated rollout
+// strategy.
+func released(rev *v1.CompositionRevision) bool {
+	return Approved(rev) || (!Staged(rev) && !Progressive(rev))
+}
+
+// lastApprovedRevision returns the newest of the supplied Composition's
+// revisions that's older than the supplied latest revision and released to
+// every composite resource. It returns nil if there isn't one.
+func lastApprovedRevision(comp *v1.Composition, latest *v1.CompositionRevision, revs []v1.CompositionRevision) *v1.CompositionRevision {
+	var last *v1.CompositionRevision
+
+	for i := range revs {
+		rev := &revs[i]
+		if !metav1.IsControlledBy(rev, comp) || rev.Spec.Revision >= latest.Spec.Revision || !released(rev) {
+			continue
+		}
+
+		if last == nil || rev.Spec.Revision > last.Spec.Revision {
+			last = rev
+		}
+	}
+
+	return last
+}
+
+// Staged returns true if the supplied CompositionRevision uses the Staged
+// rollout strategy.
+func Staged(rev *v1.CompositionRevision) bool {
+	return rev.Spec.Rollout != nil && rev.Spec.Rollout.Strategy == v1.RolloutStrategyStaged
+}
+
+// Progressive returns true if the supplied CompositionRevision uses the
+// Progressive rollout strategy.
+func Progressive(rev *v1.CompositionRevision) bool {
+	return rev.Spec.Rollout != nil && rev.Spec.Rollout.Strategy == v1.RolloutStrategyProgressive
+}
+
+// mayAdopt returns true if the supplied composite resource may adopt the
+// supplied revision of the supplied Composition. It may adopt a revision that
+// is approved, or that uses the Immediate rollout strategy. It may adopt a
+// Staged revision if it's a canary. It may adopt a Progressive revision once
+// the Composition's rollout status reports that its wave has started.
+//
+// The rollout controller evaluates each rollout once for all composite
+// resources, rather than each composite resource listing all the others. It
+// approves a Staged revision once every canary has adopted it and is ready,
+// and reports the progress of a Progressive rollout on the Composition.
+func mayAdopt(cr resource.Composite, comp *v1.Composition, rev *v1.CompositionRevision) bool {
+	if released(rev) {
+		return true
+	}
+
+	if Staged(rev) {
+		return inCanary(cr.GetUID(), ptr.Deref(rev.Spec.Rollout.CanaryPercentage, 0))
+	}
+
+	// The rollout controller hasn't reported this rollout's progress yet.
+	s := comp.Status.Rollout
+	if s == nil || s.Revision != rev.GetName() || s.Phase == v1.RolloutPhasePaused {
+		return false
+	}
+
+	return waveOf(rev, cr) <= int(s.CompletedWaves)
+}
+
+// CanarySucceeded returns true if every canary composite resource has adopted
+// the supplied Staged revision and is ready.
+func CanarySucceeded(rev *v1.CompositionRevision, xrs []kunstructured.Unstructured) bool {
+	pct := ptr.Deref(rev.Spec.Rollout.CanaryPercentage, 0)
+	canaries := 0
+
+	for i := range xrs {
+		xr := &xrs[i]
+		if !inCanary(xr.GetUID(), pct) {
+			continue
+		}
+
+		canaries++
+
+		if !adopted(xr, rev) || !ready(xr) {
+			return false
+		}
+	}
+
+	// A canary can't succeed if there are no canaries.
+	return canaries > 0
+}
+
+// ListComposites lists the composite resources of the supplied kind that use
+// the named Composition.
+func ListComposites(ctx context.Context, c client.Reader, gvk schema.GroupVersionKind, comp string) ([]kunstructured.Unstructured, error) {
+	l := &kunstructured.UnstructuredList{}
+	l.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	if err := c.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errListComposites)
+	}
+
+	out := make([]kunstructured.Unstructured, 0, len(l.Items))
+
+	for _, xr := range l.Items {
+		if compositionRefName(&xr) == comp {
+			out = append(out, xr)
+		}
+	}
+
+	return out, nil
+}
+
+// A Rollout is a Progressive rollout of a CompositionRevision across the
+// composite resources that use its Composition.
+type Rollout struct {
+	rev *v1.CompositionRevision
+
+	// Composite resources in each wave. The last wave contains the
+	// composite resources no wave selects.
+	waves [][]*kunstructured.Unstructured
+
+	// The index of the first wave that hasn't completed.
+	current int
+	paused  bool
+
+	total   int
+	updated int
+	ready   int
+}
+
+// NewRollout returns the Progressive rollout of the supplied revision across
+// the supplied composite resources, which must use the revision's
+// Composition, as of the supplied time.
+func NewRollout(rev *v1.CompositionRevision, xrs []kunstructured.Unstructured, now time.Time) *Rollout {
+	r := &Rollout{rev: rev, total: len(xrs)}
+	r.waves = make([][]*kunstructured.Unstructured, len(specWaves(rev))+1)
+
+	pause := rev.Spec.Rollout != nil && rev.Spec.Rollout.PauseOnFailure
+
+	timeout := DefaultRolloutFailureTimeout
+	if rev.Spec.Rollout != nil && rev.Spec.Rollout.FailureTimeout != nil {
+		timeout = rev.Spec.Rollout.FailureTimeout.Duration
+	}
+
+	for i := range xrs {
+		xr := &xrs[i]
+		w := waveOf(rev, xr)
+		r.waves[w] = append(r.waves[w], xr)
+
+		if !adopted(xr, rev) {
+			continue
+		}
+
+		r.updated++
+
+		if ready(xr) {
+			r.ready++
+			continue
+		}
+
+		// Composite resources that are still becoming ready don't pause
+		// the rollout. Their wave just doesn't complete yet.
+		if pause && failed(xr, now, timeout) {
+			r.paused = true
+		}
+	}
+
+	r.current = len(r.waves)
+
+	for i, wave := range r.waves {
+		if !waveComplete(wave, rev, pause) {
+			r.current = i
+			break
+		}
+	}
+
+	return r
+}
+
+func (r *Rollout) specWaves() []v1.RolloutWave {
+	return specWaves(r.rev)
+}
+
+func specWaves(rev *v1.CompositionRevision) []v1.RolloutWave {
+	if rev.Spec.Rollout == nil {
+		return nil
+	}
+
+	return rev.Spec.Rollout.Waves
+}
+
+// waveOf returns the index of the wave of the supplied revision's rollout
+// that the supplied composite resource belongs to.
+func waveOf(rev *v1.CompositionRevision, xr metav1.Object) int {
+	waves := specWaves(rev)
+
+	for i, w := range waves {
+		if len(w.MatchLabels) > 0 && labels.SelectorFromSet(w.MatchLabels).Matches(labels.Set(xr.GetLabels())) {
+			return i
+		}
+
+		if w.Percentage != nil && inCanary(xr.GetUID(), *w.Percentage) {
+			return i
+		}
+	}
+
+	return len(waves)
+}
+
+func waveComplete(wave []*kunstructured.Unstructured, rev *v1.CompositionRevision, pause bool) bool {
+	for _, xr := range wave {
+		if !adopted(xr, rev) {
+			return false
+		}
+
+		if pause && !ready(xr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Complete returns true if every composite resource has adopted the
+// revision.
+func (r *Rollout) Complete() bool {
+	return r.current == len(r.waves)
+}
+
+// Status of the rollout.
+func (r *Rollout) Status() *v1.RolloutStatus {
+	s := &v1.RolloutStatus{
+		Revision:       r.rev.GetName(),
+		Phase:          v1.RolloutPhaseProgressing,
+		CompletedWaves: int32(min(r.current, len(r.specWaves()))), //nolint:gosec // There can't be more than MaxInt32 waves.
+		Total:          int32(r.total),                            //nolint:gosec // There can't be more than MaxInt32 XRs.
+		Updated:        int32(r.updated),                          //nolint:gosec // There can't be more than MaxInt32 XRs.
+		Ready:          int32(r.ready),                            //nolint:gosec // There can't be more than MaxInt32 XRs.
+	}
+
+	if waves := r.specWaves(); r.current < len(waves) {
+		s.CurrentWave = waves[r.current].Name
+	}
+
+	switch {
+	case r.Complete():
+		s.Phase = v1.RolloutPhaseComplete
+	case r.paused:
+		s.Phase = v1.RolloutPhasePaused
+	}
+
+	return s
+}
+
+// adopted returns true if the supplied composite resource uses the supplied
+// revision.
+func adopted(xr *kunstructured.Unstructured, rev *v1.CompositionRevision) bool {
+	return compositionRevisionRefName(xr) == rev.GetName()
+}
+
+// ready returns true if the supplied composite resource is ready.
+func ready(xr *kunstructured.Unstructured) bool {
+	c := composite.Unstructured{Unstructured: *xr}
+	return c.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue
+}
+
+// failed returns true if the supplied composite resource failed to become
+// ready. It failed if it isn't ready for any reason other than that it's
+// being created, or if it has been creating (or its readiness has been
+// unknown) for longer than the supplied timeout.
+func failed(xr *kunstructured.Unstructured, now time.Time, timeout time.Duration) bool {
+	c := composite.Unstructured{Unstructured: *xr}
+	rc := c.GetCondition(xpv1.TypeReady)
+
+	switch {
+	case rc.Status == corev1.ConditionTrue:
+		return false
+	case rc.Status == corev1.ConditionFalse && rc.Reason != xpv1.ReasonCreating:
+		return true
+	case rc.LastTransitionTime.IsZero():
+		return false
+	}
+
+	return now.Sub(rc.LastTransitionTime.Time) > timeout
+}
+
+// compositionRefName returns the name of the Composition the supplied
+// composite resource references. It supports modern and legacy XRs.
+func compositionRefName(u *kunstructured.Unstructured) string {
+	return firstString(u, "spec.crossplane.compositionRef.name", "spec.compositionRef.name")
+}
+
+// compositionRevisionRefName returns the name of the CompositionRevision the
+// supplied composite resource references. It supports modern and legacy XRs.
+func compositionRevisionRefName(u *kunstructured.Unstructured) string {
+	return firstString(u, "spec.crossplane.compositionRevisionRef.name", "spec.compositionRevisionRef.name")
+}
+
+func firstString(u *kunstructured.Unstructured, paths ...string) string {
+	p := fieldpath.Pave(u.Object)
+	for _, path := range paths {
+		if s, err := p.GetString(path); err == nil {
+			return s
+		}
+	}
+
+	return ""
+}
+
+// previewPendingRevision records what adopting the supplied composite
+// resource's pending revision would change, if it hasn't been previewed. It
+// returns the pending revision, or nil if there isn't one.
+func (r *Reconciler) previewPendingRevision(ctx context.Context, xr *composite.Unstructured) *PendingRevision {
+	p := getPendingRevision(xr)
+	if p == nil || p.PreviewTime != nil || r.revision.preview == nil {
+		return p
+	}
+
+	rev := &v1.CompositionRevision{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: p.Name}, rev); err != nil {
+		r.record.Event(xr, event.Warning(reasonRollout, errors.Wrap(errors.Wrap(err, errGetCompositionRevision), errPreviewRevision)))
+		return p
+	}
+
+	d, err := r.revision.preview.PreviewRevision(ctx, xr, rev)
+	if err != nil {
+		r.record.Event(xr, event.Warning(reasonRollout, errors.Wrap(err, errPreviewRevision)))
+		return p
+	}
+
+	now := metav1.Now()
+	p.ResourcesAdded = int64(d.Added)
+	p.ResourcesRemoved = int64(d.Removed)
+	p.ResourcesChanged = int64(d.Changed)
+	p.PreviewTime = &now
+	setPendingRevision(xr, p)
+
+	r.record.Event(xr, event.Normal(reasonRollout, fmt.Sprintf("Composition revision %s is pending rollout. Adopting it would add %d, remove %d, and change %d composed resources.", p.Name, d.Added, d.Removed, d.Changed)))
+
+	return p
+}
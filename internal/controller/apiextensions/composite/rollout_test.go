@@ -0,0 +1,550 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+func TestFetchStagedRevision(t *testing.T) {
+	automatic := xpv1.UpdateAutomatic
+
+	comp := &v1.Composition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cool-composition",
+			UID:  "comp-uid",
+		},
+	}
+
+	rev := func(name string, n int64, rollout *v1.RevisionRollout, annotations map[string]string) v1.CompositionRevision {
+		return v1.CompositionRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      map[string]string{v1.LabelCompositionName: comp.GetName()},
+				Annotations: annotations,
+				OwnerReferences: []metav1.OwnerReference{{
+					UID:        comp.GetUID(),
+					Controller: ptr.To(true),
+				}},
+			},
+			Spec: v1.CompositionRevisionSpec{Revision: n, Rollout: rollout},
+		}
+	}
+
+	staged := &v1.RevisionRollout{Strategy: v1.RolloutStrategyStaged}
+	canary := &v1.RevisionRollout{Strategy: v1.RolloutStrategyStaged, CanaryPercentage: ptr.To[int32](10)}
+	waves := []v1.RolloutWave{
+		{Name: "canary", Percentage: ptr.To[int32](10)},
+		{Name: "prod", MatchLabels: map[string]string{"env": "prod"}},
+	}
+	progressive := &v1.RevisionRollout{Strategy: v1.RolloutStrategyProgressive, Waves: waves}
+	pause := &v1.RevisionRollout{Strategy: v1.RolloutStrategyProgressive, Waves: waves, PauseOnFailure: true}
+
+	// An XR with the supplied UID that uses the supplied revision, if any.
+	xr := func(uid types.UID, revName string, ready corev1.ConditionStatus) *composite.Unstructured {
+		x := composite.New()
+		x.SetAPIVersion("example.org/v1")
+		x.SetKind("XCoolResource")
+		x.SetName(string(uid))
+		x.SetUID(uid)
+		x.SetCompositionReference(&corev1.ObjectReference{Name: comp.GetName()})
+		x.SetCompositionUpdatePolicy(&automatic)
+
+		if revName != "" {
+			x.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: revName})
+		}
+
+		if ready != "" {
+			x.SetConditions(xpv1.Condition{Type: xpv1.TypeReady, Status: ready})
+		}
+
+		return x
+	}
+
+	// An XR with the env=prod label.
+	prod := func(x *composite.Unstructured) *composite.Unstructured {
+		x.SetLabels(map[string]string{"env": "prod"})
+		return x
+	}
+
+	// A client that returns the supplied revisions, and a Composition with
+	// the supplied rollout status. Composite resources decide whether to
+	// adopt a revision without listing each other.
+	mockClient := func(revs []v1.CompositionRevision, s *v1.RolloutStatus) *test.MockClient {
+		return &test.MockClient{
+			MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				switch o := obj.(type) {
+				case *v1.Composition:
+					comp.DeepCopyInto(o)
+					o.Status.Rollout = s
+				case *v1.CompositionRevision:
+					for _, r := range revs {
+						if r.GetName() == key.Name {
+							r.DeepCopyInto(o)
+						}
+					}
+				}
+				return nil
+			},
+			MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+				l, ok := obj.(*v1.CompositionRevisionList)
+				if !ok {
+					return errors.New("composite resources shouldn't be listed")
+				}
+				l.Items = revs
+				return nil
+			},
+			MockUpdate: test.NewMockUpdateFn(nil),
+		}
+	}
+
+	// The status of a Progressive rollout of rev-2.
+	rolling := func(phase v1.RolloutPhase, completed int32) *v1.RolloutStatus {
+		return &v1.RolloutStatus{Revision: "rev-2", Phase: phase, CompletedWaves: completed}
+	}
+
+	type want struct {
+		rev     string
+		pending *PendingRevision
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client client.Client
+		xr     *composite.Unstructured
+		want   want
+	}{
+		"Immediate": {
+			reason: "An XR should adopt a revision that isn't staged.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, nil, nil)}, nil),
+			xr:     xr("stable-uid", "rev-1", ""),
+			want:   want{rev: "rev-2"},
+		},
+		"Hold": {
+			reason: "An XR should stay on its current revision if the latest revision is staged and not approved.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, staged, nil)}, nil),
+			xr:     xr("stable-uid", "rev-1", ""),
+			want:   want{rev: "rev-1", pending: &PendingRevision{Name: "rev-2"}},
+		},
+		"Approved": {
+			reason: "An XR should adopt a staged revision that has been approved.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, staged, map[string]string{v1.AnnotationKeyRolloutApproved: "true"})}, nil),
+			xr: func() *composite.Unstructured {
+				x := xr("stable-uid", "rev-1", "")
+				setPendingRevision(x, &PendingRevision{Name: "rev-2"})
+				return x
+			}(),
+			want: want{rev: "rev-2"},
+		},
+		"Canary": {
+			reason: "A canary XR should adopt a staged revision.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, canary, nil)}, nil),
+			xr:     xr("canary-uid", "rev-1", ""),
+			want:   want{rev: "rev-2"},
+		},
+		"CanaryNotApproved": {
+			reason: "An XR that isn't a canary should stay on its current revision until the canary succeeds and the revision is approved.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, canary, nil)}, nil),
+			xr:     xr("stable-uid", "rev-1", ""),
+			want:   want{rev: "rev-1", pending: &PendingRevision{Name: "rev-2"}},
+		},
+		"ProgressiveNotReported": {
+			reason: "An XR should stay on its current revision until the progress of a progressive rollout is reported.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, progressive, nil)}, &v1.RolloutStatus{Revision: "rev-1", Phase: v1.RolloutPhaseComplete}),
+			xr:     xr("canary-uid", "rev-1", ""),
+			want:   want{rev: "rev-1", pending: &PendingRevision{Name: "rev-2"}},
+		},
+		"ProgressiveFirstWave": {
+			reason: "An XR in the first wave should adopt a progressive revision.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, progressive, nil)}, rolling(v1.RolloutPhaseProgressing, 0)),
+			xr:     xr("canary-uid", "rev-1", ""),
+			want:   want{rev: "rev-2"},
+		},
+		"ProgressiveLaterWave": {
+			reason: "An XR should stay on its current revision until every earlier wave has adopted a progressive revision.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, progressive, nil)}, rolling(v1.RolloutPhaseProgressing, 0)),
+			xr:     prod(xr("stable-uid", "rev-1", "")),
+			want:   want{rev: "rev-1", pending: &PendingRevision{Name: "rev-2"}},
+		},
+		"ProgressiveNextWave": {
+			reason: "An XR should adopt a progressive revision once every earlier wave has adopted it.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, progressive, nil)}, rolling(v1.RolloutPhaseProgressing, 1)),
+			xr:     prod(xr("stable-uid", "rev-1", "")),
+			want:   want{rev: "rev-2"},
+		},
+		"ProgressivePaused": {
+			reason: "An XR should stay on its current revision if a progressive rollout is paused.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, pause, nil)}, rolling(v1.RolloutPhasePaused, 1)),
+			xr:     prod(xr("stable-uid", "rev-1", "")),
+			want:   want{rev: "rev-1", pending: &PendingRevision{Name: "rev-2"}},
+		},
+		"NewXRHold": {
+			reason: "A new XR should start on the last approved revision if the latest revision is staged and not approved.",
+			client: mockClient([]v1.CompositionRevision{
+				rev("rev-1", 1, nil, nil),
+				rev("rev-2", 2, staged, map[string]string{v1.AnnotationKeyRolloutApproved: "true"}),
+				rev("rev-3", 3, staged, nil),
+			}, nil),
+			xr:   xr("stable-uid", "", ""),
+			want: want{rev: "rev-2", pending: &PendingRevision{Name: "rev-3"}},
+		},
+		"NewXRCanary": {
+			reason: "A new canary XR should adopt a staged revision.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, nil, nil), rev("rev-2", 2, canary, nil)}, nil),
+			xr:     xr("canary-uid", "", ""),
+			want:   want{rev: "rev-2"},
+		},
+		"NewXRNoApprovedRevision": {
+			reason: "We should return an error if a new XR may not adopt the latest revision and no earlier revision is approved.",
+			client: mockClient([]v1.CompositionRevision{rev("rev-1", 1, staged, nil)}, nil),
+			xr:     xr("stable-uid", "", ""),
+			want:   want{err: errors.Errorf(errFmtNoApprovedCompositionRevision, "rev-1")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := NewAPIRevisionFetcher(tc.client, WithStagedRollouts())
+			got, err := f.Fetch(context.Background(), tc.xr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nFetch(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.want.rev, got.GetName()); diff != "" {
+				t.Errorf("\n%s\nFetch(...): -want revision, +got revision:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.pending, getPendingRevision(tc.xr)); diff != "" {
+				t.Errorf("\n%s\nFetch(...): -want pending revision, +got pending revision:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPreviewPendingRevision(t *testing.T) {
+	errBoom := errors.New("boom")
+	then := metav1.Unix(100, 0)
+
+	type want struct {
+		pending   *PendingRevision
+		previewed bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		pending *PendingRevision
+		p       RevisionPreviewer
+		want    want
+	}{
+		"NoPendingRevision": {
+			reason: "We shouldn't preview anything if the XR has no pending revision.",
+			p: RevisionPreviewerFn(func(_ context.Context, _ *composite.Unstructured, _ *v1.CompositionRevision) (RevisionDiff, error) {
+				return RevisionDiff{}, errors.New("shouldn't be called")
+			}),
+			want: want{},
+		},
+		"AlreadyPreviewed": {
+			reason:  "We shouldn't preview a pending revision twice.",
+			pending: &PendingRevision{Name: "rev-2", ResourcesAdded: 1, PreviewTime: &then},
+			p: RevisionPreviewerFn(func(_ context.Context, _ *composite.Unstructured, _ *v1.CompositionRevision) (RevisionDiff, error) {
+				return RevisionDiff{}, errors.New("shouldn't be called")
+			}),
+			want: want{
+				pending:   &PendingRevision{Name: "rev-2", ResourcesAdded: 1},
+				previewed: true,
+			},
+		},
+		"PreviewError": {
+			reason:  "We should leave the pending revision unpreviewed if we can't preview it.",
+			pending: &PendingRevision{Name: "rev-2"},
+			p: RevisionPreviewerFn(func(_ context.Context, _ *composite.Unstructured, _ *v1.CompositionRevision) (RevisionDiff, error) {
+				return RevisionDiff{}, errBoom
+			}),
+			want: want{
+				pending: &PendingRevision{Name: "rev-2"},
+			},
+		},
+		"Previewed": {
+			reason:  "We should record what adopting the pending revision would change.",
+			pending: &PendingRevision{Name: "rev-2"},
+			p: RevisionPreviewerFn(func(_ context.Context, _ *composite.Unstructured, rev *v1.CompositionRevision) (RevisionDiff, error) {
+				if rev.GetName() != "rev-2" {
+					return RevisionDiff{}, errors.Errorf("wrong revision %q", rev.GetName())
+				}
+				return RevisionDiff{Added: 1, Removed: 2, Changed: 3}, nil
+			}),
+			want: want{
+				pending:   &PendingRevision{Name: "rev-2", ResourcesAdded: 1, ResourcesRemoved: 2, ResourcesChanged: 3},
+				previewed: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.SetName("rev-2")
+					return nil
+				}),
+			}
+			r := NewReconciler(c, schema.GroupVersionKind{}, WithRevisionPreviewer(tc.p), WithRecorder(event.NewNopRecorder()))
+
+			xr := composite.New()
+			if tc.pending != nil {
+				setPendingRevision(xr, tc.pending)
+			}
+
+			got := r.previewPendingRevision(context.Background(), xr)
+
+			if diff := cmp.Diff(tc.want.previewed, got != nil && got.PreviewTime != nil); diff != "" {
+				t.Errorf("\n%s\npreviewPendingRevision(...): -want previewed, +got previewed:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.pending, got, cmpopts.IgnoreFields(PendingRevision{}, "PreviewTime")); diff != "" {
+				t.Errorf("\n%s\npreviewPendingRevision(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	waves := []v1.RolloutWave{
+		{Name: "canary", Percentage: ptr.To[int32](10)},
+		{Name: "prod", MatchLabels: map[string]string{"env": "prod"}},
+	}
+
+	rev := func(pause bool) *v1.CompositionRevision {
+		r := &v1.CompositionRevision{Spec: v1.CompositionRevisionSpec{
+			Rollout: &v1.RevisionRollout{Strategy: v1.RolloutStrategyProgressive, Waves: waves, PauseOnFailure: pause},
+		}}
+		r.SetName("rev-2")
+		return r
+	}
+
+	// An XR with the supplied UID and labels that uses the supplied revision.
+	xr := func(uid types.UID, labels map[string]string, revName string, ready corev1.ConditionStatus) kunstructured.Unstructured {
+		x := composite.New()
+		x.SetUID(uid)
+		x.SetLabels(labels)
+		x.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: revName})
+		x.SetConditions(xpv1.Condition{Type: xpv1.TypeReady, Status: ready})
+		return x.Unstructured
+	}
+
+	prod := map[string]string{"env": "prod"}
+
+	now := time.Now()
+
+	// An XR that uses the supplied revision, and has been creating since the
+	// supplied time.
+	creating := func(uid types.UID, revName string, since time.Time) kunstructured.Unstructured {
+		x := composite.New()
+		x.SetUID(uid)
+		x.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: revName})
+		c := xpv1.Creating()
+		c.LastTransitionTime = metav1.NewTime(since)
+		x.SetConditions(c)
+		return x.Unstructured
+	}
+
+	cases := map[string]struct {
+		reason string
+		rev    *v1.CompositionRevision
+		xrs    []kunstructured.Unstructured
+		want   *v1.RolloutStatus
+	}{
+		"Progressing": {
+			reason: "A rollout should progress to the next wave once every XR in the current wave has adopted the revision.",
+			rev:    rev(false),
+			xrs: []kunstructured.Unstructured{
+				xr("canary-uid", nil, "rev-2", corev1.ConditionTrue),
+				xr("stable-uid", prod, "rev-1", corev1.ConditionTrue),
+				xr("cool-uid", nil, "rev-1", corev1.ConditionTrue),
+			},
+			want: &v1.RolloutStatus{
+				Revision:       "rev-2",
+				Phase:          v1.RolloutPhaseProgressing,
+				CurrentWave:    "prod",
+				CompletedWaves: 1,
+				Total:          3,
+				Updated:        1,
+				Ready:          1,
+			},
+		},
+		"Paused": {
+			reason: "A rollout that pauses on failure should pause if an XR that adopted the revision isn't ready.",
+			rev:    rev(true),
+			xrs: []kunstructured.Unstructured{
+				xr("canary-uid", nil, "rev-2", corev1.ConditionFalse),
+				xr("stable-uid", prod, "rev-1", corev1.ConditionTrue),
+			},
+			want: &v1.RolloutStatus{
+				Revision:    "rev-2",
+				Phase:       v1.RolloutPhasePaused,
+				CurrentWave: "canary",
+				Total:       2,
+				Updated:     1,
+			},
+		},
+		"Creating": {
+			reason: "A rollout that pauses on failure should keep progressing while an XR that adopted the revision is being created.",
+			rev:    rev(true),
+			xrs: []kunstructured.Unstructured{
+				creating("canary-uid", "rev-2", now.Add(-1*time.Minute)),
+				xr("stable-uid", prod, "rev-1", corev1.ConditionTrue),
+			},
+			want: &v1.RolloutStatus{
+				Revision:    "rev-2",
+				Phase:       v1.RolloutPhaseProgressing,
+				CurrentWave: "canary",
+				Total:       2,
+				Updated:     1,
+			},
+		},
+		"CreatingTimedOut": {
+			reason: "A rollout that pauses on failure should pause if an XR that adopted the revision is being created for longer than the failure timeout.",
+			rev:    rev(true),
+			xrs: []kunstructured.Unstructured{
+				creating("canary-uid", "rev-2", now.Add(-1*time.Hour)),
+				xr("stable-uid", prod, "rev-1", corev1.ConditionTrue),
+			},
+			want: &v1.RolloutStatus{
+				Revision:    "rev-2",
+				Phase:       v1.RolloutPhasePaused,
+				CurrentWave: "canary",
+				Total:       2,
+				Updated:     1,
+			},
+		},
+		"Complete": {
+			reason: "A rollout should be complete once every XR has adopted the revision.",
+			rev:    rev(true),
+			xrs: []kunstructured.Unstructured{
+				xr("canary-uid", nil, "rev-2", corev1.ConditionTrue),
+				xr("stable-uid", prod, "rev-2", corev1.ConditionTrue),
+				xr("cool-uid", nil, "rev-2", corev1.ConditionTrue),
+			},
+			want: &v1.RolloutStatus{
+				Revision:       "rev-2",
+				Phase:          v1.RolloutPhaseComplete,
+				CompletedWaves: 2,
+				Total:          3,
+				Updated:        3,
+				Ready:          3,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewRollout(tc.rev, tc.xrs, now).Status()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nStatus(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCanarySucceeded(t *testing.T) {
+	rev := &v1.CompositionRevision{Spec: v1.CompositionRevisionSpec{
+		Rollout: &v1.RevisionRollout{Strategy: v1.RolloutStrategyStaged, CanaryPercentage: ptr.To[int32](10)},
+	}}
+	rev.SetName("rev-2")
+
+	// An XR with the supplied UID that uses the supplied revision.
+	xr := func(uid types.UID, revName string, ready corev1.ConditionStatus) kunstructured.Unstructured {
+		x := composite.New()
+		x.SetUID(uid)
+		x.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: revName})
+		x.SetConditions(xpv1.Condition{Type: xpv1.TypeReady, Status: ready})
+		return x.Unstructured
+	}
+
+	cases := map[string]struct {
+		reason string
+		xrs    []kunstructured.Unstructured
+		want   bool
+	}{
+		"CanaryNotAdopted": {
+			reason: "A canary shouldn't succeed until every canary XR has adopted the revision.",
+			xrs: []kunstructured.Unstructured{
+				xr("canary-uid", "rev-1", corev1.ConditionTrue),
+				xr("stable-uid", "rev-1", corev1.ConditionTrue),
+			},
+			want: false,
+		},
+		"CanaryNotReady": {
+			reason: "A canary shouldn't succeed until every canary XR is ready.",
+			xrs: []kunstructured.Unstructured{
+				xr("canary-uid", "rev-2", corev1.ConditionFalse),
+				xr("stable-uid", "rev-1", corev1.ConditionTrue),
+			},
+			want: false,
+		},
+		"NoCanaries": {
+			reason: "A canary can't succeed if there are no canary XRs.",
+			xrs: []kunstructured.Unstructured{
+				xr("stable-uid", "rev-1", corev1.ConditionTrue),
+			},
+			want: false,
+		},
+		"Succeeded": {
+			reason: "A canary should succeed once every canary XR has adopted the revision and is ready.",
+			xrs: []kunstructured.Unstructured{
+				xr("canary-uid", "rev-2", corev1.ConditionTrue),
+				xr("stable-uid", "rev-1", corev1.ConditionTrue),
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CanarySucceeded(rev, tc.xrs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCanarySucceeded(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
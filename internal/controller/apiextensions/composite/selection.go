@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xcel"
+)
+
+// Variables that may be used in a composition selection expression.
+const (
+	// SelectionVarSpec is the composite resource's spec.
+	SelectionVarSpec = "spec"
+
+	// SelectionVarMetadata is the composite resource's name, namespace, and
+	// labels. Namespace is empty for cluster scoped composite resources.
+	SelectionVarMetadata = "metadata"
+)
+
+const (
+	errEvalSelection          = "cannot evaluate selection expression"
+	errConvertComposite       = "cannot convert composite resource to unstructured"
+	errNoWeightedComposition  = "no compatible Compositions with a non-zero selection weight found"
+	errFmtSelectionExpression = "cannot select Composition %q"
+)
+
+var selections = xcel.NewEvaluator([]cel.EnvOption{ //nolint:gochecknoglobals // We want to share compiled expressions.
+	cel.Variable(SelectionVarSpec, cel.MapType(cel.StringType, cel.DynType)),
+	cel.Variable(SelectionVarMetadata, cel.MapType(cel.StringType, cel.DynType)),
+})
+
+// SelectionMatches returns true if the supplied composite resource matches
+// the supplied composition selection policy. A composite resource matches a
+// policy if the policy's expression evaluates to true. The expression may
+// refer to the composite resource's spec and metadata. CEL reserves the
+// namespace identifier, so the composite resource's namespace is
+// metadata.namespace. A nil policy,
+// or one without an expression, matches every composite resource.
+func SelectionMatches(p *v1.CompositionSelectionPolicy, xr resource.Composite) (bool, error) {
+	if p == nil || p.Expression == "" {
+		return true, nil
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(xr)
+	if err != nil {
+		return false, errors.Wrap(err, errConvertComposite)
+	}
+
+	spec, _ := u["spec"].(map[string]any)
+	if spec == nil {
+		spec = map[string]any{}
+	}
+
+	labels := xr.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	ok, err := selections.EvalBool(p.Expression, map[string]any{
+		SelectionVarSpec: spec,
+		SelectionVarMetadata: map[string]any{
+			"name":      xr.GetName(),
+			"namespace": xr.GetNamespace(),
+			"labels":    labels,
+		},
+	})
+
+	return ok, errors.Wrap(err, errEvalSelection)
+}
+
+// selectionWeight returns the selection weight of the supplied composition.
+func selectionWeight(comp *v1.Composition) int64 {
+	if comp.Spec.Selection == nil {
+		return 1
+	}
+
+	return int64(ptr.Deref(comp.Spec.Selection.Weight, 1))
+}
+
+// selectWeighted selects one of the supplied compositions at random, weighted
+// by their selection weight. It returns the selected composition and why it
+// was selected.
+func selectWeighted(random *rand.Rand, candidates []v1.Composition) (*v1.Composition, string, error) {
+	var total int64
+	for i := range candidates {
+		total += selectionWeight(&candidates[i])
+	}
+
+	if total == 0 {
+		return nil, "", errors.New(errNoWeightedComposition)
+	}
+
+	n := random.Int63n(total)
+
+	for i := range candidates {
+		comp := &candidates[i]
+
+		w := selectionWeight(comp)
+		if n < w {
+			return comp, fmt.Sprintf("selected with weight %d of %d from %d compositions that match the composite resource", w, total, len(candidates)), nil
+		}
+
+		n -= w
+	}
+
+	// Unreachable, since n < total.
+	return nil, "", errors.New(errNoWeightedComposition)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+func TestSelectionMatches(t *testing.T) {
+	xr := composite.New()
+	xr.SetNamespace("default")
+	xr.SetLabels(map[string]string{"tier": "gold"})
+	xr.Object["spec"] = map[string]any{"region": "eu-west-1", "replicas": int64(3)}
+
+	type want struct {
+		ok  bool
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      *v1.CompositionSelectionPolicy
+		want   want
+	}{
+		"NilPolicy": {
+			reason: "A nil policy should match every composite resource.",
+			want:   want{ok: true},
+		},
+		"NoExpression": {
+			reason: "A policy without an expression should match every composite resource.",
+			p:      &v1.CompositionSelectionPolicy{},
+			want:   want{ok: true},
+		},
+		"SpecMatches": {
+			reason: "An expression may refer to the composite resource's spec.",
+			p:      &v1.CompositionSelectionPolicy{Expression: "spec.region.startsWith('eu-') && spec.replicas > 1"},
+			want:   want{ok: true},
+		},
+		"LabelsAndNamespaceMatch": {
+			reason: "An expression may refer to the composite resource's metadata.",
+			p:      &v1.CompositionSelectionPolicy{Expression: "metadata.labels['tier'] == 'gold' && metadata.namespace == 'default'"},
+			want:   want{ok: true},
+		},
+		"NoMatch": {
+			reason: "A composite resource shouldn't match an expression that evaluates to false.",
+			p:      &v1.CompositionSelectionPolicy{Expression: "has(spec.size) || spec.region.startsWith('us-')"},
+			want:   want{ok: false},
+		},
+		"NotBool": {
+			reason: "An expression that doesn't evaluate to a bool should return an error.",
+			p:      &v1.CompositionSelectionPolicy{Expression: "spec.region"},
+			want:   want{err: errors.Wrap(errors.New("expression must evaluate to a bool"), errEvalSelection)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ok, err := SelectionMatches(tc.p, xr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSelectionMatches(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nSelectionMatches(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
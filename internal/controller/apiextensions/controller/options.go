@@ -18,6 +18,8 @@ limitations under the License.
 package controller
 
 import (
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 
 	"github.com/crossplane/crossplane/v2/internal/engine"
@@ -33,4 +35,11 @@ type Options struct {
 
 	// FunctionRunner used to run Composition Functions.
 	FunctionRunner xfn.FunctionRunner
+
+	// Namespace Crossplane is running in.
+	Namespace string
+
+	// ConversionWebhook is how the API server calls Crossplane's composite
+	// resource conversion webhook. It's nil if the webhook isn't served.
+	ConversionWebhook *extv1.WebhookClientConfig
 }
@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package definition
+
+import (
+	"context"
+	"sync"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+)
+
+const (
+	errFmtGetMigrationCRD = "cannot get CustomResourceDefinition %q"
+	errFmtListMigrateCRs  = "cannot list %s to migrate them to storage version %s"
+	errFmtMigrateCR       = "cannot migrate %s %q to storage version %s"
+	errFmtPruneStored     = "cannot prune stored versions of CustomResourceDefinition %q"
+)
+
+// DefaultMigrationPageSize is the number of custom resources a
+// StorageVersionMigrator migrates each time it's called.
+const DefaultMigrationPageSize = 500
+
+// A MigrationStatus reports the progress of a storage version migration.
+type MigrationStatus struct {
+	// Done is true once every custom resource is stored at the storage
+	// version, and older versions were pruned from the CRD's stored versions.
+	Done bool
+
+	// StorageVersion the custom resources are being migrated to.
+	StorageVersion string
+
+	// Migrated is the number of custom resources migrated so far.
+	Migrated int
+}
+
+// A StorageVersionMigrator migrates the custom resources a CRD defines to its
+// storage version.
+type StorageVersionMigrator interface {
+	// MigrateStorageVersion migrates some or all custom resources of the
+	// named CRD to its storage version. It's called repeatedly until the
+	// migration is done.
+	MigrateStorageVersion(ctx context.Context, crdName string) (MigrationStatus, error)
+}
+
+// A StorageVersionMigratorFn migrates the custom resources a CRD defines to
+// its storage version.
+type StorageVersionMigratorFn func(ctx context.Context, crdName string) (MigrationStatus, error)
+
+// MigrateStorageVersion migrates some or all custom resources of the named
+// CRD to its storage version.
+func (fn StorageVersionMigratorFn) MigrateStorageVersion(ctx context.Context, crdName string) (MigrationStatus, error) {
+	return fn(ctx, crdName)
+}
+
+type migration struct {
+	storageVersion string
+	continueToken  string
+	migrated       int
+}
+
+// An APIStorageVersionMigrator migrates custom resources to their CRD's
+// storage version by rewriting them one page at a time, the same way
+// Crossplane migrates its own CRDs when it's upgraded. Once every custom
+// resource is rewritten it prunes all other versions from the CRD's stored
+// versions.
+type APIStorageVersionMigrator struct {
+	client   client.Client
+	pageSize int64
+
+	mu         sync.Mutex
+	migrations map[string]*migration
+}
+
+// An APIStorageVersionMigratorOption configures an APIStorageVersionMigrator.
+type APIStorageVersionMigratorOption func(m *APIStorageVersionMigrator)
+
+// WithMigrationPageSize configures how many custom resources an
+// APIStorageVersionMigrator migrates each time it's called.
+func WithMigrationPageSize(n int64) APIStorageVersionMigratorOption {
+	return func(m *APIStorageVersionMigrator) {
+		m.pageSize = n
+	}
+}
+
+// NewAPIStorageVersionMigrator returns a StorageVersionMigrator that migrates
+// custom resources using the supplied client.
+func NewAPIStorageVersionMigrator(c client.Client, o ...APIStorageVersionMigratorOption) *APIStorageVersionMigrator {
+	m := &APIStorageVersionMigrator{
+		client:     c,
+		pageSize:   DefaultMigrationPageSize,
+		migrations: make(map[string]*migration),
+	}
+
+	for _, fn := range o {
+		fn(m)
+	}
+
+	return m
+}
+
+// MigrateStorageVersion migrates a page of custom resources of the named CRD
+// to its storage version. It remembers where it got to, and continues from
+// there the next time it's called.
+func (m *APIStorageVersionMigrator) MigrateStorageVersion(ctx context.Context, crdName string) (MigrationStatus, error) {
+	crd := &extv1.CustomResourceDefinition{}
+	if err := m.client.Get(ctx, types.NamespacedName{Name: crdName}, crd); err != nil {
+		// There's nothing to migrate if the CRD doesn't exist (yet).
+		if kerrors.IsNotFound(err) {
+			return MigrationStatus{Done: true}, nil
+		}
+
+		return MigrationStatus{}, errors.Wrapf(err, errFmtGetMigrationCRD, crdName)
+	}
+
+	storage := StorageVersion(crd)
+	if !NeedsMigration(crd) {
+		m.forget(crdName)
+		return MigrationStatus{Done: true, StorageVersion: storage}, nil
+	}
+
+	mg := m.get(crdName, storage)
+
+	l := &kunstructured.UnstructuredList{}
+	l.SetGroupVersionKind(schema.GroupVersionKind{Group: crd.Spec.Group, Version: storage, Kind: crd.Spec.Names.ListKind})
+
+	if err := m.client.List(ctx, l, client.Limit(m.pageSize), client.Continue(mg.continueToken)); err != nil {
+		// Our continue token expired. Start again from the beginning.
+		if kerrors.IsResourceExpired(err) {
+			m.forget(crdName)
+		}
+
+		return MigrationStatus{StorageVersion: storage, Migrated: mg.migrated}, errors.Wrapf(err, errFmtListMigrateCRs, crd.Spec.Names.Plural, storage)
+	}
+
+	for i := range l.Items {
+		// An empty patch rewrites the custom resource at the storage version.
+		cr := &l.Items[i]
+		if err := m.client.Patch(ctx, cr, client.RawPatch(types.MergePatchType, []byte(`{}`))); resource.IgnoreNotFound(err) != nil {
+			return MigrationStatus{StorageVersion: storage, Migrated: mg.migrated}, errors.Wrapf(err, errFmtMigrateCR, crd.Spec.Names.Kind, cr.GetName(), storage)
+		}
+
+		mg.migrated++
+	}
+
+	mg.continueToken = l.GetContinue()
+	if mg.continueToken != "" {
+		return MigrationStatus{StorageVersion: storage, Migrated: mg.migrated}, nil
+	}
+
+	orig := crd.DeepCopy()
+	crd.Status.StoredVersions = []string{storage}
+
+	if err := m.client.Status().Patch(ctx, crd, client.MergeFrom(orig)); err != nil {
+		// Start again from the beginning, in case anything was written at
+		// an old version while we were migrating.
+		m.forget(crdName)
+		return MigrationStatus{StorageVersion: storage, Migrated: mg.migrated}, errors.Wrapf(err, errFmtPruneStored, crdName)
+	}
+
+	m.forget(crdName)
+
+	return MigrationStatus{Done: true, StorageVersion: storage, Migrated: mg.migrated}, nil
+}
+
+// get returns the named CRD's migration to the supplied storage version. It
+// starts a new migration if the storage version changed since the last call,
+// because the custom resources we already migrated are stored at the old one.
+func (m *APIStorageVersionMigrator) get(crdName, storage string) *migration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mg, ok := m.migrations[crdName]
+	if !ok || mg.storageVersion != storage {
+		mg = &migration{storageVersion: storage}
+		m.migrations[crdName] = mg
+	}
+
+	return mg
+}
+
+func (m *APIStorageVersionMigrator) forget(crdName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.migrations, crdName)
+}
+
+// StorageVersion returns the storage version of the supplied CRD.
+func StorageVersion(crd *extv1.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+
+	return ""
+}
+
+// NeedsMigration returns true if any custom resources of the supplied CRD may
+// be stored at a version other than its storage version.
+func NeedsMigration(crd *extv1.CustomResourceDefinition) bool {
+	storage := StorageVersion(crd)
+	for _, v := range crd.Status.StoredVersions {
+		if v != storage {
+			return true
+		}
+	}
+
+	return false
+}
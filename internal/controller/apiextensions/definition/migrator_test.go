@@ -0,0 +1,265 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package definition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+)
+
+func TestMigrateStorageVersion(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	crdAt := func(storage string, stored ...string) *extv1.CustomResourceDefinition {
+		c := &extv1.CustomResourceDefinition{
+			Spec: extv1.CustomResourceDefinitionSpec{
+				Group: "example.org",
+				Names: extv1.CustomResourceDefinitionNames{Kind: "XDatabase", ListKind: "XDatabaseList", Plural: "xdatabases"},
+				Versions: []extv1.CustomResourceDefinitionVersion{
+					{Name: "v1", Storage: storage == "v1"},
+					{Name: "v2", Storage: storage == "v2"},
+					{Name: "v3", Storage: storage == "v3"},
+				},
+			},
+			Status: extv1.CustomResourceDefinitionStatus{StoredVersions: stored},
+		}
+		c.SetName("xdatabases.example.org")
+
+		return c
+	}
+
+	crd := func(stored ...string) *extv1.CustomResourceDefinition {
+		return crdAt("v2", stored...)
+	}
+
+	get := func(c *extv1.CustomResourceDefinition) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			c.DeepCopyInto(obj.(*extv1.CustomResourceDefinition))
+			return nil
+		}
+	}
+
+	list := func(continueToken string, names ...string) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			l := obj.(*unstructured.UnstructuredList)
+			for _, n := range names {
+				u := unstructured.Unstructured{}
+				u.SetName(n)
+				l.Items = append(l.Items, u)
+			}
+			l.SetContinue(continueToken)
+
+			return nil
+		}
+	}
+
+	type args struct {
+		client client.Client
+		calls  int
+	}
+
+	type want struct {
+		ms  MigrationStatus
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"CRDNotFound": {
+			reason: "We should be done if the CRD doesn't exist.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				},
+				calls: 1,
+			},
+			want: want{
+				ms: MigrationStatus{Done: true},
+			},
+		},
+		"GetCRDError": {
+			reason: "We should return any error encountered getting the CRD.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				calls: 1,
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtGetMigrationCRD, "xdatabases.example.org"),
+			},
+		},
+		"NothingToMigrate": {
+			reason: "We should be done if the CRD only stores its storage version.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: get(crd("v2")),
+				},
+				calls: 1,
+			},
+			want: want{
+				ms: MigrationStatus{Done: true, StorageVersion: "v2"},
+			},
+		},
+		"ListError": {
+			reason: "We should return any error encountered listing custom resources.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  get(crd("v1", "v2")),
+					MockList: test.NewMockListFn(errBoom),
+				},
+				calls: 1,
+			},
+			want: want{
+				ms:  MigrationStatus{StorageVersion: "v2"},
+				err: errors.Wrapf(errBoom, errFmtListMigrateCRs, "xdatabases", "v2"),
+			},
+		},
+		"PatchError": {
+			reason: "We should return any error encountered migrating a custom resource.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:   get(crd("v1", "v2")),
+					MockList:  list("", "a"),
+					MockPatch: test.NewMockPatchFn(errBoom),
+				},
+				calls: 1,
+			},
+			want: want{
+				ms:  MigrationStatus{StorageVersion: "v2"},
+				err: errors.Wrapf(errBoom, errFmtMigrateCR, "XDatabase", "a", "v2"),
+			},
+		},
+		"MorePages": {
+			reason: "We shouldn't be done if there are more pages of custom resources to migrate.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:   get(crd("v1", "v2")),
+					MockList:  list("more", "a", "b"),
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				calls: 2,
+			},
+			want: want{
+				ms: MigrationStatus{StorageVersion: "v2", Migrated: 4},
+			},
+		},
+		"StorageVersionChanged": {
+			reason: "We should start migrating from the beginning if the storage version changes during a migration.",
+			args: args{
+				client: &test.MockClient{
+					// The first call migrates to v2, the second to v3.
+					MockGet: func() test.MockGetFn {
+						crds := []*extv1.CustomResourceDefinition{crdAt("v2", "v1", "v2"), crdAt("v3", "v1", "v2", "v3")}
+						return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+							crds[0].DeepCopyInto(obj.(*extv1.CustomResourceDefinition))
+							crds = crds[1:]
+							return nil
+						}
+					}(),
+					MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						l := obj.(*unstructured.UnstructuredList)
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+
+						// We should never continue the v2 migration at v3.
+						if l.GroupVersionKind().Version == "v3" && lo.Continue != "" {
+							t.Errorf("List(...): want no continue token at storage version v3, got %q", lo.Continue)
+						}
+
+						return list("more", "a", "b")(context.Background(), obj, opts...)
+					},
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				calls: 2,
+			},
+			want: want{
+				ms: MigrationStatus{StorageVersion: "v3", Migrated: 2},
+			},
+		},
+		"PruneError": {
+			reason: "We should return any error encountered pruning the CRD's stored versions.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:         get(crd("v1", "v2")),
+					MockList:        list("", "a"),
+					MockPatch:       test.NewMockPatchFn(nil),
+					MockStatusPatch: test.NewMockSubResourcePatchFn(errBoom),
+				},
+				calls: 1,
+			},
+			want: want{
+				ms:  MigrationStatus{StorageVersion: "v2", Migrated: 1},
+				err: errors.Wrapf(errBoom, errFmtPruneStored, "xdatabases.example.org"),
+			},
+		},
+		"Migrated": {
+			reason: "We should prune the CRD's stored versions once every custom resource is migrated.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:   get(crd("v1", "v2")),
+					MockList:  list("", "a", "b"),
+					MockPatch: test.NewMockPatchFn(nil),
+					MockStatusPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						got := obj.(*extv1.CustomResourceDefinition).Status.StoredVersions
+						if diff := cmp.Diff([]string{"v2"}, got); diff != "" {
+							t.Errorf("StoredVersions: -want, +got:\n%s", diff)
+						}
+						return nil
+					},
+				},
+				calls: 1,
+			},
+			want: want{
+				ms: MigrationStatus{Done: true, StorageVersion: "v2", Migrated: 2},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := NewAPIStorageVersionMigrator(tc.args.client)
+
+			var ms MigrationStatus
+			var err error
+			for range tc.args.calls {
+				ms, err = m.MigrateStorageVersion(context.Background(), "xdatabases.example.org")
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nMigrateStorageVersion(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.ms, ms); diff != "" {
+				t.Errorf("\n%s\nMigrateStorageVersion(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -53,8 +53,10 @@ import (
 	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
 	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite/watch"
 	apiextensionscontroller "github.com/crossplane/crossplane/v2/internal/controller/apiextensions/controller"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/preview"
 	"github.com/crossplane/crossplane/v2/internal/engine"
 	"github.com/crossplane/crossplane/v2/internal/features"
+	"github.com/crossplane/crossplane/v2/internal/tracing"
 	"github.com/crossplane/crossplane/v2/internal/xcrd"
 )
 
@@ -62,6 +64,9 @@ const (
 	timeout   = 2 * time.Minute
 	finalizer = "defined.apiextensions.crossplane.io"
 
+	// How long to wait between batches of a storage version migration.
+	migrationInterval = 5 * time.Second
+
 	errGetXRD                         = "cannot get CompositeResourceDefinition"
 	errRenderCRD                      = "cannot render composite resource CustomResourceDefinition"
 	errGetCRD                         = "cannot get composite resource CustomResourceDefinition"
@@ -78,6 +83,7 @@ const (
 	errDeleteCRs                      = "cannot delete defined composite resources"
 	errListCRDs                       = "cannot list CustomResourceDefinitions"
 	errCannotAddInformerLoopToManager = "cannot add resources informer loop to manager"
+	errMigrateStorageVersion          = "cannot migrate composite resources to the referenceable version"
 )
 
 // Wait strings.
@@ -91,6 +97,7 @@ const (
 	reasonRenderCRD   event.Reason = "RenderCRD"
 	reasonEstablishXR event.Reason = "EstablishComposite"
 	reasonTerminateXR event.Reason = "TerminateComposite"
+	reasonMigrateXR   event.Reason = "MigrateStorageVersion"
 )
 
 // A ControllerEngine can start and stop Kubernetes controllers on demand.
@@ -175,11 +182,32 @@ func (fn CRDRenderFn) Render(d *v1.CompositeResourceDefinition) (*extv1.CustomRe
 func Setup(mgr ctrl.Manager, o apiextensionscontroller.Options) error {
 	name := "defined/" + strings.ToLower(v1.CompositeResourceDefinitionGroupKind)
 
-	r := NewReconciler(NewClientApplicator(mgr.GetClient()),
+	ro := []ReconcilerOption{
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		WithControllerEngine(o.ControllerEngine),
-		WithOptions(o))
+		WithOptions(o),
+	}
+
+	var co []xcrd.CRDOption
+
+	// Generated CRDs use Crossplane's conversion webhook if their XRD
+	// configures a converter.
+	if o.Features.Enabled(features.EnableAlphaCompositeResourceConversion) && o.ConversionWebhook != nil {
+		co = append(co, xcrd.WithConversionWebhook(*o.ConversionWebhook))
+	}
+
+	if o.Features.Enabled(features.EnableAlphaCompositeResourceValidationRules) {
+		co = append(co, xcrd.WithValidationRules(), xcrd.WithImmutableFields())
+	}
+
+	if len(co) > 0 {
+		ro = append(ro, WithCRDRenderer(CRDRenderFn(func(d *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+			return xcrd.ForCompositeResource(d, co...)
+		})))
+	}
+
+	r := NewReconciler(NewClientApplicator(mgr.GetClient()), ro...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -238,6 +266,14 @@ func WithCRDRenderer(c CRDRenderer) ReconcilerOption {
 	}
 }
 
+// WithStorageVersionMigrator specifies how the Reconciler should migrate
+// composite resources and claims to the referenceable version.
+func WithStorageVersionMigrator(m StorageVersionMigrator) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.migrator = m
+	}
+}
+
 type definition struct {
 	CRDRenderer
 	resource.Finalizer
@@ -256,11 +292,14 @@ func NewReconciler(ca resource.ClientApplicator, opts ...ReconcilerOption) *Reco
 		client: ca,
 
 		composite: definition{
-			CRDRenderer: CRDRenderFn(xcrd.ForCompositeResource),
-			Finalizer:   resource.NewAPIFinalizer(ca, finalizer),
+			CRDRenderer: CRDRenderFn(func(d *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+				return xcrd.ForCompositeResource(d)
+			}),
+			Finalizer: resource.NewAPIFinalizer(ca, finalizer),
 		},
 
-		engine: &NopEngine{},
+		engine:   &NopEngine{},
+		migrator: NewAPIStorageVersionMigrator(ca),
 
 		log:        logging.NewNopLogger(),
 		record:     event.NewNopRecorder(),
@@ -288,7 +327,8 @@ type Reconciler struct {
 
 	composite definition
 
-	engine ControllerEngine
+	engine   ControllerEngine
+	migrator StorageVersionMigrator
 
 	log        logging.Logger
 	record     event.Recorder
@@ -510,18 +550,57 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			"desired-version", desired.APIVersion)
 	}
 
+	// Composite resources and claims may be stored at a version other than
+	// the referenceable version, e.g. because the referenceable version
+	// changed. We migrate them while the controller runs, requeueing until
+	// the migration is done.
+	result := reconcile.Result{}
+	if r.options.Features.Enabled(features.EnableAlphaStorageVersionMigration) {
+		result = r.migrateStorageVersion(ctx, d, status, crd.GetName(), log)
+	}
+
 	if r.engine.IsRunning(composite.ControllerName(d.GetName())) {
 		log.Debug("Composite resource controller is running")
 		status.MarkConditions(v1.WatchingComposite())
 
-		return reconcile.Result{Requeue: false}, errors.Wrap(r.client.Status().Update(ctx, d), errUpdateStatus)
+		return result, errors.Wrap(r.client.Status().Update(ctx, d), errUpdateStatus)
 	}
 
 	fetcher := composite.NewSecretConnectionDetailsFetcher(r.engine.GetCached())
-	fc := composite.NewFunctionComposer(r.engine.GetCached(), r.engine.GetUncached(), r.options.FunctionRunner,
-		composite.WithComposedResourceObserver(composite.NewExistingComposedResourceObserver(r.engine.GetCached(), r.engine.GetUncached(), fetcher)),
+	observer := composite.NewExistingComposedResourceObserver(r.engine.GetCached(), r.engine.GetUncached(), fetcher)
+	fco := []composite.FunctionComposerOption{
+		composite.WithComposedResourceObserver(observer),
 		composite.WithCompositeConnectionDetailsFetcher(fetcher),
-	)
+	}
+
+	// Traces of cluster scoped XRs are written to Crossplane's namespace.
+	if r.options.Features.Enabled(features.EnableAlphaPipelineTraces) {
+		fco = append(fco, composite.WithPipelineTraceWriter(composite.NewConfigMapPipelineTraceWriter(r.engine.GetUncached(), r.options.Namespace)))
+	}
+
+	if r.options.Features.Enabled(features.EnableAlphaReadinessChecks) {
+		fco = append(fco, composite.WithReadinessChecker(composite.ReadinessCheckerFn(composite.IsReady)))
+	}
+
+	if r.options.Features.Enabled(features.EnableAlphaComposedResourceOrdering) {
+		fco = append(fco, composite.WithComposedResourceOrdering())
+	}
+
+	if r.options.Features.Enabled(features.EnableAlphaComposedResourceDeletePolicies) {
+		fco = append(fco,
+			composite.WithComposedResourceDeletePolicies(),
+			composite.WithComposedResourceGarbageCollector(composite.NewDeletingComposedResourceGarbageCollector(r.engine.GetCached(), composite.WithComposedDeletePolicies())),
+		)
+	}
+
+	// Previews compose with the same credentials as the XR controller.
+	var pco []composite.FunctionComposerOption
+	if r.options.Features.Enabled(features.EnableAlphaFunctionServiceAccountTokens) {
+		fco = append(fco, composite.WithServiceAccountTokenCredentials())
+		pco = append(pco, composite.WithServiceAccountTokenCredentials())
+	}
+
+	fc := composite.NewFunctionComposer(r.engine.GetCached(), r.engine.GetUncached(), r.options.FunctionRunner, fco...)
 
 	// All XRs have modern schema unless their XRD's scope is LegacyCluster.
 	schema := ucomposite.SchemaModern
@@ -529,12 +608,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		schema = ucomposite.SchemaLegacy
 	}
 
+	lso := []composite.APILabelSelectorResolverOption{composite.WithSelectionRecorder(r.record)}
+	if r.options.Features.Enabled(features.EnableAlphaCompositionSelectionPolicies) {
+		lso = append(lso, composite.WithSelectionPolicies())
+	}
+
 	ro := []composite.ReconcilerOption{
 		composite.WithCompositeSchema(schema),
 		composite.WithCompositionSelector(composite.NewCompositionSelectorChain(
 			composite.NewEnforcedCompositionSelector(r.client, corev1.ObjectReference{Name: d.Name}, r.record),
 			composite.NewAPIDefaultCompositionSelector(r.engine.GetCached(), *meta.ReferenceTo(d, v1.CompositeResourceDefinitionGroupVersionKind), r.record),
-			composite.NewAPILabelSelectorResolver(r.engine.GetCached()),
+			composite.NewAPILabelSelectorResolver(r.engine.GetCached(), lso...),
 		)),
 		composite.WithLogger(r.log.WithValues("controller", composite.ControllerName(d.GetName()))),
 		composite.WithRecorder(r.record.WithAnnotations("controller", composite.ControllerName(d.GetName()))),
@@ -543,6 +627,34 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		composite.WithFeatures(r.options.Features),
 	}
 
+	// Staged rollouts preview what adopting a pending revision would change
+	// by composing the XR with a dry-run client.
+	if r.options.Features.Enabled(features.EnableAlphaStagedRollouts) {
+		uncached := r.engine.GetUncached()
+		ro = append(ro,
+			composite.WithCompositionRevisionFetcher(composite.NewAPIRevisionFetcher(r.engine.GetCached(), composite.WithStagedRollouts())),
+			composite.WithRevisionPreviewer(preview.NewRevisionPreviewer(uncached, func(c client.Client) composite.Composer {
+				return composite.NewFunctionComposer(c, uncached, r.options.FunctionRunner, pco...)
+			})),
+		)
+	}
+
+	// Composed resources are orphaned according to their delete policies,
+	// then those that others depend on are deleted in order, before the XR's
+	// finalizer is removed.
+	var deleters composite.ComposedResourceDeleterChain
+	if r.options.Features.Enabled(features.EnableAlphaComposedResourceDeletePolicies) {
+		deleters = append(deleters, composite.NewOrphaningComposedResourceDeleter(observer, r.engine.GetCached()))
+	}
+
+	if r.options.Features.Enabled(features.EnableAlphaComposedResourceOrdering) {
+		deleters = append(deleters, composite.NewOrderedComposedResourceDeleter(observer, r.engine.GetCached()))
+	}
+
+	if len(deleters) > 0 {
+		ro = append(ro, composite.WithComposedResourceDeleter(deleters))
+	}
+
 	if schema == ucomposite.SchemaLegacy {
 		ro = append(ro,
 			composite.WithConnectionPublishers(composite.NewAPIFilteredSecretPublisher(r.engine.GetCached(), d.GetConnectionSecretKeys())),
@@ -580,7 +692,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	// for composed resources to become ready, and we don't want to back off as
 	// far as 60 seconds. Instead we cap the XR reconciler at 30 seconds.
 	ko.RateLimiter = workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](1*time.Second, 30*time.Second)
-	ko.Reconciler = ratelimiter.NewReconciler(composite.ControllerName(d.GetName()), errors.WithSilentRequeueOnConflict(cr), r.options.GlobalRateLimiter)
+	ko.Reconciler = ratelimiter.NewReconciler(composite.ControllerName(d.GetName()), errors.WithSilentRequeueOnConflict(tracing.NewReconciler(composite.ControllerName(d.GetName()), cr)), r.options.GlobalRateLimiter)
 
 	gvk := d.GetCompositeGroupVersionKind()
 	name := composite.ControllerName(d.GetName())
@@ -628,5 +740,44 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	d.Status.Controllers.CompositeResourceTypeRef = v1.TypeReferenceTo(d.GetCompositeGroupVersionKind())
 	status.MarkConditions(v1.WatchingComposite())
 
-	return reconcile.Result{Requeue: false}, errors.Wrap(r.client.Status().Update(ctx, d), errUpdateStatus)
+	return result, errors.Wrap(r.client.Status().Update(ctx, d), errUpdateStatus)
+}
+
+// migrateStorageVersion migrates the XRD's composite resources, then its
+// claims, to the referenceable version. It returns a result that requeues the
+// XRD until they're all migrated. Migration is paced at a fixed interval,
+// rather than by the rate limiter's backoff, which would soon slow it to one
+// batch a minute. Failures are retried with backoff.
+func (r *Reconciler) migrateStorageVersion(ctx context.Context, d *v1.CompositeResourceDefinition, status conditions.ConditionSet, crdName string, log logging.Logger) reconcile.Result {
+	names := []string{crdName}
+	if d.OffersClaim() {
+		names = append(names, d.Spec.ClaimNames.Plural+"."+d.Spec.Group)
+	}
+
+	for _, name := range names {
+		ms, err := r.migrator.MigrateStorageVersion(ctx, name)
+		if err != nil {
+			log.Debug(errMigrateStorageVersion, "error", err, "crd", name)
+			err = errors.Wrap(err, errMigrateStorageVersion)
+			r.record.Event(d, event.Warning(reasonMigrateXR, err))
+			status.MarkConditions(v1.MigrationFailed(err))
+
+			return reconcile.Result{Requeue: true}
+		}
+
+		if !ms.Done {
+			log.Debug("Migrating custom resources to storage version", "crd", name, "storage-version", ms.StorageVersion, "migrated", ms.Migrated)
+			status.MarkConditions(v1.MigratingStorageVersion(fmt.Sprintf("Migrated %d %s to storage version %s so far", ms.Migrated, name, ms.StorageVersion)))
+
+			return reconcile.Result{RequeueAfter: migrationInterval}
+		}
+
+		if ms.Migrated > 0 {
+			r.record.Event(d, event.Normal(reasonMigrateXR, fmt.Sprintf("Migrated %d %s to storage version %s", ms.Migrated, name, ms.StorageVersion)))
+		}
+	}
+
+	status.MarkConditions(v1.StorageVersionMigrated())
+
+	return reconcile.Result{}
 }
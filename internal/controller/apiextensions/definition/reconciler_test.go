@@ -30,12 +30,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 
 	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	apiextensionscontroller "github.com/crossplane/crossplane/v2/internal/controller/apiextensions/controller"
 	"github.com/crossplane/crossplane/v2/internal/engine"
+	"github.com/crossplane/crossplane/v2/internal/features"
 )
 
 var (
@@ -903,6 +907,61 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{Requeue: false},
 			},
 		},
+		"MigratingStorageVersion": {
+			reason: "We should requeue and report progress while we migrate composite resources to the referenceable version.",
+			args: args{
+				ca: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil),
+						MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(o client.Object) error {
+							want := &v1.CompositeResourceDefinition{}
+							want.Status.SetConditions(
+								v1.MigratingStorageVersion("Migrated 500 coolcomposites.example.org to storage version v2 so far"),
+								v1.WatchingComposite(),
+							)
+
+							if diff := cmp.Diff(want, o, test.EquateConditions()); diff != "" {
+								t.Errorf("-want, +got:\n%s", diff)
+							}
+							return nil
+						}),
+					},
+					Applicator: resource.ApplyFn(func(_ context.Context, _ client.Object, _ ...resource.ApplyOption) error {
+						return nil
+					}),
+				},
+				opts: []ReconcilerOption{
+					WithOptions(apiextensionscontroller.Options{Options: controller.Options{Features: func() *feature.Flags {
+						f := &feature.Flags{}
+						f.Enable(features.EnableAlphaStorageVersionMigration)
+						return f
+					}()}}),
+					WithCRDRenderer(CRDRenderFn(func(_ *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+						crd := &extv1.CustomResourceDefinition{
+							Status: extv1.CustomResourceDefinitionStatus{
+								Conditions: []extv1.CustomResourceDefinitionCondition{
+									{Type: extv1.Established, Status: extv1.ConditionTrue},
+								},
+							},
+						}
+						crd.SetName("coolcomposites.example.org")
+						return crd, nil
+					})),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+					WithStorageVersionMigrator(StorageVersionMigratorFn(func(_ context.Context, _ string) (MigrationStatus, error) {
+						return MigrationStatus{StorageVersion: "v2", Migrated: 500}, nil
+					})),
+					WithControllerEngine(&MockEngine{
+						MockIsRunning: func(_ string) bool { return true },
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: migrationInterval},
+			},
+		},
 	}
 
 	for name, tc := range cases {
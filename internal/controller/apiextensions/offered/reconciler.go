@@ -144,11 +144,32 @@ func (fn CRDRenderFn) Render(d *v1.CompositeResourceDefinition) (*extv1.CustomRe
 func Setup(mgr ctrl.Manager, o apiextensionscontroller.Options) error {
 	name := "offered/" + strings.ToLower(v1.CompositeResourceDefinitionGroupKind)
 
-	r := NewReconciler(NewClientApplicator(mgr.GetClient()),
+	ro := []ReconcilerOption{
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		WithControllerEngine(o.ControllerEngine),
-		WithOptions(o))
+		WithOptions(o),
+	}
+
+	var co []xcrd.CRDOption
+
+	// Generated CRDs use Crossplane's conversion webhook if their XRD
+	// configures a converter.
+	if o.Features.Enabled(features.EnableAlphaCompositeResourceConversion) && o.ConversionWebhook != nil {
+		co = append(co, xcrd.WithConversionWebhook(*o.ConversionWebhook))
+	}
+
+	if o.Features.Enabled(features.EnableAlphaCompositeResourceValidationRules) {
+		co = append(co, xcrd.WithImmutableFields())
+	}
+
+	if len(co) > 0 {
+		ro = append(ro, WithCRDRenderer(CRDRenderFn(func(d *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+			return xcrd.ForCompositeResourceClaim(d, co...)
+		})))
+	}
+
+	r := NewReconciler(NewClientApplicator(mgr.GetClient()), ro...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -220,8 +241,10 @@ func NewReconciler(ca resource.ClientApplicator, opts ...ReconcilerOption) *Reco
 		client: ca,
 
 		claim: definition{
-			CRDRenderer: CRDRenderFn(xcrd.ForCompositeResourceClaim),
-			Finalizer:   resource.NewAPIFinalizer(ca, finalizer),
+			CRDRenderer: CRDRenderFn(func(d *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+				return xcrd.ForCompositeResourceClaim(d)
+			}),
+			Finalizer: resource.NewAPIFinalizer(ca, finalizer),
 		},
 
 		engine: &NopEngine{},
@@ -459,15 +482,27 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		claim.WithRecorder(r.record.WithAnnotations("controller", claim.ControllerName(d.GetName()))),
 	}
 
+	if r.options.Features.Enabled(features.EnableAlphaClaimPolicies) {
+		o = append(o, claim.WithPolicyEnforcer(claim.NewAPIPolicyEnforcer(r.engine.GetCached(), d.GetName())))
+	}
+
+	var so []claim.CompositeSyncerOption
+	if r.options.Features.Enabled(features.EnableAlphaClaimFieldMappings) && d.Spec.ClaimFieldMappings != nil {
+		so = append(so, claim.WithClaimFieldMappings(*d.Spec.ClaimFieldMappings))
+	}
+
 	// We only want to use the server-side XR syncer if the relevant feature
 	// flag is enabled. Otherwise, we start claim reconcilers with the default
 	// client-side syncer. If we use a server-side syncer we also need to handle
 	// upgrading fields that were previously managed using client-side apply.
-	if r.options.Features.Enabled(features.EnableBetaClaimSSA) {
+	switch {
+	case r.options.Features.Enabled(features.EnableBetaClaimSSA):
 		o = append(o,
-			claim.WithCompositeSyncer(claim.NewServerSideCompositeSyncer(r.engine.GetCached(), names.NewNameGenerator(r.engine.GetCached()))),
+			claim.WithCompositeSyncer(claim.NewServerSideCompositeSyncer(r.engine.GetCached(), names.NewNameGenerator(r.engine.GetCached()), so...)),
 			claim.WithManagedFieldsUpgrader(claim.NewPatchingManagedFieldsUpgrader(r.engine.GetCached())),
 		)
+	case len(so) > 0:
+		o = append(o, claim.WithCompositeSyncer(claim.NewClientSideCompositeSyncer(r.engine.GetCached(), names.NewNameGenerator(r.engine.GetCached()), so...)))
 	}
 
 	observed := d.Status.Controllers.CompositeResourceClaimTypeRef
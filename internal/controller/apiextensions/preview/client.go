@@ -0,0 +1,275 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/apis/apiextensions/v1alpha1"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+// Error strings.
+const (
+	errGetComposed     = "cannot get composed resource"
+	errToUnstructured  = "cannot convert composed resource to unstructured"
+	errMarshalComposed = "cannot marshal desired composed resource"
+)
+
+// A RecordingClient records the composed resources a Composer would apply and
+// delete, without applying or deleting anything. All writes are sent to the
+// API server as dry runs. Writes to the previewed composite resource itself
+// are dropped; it may not exist, and we only care about what it composes.
+type RecordingClient struct {
+	client.Client
+
+	reader client.Reader
+	xr     client.Object
+
+	mx      sync.Mutex
+	changes []*change
+}
+
+type change struct {
+	before *kunstructured.Unstructured
+	after  *kunstructured.Unstructured
+	delete bool
+}
+
+// NewRecordingClient returns a client that records the composed resources
+// written while composing the supplied composite resource.
+func NewRecordingClient(c client.Client, xr client.Object) *RecordingClient {
+	return &RecordingClient{
+		Client: client.NewDryRunClient(c),
+		reader: c,
+		xr:     xr,
+	}
+}
+
+// isXR returns true if the supplied object is the previewed composite
+// resource.
+func (c *RecordingClient) isXR(obj client.Object) bool {
+	return obj.GetObjectKind().GroupVersionKind() == c.xr.GetObjectKind().GroupVersionKind() &&
+		obj.GetNamespace() == c.xr.GetNamespace() &&
+		obj.GetName() == c.xr.GetName()
+}
+
+// Patch the supplied object as a dry run. Server-side applies of composed
+// resources are recorded.
+func (c *RecordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if c.isXR(obj) {
+		return nil
+	}
+
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+
+	before, err := c.get(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+
+	after, err := toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	c.record(&change{before: before, after: after})
+
+	return nil
+}
+
+// Delete the supplied object as a dry run, recording that it would be
+// deleted.
+func (c *RecordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if c.isXR(obj) {
+		return nil
+	}
+
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+
+	before, err := toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	c.record(&change{before: before, delete: true})
+
+	return nil
+}
+
+// Status returns a client for the status subresource. Writes are dry runs,
+// and writes to the previewed composite resource are dropped.
+func (c *RecordingClient) Status() client.SubResourceWriter {
+	return &statusWriter{SubResourceWriter: c.Client.Status(), client: c}
+}
+
+func (c *RecordingClient) get(ctx context.Context, obj client.Object) (*kunstructured.Unstructured, error) {
+	if obj.GetName() == "" {
+		return nil, nil
+	}
+
+	u := &kunstructured.Unstructured{}
+	u.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+
+	err := c.reader.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, u)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	return u, errors.Wrap(err, errGetComposed)
+}
+
+func (c *RecordingClient) record(ch *change) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.changes = append(c.changes, ch)
+}
+
+// Resources returns the composed resources that would be applied or deleted,
+// ordered by composition resource name.
+func (c *RecordingClient) Resources() ([]v1alpha1.PreviewedResource, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	out := make([]v1alpha1.PreviewedResource, 0, len(c.changes))
+
+	for _, ch := range c.changes {
+		obj := ch.after
+		if ch.delete {
+			obj = ch.before
+		}
+
+		pr := v1alpha1.PreviewedResource{
+			Name: obj.GetAnnotations()[xcrd.AnnotationKeyCompositionResourceName],
+			Reference: v1alpha1.PreviewedResourceReference{
+				APIVersion: obj.GetAPIVersion(),
+				Kind:       obj.GetKind(),
+				Name:       obj.GetName(),
+				Namespace:  obj.GetNamespace(),
+			},
+		}
+
+		switch {
+		case ch.delete:
+			pr.Action = v1alpha1.PreviewActionDelete
+			out = append(out, pr)
+
+			continue
+		case ch.before == nil:
+			pr.Action = v1alpha1.PreviewActionCreate
+			pr.Diff = cmp.Diff(map[string]any{}, clean(ch.after).Object)
+		default:
+			pr.Action = v1alpha1.PreviewActionUpdate
+			pr.Diff = cmp.Diff(clean(ch.before).Object, clean(ch.after).Object)
+
+			if pr.Diff == "" {
+				pr.Action = v1alpha1.PreviewActionNone
+			}
+		}
+
+		raw, err := json.Marshal(clean(ch.after).Object)
+		if err != nil {
+			return nil, errors.Wrap(err, errMarshalComposed)
+		}
+
+		pr.Desired = &runtime.RawExtension{Raw: raw}
+
+		out = append(out, pr)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	return out, nil
+}
+
+type statusWriter struct {
+	client.SubResourceWriter
+
+	client *RecordingClient
+}
+
+func (w *statusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if w.client.isXR(obj) {
+		return nil
+	}
+
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w *statusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if w.client.isXR(obj) {
+		return nil
+	}
+
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+func toUnstructured(obj client.Object) (*kunstructured.Unstructured, error) {
+	if u, ok := obj.(interface{ UnstructuredContent() map[string]any }); ok {
+		out := &kunstructured.Unstructured{Object: runtime.DeepCopyJSON(u.UnstructuredContent())}
+		out.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+
+		return out, nil
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, errToUnstructured)
+	}
+
+	return &kunstructured.Unstructured{Object: m}, nil
+}
+
+// clean returns a copy of the supplied object without the metadata the API
+// server manages, and with the data of Secrets redacted.
+func clean(u *kunstructured.Unstructured) *kunstructured.Unstructured {
+	out := u.DeepCopy()
+
+	out.SetManagedFields(nil)
+	out.SetResourceVersion("")
+	out.SetGeneration(0)
+	out.SetUID("")
+	out.SetCreationTimestamp(metav1.Time{})
+	out.SetSelfLink("")
+
+	composite.RedactSecretData(out.Object)
+
+	return out
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/apis/apiextensions/v1alpha1"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+func TestResources(t *testing.T) {
+	existing := func() *kunstructured.Unstructured {
+		u := &kunstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("Secret")
+		u.SetNamespace("default")
+		u.SetName("cool-secret")
+		u.SetResourceVersion("42")
+		u.SetAnnotations(map[string]string{xcrd.AnnotationKeyCompositionResourceName: "secret"})
+		u.Object["data"] = map[string]any{"password": "c2VjcmV0"}
+		return u
+	}
+
+	type want struct {
+		rs  []v1alpha1.PreviewedResource
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		apply  *kunstructured.Unstructured
+		delete *kunstructured.Unstructured
+		want   want
+	}{
+		"Unchanged": {
+			reason: "Applying a resource that wouldn't change should be recorded as no action.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					existing().DeepCopyInto(obj.(*kunstructured.Unstructured))
+					return nil
+				}),
+				MockPatch: test.NewMockPatchFn(nil),
+			},
+			apply: existing(),
+			want: want{
+				rs: []v1alpha1.PreviewedResource{{
+					Name: "secret",
+					Reference: v1alpha1.PreviewedResourceReference{
+						APIVersion: "v1",
+						Kind:       "Secret",
+						Name:       "cool-secret",
+						Namespace:  "default",
+					},
+					Action: v1alpha1.PreviewActionNone,
+					Desired: &runtime.RawExtension{
+						Raw: []byte(`{"apiVersion":"v1","data":{"password":"(redacted)"},"kind":"Secret","metadata":{"annotations":{"crossplane.io/composition-resource-name":"secret"},"name":"cool-secret","namespace":"default"}}`),
+					},
+				}},
+			},
+		},
+		"Updated": {
+			reason: "Applying a resource that would change should be recorded as an update, without revealing Secret data.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					existing().DeepCopyInto(obj.(*kunstructured.Unstructured))
+					return nil
+				}),
+				MockPatch: test.NewMockPatchFn(nil),
+			},
+			apply: func() *kunstructured.Unstructured {
+				u := existing()
+				u.SetLabels(map[string]string{"cool": "true"})
+				return u
+			}(),
+			want: want{
+				rs: []v1alpha1.PreviewedResource{{
+					Name: "secret",
+					Reference: v1alpha1.PreviewedResourceReference{
+						APIVersion: "v1",
+						Kind:       "Secret",
+						Name:       "cool-secret",
+						Namespace:  "default",
+					},
+					Action: v1alpha1.PreviewActionUpdate,
+					Desired: &runtime.RawExtension{
+						Raw: []byte(`{"apiVersion":"v1","data":{"password":"(redacted)"},"kind":"Secret","metadata":{"annotations":{"crossplane.io/composition-resource-name":"secret"},"labels":{"cool":"true"},"name":"cool-secret","namespace":"default"}}`),
+					},
+				}},
+			},
+		},
+		"Deleted": {
+			reason: "Deleting a resource should be recorded as a deletion.",
+			c: &test.MockClient{
+				MockDelete: test.NewMockDeleteFn(nil),
+			},
+			delete: existing(),
+			want: want{
+				rs: []v1alpha1.PreviewedResource{{
+					Name: "secret",
+					Reference: v1alpha1.PreviewedResourceReference{
+						APIVersion: "v1",
+						Kind:       "Secret",
+						Name:       "cool-secret",
+						Namespace:  "default",
+					},
+					Action: v1alpha1.PreviewActionDelete,
+				}},
+			},
+		},
+		"GetError": {
+			reason: "We should return any error encountered getting the existing resource.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(errors.New("boom")),
+			},
+			apply: existing(),
+			want: want{
+				err: errors.Wrap(errors.New("boom"), errGetComposed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			x := composite.New()
+			x.SetAPIVersion("example.org/v1")
+			x.SetKind("XCoolResource")
+			x.SetName("cool-xr")
+
+			rc := NewRecordingClient(tc.c, x)
+
+			var err error
+			if tc.apply != nil {
+				err = rc.Patch(context.Background(), tc.apply, client.Apply)
+			}
+			if tc.delete != nil {
+				err = rc.Delete(context.Background(), tc.delete)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nrc.Patch(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if err != nil {
+				return
+			}
+
+			got, err := rc.Resources()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want.rs, got, cmpopts.IgnoreFields(v1alpha1.PreviewedResource{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\nrc.Resources(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,388 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preview implements composition previews, which run a Composition's
+// function pipeline without applying the resources it produces.
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	ucomposite "github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/apis/apiextensions/v1alpha1"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/controller"
+	"github.com/crossplane/crossplane/v2/internal/features"
+	"github.com/crossplane/crossplane/v2/internal/tracing"
+)
+
+const (
+	timeout = 2 * time.Minute
+)
+
+// Error strings.
+const (
+	errGet                 = "cannot get CompositionPreview"
+	errUpdateStatus        = "cannot update CompositionPreview status"
+	errNewClient           = "cannot create uncached client"
+	errUnmarshalXR         = "cannot unmarshal composite resource"
+	errListXRDs            = "cannot list CompositeResourceDefinitions"
+	errGetXR               = "cannot get composite resource"
+	errGetRevision         = "cannot get CompositionRevision"
+	errGetComposition      = "cannot get Composition"
+	errListRevisions       = "cannot list CompositionRevisions"
+	errNoComposition       = "cannot determine which Composition to preview: set spec.compositionRef, or reference a Composition from the composite resource"
+	errNoRevision          = "cannot find a CompositionRevision of the Composition"
+	errCompose             = "cannot compose resources"
+	errRecordResources     = "cannot record previewed resources"
+	errFmtNoXRD            = "cannot find a CompositeResourceDefinition that defines %s"
+	errFmtInvalidXRVersion = "cannot preview composite resource: invalid apiVersion %q"
+)
+
+// Event reasons.
+const (
+	reasonPreview event.Reason = "PreviewComposition"
+)
+
+// A SchemaResolver determines the schema of the supplied kind of composite
+// resource.
+type SchemaResolver interface {
+	ResolveSchema(ctx context.Context, gvk schema.GroupVersionKind) (ucomposite.Schema, error)
+}
+
+// A SchemaResolverFn determines the schema of a kind of composite resource.
+type SchemaResolverFn func(ctx context.Context, gvk schema.GroupVersionKind) (ucomposite.Schema, error)
+
+// ResolveSchema of the supplied kind of composite resource.
+func (fn SchemaResolverFn) ResolveSchema(ctx context.Context, gvk schema.GroupVersionKind) (ucomposite.Schema, error) {
+	return fn(ctx, gvk)
+}
+
+// An XRDSchemaResolver determines the schema of a kind of composite resource
+// by finding the CompositeResourceDefinition that defines it.
+type XRDSchemaResolver struct {
+	client client.Reader
+}
+
+// NewXRDSchemaResolver returns a SchemaResolver that reads XRDs using the
+// supplied client.
+func NewXRDSchemaResolver(c client.Reader) *XRDSchemaResolver {
+	return &XRDSchemaResolver{client: c}
+}
+
+// ResolveSchema of the supplied kind of composite resource.
+func (r *XRDSchemaResolver) ResolveSchema(ctx context.Context, gvk schema.GroupVersionKind) (ucomposite.Schema, error) {
+	l := &v1.CompositeResourceDefinitionList{}
+	if err := r.client.List(ctx, l); err != nil {
+		return ucomposite.SchemaModern, errors.Wrap(err, errListXRDs)
+	}
+
+	for _, d := range l.Items {
+		if d.Spec.Group != gvk.Group || d.Spec.Names.Kind != gvk.Kind {
+			continue
+		}
+
+		if ptr.Deref(d.Spec.Scope, v1.CompositeResourceScopeLegacyCluster) == v1.CompositeResourceScopeLegacyCluster {
+			return ucomposite.SchemaLegacy, nil
+		}
+
+		return ucomposite.SchemaModern, nil
+	}
+
+	return ucomposite.SchemaModern, errors.Errorf(errFmtNoXRD, gvk.String())
+}
+
+// A NewComposerFn returns a Composer that writes using the supplied client.
+type NewComposerFn func(c client.Client) composite.Composer
+
+// Setup adds a controller that reconciles CompositionPreviews.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := "preview/" + strings.ToLower(v1alpha1.CompositionPreviewGroupKind)
+
+	// Previews read and dry-run apply arbitrary kinds of composed resource.
+	// We don't want to start a cache informer for each of them.
+	uncached, err := client.New(mgr.GetConfig(), client.Options{
+		HTTPClient: mgr.GetHTTPClient(),
+		Scheme:     mgr.GetScheme(),
+		Mapper:     mgr.GetRESTMapper(),
+	})
+	if err != nil {
+		return errors.Wrap(err, errNewClient)
+	}
+
+	var fco []composite.FunctionComposerOption
+	if o.Features.Enabled(features.EnableAlphaFunctionServiceAccountTokens) {
+		fco = append(fco, composite.WithServiceAccountTokenCredentials())
+	}
+
+	r := NewReconciler(mgr.GetClient(), uncached,
+		func(c client.Client) composite.Composer {
+			return composite.NewFunctionComposer(c, uncached, o.FunctionRunner, fco...)
+		},
+		WithLogger(o.Logger.WithValues("controller", name)),
+		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.CompositionPreview{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(o.ForControllerRuntime()).
+		Complete(ratelimiter.NewReconciler(name, errors.WithSilentRequeueOnConflict(tracing.NewReconciler(name, r)), o.GlobalRateLimiter))
+}
+
+// ReconcilerOption is used to configure the Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithLogger specifies how the Reconciler should log messages.
+func WithLogger(log logging.Logger) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.log = log
+	}
+}
+
+// WithRecorder specifies how the Reconciler should record Kubernetes events.
+func WithRecorder(er event.Recorder) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.record = er
+	}
+}
+
+// WithSchemaResolver specifies how the Reconciler should determine the
+// schema of the previewed composite resource.
+func WithSchemaResolver(sr SchemaResolver) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.schema = sr
+	}
+}
+
+// NewReconciler returns a Reconciler of CompositionPreviews. The supplied
+// client is used to read previews, Compositions, and CompositionRevisions. The
+// supplied uncached client is used to read and dry-run apply composite and
+// composed resources. Composers are created using the supplied function.
+func NewReconciler(c, uncached client.Client, fn NewComposerFn, opts ...ReconcilerOption) *Reconciler {
+	r := &Reconciler{
+		client:      c,
+		uncached:    uncached,
+		newComposer: fn,
+		schema:      NewXRDSchemaResolver(c),
+		log:         logging.NewNopLogger(),
+		record:      event.NewNopRecorder(),
+	}
+
+	for _, f := range opts {
+		f(r)
+	}
+
+	return r
+}
+
+// A Reconciler reconciles CompositionPreviews.
+type Reconciler struct {
+	client   client.Client
+	uncached client.Client
+
+	newComposer NewComposerFn
+	schema      SchemaResolver
+
+	log    logging.Logger
+	record event.Recorder
+}
+
+// Reconcile a CompositionPreview.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	p := &v1alpha1.CompositionPreview{}
+	if err := r.client.Get(ctx, req.NamespacedName, p); err != nil {
+		log.Debug(errGet, "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGet)
+	}
+
+	if meta.WasDeleted(p) {
+		return reconcile.Result{}, nil
+	}
+
+	// A preview is computed once for each generation of its spec.
+	if p.Status.ObservedGeneration == p.GetGeneration() {
+		return reconcile.Result{}, nil
+	}
+
+	p.Status.ObservedGeneration = p.GetGeneration()
+	p.Status.CompositionRevision = ""
+	p.Status.Resources = nil
+	p.Status.Events = nil
+
+	if err := r.preview(ctx, p); err != nil {
+		log.Debug("Cannot preview composition", "error", err)
+		r.record.Event(p, event.Warning(reasonPreview, err))
+		p.SetConditions(xpv1.ReconcileError(err))
+
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, p), errUpdateStatus)
+	}
+
+	r.record.Event(p, event.Normal(reasonPreview, "Successfully previewed composition"))
+	p.SetConditions(xpv1.ReconcileSuccess())
+
+	return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, p), errUpdateStatus)
+}
+
+// preview the composition of the supplied preview's composite resource,
+// recording the result to the preview's status.
+func (r *Reconciler) preview(ctx context.Context, p *v1alpha1.CompositionPreview) error {
+	xr, err := r.compositeResource(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	rev, err := r.revision(ctx, p, xr)
+	if err != nil {
+		return err
+	}
+
+	p.Status.CompositionRevision = rev.GetName()
+
+	rc := NewRecordingClient(r.uncached, xr)
+
+	res, err := r.newComposer(rc).Compose(ctx, xr, composite.CompositionRequest{Revision: rev})
+	if err != nil {
+		return errors.Wrap(err, errCompose)
+	}
+
+	p.Status.Resources, err = rc.Resources()
+	if err != nil {
+		return errors.Wrap(err, errRecordResources)
+	}
+
+	for _, e := range res.Events {
+		p.Status.Events = append(p.Status.Events, v1alpha1.PreviewEvent{
+			Type:    string(e.Type),
+			Reason:  string(e.Reason),
+			Message: e.Message,
+		})
+	}
+
+	return nil
+}
+
+// compositeResource returns the preview's composite resource. If the
+// composite resource exists, it references the composed resources of the
+// existing composite resource.
+func (r *Reconciler) compositeResource(ctx context.Context, p *v1alpha1.CompositionPreview) (*ucomposite.Unstructured, error) {
+	obj := map[string]any{}
+	if err := json.Unmarshal(p.Spec.CompositeResource.Raw, &obj); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalXR)
+	}
+
+	in := ucomposite.New()
+	in.Object = obj
+
+	gvk := in.GroupVersionKind()
+	if gvk.Version == "" || gvk.Kind == "" {
+		return nil, errors.Errorf(errFmtInvalidXRVersion, in.GetAPIVersion())
+	}
+
+	s, err := r.schema.ResolveSchema(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	xr := ucomposite.New(ucomposite.WithGroupVersionKind(gvk), ucomposite.WithSchema(s))
+	xr.Object = obj
+
+	live := ucomposite.New(ucomposite.WithGroupVersionKind(gvk), ucomposite.WithSchema(s))
+	err = r.uncached.Get(ctx, types.NamespacedName{Namespace: xr.GetNamespace(), Name: xr.GetName()}, live)
+
+	switch {
+	case kerrors.IsNotFound(err):
+		// Composed resources are owned by their composite resource. The
+		// previewed composite resource doesn't exist, so we borrow the
+		// preview's UID.
+		xr.SetUID(p.GetUID())
+	case err != nil:
+		return nil, errors.Wrap(err, errGetXR)
+	default:
+		xr.SetUID(live.GetUID())
+		xr.SetResourceReferences(live.GetResourceReferences())
+		xr.Object["status"] = live.Object["status"]
+	}
+
+	return xr, nil
+}
+
+// revision returns the CompositionRevision to preview.
+func (r *Reconciler) revision(ctx context.Context, p *v1alpha1.CompositionPreview, xr *ucomposite.Unstructured) (*v1.CompositionRevision, error) {
+	if ref := p.Spec.CompositionRevisionRef; ref != nil {
+		rev := &v1.CompositionRevision{}
+		err := r.client.Get(ctx, types.NamespacedName{Name: ref.Name}, rev)
+
+		return rev, errors.Wrap(err, errGetRevision)
+	}
+
+	name := ""
+	if ref := xr.GetCompositionReference(); ref != nil {
+		name = ref.Name
+	}
+
+	if ref := p.Spec.CompositionRef; ref != nil {
+		name = ref.Name
+	}
+
+	if name == "" {
+		return nil, errors.New(errNoComposition)
+	}
+
+	comp := &v1.Composition{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name}, comp); err != nil {
+		return nil, errors.Wrap(err, errGetComposition)
+	}
+
+	rl := &v1.CompositionRevisionList{}
+	if err := r.client.List(ctx, rl, client.MatchingLabels{v1.LabelCompositionName: name}); err != nil {
+		return nil, errors.Wrap(err, errListRevisions)
+	}
+
+	latest := v1.LatestRevision(comp, rl.Items)
+	if latest == nil {
+		return nil, errors.New(errNoRevision)
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/apis/apiextensions/v1alpha1"
+	xr "github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	spec := v1alpha1.CompositionPreviewSpec{
+		CompositeResource: runtime.RawExtension{Raw: []byte(`{"apiVersion":"example.org/v1","kind":"XCoolResource","metadata":{"name":"cool-xr","namespace":"default"}}`)},
+		CompositionRevisionRef: &v1alpha1.CompositionPreviewReference{
+			Name: "cool-comp-abc123",
+		},
+	}
+
+	modern := SchemaResolverFn(func(_ context.Context, _ schema.GroupVersionKind) (composite.Schema, error) {
+		return composite.SchemaModern, nil
+	})
+
+	notFound := kerrors.NewNotFound(schema.GroupResource{}, "")
+
+	type params struct {
+		c        client.Client
+		uncached client.Client
+		fn       NewComposerFn
+		opts     []ReconcilerOption
+	}
+
+	type want struct {
+		r   reconcile.Result
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		params params
+		want   want
+	}{
+		"PreviewNotFound": {
+			reason: "We should not return an error if the CompositionPreview was not found.",
+			params: params{
+				c: &test.MockClient{
+					MockGet: test.NewMockGetFn(notFound),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"GetPreviewError": {
+			reason: "We should return any error encountered getting the CompositionPreview.",
+			params: params{
+				c: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGet),
+			},
+		},
+		"AlreadyPreviewed": {
+			reason: "We should not preview a generation of the CompositionPreview twice.",
+			params: params{
+				c: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						p := obj.(*v1alpha1.CompositionPreview)
+						p.SetGeneration(2)
+						p.Status.ObservedGeneration = 2
+						return nil
+					}),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(errors.New("should not be called")),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"NoComposition": {
+			reason: "We should report an error if we can't determine which Composition to preview.",
+			params: params{
+				c: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						p := obj.(*v1alpha1.CompositionPreview)
+						p.SetGeneration(1)
+						p.Spec.CompositeResource = spec.CompositeResource
+						return nil
+					}),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+						want := &v1alpha1.CompositionPreview{}
+						want.SetGeneration(1)
+						want.Spec.CompositeResource = spec.CompositeResource
+						want.Status.ObservedGeneration = 1
+						want.SetConditions(xpv1.ReconcileError(errors.New(errNoComposition)))
+
+						if diff := cmp.Diff(want, obj, test.EquateConditions()); diff != "" {
+							t.Errorf("Status().Update(...): -want, +got:\n%s", diff)
+						}
+						return nil
+					}),
+				},
+				uncached: &test.MockClient{
+					MockGet: test.NewMockGetFn(notFound),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"ComposeError": {
+			reason: "We should report an error if we can't compose resources.",
+			params: params{
+				c: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.CompositionPreview:
+							o.SetGeneration(1)
+							o.Spec = spec
+						case *v1.CompositionRevision:
+							o.SetName("cool-comp-abc123")
+						}
+						return nil
+					}),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+						want := &v1alpha1.CompositionPreview{}
+						want.SetGeneration(1)
+						want.Spec = spec
+						want.Status.ObservedGeneration = 1
+						want.Status.CompositionRevision = "cool-comp-abc123"
+						want.SetConditions(xpv1.ReconcileError(errors.Wrap(errBoom, errCompose)))
+
+						if diff := cmp.Diff(want, obj, test.EquateConditions()); diff != "" {
+							t.Errorf("Status().Update(...): -want, +got:\n%s", diff)
+						}
+						return nil
+					}),
+				},
+				uncached: &test.MockClient{
+					MockGet: test.NewMockGetFn(notFound),
+				},
+				fn: func(_ client.Client) xr.Composer {
+					return xr.ComposerFn(func(_ context.Context, _ *composite.Unstructured, _ xr.CompositionRequest) (xr.CompositionResult, error) {
+						return xr.CompositionResult{}, errBoom
+					})
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"Success": {
+			reason: "We should record the composed resources and events the Composition would produce.",
+			params: params{
+				c: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.CompositionPreview:
+							o.SetGeneration(1)
+							o.SetUID("preview-uid")
+							o.Spec = spec
+						case *v1.CompositionRevision:
+							o.SetName("cool-comp-abc123")
+						}
+						return nil
+					}),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+						want := &v1alpha1.CompositionPreview{}
+						want.SetGeneration(1)
+						want.SetUID("preview-uid")
+						want.Spec = spec
+						want.Status.ObservedGeneration = 1
+						want.Status.CompositionRevision = "cool-comp-abc123"
+						want.Status.Resources = []v1alpha1.PreviewedResource{{
+							Name: "cool-resource",
+							Reference: v1alpha1.PreviewedResourceReference{
+								APIVersion: "example.org/v1",
+								Kind:       "CoolComposed",
+								Name:       "cool-composed",
+								Namespace:  "default",
+							},
+							Action: v1alpha1.PreviewActionCreate,
+							Desired: &runtime.RawExtension{
+								Raw: []byte(`{"apiVersion":"example.org/v1","kind":"CoolComposed","metadata":{"annotations":{"crossplane.io/composition-resource-name":"cool-resource"},"name":"cool-composed","namespace":"default","ownerReferences":[{"apiVersion":"example.org/v1","kind":"XCoolResource","name":"cool-xr","uid":"preview-uid"}]}}`),
+							},
+						}}
+						want.Status.Events = []v1alpha1.PreviewEvent{{
+							Type:    "Normal",
+							Reason:  "ComposeResources",
+							Message: "Composed!",
+						}}
+						want.SetConditions(xpv1.ReconcileSuccess())
+
+						if diff := cmp.Diff(want, obj, test.EquateConditions(), cmpopts.IgnoreFields(v1alpha1.PreviewedResource{}, "Diff")); diff != "" {
+							t.Errorf("Status().Update(...): -want, +got:\n%s", diff)
+						}
+						return nil
+					}),
+				},
+				uncached: &test.MockClient{
+					MockGet:   test.NewMockGetFn(notFound),
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				fn: func(c client.Client) xr.Composer {
+					return xr.ComposerFn(func(ctx context.Context, x *composite.Unstructured, req xr.CompositionRequest) (xr.CompositionResult, error) {
+						if req.Revision.GetName() != "cool-comp-abc123" {
+							return xr.CompositionResult{}, errors.Errorf("wrong revision %q", req.Revision.GetName())
+						}
+
+						cd := &kunstructured.Unstructured{}
+						cd.SetAPIVersion("example.org/v1")
+						cd.SetKind("CoolComposed")
+						cd.SetNamespace("default")
+						cd.SetName("cool-composed")
+						cd.SetAnnotations(map[string]string{xcrd.AnnotationKeyCompositionResourceName: "cool-resource"})
+						cd.SetOwnerReferences([]metav1.OwnerReference{{
+							APIVersion: x.GetAPIVersion(),
+							Kind:       x.GetKind(),
+							Name:       x.GetName(),
+							UID:        x.GetUID(),
+						}})
+
+						if err := c.Patch(ctx, cd, client.Apply); err != nil {
+							return xr.CompositionResult{}, err
+						}
+
+						// Writes to the XR should be dropped.
+						if err := c.Status().Update(ctx, x); err != nil {
+							return xr.CompositionResult{}, err
+						}
+
+						return xr.CompositionResult{
+							Events: []xr.TargetedEvent{{Event: event.Normal("ComposeResources", "Composed!")}},
+						}, nil
+					})
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			opts := append([]ReconcilerOption{WithSchemaResolver(modern)}, tc.params.opts...)
+			r := NewReconciler(tc.params.c, tc.params.uncached, tc.params.fn, opts...)
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "cool-preview"}})
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
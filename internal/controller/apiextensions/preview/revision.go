@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preview
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	ucomposite "github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/apis/apiextensions/v1alpha1"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
+)
+
+// A RevisionPreviewer previews what composing a composite resource using a
+// CompositionRevision would change, by composing it using a RecordingClient.
+type RevisionPreviewer struct {
+	client      client.Client
+	newComposer NewComposerFn
+}
+
+// NewRevisionPreviewer returns a RevisionPreviewer that reads and dry-run
+// applies composed resources using the supplied client. Composers are created
+// using the supplied function.
+func NewRevisionPreviewer(c client.Client, fn NewComposerFn) *RevisionPreviewer {
+	return &RevisionPreviewer{client: c, newComposer: fn}
+}
+
+// PreviewRevision previews what composing the supplied composite resource
+// using the supplied CompositionRevision would change. The supplied composite
+// resource isn't modified.
+func (p *RevisionPreviewer) PreviewRevision(ctx context.Context, xr *ucomposite.Unstructured, rev *v1.CompositionRevision) (composite.RevisionDiff, error) {
+	// Composing an XR updates it in memory.
+	x := ucomposite.New(ucomposite.WithSchema(xr.Schema))
+	x.Object = runtime.DeepCopyJSON(xr.Object)
+
+	rc := NewRecordingClient(p.client, x)
+
+	if _, err := p.newComposer(rc).Compose(ctx, x, composite.CompositionRequest{Revision: rev}); err != nil {
+		return composite.RevisionDiff{}, errors.Wrap(err, errCompose)
+	}
+
+	rs, err := rc.Resources()
+	if err != nil {
+		return composite.RevisionDiff{}, errors.Wrap(err, errRecordResources)
+	}
+
+	d := composite.RevisionDiff{}
+
+	for _, r := range rs {
+		switch r.Action {
+		case v1alpha1.PreviewActionCreate:
+			d.Added++
+		case v1alpha1.PreviewActionDelete:
+			d.Removed++
+		case v1alpha1.PreviewActionUpdate:
+			d.Changed++
+		case v1alpha1.PreviewActionNone:
+			// Nothing would change.
+		}
+	}
+
+	return d, nil
+}
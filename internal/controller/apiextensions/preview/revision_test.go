@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	ucomposite "github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
+)
+
+func TestPreviewRevision(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	composed := func(name string) *kunstructured.Unstructured {
+		u := &kunstructured.Unstructured{}
+		u.SetAPIVersion("example.org/v1")
+		u.SetKind("CoolComposed")
+		u.SetName(name)
+		return u
+	}
+
+	type want struct {
+		d   composite.RevisionDiff
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		fn     NewComposerFn
+		want   want
+	}{
+		"ComposeError": {
+			reason: "We should return any error encountered composing resources.",
+			c:      &test.MockClient{},
+			fn: func(_ client.Client) composite.Composer {
+				return composite.ComposerFn(func(_ context.Context, _ *ucomposite.Unstructured, _ composite.CompositionRequest) (composite.CompositionResult, error) {
+					return composite.CompositionResult{}, errBoom
+				})
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCompose),
+			},
+		},
+		"Success": {
+			reason: "We should count the composed resources that would be added, removed, and changed.",
+			c: &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					if key.Name == "new" {
+						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+					}
+					composed(key.Name).DeepCopyInto(obj.(*kunstructured.Unstructured))
+					return nil
+				},
+				MockPatch:  test.NewMockPatchFn(nil),
+				MockDelete: test.NewMockDeleteFn(nil),
+			},
+			fn: func(c client.Client) composite.Composer {
+				return composite.ComposerFn(func(ctx context.Context, xr *ucomposite.Unstructured, _ composite.CompositionRequest) (composite.CompositionResult, error) {
+					// Composing shouldn't modify the previewed XR.
+					xr.SetLabels(map[string]string{"composed": "true"})
+
+					changed := composed("changed")
+					changed.SetLabels(map[string]string{"cool": "true"})
+
+					for _, cd := range []*kunstructured.Unstructured{composed("new"), changed, composed("unchanged")} {
+						if err := c.Patch(ctx, cd, client.Apply); err != nil {
+							return composite.CompositionResult{}, err
+						}
+					}
+
+					return composite.CompositionResult{}, c.Delete(ctx, composed("removed"))
+				})
+			},
+			want: want{
+				d: composite.RevisionDiff{Added: 1, Removed: 1, Changed: 1},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			xr := ucomposite.New()
+			xr.SetAPIVersion("example.org/v1")
+			xr.SetKind("XCoolResource")
+			xr.SetName("cool-xr")
+
+			p := NewRevisionPreviewer(tc.c, tc.fn)
+			d, err := p.PreviewRevision(context.Background(), xr, &v1.CompositionRevision{})
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\np.PreviewRevision(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.d, d); diff != "" {
+				t.Errorf("\n%s\np.PreviewRevision(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if xr.GetLabels() != nil {
+				t.Errorf("\n%s\np.PreviewRevision(...): previewed composite resource was modified", tc.reason)
+			}
+		})
+	}
+}
@@ -39,7 +39,9 @@ import (
 	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
 	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
 	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
 	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/controller"
+	"github.com/crossplane/crossplane/v2/internal/features"
 	"github.com/crossplane/crossplane/v2/internal/xfn"
 )
 
@@ -57,16 +59,24 @@ const (
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := "revision/" + strings.ToLower(v1.CompositionRevisionGroupKind)
 
+	cco := []xfn.RevisionCapabilityCheckerOption{}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1.CompositionRevision{}).
+		Watches(&pkgv1.FunctionRevision{}, EnqueueCompositionRevisionsForFunctionRevision(mgr.GetClient(), o.Logger))
+
+	if o.Features.Enabled(features.EnableAlphaExternalFunctions) {
+		cco = append(cco, xfn.WithExternalFunctionCapabilities())
+		b = b.Watches(&pkgv1beta1.ExternalFunction{}, EnqueueCompositionRevisionsForExternalFunction(mgr.GetClient(), o.Logger))
+	}
+
 	r := NewReconciler(mgr,
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		WithCapabilityChecker(xfn.NewRevisionCapabilityChecker(mgr.GetClient())))
+		WithCapabilityChecker(xfn.NewRevisionCapabilityChecker(mgr.GetClient(), cco...)))
 
-	return ctrl.NewControllerManagedBy(mgr).
-		Named(name).
-		For(&v1.CompositionRevision{}).
-		Watches(&pkgv1.FunctionRevision{}, EnqueueCompositionRevisionsForFunctionRevision(mgr.GetClient(), o.Logger)).
-		WithOptions(o.ForControllerRuntime()).
+	return b.WithOptions(o.ForControllerRuntime()).
 		Complete(ratelimiter.NewReconciler(name, errors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
 }
 
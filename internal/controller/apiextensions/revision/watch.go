@@ -28,6 +28,7 @@ import (
 
 	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
 	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
 )
 
 // EnqueueCompositionRevisionsForFunctionRevision enqueues a reconcile for all CompositionRevisions
@@ -46,31 +47,47 @@ func EnqueueCompositionRevisionsForFunctionRevision(kube client.Reader, log logg
 			return nil
 		}
 
-		// List all CompositionRevisions to find those that reference this function
-		revs := &v1.CompositionRevisionList{}
-		if err := kube.List(ctx, revs); err != nil {
-			log.Debug("Cannot list CompositionRevisions while attempting to enqueue from FunctionRevision", "error", err)
+		return compositionRevisionsForFunction(ctx, kube, log.WithValues("function-revision", fr.GetName()), name)
+	})
+}
+
+// EnqueueCompositionRevisionsForExternalFunction enqueues a reconcile for all
+// CompositionRevisions that reference an ExternalFunction when it changes.
+func EnqueueCompositionRevisionsForExternalFunction(kube client.Reader, log logging.Logger) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+		xf, ok := o.(*pkgv1beta1.ExternalFunction)
+		if !ok {
 			return nil
 		}
 
-		var matches []reconcile.Request
-		for _, rev := range revs.Items {
-			for _, fn := range rev.Spec.Pipeline {
-				if fn.FunctionRef.Name != name {
-					continue
-				}
-
-				log.Debug("Enqueuing CompositionRevision for FunctionRevision change",
-					"composition-revision", rev.GetName(),
-					"function-revision", fr.GetName(),
-					"function", name)
-				matches = append(matches, reconcile.Request{NamespacedName: types.NamespacedName{Name: rev.GetName()}})
-
-				// Functions are unique within the pipeline.
-				break
+		return compositionRevisionsForFunction(ctx, kube, log, xf.GetName())
+	})
+}
+
+func compositionRevisionsForFunction(ctx context.Context, kube client.Reader, log logging.Logger, name string) []reconcile.Request {
+	// List all CompositionRevisions to find those that reference this function
+	revs := &v1.CompositionRevisionList{}
+	if err := kube.List(ctx, revs); err != nil {
+		log.Debug("Cannot list CompositionRevisions while attempting to enqueue for function change", "error", err)
+		return nil
+	}
+
+	var matches []reconcile.Request
+	for _, rev := range revs.Items {
+		for _, fn := range rev.Spec.Pipeline {
+			if fn.FunctionRef.Name != name {
+				continue
 			}
+
+			log.Debug("Enqueuing CompositionRevision for function change",
+				"composition-revision", rev.GetName(),
+				"function", name)
+			matches = append(matches, reconcile.Request{NamespacedName: types.NamespacedName{Name: rev.GetName()}})
+
+			// Functions are unique within the pipeline.
+			break
 		}
+	}
 
-		return matches
-	})
+	return matches
 }
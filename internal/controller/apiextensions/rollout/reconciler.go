@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rollout reports the progress of Progressive CompositionRevision
+// rollouts, and approves revisions once their rollout succeeds.
+package rollout
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/controller"
+	"github.com/crossplane/crossplane/v2/internal/tracing"
+)
+
+const (
+	timeout = 2 * time.Minute
+)
+
+// Error strings.
+const (
+	errGet          = "cannot get Composition"
+	errListRevs     = "cannot list CompositionRevisions"
+	errUpdateStatus = "cannot update Composition status"
+	errApprove      = "cannot approve CompositionRevision"
+)
+
+// Event reasons.
+const (
+	reasonRollout event.Reason = "RolloutCompositionRevision"
+)
+
+// Setup adds a controller that reports the progress of Progressive
+// CompositionRevision rollouts on Compositions, and approves revisions once
+// their rollout succeeds.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := "rollout/" + strings.ToLower(v1.CompositionGroupKind)
+
+	// The manager's client doesn't cache unstructured objects, so composite
+	// resources are read from the API server each time we poll.
+	r := NewReconciler(mgr.GetClient(),
+		WithLogger(o.Logger.WithValues("controller", name)),
+		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		WithPollInterval(o.PollInterval))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1.Composition{}).
+		Owns(&v1.CompositionRevision{}).
+		WithOptions(o.ForControllerRuntime()).
+		Complete(ratelimiter.NewReconciler(name, errors.WithSilentRequeueOnConflict(tracing.NewReconciler(name, r)), o.GlobalRateLimiter))
+}
+
+// ReconcilerOption is used to configure the Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithLogger specifies how the Reconciler should log messages.
+func WithLogger(log logging.Logger) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.log = log
+	}
+}
+
+// WithRecorder specifies how the Reconciler should record Kubernetes events.
+func WithRecorder(er event.Recorder) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.record = er
+	}
+}
+
+// WithPollInterval specifies how long the Reconciler should wait before
+// checking the progress of an incomplete rollout.
+func WithPollInterval(after time.Duration) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.pollInterval = after
+	}
+}
+
+// NewReconciler returns a Reconciler of Compositions.
+func NewReconciler(c client.Client, opts ...ReconcilerOption) *Reconciler {
+	r := &Reconciler{
+		client:       c,
+		log:          logging.NewNopLogger(),
+		record:       event.NewNopRecorder(),
+		pollInterval: 1 * time.Minute,
+	}
+
+	for _, f := range opts {
+		f(r)
+	}
+
+	return r
+}
+
+// A Reconciler reconciles Compositions by reporting the progress of their
+// latest CompositionRevision's Progressive rollout. It approves the latest
+// revision once every composite resource adopted a Progressive revision, or
+// every canary composite resource adopted a Staged revision and is ready.
+// Composite resources decide whether they may adopt the latest revision using
+// the approval and rollout status, so only this controller lists them.
+type Reconciler struct {
+	client client.Client
+
+	log    logging.Logger
+	record event.Recorder
+
+	pollInterval time.Duration
+}
+
+// Reconcile a Composition.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	comp := &v1.Composition{}
+	if err := r.client.Get(ctx, req.NamespacedName, comp); err != nil {
+		log.Debug(errGet, "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGet)
+	}
+
+	if meta.WasDeleted(comp) {
+		return reconcile.Result{}, nil
+	}
+
+	rl := &v1.CompositionRevisionList{}
+	if err := r.client.List(ctx, rl, client.MatchingLabels{v1.LabelCompositionName: comp.GetName()}); err != nil {
+		log.Debug(errListRevs, "error", err)
+		r.record.Event(comp, event.Warning(reasonRollout, errors.Wrap(err, errListRevs)))
+
+		return reconcile.Result{}, errors.Wrap(err, errListRevs)
+	}
+
+	latest := v1.LatestRevision(comp, rl.Items)
+	if latest == nil || !composite.Progressive(latest) {
+		// There's no Progressive rollout to report.
+		if comp.Status.Rollout != nil {
+			comp.Status.Rollout = nil
+
+			if err := r.client.Status().Update(ctx, comp); err != nil {
+				log.Debug(errUpdateStatus, "error", err)
+				return reconcile.Result{}, errors.Wrap(err, errUpdateStatus)
+			}
+		}
+
+		if latest == nil || !composite.Staged(latest) {
+			return reconcile.Result{}, nil
+		}
+
+		return r.reconcileCanary(ctx, log, comp, latest)
+	}
+
+	gvk := schema.FromAPIVersionAndKind(comp.Spec.CompositeTypeRef.APIVersion, comp.Spec.CompositeTypeRef.Kind)
+
+	xrs, err := composite.ListComposites(ctx, r.client, gvk, comp.GetName())
+	if err != nil {
+		log.Debug("Cannot list composite resources", "error", err)
+		r.record.Event(comp, event.Warning(reasonRollout, err))
+
+		return reconcile.Result{}, err
+	}
+
+	ro := composite.NewRollout(latest, xrs, time.Now())
+
+	prev := comp.Status.Rollout
+	comp.Status.Rollout = ro.Status()
+
+	if prev == nil || prev.Phase != comp.Status.Rollout.Phase {
+		log.Debug("Rollout phase changed", "revision", latest.GetName(), "phase", comp.Status.Rollout.Phase)
+		r.record.Event(comp, event.Normal(reasonRollout, "Rollout of "+latest.GetName()+" is "+string(comp.Status.Rollout.Phase)))
+	}
+
+	if err := r.client.Status().Update(ctx, comp); err != nil {
+		log.Debug(errUpdateStatus, "error", err)
+		return reconcile.Result{}, errors.Wrap(err, errUpdateStatus)
+	}
+
+	if !ro.Complete() {
+		return reconcile.Result{RequeueAfter: r.pollInterval}, nil
+	}
+
+	// Approve the revision, so that composite resources created from now on
+	// may adopt it without waiting for us, and later revisions may fall back
+	// to it.
+	if err := r.approve(ctx, comp, latest); err != nil {
+		log.Debug(errApprove, "error", err)
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileCanary approves the supplied Staged revision once every canary
+// composite resource adopted it and is ready.
+func (r *Reconciler) reconcileCanary(ctx context.Context, log logging.Logger, comp *v1.Composition, latest *v1.CompositionRevision) (reconcile.Result, error) {
+	// A Staged revision without canaries waits for someone to approve it.
+	if composite.Approved(latest) || ptr.Deref(latest.Spec.Rollout.CanaryPercentage, 0) <= 0 {
+		return reconcile.Result{}, nil
+	}
+
+	gvk := schema.FromAPIVersionAndKind(comp.Spec.CompositeTypeRef.APIVersion, comp.Spec.CompositeTypeRef.Kind)
+
+	xrs, err := composite.ListComposites(ctx, r.client, gvk, comp.GetName())
+	if err != nil {
+		log.Debug("Cannot list composite resources", "error", err)
+		r.record.Event(comp, event.Warning(reasonRollout, err))
+
+		return reconcile.Result{}, err
+	}
+
+	if !composite.CanarySucceeded(latest, xrs) {
+		return reconcile.Result{RequeueAfter: r.pollInterval}, nil
+	}
+
+	if err := r.approve(ctx, comp, latest); err != nil {
+		log.Debug(errApprove, "error", err)
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// approve the supplied revision for rollout to all composite resources.
+func (r *Reconciler) approve(ctx context.Context, comp *v1.Composition, rev *v1.CompositionRevision) error {
+	if composite.Approved(rev) {
+		return nil
+	}
+
+	meta.AddAnnotations(rev, map[string]string{v1.AnnotationKeyRolloutApproved: "true"})
+
+	if err := r.client.Update(ctx, rev); err != nil {
+		r.record.Event(comp, event.Warning(reasonRollout, errors.Wrap(err, errApprove)))
+		return errors.Wrap(err, errApprove)
+	}
+
+	r.record.Event(comp, event.Normal(reasonRollout, "Approved "+rev.GetName()+" for rollout to all composite resources"))
+
+	return nil
+}
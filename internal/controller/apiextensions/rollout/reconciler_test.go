@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	comp := func(s *v1.RolloutStatus) *v1.Composition {
+		c := &v1.Composition{
+			ObjectMeta: metav1.ObjectMeta{Name: "cool-comp", UID: "comp-uid"},
+			Spec: v1.CompositionSpec{
+				CompositeTypeRef: v1.TypeReference{APIVersion: "example.org/v1", Kind: "XCoolResource"},
+			},
+			Status: v1.CompositionStatus{Rollout: s},
+		}
+		return c
+	}
+
+	rev := func(rollout *v1.RevisionRollout) v1.CompositionRevision {
+		return v1.CompositionRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "cool-comp-abc123",
+				Labels: map[string]string{v1.LabelCompositionName: "cool-comp"},
+				OwnerReferences: []metav1.OwnerReference{{
+					UID:        "comp-uid",
+					Controller: ptr.To(true),
+				}},
+			},
+			Spec: v1.CompositionRevisionSpec{Revision: 1, Rollout: rollout},
+		}
+	}
+
+	progressive := &v1.RevisionRollout{
+		Strategy: v1.RolloutStrategyProgressive,
+		Waves:    []v1.RolloutWave{{Name: "canary", Percentage: ptr.To[int32](10)}},
+	}
+
+	// An XR with the supplied UID that uses the supplied revision.
+	xr := func(uid types.UID, compName, revName string) kunstructured.Unstructured {
+		x := composite.New()
+		x.SetUID(uid)
+		x.SetCompositionReference(&corev1.ObjectReference{Name: compName})
+		x.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: revName})
+		x.SetConditions(xpv1.Condition{Type: xpv1.TypeReady, Status: corev1.ConditionTrue})
+		return x.Unstructured
+	}
+
+	staged := &v1.RevisionRollout{Strategy: v1.RolloutStrategyStaged}
+	canary := &v1.RevisionRollout{Strategy: v1.RolloutStrategyStaged, CanaryPercentage: ptr.To[int32](10)}
+
+	// A client that returns the supplied Composition, revisions, and XRs.
+	mockClient := func(c *v1.Composition, revs []v1.CompositionRevision, xrs []kunstructured.Unstructured, update test.MockSubResourceUpdateFn, approve test.MockUpdateFn) *test.MockClient {
+		return &test.MockClient{
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				c.DeepCopyInto(obj.(*v1.Composition))
+				return nil
+			}),
+			MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+				switch l := obj.(type) {
+				case *v1.CompositionRevisionList:
+					l.Items = revs
+				case *kunstructured.UnstructuredList:
+					if l.GroupVersionKind() != (schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "XCoolResourceList"}) {
+						return errors.Errorf("wrong list kind %s", l.GroupVersionKind())
+					}
+					l.Items = xrs
+				}
+				return nil
+			}),
+			MockStatusUpdate: update,
+			MockUpdate:       approve,
+		}
+	}
+
+	// A status update that expects the supplied rollout status.
+	wantStatus := func(want *v1.RolloutStatus) test.MockSubResourceUpdateFn {
+		return test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+			if diff := cmp.Diff(want, obj.(*v1.Composition).Status.Rollout); diff != "" {
+				t.Errorf("Status().Update(...): -want, +got:\n%s", diff)
+			}
+			return nil
+		})
+	}
+
+	// An update that expects the revision to be approved.
+	wantApproved := test.NewMockUpdateFn(nil, func(obj client.Object) error {
+		if got := obj.GetAnnotations()[v1.AnnotationKeyRolloutApproved]; got != "true" {
+			t.Errorf("Update(...): want revision approved, got %s annotation %q", v1.AnnotationKeyRolloutApproved, got)
+		}
+		return nil
+	})
+
+	// An update that shouldn't happen.
+	wantNoUpdate := test.NewMockUpdateFn(nil, func(obj client.Object) error {
+		t.Errorf("Update(...): unexpected update of %s", obj.GetName())
+		return nil
+	})
+
+	type want struct {
+		r   reconcile.Result
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client client.Client
+		want   want
+	}{
+		"CompositionNotFound": {
+			reason: "We should not return an error if the Composition was not found.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+			},
+			want: want{r: reconcile.Result{}},
+		},
+		"ListRevisionsError": {
+			reason: "We should return any error encountered listing CompositionRevisions.",
+			client: &test.MockClient{
+				MockGet:  test.NewMockGetFn(nil),
+				MockList: test.NewMockListFn(errBoom),
+			},
+			want: want{err: errors.Wrap(errBoom, errListRevs)},
+		},
+		"NotProgressive": {
+			reason: "We should clear the rollout status if the latest revision doesn't use the Progressive strategy.",
+			client: mockClient(comp(&v1.RolloutStatus{Revision: "cool-comp-abc123"}), []v1.CompositionRevision{rev(nil)}, nil, wantStatus(nil), wantNoUpdate),
+			want:   want{r: reconcile.Result{}},
+		},
+		"Progressing": {
+			reason: "We should report the progress of an incomplete rollout and poll it again later.",
+			client: mockClient(comp(nil), []v1.CompositionRevision{rev(progressive)}, []kunstructured.Unstructured{
+				xr("canary-uid", "cool-comp", "cool-comp-abc123"),
+				xr("stable-uid", "cool-comp", "cool-comp-old"),
+				xr("other-uid", "other-comp", "other-comp-abc123"),
+			}, wantStatus(&v1.RolloutStatus{
+				Revision:       "cool-comp-abc123",
+				Phase:          v1.RolloutPhaseProgressing,
+				CompletedWaves: 1,
+				Total:          2,
+				Updated:        1,
+				Ready:          1,
+			}), wantNoUpdate),
+			want: want{r: reconcile.Result{RequeueAfter: 1 * time.Minute}},
+		},
+		"Complete": {
+			reason: "We should report a complete rollout, approve its revision, and stop polling it.",
+			client: mockClient(comp(nil), []v1.CompositionRevision{rev(progressive)}, []kunstructured.Unstructured{
+				xr("canary-uid", "cool-comp", "cool-comp-abc123"),
+			}, wantStatus(&v1.RolloutStatus{
+				Revision:       "cool-comp-abc123",
+				Phase:          v1.RolloutPhaseComplete,
+				CompletedWaves: 1,
+				Total:          1,
+				Updated:        1,
+				Ready:          1,
+			}), wantApproved),
+			want: want{r: reconcile.Result{}},
+		},
+		"ApproveError": {
+			reason: "We should return any error encountered approving the revision of a complete rollout.",
+			client: mockClient(comp(nil), []v1.CompositionRevision{rev(progressive)}, []kunstructured.Unstructured{
+				xr("canary-uid", "cool-comp", "cool-comp-abc123"),
+			}, test.NewMockSubResourceUpdateFn(nil), test.NewMockUpdateFn(errBoom)),
+			want: want{err: errors.Wrap(errBoom, errApprove)},
+		},
+		"StagedWithoutCanary": {
+			reason: "We shouldn't approve a staged revision without a canary.",
+			client: mockClient(comp(nil), []v1.CompositionRevision{rev(staged)}, nil, nil, wantNoUpdate),
+			want:   want{r: reconcile.Result{}},
+		},
+		"CanaryInProgress": {
+			reason: "We shouldn't approve a staged revision until its canary succeeds, and should poll it again later.",
+			client: mockClient(comp(nil), []v1.CompositionRevision{rev(canary)}, []kunstructured.Unstructured{
+				xr("canary-uid", "cool-comp", "cool-comp-old"),
+				xr("stable-uid", "cool-comp", "cool-comp-old"),
+			}, nil, wantNoUpdate),
+			want: want{r: reconcile.Result{RequeueAfter: 1 * time.Minute}},
+		},
+		"CanarySucceeded": {
+			reason: "We should approve a staged revision once its canary succeeds.",
+			client: mockClient(comp(nil), []v1.CompositionRevision{rev(canary)}, []kunstructured.Unstructured{
+				xr("canary-uid", "cool-comp", "cool-comp-abc123"),
+				xr("stable-uid", "cool-comp", "cool-comp-old"),
+			}, nil, wantApproved),
+			want: want{r: reconcile.Result{}},
+		},
+		"UpdateStatusError": {
+			reason: "We should return any error encountered updating the Composition's status.",
+			client: mockClient(comp(nil), []v1.CompositionRevision{rev(progressive)}, nil, test.NewMockSubResourceUpdateFn(errBoom), wantNoUpdate),
+			want:   want{err: errors.Wrap(errBoom, errUpdateStatus)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewReconciler(tc.client)
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "cool-comp"}})
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -33,4 +33,8 @@ type Options struct {
 
 	// ControllerEngine used to dynamically manage watches.
 	ControllerEngine *engine.ControllerEngine
+
+	// ServiceAccountTokenNamespaces are the namespaces whose ServiceAccounts
+	// Operations may request tokens for.
+	ServiceAccountTokenNamespaces []string
 }
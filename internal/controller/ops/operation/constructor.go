@@ -33,6 +33,8 @@ import (
 
 	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
 	opscontroller "github.com/crossplane/crossplane/v2/internal/controller/ops/controller"
+	"github.com/crossplane/crossplane/v2/internal/features"
+	"github.com/crossplane/crossplane/v2/internal/tracing"
 	"github.com/crossplane/crossplane/v2/internal/xfn"
 )
 
@@ -41,16 +43,32 @@ import (
 func Setup(mgr ctrl.Manager, o opscontroller.Options) error {
 	name := "ops/" + strings.ToLower(v1alpha1.OperationGroupKind)
 
-	r := NewReconciler(mgr,
+	cco := []xfn.RevisionCapabilityCheckerOption{}
+	if o.Features.Enabled(features.EnableAlphaExternalFunctions) {
+		cco = append(cco, xfn.WithExternalFunctionCapabilities())
+	}
+
+	ro := []ReconcilerOption{
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		WithFunctionRunner(o.FunctionRunner))
+		WithFunctionRunner(o.FunctionRunner),
+		WithCapabilityChecker(xfn.NewRevisionCapabilityChecker(mgr.GetClient(), cco...)),
+		// Read credentials directly from the API server, rather than caching
+		// every Secret and ConfigMap in the cluster.
+		WithCredentialsFetcher(xfn.NewExistingCredentialsFetcher(mgr.GetAPIReader(), mgr.GetClient())),
+	}
+
+	if o.Features.Enabled(features.EnableAlphaFunctionServiceAccountTokens) {
+		ro = append(ro, WithServiceAccountTokenCredentials(o.ServiceAccountTokenNamespaces...))
+	}
+
+	r := NewReconciler(mgr, ro...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.Operation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		WithOptions(o.ForControllerRuntime()).
-		Complete(ratelimiter.NewReconciler(name, errors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, errors.WithSilentRequeueOnConflict(tracing.NewReconciler(name, r)), o.GlobalRateLimiter))
 }
 
 // ReconcilerOption is used to configure the Reconciler.
@@ -91,6 +109,25 @@ func WithRequiredResourcesFetcher(f xfn.RequiredResourcesFetcher) ReconcilerOpti
 	}
 }
 
+// WithCredentialsFetcher configures how the Reconciler fetches function
+// credentials.
+func WithCredentialsFetcher(f xfn.CredentialsFetcher) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.credentials = f
+	}
+}
+
+// WithServiceAccountTokenCredentials configures the Reconciler to supply
+// functions with ServiceAccount tokens using the ServiceAccountToken
+// credentials source. Operations may only request tokens for ServiceAccounts
+// in the supplied namespaces.
+func WithServiceAccountTokenCredentials(namespaces ...string) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.tokens = true
+		r.tokenNamespaces = namespaces
+	}
+}
+
 // NewReconciler returns a Reconciler of Usages.
 func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 	r := &Reconciler{
@@ -100,6 +137,8 @@ func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 		conditions: conditions.ObservedGenerationPropagationManager{},
 		functions:  xfn.NewRevisionCapabilityChecker(mgr.GetClient()),
 		resources:  xfn.NewExistingRequiredResourcesFetcher(mgr.GetClient()),
+
+		credentials: xfn.NewExistingCredentialsFetcher(mgr.GetClient(), mgr.GetClient()),
 	}
 
 	for _, f := range opts {
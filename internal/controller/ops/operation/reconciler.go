@@ -26,8 +26,10 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -42,12 +44,16 @@ import (
 
 	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
 	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	"github.com/crossplane/crossplane/v2/internal/tracing"
 	"github.com/crossplane/crossplane/v2/internal/xfn"
 	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
 )
 
 const timeout = 2 * time.Minute
 
+// How often to update an Operation's status with pipeline step progress.
+const progressInterval = 5 * time.Second
+
 // DefaultRetryLimit before an Operation is marked failed.
 const DefaultRetryLimit = 5
 
@@ -74,9 +80,16 @@ type Reconciler struct {
 	record     event.Recorder
 	conditions conditions.Manager
 
-	pipeline  xfn.FunctionRunner
-	functions xfn.CapabilityChecker
-	resources xfn.RequiredResourcesFetcher
+	pipeline    xfn.FunctionRunner
+	functions   xfn.CapabilityChecker
+	resources   xfn.RequiredResourcesFetcher
+	credentials xfn.CredentialsFetcher
+
+	// Operations may only request tokens for ServiceAccounts in these
+	// namespaces, because anyone who can create an Operation could otherwise
+	// get a token for any ServiceAccount.
+	tokens          bool
+	tokenNamespaces []string
 }
 
 // Reconcile an Operation by running its function pipeline.
@@ -176,6 +189,23 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	for _, fn := range op.Spec.Pipeline {
 		log = log.WithValues("step", fn.Step)
 
+		run, err := xfn.EvaluateCondition(fn.When, nil, d, fctx)
+		if err != nil {
+			log.Debug("Cannot evaluate when condition of operation pipeline step", "error", err)
+
+			// An invalid condition requires human intervention to fix, so
+			// we immediately fail this operation without retrying.
+			status.MarkConditions(xpv1.ReconcileSuccess(), v1alpha1.Failed(fmt.Sprintf("cannot evaluate when condition of operation pipeline step %q", fn.Step)))
+			_ = r.client.Status().Update(ctx, op)
+
+			return reconcile.Result{}, errors.Wrapf(err, "cannot evaluate when condition of operation pipeline step %q", fn.Step)
+		}
+
+		if !run {
+			log.Debug("Skipping operation pipeline step because its when condition is false")
+			continue
+		}
+
 		req := &fnv1.RunFunctionRequest{Desired: d, Context: fctx}
 
 		if fn.Input != nil {
@@ -196,17 +226,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 		req.Credentials = map[string]*fnv1.Credentials{}
 		for _, cs := range fn.Credentials {
-			// For now we only support loading credentials from secrets.
-			if cs.Source != v1alpha1.FunctionCredentialsSourceSecret || cs.SecretRef == nil {
-				continue
-			}
-
-			s := &corev1.Secret{}
-			if err := r.client.Get(ctx, client.ObjectKey{Namespace: cs.SecretRef.Namespace, Name: cs.SecretRef.Name}, s); err != nil {
+			creds, err := r.fetchCredentials(ctx, cs)
+			if err != nil {
 				op.Status.Failures++
 
 				log.Debug("Cannot get Operation pipeline step credential", "error", err, "failures", op.Status.Failures, "credential", cs.Name)
-				err = errors.Wrapf(err, "cannot get operation pipeline step %q credential %q from Secret", fn.Step, cs.Name)
+				err = errors.Wrapf(err, "cannot get operation pipeline step %q credential %q", fn.Step, cs.Name)
 				r.record.Event(op, event.Warning(reasonFunctionInvocation, err))
 				status.MarkConditions(xpv1.ReconcileError(err))
 				_ = r.client.Status().Update(ctx, op)
@@ -214,12 +239,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 				return reconcile.Result{}, err
 			}
 
-			req.Credentials[cs.Name] = &fnv1.Credentials{
-				Source: &fnv1.Credentials_CredentialData{
-					CredentialData: &fnv1.CredentialData{
-						Data: s.Data,
-					},
-				},
+			if creds != nil {
+				req.Credentials[cs.Name] = creds
 			}
 		}
 
@@ -249,7 +270,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 		req.Meta = &fnv1.RequestMeta{Tag: xfn.Tag(req)}
 
-		rsp, err := r.pipeline.RunFunction(ctx, fn.FunctionRef.Name, req)
+		// Functions that can stream progress updates report them while they
+		// run, so long-running steps don't look hung.
+		sctx, span := tracing.Start(ctx, "RunPipelineStep", tracing.AttrKeyStep.String(fn.Step), tracing.AttrKeyFunction.String(fn.FunctionRef.Name))
+		rctx := xfn.WithProgressHandler(xfn.WithStepPolicy(sctx, ToStepPolicy(fn)), r.progressHandler(ctx, op, fn.Step, log))
+
+		rsp, err := r.pipeline.RunFunction(rctx, fn.FunctionRef.Name, req)
+		tracing.End(span, err)
+
 		if err != nil {
 			op.Status.Failures++
 
@@ -333,7 +361,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// always be operating on a resource some other controller owns.
 		// TODO(negz): Do we ever want to be an owner reference of these
 		// resources?
-		if err := r.client.Patch(ctx, u, client.Apply, client.ForceOwnership, client.FieldOwner(FieldOwnerPrefix+op.GetUID())); err != nil {
+		actx, span := tracing.Start(ctx, "ApplyDesiredResource", tracing.AttrKeyResource.String(name), tracing.AttrKeyKind.String(u.GetKind()))
+		err := r.client.Patch(actx, u, client.Apply, client.ForceOwnership, client.FieldOwner(FieldOwnerPrefix+op.GetUID()))
+		tracing.End(span, err)
+
+		if err != nil {
 			op.Status.Failures++
 			log.Debug("Cannot apply desired resource", "error", err, "failures", op.Status.Failures, "resource-name", name)
 
@@ -397,6 +429,95 @@ func AddResourceRef(refs []v1alpha1.AppliedResourceRef, u *kunstructured.Unstruc
 	return refs
 }
 
+// progressHandler returns a ProgressHandler that records progress streamed by
+// the supplied pipeline step on the supplied Operation's status. Progress
+// results are emitted as events, and progress conditions are set on the
+// Operation. Progress is written to the API server at most once per
+// progressInterval.
+func (r *Reconciler) progressHandler(ctx context.Context, op *v1alpha1.Operation, step string, log logging.Logger) xfn.ProgressHandler {
+	var last time.Time
+
+	return func(p *fnv1.Progress) {
+		for _, rs := range p.GetResults() {
+			// Progress results are informational. Only fatal results in
+			// the final response stop the Operation.
+			if rs.GetSeverity() == fnv1.Severity_SEVERITY_NORMAL {
+				r.record.Event(op, event.Normal(reasonRunPipelineStep, fmt.Sprintf("Pipeline step %q: %s", step, rs.GetMessage())))
+				continue
+			}
+
+			r.record.Event(op, event.Warning(reasonRunPipelineStep, errors.Errorf("Pipeline step %q: %s", step, rs.GetMessage())))
+		}
+
+		for _, c := range p.GetConditions() {
+			cd := ToCondition(c)
+
+			// Don't let functions set the conditions Crossplane uses to
+			// report the Operation's status.
+			if xpv1.IsSystemConditionType(cd.Type) || cd.Type == v1alpha1.TypeSucceeded || cd.Type == v1alpha1.TypeValidPipeline {
+				continue
+			}
+
+			r.conditions.For(op).MarkConditions(cd)
+		}
+
+		op.Status.Pipeline = SetPipelineStepProgress(op.Status.Pipeline, step, &v1alpha1.PipelineStepProgress{
+			Message:         p.GetMessage(),
+			PercentComplete: p.PercentComplete,
+			LastUpdateTime:  metav1.Now(),
+		})
+
+		if time.Since(last) < progressInterval {
+			return
+		}
+
+		last = time.Now()
+
+		if err := r.client.Status().Update(ctx, op); err != nil {
+			log.Debug("Cannot update Operation status with pipeline step progress", "error", err)
+		}
+	}
+}
+
+// ToCondition converts the supplied function condition to a status condition.
+func ToCondition(c *fnv1.Condition) xpv1.Condition {
+	var status corev1.ConditionStatus
+
+	switch c.GetStatus() {
+	case fnv1.Status_STATUS_CONDITION_TRUE:
+		status = corev1.ConditionTrue
+	case fnv1.Status_STATUS_CONDITION_FALSE:
+		status = corev1.ConditionFalse
+	case fnv1.Status_STATUS_CONDITION_UNKNOWN, fnv1.Status_STATUS_CONDITION_UNSPECIFIED:
+		status = corev1.ConditionUnknown
+	}
+
+	return xpv1.Condition{
+		Type:               xpv1.ConditionType(c.GetType()),
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             xpv1.ConditionReason(c.GetReason()),
+		Message:            c.GetMessage(),
+	}
+}
+
+// SetPipelineStepProgress updates the progress of a pipeline step in the
+// supplied pipeline status slice. If the step already exists, its progress is
+// updated in place. If it doesn't exist, it's appended to the slice.
+func SetPipelineStepProgress(pipeline []v1alpha1.PipelineStepStatus, step string, p *v1alpha1.PipelineStepProgress) []v1alpha1.PipelineStepStatus {
+	for i, ps := range pipeline {
+		if ps.Step == step {
+			pipeline[i].Progress = p
+			return pipeline
+		}
+	}
+
+	return append(pipeline, v1alpha1.PipelineStepStatus{
+		Step:     step,
+		Progress: p,
+	})
+}
+
 // AddPipelineStepOutput updates the output for a pipeline step in the
 // supplied pipeline status slice. If the step already exists, its output is
 // updated in place. If it doesn't exist, it's appended to the slice. The input
@@ -415,6 +536,39 @@ func AddPipelineStepOutput(pipeline []v1alpha1.PipelineStepStatus, step string,
 	})
 }
 
+// ToStepPolicy converts the timeout and retry policy of the supplied API
+// PipelineStep to an xfn StepPolicy.
+func ToStepPolicy(fn v1alpha1.PipelineStep) xfn.StepPolicy {
+	p := xfn.StepPolicy{Step: fn.Step}
+
+	if fn.Timeout != nil {
+		p.Timeout = fn.Timeout.Duration
+	}
+
+	if fn.Retry == nil {
+		return p
+	}
+
+	p.Retry.MaxAttempts = int(ptr.Deref(fn.Retry.MaxAttempts, xfn.DefaultRetryMaxAttempts))
+
+	if fn.Retry.Backoff != nil {
+		p.Retry.Backoff = fn.Retry.Backoff.Duration
+	}
+
+	if fn.Retry.MaxBackoff != nil {
+		p.Retry.MaxBackoff = fn.Retry.MaxBackoff.Duration
+	}
+
+	if fn.Retry.Codes != nil {
+		p.Retry.Codes = make([]string, len(fn.Retry.Codes))
+		for i, c := range fn.Retry.Codes {
+			p.Retry.Codes[i] = string(c)
+		}
+	}
+
+	return p
+}
+
 // ToProtobufResourceSelector converts API RequiredResourceSelector to protobuf ResourceSelector.
 func ToProtobufResourceSelector(r v1alpha1.RequiredResourceSelector) *fnv1.ResourceSelector {
 	selector := &fnv1.ResourceSelector{
@@ -441,3 +595,37 @@ func ToProtobufResourceSelector(r v1alpha1.RequiredResourceSelector) *fnv1.Resou
 
 	return selector
 }
+
+// fetchCredentials fetches the supplied pipeline step credentials. It returns
+// nil credentials for the None source.
+func (r *Reconciler) fetchCredentials(ctx context.Context, cs v1alpha1.FunctionCredentials) (*fnv1.Credentials, error) {
+	switch cs.Source {
+	case v1alpha1.FunctionCredentialsSourceNone:
+		return nil, nil
+	case v1alpha1.FunctionCredentialsSourceSecret:
+		if cs.SecretRef == nil {
+			return nil, errors.New("the Secret source requires a secretRef")
+		}
+		return r.credentials.FetchSecret(ctx, types.NamespacedName{Namespace: cs.SecretRef.Namespace, Name: cs.SecretRef.Name})
+	case v1alpha1.FunctionCredentialsSourceConfigMap:
+		if cs.ConfigMapRef == nil {
+			return nil, errors.New("the ConfigMap source requires a configMapRef")
+		}
+		return r.credentials.FetchConfigMap(ctx, types.NamespacedName{Namespace: cs.ConfigMapRef.Namespace, Name: cs.ConfigMapRef.Name})
+	case v1alpha1.FunctionCredentialsSourceServiceAccountToken:
+		if !r.tokens {
+			return nil, errors.New("the ServiceAccountToken source requires the --enable-function-service-account-tokens feature flag")
+		}
+		sat := cs.ServiceAccountToken
+		if sat == nil {
+			return nil, errors.New("the ServiceAccountToken source requires a serviceAccountToken")
+		}
+		if !slices.Contains(r.tokenNamespaces, sat.ServiceAccountRef.Namespace) {
+			return nil, errors.Errorf("cannot request a token for ServiceAccount %q; Operations may not request tokens for ServiceAccounts in namespace %q", sat.ServiceAccountRef.Name, sat.ServiceAccountRef.Namespace)
+		}
+		ref := types.NamespacedName{Namespace: sat.ServiceAccountRef.Namespace, Name: sat.ServiceAccountRef.Name}
+		return r.credentials.FetchServiceAccountToken(ctx, ref, sat.Audiences, ptr.Deref(sat.ExpirationSeconds, 0))
+	}
+
+	return nil, errors.Errorf("unknown credentials source %q", cs.Source)
+}
@@ -20,11 +20,14 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/structpb"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -467,6 +470,82 @@ func TestReconcile(t *testing.T) {
 				err: cmpopts.AnyError,
 			},
 		},
+		"InvalidWhenConditionError": {
+			reason: "We should return an error if we can't evaluate a step's when condition.",
+			params: params{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							op := &v1alpha1.Operation{
+								Spec: v1alpha1.OperationSpec{
+									Pipeline: []v1alpha1.PipelineStep{
+										{
+											Step: "maybe",
+											FunctionRef: v1alpha1.FunctionReference{
+												Name: "function-cool",
+											},
+											When: "context.",
+										},
+									},
+								},
+							}
+							op.DeepCopyInto(obj.(*v1alpha1.Operation))
+
+							return nil
+						}),
+						MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					},
+				},
+				opts: []ReconcilerOption{
+					WithCapabilityChecker(xfn.CapabilityCheckerFn(func(_ context.Context, _ []string, _ ...string) error {
+						return nil
+					})),
+				},
+			},
+			want: want{
+				r:   reconcile.Result{},
+				err: cmpopts.AnyError,
+			},
+		},
+		"SkipStep": {
+			reason: "We shouldn't run a step whose when condition is false.",
+			params: params{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							op := &v1alpha1.Operation{
+								Spec: v1alpha1.OperationSpec{
+									Pipeline: []v1alpha1.PipelineStep{
+										{
+											Step: "never",
+											FunctionRef: v1alpha1.FunctionReference{
+												Name: "function-cool",
+											},
+											When: "has(context.enabled)",
+										},
+									},
+								},
+							}
+							op.DeepCopyInto(obj.(*v1alpha1.Operation))
+
+							return nil
+						}),
+						MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					},
+				},
+				opts: []ReconcilerOption{
+					WithCapabilityChecker(xfn.CapabilityCheckerFn(func(_ context.Context, _ []string, _ ...string) error {
+						return nil
+					})),
+					WithFunctionRunner(xfn.FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+						return nil, errors.New("boom")
+					})),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
 		"Success": {
 			reason: "We shouldn't return an error if we successfully run the Operation",
 			params: params{
@@ -675,6 +754,68 @@ func TestAddPipelineStepOutput(t *testing.T) {
 	}
 }
 
+func TestSetPipelineStepProgress(t *testing.T) {
+	type args struct {
+		pipeline []v1alpha1.PipelineStepStatus
+		step     string
+		progress *v1alpha1.PipelineStepProgress
+	}
+
+	type want struct {
+		pipeline []v1alpha1.PipelineStepStatus
+	}
+
+	output := &runtime.RawExtension{Raw: []byte(`{"key": "value"}`)}
+	progress := &v1alpha1.PipelineStepProgress{Message: "Migrating", PercentComplete: ptr.To[int32](50)}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"AddNewStep": {
+			reason: "Should add a step that isn't in the pipeline status yet",
+			args: args{
+				pipeline: []v1alpha1.PipelineStepStatus{
+					{Step: "step1", Output: output},
+				},
+				step:     "step2",
+				progress: progress,
+			},
+			want: want{
+				pipeline: []v1alpha1.PipelineStepStatus{
+					{Step: "step1", Output: output},
+					{Step: "step2", Progress: progress},
+				},
+			},
+		},
+		"UpdateExistingStep": {
+			reason: "Should update existing step progress in place, preserving its output",
+			args: args{
+				pipeline: []v1alpha1.PipelineStepStatus{
+					{Step: "step1", Output: output},
+				},
+				step:     "step1",
+				progress: progress,
+			},
+			want: want{
+				pipeline: []v1alpha1.PipelineStepStatus{
+					{Step: "step1", Output: output, Progress: progress},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SetPipelineStepProgress(tc.args.pipeline, tc.args.step, tc.args.progress)
+			if diff := cmp.Diff(tc.want.pipeline, got); diff != "" {
+				t.Errorf("\n%s\nSetPipelineStepProgress(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestToProtobufResourceSelector(t *testing.T) {
 	type args struct {
 		selector v1alpha1.RequiredResourceSelector
@@ -973,3 +1114,127 @@ func TestAddResourceRef(t *testing.T) {
 		})
 	}
 }
+
+func TestProgressHandler(t *testing.T) {
+	type want struct {
+		conditions []v1.Condition
+	}
+
+	cases := map[string]struct {
+		reason   string
+		progress *fnv1.Progress
+		want     want
+	}{
+		"Conditions": {
+			reason: "We should set progress conditions on the Operation.",
+			progress: &fnv1.Progress{
+				Conditions: []*fnv1.Condition{
+					{Type: "Migrated", Status: fnv1.Status_STATUS_CONDITION_FALSE, Reason: "InProgress", Message: ptr.To("Migrated 5 of 10 resources")},
+				},
+			},
+			want: want{
+				conditions: []v1.Condition{
+					{Type: "Migrated", Status: corev1.ConditionFalse, Reason: "InProgress", Message: "Migrated 5 of 10 resources"},
+				},
+			},
+		},
+		"ReservedConditions": {
+			reason: "We shouldn't let progress conditions override the conditions Crossplane uses to report the Operation's status.",
+			progress: &fnv1.Progress{
+				Conditions: []*fnv1.Condition{
+					{Type: string(v1.TypeSynced), Status: fnv1.Status_STATUS_CONDITION_TRUE, Reason: "Cool"},
+					{Type: string(v1alpha1.TypeSucceeded), Status: fnv1.Status_STATUS_CONDITION_TRUE, Reason: "Cool"},
+					{Type: string(v1alpha1.TypeValidPipeline), Status: fnv1.Status_STATUS_CONDITION_FALSE, Reason: "Cool"},
+				},
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewReconciler(&fake.Manager{
+				Client: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+			})
+			op := &v1alpha1.Operation{}
+
+			r.progressHandler(context.Background(), op, "cool-step", r.log)(tc.progress)
+
+			if diff := cmp.Diff(tc.want.conditions, op.Status.Conditions, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(v1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nprogressHandler(...): -want conditions, +got conditions:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFetchCredentials(t *testing.T) {
+	token := func(_ context.Context, _, sub client.Object, _ ...client.SubResourceCreateOption) error {
+		tr := sub.(*authenticationv1.TokenRequest)
+		tr.Status.Token = "cool-token"
+		tr.Status.ExpirationTimestamp = metav1.NewTime(time.Now().Add(time.Hour))
+		return nil
+	}
+
+	cs := v1alpha1.FunctionCredentials{
+		Name:   "cool-creds",
+		Source: v1alpha1.FunctionCredentialsSourceServiceAccountToken,
+		ServiceAccountToken: &v1alpha1.ServiceAccountTokenSource{
+			ServiceAccountRef: v1alpha1.ServiceAccountReference{Namespace: "cool-ns", Name: "cool-sa"},
+			Audiences:         []string{"cool-audience"},
+		},
+	}
+
+	type want struct {
+		creds *fnv1.Credentials
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		opts   []ReconcilerOption
+		want   want
+	}{
+		"TokensDisabled": {
+			reason: "We should return an error if ServiceAccount token credentials aren't enabled.",
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"NamespaceNotAllowed": {
+			reason: "We should return an error if the ServiceAccount isn't in an allowed namespace.",
+			opts:   []ReconcilerOption{WithServiceAccountTokenCredentials("other-ns")},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"NamespaceAllowed": {
+			reason: "We should return a token if the ServiceAccount is in an allowed namespace.",
+			opts:   []ReconcilerOption{WithServiceAccountTokenCredentials("other-ns", "cool-ns")},
+			want: want{
+				creds: xfn.CredentialsFromData(map[string][]byte{xfn.CredentialsKeyToken: []byte("cool-token")}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mgr := &fake.Manager{Client: &test.MockClient{}}
+			opts := append([]ReconcilerOption{
+				WithCredentialsFetcher(xfn.NewExistingCredentialsFetcher(nil, &test.MockClient{MockSubResourceCreate: token})),
+			}, tc.opts...)
+			r := NewReconciler(mgr, opts...)
+
+			creds, err := r.fetchCredentials(context.Background(), cs)
+
+			if diff := cmp.Diff(tc.want.creds, creds, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nr.fetchCredentials(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.fetchCredentials(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
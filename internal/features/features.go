@@ -42,6 +42,84 @@ const (
 	// EnableAlphaOperations enables alpha support for Operations, including
 	// CronOperations and WatchOperations.
 	EnableAlphaOperations feature.Flag = "EnableAlphaOperations"
+
+	// EnableAlphaExternalFunctions enables alpha support for
+	// ExternalFunctions, i.e. composition functions that Crossplane calls
+	// but doesn't install or run.
+	EnableAlphaExternalFunctions feature.Flag = "EnableAlphaExternalFunctions"
+
+	// EnableAlphaPipelineTraces enables alpha support for recording what each
+	// step of a composition function pipeline did, for XRs and Compositions
+	// that opt in.
+	EnableAlphaPipelineTraces feature.Flag = "EnableAlphaPipelineTraces"
+
+	// EnableAlphaCompositionPreviews enables alpha support for
+	// CompositionPreviews, which show what a Composition would do to a
+	// composite resource without applying anything.
+	EnableAlphaCompositionPreviews feature.Flag = "EnableAlphaCompositionPreviews"
+
+	// EnableAlphaStagedRollouts enables alpha support for rolling out new
+	// CompositionRevisions in stages, gated by approval, a canary, or
+	// progressive waves.
+	EnableAlphaStagedRollouts feature.Flag = "EnableAlphaStagedRollouts"
+
+	// EnableAlphaCompositionSelectionPolicies enables alpha support for
+	// selecting a Composition for an XR by CEL expression, and at random
+	// weighted by each Composition's selection weight.
+	EnableAlphaCompositionSelectionPolicies feature.Flag = "EnableAlphaCompositionSelectionPolicies"
+
+	// EnableAlphaReadinessChecks enables alpha support for declarative
+	// readiness checks of composed resources, defined in the Composition.
+	EnableAlphaReadinessChecks feature.Flag = "EnableAlphaReadinessChecks"
+
+	// EnableAlphaComposedResourceOrdering enables alpha support for ordering
+	// the creation and deletion of composed resources using the
+	// crossplane.io/depends-on annotation.
+	EnableAlphaComposedResourceOrdering feature.Flag = "EnableAlphaComposedResourceOrdering"
+
+	// EnableAlphaComposedResourceDeletePolicies enables alpha support for
+	// orphaning composed resources when they're removed from the desired
+	// state, or when their composite resource is deleted.
+	EnableAlphaComposedResourceDeletePolicies feature.Flag = "EnableAlphaComposedResourceDeletePolicies"
+
+	// EnableAlphaCompositeResourceConversion enables alpha support for
+	// converting composite resources and claims between the versions of an
+	// XRD using a conversion webhook served by Crossplane.
+	EnableAlphaCompositeResourceConversion feature.Flag = "EnableAlphaCompositeResourceConversion"
+
+	// EnableAlphaStorageVersionMigration enables alpha support for migrating
+	// composite resources and claims to their XRD's referenceable version,
+	// and pruning older versions from their CRDs' stored versions.
+	EnableAlphaStorageVersionMigration feature.Flag = "EnableAlphaStorageVersionMigration"
+
+	// EnableAlphaCompositeResourceValidationRules enables alpha support for
+	// adding an XRD's validation rules and immutable fields to the schemas of
+	// the CRDs it defines.
+	EnableAlphaCompositeResourceValidationRules feature.Flag = "EnableAlphaCompositeResourceValidationRules"
+
+	// EnableAlphaClaimFieldMappings enables alpha support for customizing
+	// which fields propagate between claims and composite resources.
+	EnableAlphaClaimFieldMappings feature.Flag = "EnableAlphaClaimFieldMappings"
+
+	// EnableAlphaClaimPolicies enables alpha support for XRD claim policies,
+	// which limit how many claims each namespace may create and which
+	// Compositions they may use.
+	EnableAlphaClaimPolicies feature.Flag = "EnableAlphaClaimPolicies"
+
+	// EnableAlphaFunctionEndpointSlices enables alpha support for resolving
+	// the endpoints of function packages by reading their Service's
+	// EndpointSlices, rather than using DNS.
+	EnableAlphaFunctionEndpointSlices feature.Flag = "EnableAlphaFunctionEndpointSlices"
+
+	// EnableAlphaFunctionCircuitBreaker enables alpha support for per-function
+	// circuit breakers, which make calls to functions that appear to be
+	// unhealthy fail fast.
+	EnableAlphaFunctionCircuitBreaker feature.Flag = "EnableAlphaFunctionCircuitBreaker"
+
+	// EnableAlphaFunctionServiceAccountTokens enables alpha support for
+	// supplying functions with short-lived ServiceAccount tokens as
+	// credentials.
+	EnableAlphaFunctionServiceAccountTokens feature.Flag = "EnableAlphaFunctionServiceAccountTokens"
 )
 
 // Beta Feature Flags.
@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+// Package tracing contains functionality for emitting OpenTelemetry traces.
+package tracing
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/stats"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+// Error strings.
+const (
+	errCreateExporter     = "cannot create trace exporter"
+	errCreateResource     = "cannot create trace resource"
+	errFmtUnknownExporter = "unknown trace exporter %q"
+)
+
+// TracerName is the name of the OpenTelemetry tracer Crossplane uses.
+const TracerName = "github.com/crossplane/crossplane"
+
+// Supported trace exporters.
+const (
+	// ExporterNone disables tracing.
+	ExporterNone = "none"
+
+	// ExporterOTLP exports traces to an OTLP collector using gRPC.
+	ExporterOTLP = "otlp"
+
+	// ExporterStdout writes traces to stdout. It's useful for debugging.
+	ExporterStdout = "stdout"
+)
+
+// Span attribute keys.
+const (
+	AttrKeyController  = attribute.Key("crossplane.controller")
+	AttrKeyName        = attribute.Key("crossplane.name")
+	AttrKeyNamespace   = attribute.Key("crossplane.namespace")
+	AttrKeyKind        = attribute.Key("crossplane.kind")
+	AttrKeyStep        = attribute.Key("crossplane.step")
+	AttrKeyFunction    = attribute.Key("crossplane.function")
+	AttrKeyIteration   = attribute.Key("crossplane.iteration")
+	AttrKeyRequirement = attribute.Key("crossplane.requirement")
+	AttrKeyResource    = attribute.Key("crossplane.resource")
+)
+
+// Start a span with the supplied name and attributes, using Crossplane's
+// tracer. Spans are no-ops unless a TracerProvider has been configured.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End the supplied span. The span's status is set to error if the supplied
+// error is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// A Reconciler starts a span for each reconcile of the wrapped Reconciler.
+type Reconciler struct {
+	name    string
+	wrapped reconcile.Reconciler
+}
+
+// NewReconciler wraps the supplied Reconciler, starting a span for each
+// reconcile.
+func NewReconciler(name string, r reconcile.Reconciler) *Reconciler {
+	return &Reconciler{name: name, wrapped: r}
+}
+
+// Reconcile the supplied request in a new span.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := Start(ctx, "Reconcile",
+		AttrKeyController.String(r.name),
+		AttrKeyName.String(req.Name),
+		AttrKeyNamespace.String(req.Namespace),
+	)
+	res, err := r.wrapped.Reconcile(ctx, req)
+	End(span, err)
+
+	return res, err
+}
+
+// ProviderOptions configure a TracerProvider.
+type ProviderOptions struct {
+	// Exporter is the trace exporter to use.
+	Exporter string
+
+	// Endpoint is the OTLP collector endpoint. If empty the endpoint is read
+	// from the standard OTEL_EXPORTER_OTLP_ENDPOINT environment variable.
+	Endpoint string
+
+	// Insecure disables TLS when exporting to an OTLP collector.
+	Insecure bool
+
+	// SampleRatio is the fraction of traces to sample.
+	SampleRatio float64
+
+	// Version of Crossplane, recorded as the service version.
+	Version string
+
+	// Writer that traces are written to when using the stdout exporter.
+	Writer io.Writer
+}
+
+// NewTracerProvider returns a TracerProvider that exports traces using the
+// supplied options. It returns nil if tracing is disabled.
+func NewTracerProvider(ctx context.Context, o ProviderOptions) (*sdktrace.TracerProvider, error) {
+	var exp sdktrace.SpanExporter
+
+	switch o.Exporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterOTLP:
+		eo := []otlptracegrpc.Option{}
+		if o.Endpoint != "" {
+			eo = append(eo, otlptracegrpc.WithEndpoint(o.Endpoint))
+		}
+		if o.Insecure {
+			eo = append(eo, otlptracegrpc.WithInsecure())
+		}
+		e, err := otlptracegrpc.New(ctx, eo...)
+		if err != nil {
+			return nil, errors.Wrap(err, errCreateExporter)
+		}
+		exp = e
+	case ExporterStdout:
+		eo := []stdouttrace.Option{}
+		if o.Writer != nil {
+			eo = append(eo, stdouttrace.WithWriter(o.Writer))
+		}
+		e, err := stdouttrace.New(eo...)
+		if err != nil {
+			return nil, errors.Wrap(err, errCreateExporter)
+		}
+		exp = e
+	default:
+		return nil, errors.Errorf(errFmtUnknownExporter, o.Exporter)
+	}
+
+	res, err := sdkresource.Merge(sdkresource.Default(), sdkresource.NewSchemaless(
+		attribute.String("service.name", "crossplane"),
+		attribute.String("service.version", o.Version),
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateResource)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.SampleRatio))),
+	), nil
+}
+
+// Propagator returns the propagator Crossplane uses to propagate trace context.
+// It uses W3C trace context.
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// ClientStatsHandler returns a gRPC stats handler that starts a span for each
+// gRPC call, and propagates its trace context to the server using gRPC
+// metadata.
+func ClientStatsHandler() stats.Handler {
+	return otelgrpc.NewClientHandler(otelgrpc.WithPropagators(Propagator()))
+}
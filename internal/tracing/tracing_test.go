@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+)
+
+// A span as recorded by a SpanRecorder, reduced to what we want to compare.
+type span struct {
+	Name       string
+	Attributes []attribute.KeyValue
+	Status     codes.Code
+}
+
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	orig := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(orig) })
+
+	return sr
+}
+
+func spans(sr *tracetest.SpanRecorder) []span {
+	out := []span{}
+	for _, s := range sr.Ended() {
+		out = append(out, span{Name: s.Name(), Attributes: s.Attributes(), Status: s.Status().Code})
+	}
+
+	return out
+}
+
+func TestReconciler(t *testing.T) {
+	type want struct {
+		spans []span
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		r      reconcile.Reconciler
+		want   want
+	}{
+		"Success": {
+			reason: "We should record a span for a successful reconcile.",
+			r: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, nil
+			}),
+			want: want{
+				spans: []span{{
+					Name: "Reconcile",
+					Attributes: []attribute.KeyValue{
+						AttrKeyController.String("cool-controller"),
+						AttrKeyName.String("cool-xr"),
+						AttrKeyNamespace.String("default"),
+					},
+					Status: codes.Unset,
+				}},
+			},
+		},
+		"ChildSpan": {
+			reason: "Spans started by the wrapped reconciler should be children of the reconcile span.",
+			r: reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				_, span := Start(ctx, "Child")
+				End(span, nil)
+				return reconcile.Result{}, nil
+			}),
+			want: want{
+				spans: []span{
+					{Name: "Child", Status: codes.Unset},
+					{
+						Name: "Reconcile",
+						Attributes: []attribute.KeyValue{
+							AttrKeyController.String("cool-controller"),
+							AttrKeyName.String("cool-xr"),
+							AttrKeyNamespace.String("default"),
+						},
+						Status: codes.Unset,
+					},
+				},
+			},
+		},
+		"Error": {
+			reason: "We should record the error returned by a failed reconcile.",
+			r: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("boom")
+			}),
+			want: want{
+				spans: []span{{
+					Name: "Reconcile",
+					Attributes: []attribute.KeyValue{
+						AttrKeyController.String("cool-controller"),
+						AttrKeyName.String("cool-xr"),
+						AttrKeyNamespace.String("default"),
+					},
+					Status: codes.Error,
+				}},
+				err: errors.New("boom"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := record(t)
+
+			r := NewReconciler("cool-controller", tc.r)
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "cool-xr"}})
+
+			if diff := cmp.Diff(tc.want.spans, spans(sr), cmpopts.EquateEmpty(), cmp.AllowUnexported(attribute.Value{})); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want spans, +got spans:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if len(sr.Ended()) > 1 {
+				child, parent := sr.Ended()[0], sr.Ended()[1]
+				if child.Parent().SpanID() != parent.SpanContext().SpanID() {
+					t.Errorf("\n%s\nReconcile(...): child span's parent is not the reconcile span", tc.reason)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	type want struct {
+		provider bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      ProviderOptions
+		want   want
+	}{
+		"None": {
+			reason: "We shouldn't return a TracerProvider when tracing is disabled.",
+			o:      ProviderOptions{Exporter: ExporterNone},
+			want:   want{provider: false},
+		},
+		"Stdout": {
+			reason: "We should return a TracerProvider that writes to the supplied writer.",
+			o:      ProviderOptions{Exporter: ExporterStdout, SampleRatio: 1, Writer: &bytes.Buffer{}},
+			want:   want{provider: true},
+		},
+		"Unknown": {
+			reason: "We should return an error for an unknown exporter.",
+			o:      ProviderOptions{Exporter: "carrier-pigeon"},
+			want:   want{err: errors.Errorf(errFmtUnknownExporter, "carrier-pigeon")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tp, err := NewTracerProvider(context.Background(), tc.o)
+
+			if diff := cmp.Diff(tc.want.provider, tp != nil); diff != "" {
+				t.Errorf("\n%s\nNewTracerProvider(...): -want provider, +got provider:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNewTracerProvider(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if tp == nil {
+				return
+			}
+
+			_, span := tp.Tracer(TracerName).Start(context.Background(), "CoolSpan")
+			span.End()
+
+			if err := tp.Shutdown(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if w, ok := tc.o.Writer.(*bytes.Buffer); ok && !strings.Contains(w.String(), "CoolSpan") {
+				t.Errorf("\n%s\nNewTracerProvider(...): want span written to stdout exporter, got:\n%s", tc.reason, w.String())
+			}
+		})
+	}
+}
+
+func TestClientStatsHandler(t *testing.T) {
+	record(t)
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	got := make(chan string, 1)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		got <- strings.Join(md.Get("traceparent"), "")
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(ClientStatsHandler()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, span := Start(context.Background(), "RunPipelineStep")
+	defer span.End()
+
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tp := <-got
+	if !strings.Contains(tp, span.SpanContext().TraceID().String()) {
+		t.Errorf("\nRunFunction(...): want W3C traceparent metadata containing trace ID %s, got %q", span.SpanContext().TraceID(), tp)
+	}
+}
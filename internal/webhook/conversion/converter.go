@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// ContextKeyConversion is the key of the function pipeline context a
+// conversion function can read to learn what versions it's converting
+// between.
+const ContextKeyConversion = "apiextensions.crossplane.io/conversion"
+
+// Error strings.
+const (
+	errFmtGetField     = "cannot get field %q"
+	errFmtSetField     = "cannot set field %q"
+	errFmtDeleteField  = "cannot delete field %q"
+	errFmtRunFunction  = "cannot run conversion function %q"
+	errFmtFatalResult  = "conversion function %q returned a fatal result: %s"
+	errFmtNoDesired    = "conversion function %q didn't return a desired composite resource"
+	errConvertToStruct = "cannot convert resource to protobuf Struct"
+	errConvertContext  = "cannot convert conversion context to protobuf Struct"
+)
+
+// A FunctionRunner runs a composition function.
+type FunctionRunner interface {
+	// RunFunction runs the named composition function.
+	RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error)
+}
+
+// A Converter converts a composite resource or claim to another version.
+type Converter interface {
+	// Convert the supplied resource to the supplied version.
+	Convert(ctx context.Context, c *v1.CompositeResourceConverter, obj *kunstructured.Unstructured, version string) (*kunstructured.Unstructured, error)
+}
+
+// A ConverterFn converts a composite resource or claim to another version.
+type ConverterFn func(ctx context.Context, c *v1.CompositeResourceConverter, obj *kunstructured.Unstructured, version string) (*kunstructured.Unstructured, error)
+
+// Convert the supplied resource to the supplied version.
+func (fn ConverterFn) Convert(ctx context.Context, c *v1.CompositeResourceConverter, obj *kunstructured.Unstructured, version string) (*kunstructured.Unstructured, error) {
+	return fn(ctx, c, obj, version)
+}
+
+// A PipelineConverter converts composite resources and claims by applying an
+// XRD's field mappings, then calling its conversion function, if any.
+type PipelineConverter struct {
+	runner FunctionRunner
+}
+
+// NewPipelineConverter returns a Converter that calls conversion functions
+// using the supplied FunctionRunner.
+func NewPipelineConverter(r FunctionRunner) *PipelineConverter {
+	return &PipelineConverter{runner: r}
+}
+
+// Convert the supplied resource to the supplied version.
+func (p *PipelineConverter) Convert(ctx context.Context, c *v1.CompositeResourceConverter, obj *kunstructured.Unstructured, version string) (*kunstructured.Unstructured, error) {
+	from := obj.GroupVersionKind()
+
+	out, err := MapFields(c.FieldMappings, obj, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.FunctionRef == nil {
+		return out, nil
+	}
+
+	return p.runFunction(ctx, c.FunctionRef.Name, obj, out, from.Version, version)
+}
+
+func (p *PipelineConverter) runFunction(ctx context.Context, name string, observed, desired *kunstructured.Unstructured, from, to string) (*kunstructured.Unstructured, error) {
+	os, err := xfn.AsStruct(observed)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertToStruct)
+	}
+
+	ds, err := xfn.AsStruct(desired)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertToStruct)
+	}
+
+	fctx, err := structpb.NewStruct(map[string]any{
+		ContextKeyConversion: map[string]any{
+			"fromVersion": from,
+			"toVersion":   to,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertContext)
+	}
+
+	req := &fnv1.RunFunctionRequest{
+		Observed: &fnv1.State{Composite: &fnv1.Resource{Resource: os}},
+		Desired:  &fnv1.State{Composite: &fnv1.Resource{Resource: ds}},
+		Context:  fctx,
+	}
+
+	rsp, err := p.runner.RunFunction(ctx, name, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtRunFunction, name)
+	}
+
+	for _, rs := range rsp.GetResults() {
+		if rs.GetSeverity() == fnv1.Severity_SEVERITY_FATAL {
+			return nil, errors.Errorf(errFmtFatalResult, name, rs.GetMessage())
+		}
+	}
+
+	s := rsp.GetDesired().GetComposite().GetResource()
+	if s == nil {
+		return nil, errors.Errorf(errFmtNoDesired, name)
+	}
+
+	out := &kunstructured.Unstructured{}
+	if err := xfn.FromStruct(out, s); err != nil {
+		return nil, errors.Wrap(err, errConvertToStruct)
+	}
+
+	// The API server doesn't let a conversion webhook change anything but
+	// the type and the content of a resource, so we only take those from
+	// the function's desired state.
+	out.Object["metadata"] = desired.Object["metadata"]
+	out.SetGroupVersionKind(desired.GroupVersionKind())
+
+	return out, nil
+}
+
+// MapFields converts the supplied resource to the supplied version by applying
+// the supplied field mappings. It moves fields mapped between the resource's
+// version and the supplied version, and copies all other fields unchanged.
+func MapFields(mappings []v1.ConversionFieldMapping, obj *kunstructured.Unstructured, version string) (*kunstructured.Unstructured, error) {
+	from := obj.GroupVersionKind()
+
+	in := fieldpath.Pave(obj.Object)
+	out := obj.DeepCopy()
+	op := fieldpath.Pave(out.Object)
+
+	for _, m := range mappings {
+		src, dst := m.FromFieldPath, m.ToFieldPath
+
+		switch {
+		case m.FromVersion == from.Version && m.ToVersion == version:
+		case m.ToVersion == from.Version && m.FromVersion == version:
+			src, dst = dst, src
+		default:
+			continue
+		}
+
+		v, err := in.GetValue(src)
+		if fieldpath.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtGetField, src)
+		}
+
+		if err := op.DeleteField(src); err != nil {
+			return nil, errors.Wrapf(err, errFmtDeleteField, src)
+		}
+
+		if err := op.SetValue(dst, v); err != nil {
+			return nil, errors.Wrapf(err, errFmtSetField, dst)
+		}
+	}
+
+	out.SetGroupVersionKind(schema.GroupVersionKind{Group: from.Group, Version: version, Kind: from.Kind})
+
+	return out, nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func xr(apiVersion string, spec map[string]any) *kunstructured.Unstructured {
+	return &kunstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       "XDatabase",
+		"metadata": map[string]any{
+			"name": "cool-xr",
+		},
+		"spec": spec,
+	}}
+}
+
+func TestMapFields(t *testing.T) {
+	mappings := []v1.ConversionFieldMapping{
+		{FromVersion: "v1", FromFieldPath: "spec.size", ToVersion: "v2", ToFieldPath: "spec.parameters.size"},
+		{FromVersion: "v2", FromFieldPath: "spec.region", ToVersion: "v3", ToFieldPath: "spec.location"},
+	}
+
+	type args struct {
+		mappings []v1.ConversionFieldMapping
+		obj      *kunstructured.Unstructured
+		version  string
+	}
+
+	type want struct {
+		obj *kunstructured.Unstructured
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoMappings": {
+			reason: "We should copy all fields and change only the version if there are no mappings.",
+			args: args{
+				obj:     xr("example.org/v1", map[string]any{"size": "large"}),
+				version: "v2",
+			},
+			want: want{
+				obj: xr("example.org/v2", map[string]any{"size": "large"}),
+			},
+		},
+		"Forward": {
+			reason: "We should move fields mapped from the resource's version to the desired version.",
+			args: args{
+				mappings: mappings,
+				obj:      xr("example.org/v1", map[string]any{"size": "large", "region": "us-west-2"}),
+				version:  "v2",
+			},
+			want: want{
+				obj: xr("example.org/v2", map[string]any{"parameters": map[string]any{"size": "large"}, "region": "us-west-2"}),
+			},
+		},
+		"Reverse": {
+			reason: "We should apply mappings in reverse when converting from their to version to their from version.",
+			args: args{
+				mappings: mappings,
+				obj:      xr("example.org/v2", map[string]any{"parameters": map[string]any{"size": "large"}, "region": "us-west-2"}),
+				version:  "v1",
+			},
+			want: want{
+				obj: xr("example.org/v1", map[string]any{"parameters": map[string]any{}, "size": "large", "region": "us-west-2"}),
+			},
+		},
+		"MissingField": {
+			reason: "We should skip mappings whose field isn't set.",
+			args: args{
+				mappings: mappings,
+				obj:      xr("example.org/v1", map[string]any{"region": "us-west-2"}),
+				version:  "v2",
+			},
+			want: want{
+				obj: xr("example.org/v2", map[string]any{"region": "us-west-2"}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := MapFields(tc.args.mappings, tc.args.obj, tc.args.version)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nMapFields(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.obj, got); diff != "" {
+				t.Errorf("\n%s\nMapFields(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPipelineConverterConvert(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	fn := &v1.CompositeResourceConverter{
+		FieldMappings: []v1.ConversionFieldMapping{
+			{FromVersion: "v1", FromFieldPath: "spec.size", ToVersion: "v2", ToFieldPath: "spec.parameters.size"},
+		},
+		FunctionRef: &v1.ConversionFunctionReference{Name: "function-convert"},
+	}
+
+	type params struct {
+		runner FunctionRunner
+	}
+
+	type args struct {
+		c       *v1.CompositeResourceConverter
+		obj     *kunstructured.Unstructured
+		version string
+	}
+
+	type want struct {
+		obj *kunstructured.Unstructured
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		params params
+		args   args
+		want   want
+	}{
+		"FieldMappingsOnly": {
+			reason: "We should only apply field mappings if there's no conversion function.",
+			args: args{
+				c: &v1.CompositeResourceConverter{
+					FieldMappings: fn.FieldMappings,
+				},
+				obj:     xr("example.org/v1", map[string]any{"size": "large"}),
+				version: "v2",
+			},
+			want: want{
+				obj: xr("example.org/v2", map[string]any{"parameters": map[string]any{"size": "large"}}),
+			},
+		},
+		"RunFunctionError": {
+			reason: "We should return any error encountered running the conversion function.",
+			params: params{
+				runner: xfn.FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return nil, errBoom
+				}),
+			},
+			args: args{
+				c:       fn,
+				obj:     xr("example.org/v1", map[string]any{"size": "large"}),
+				version: "v2",
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtRunFunction, "function-convert"),
+			},
+		},
+		"FatalResult": {
+			reason: "We should return an error if the conversion function returns a fatal result.",
+			params: params{
+				runner: xfn.FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return &fnv1.RunFunctionResponse{Results: []*fnv1.Result{{Severity: fnv1.Severity_SEVERITY_FATAL, Message: "nope"}}}, nil
+				}),
+			},
+			args: args{
+				c:       fn,
+				obj:     xr("example.org/v1", map[string]any{"size": "large"}),
+				version: "v2",
+			},
+			want: want{
+				err: errors.Errorf(errFmtFatalResult, "function-convert", "nope"),
+			},
+		},
+		"NoDesiredComposite": {
+			reason: "We should return an error if the conversion function doesn't return a desired composite resource.",
+			params: params{
+				runner: xfn.FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return &fnv1.RunFunctionResponse{}, nil
+				}),
+			},
+			args: args{
+				c:       fn,
+				obj:     xr("example.org/v1", map[string]any{"size": "large"}),
+				version: "v2",
+			},
+			want: want{
+				err: errors.Errorf(errFmtNoDesired, "function-convert"),
+			},
+		},
+		"FunctionConverted": {
+			reason: "We should return the function's desired composite resource, preserving its type and metadata.",
+			params: params{
+				runner: xfn.FunctionRunnerFn(func(_ context.Context, _ string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					// The function should be sent the result of the field mappings.
+					d := req.GetDesired().GetComposite().GetResource().AsMap()
+					size := d["spec"].(map[string]any)["parameters"].(map[string]any)["size"]
+
+					out := xr("example.org/v2", map[string]any{"parameters": map[string]any{"size": size, "replicas": float64(3)}})
+					out.SetName("renamed")
+
+					s, _ := xfn.AsStruct(out)
+
+					return &fnv1.RunFunctionResponse{Desired: &fnv1.State{Composite: &fnv1.Resource{Resource: s}}}, nil
+				}),
+			},
+			args: args{
+				c:       fn,
+				obj:     xr("example.org/v1", map[string]any{"size": "large"}),
+				version: "v2",
+			},
+			want: want{
+				obj: xr("example.org/v2", map[string]any{"parameters": map[string]any{"size": "large", "replicas": float64(3)}}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewPipelineConverter(tc.params.runner)
+
+			got, err := c.Convert(context.Background(), tc.args.c, tc.args.obj, tc.args.version)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.obj, got); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
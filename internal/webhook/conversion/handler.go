@@ -0,0 +1,221 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion contains the Handler for the composite resource
+// conversion webhook.
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+// Path at which the webhook is served.
+const Path = "/convert-composites"
+
+// Error strings.
+const (
+	errDecodeReview      = "cannot decode conversion review"
+	errNoRequest         = "conversion review has no request"
+	errDecodeObject      = "cannot decode object to convert"
+	errEncodeObject      = "cannot encode converted object"
+	errListXRDs          = "cannot list CompositeResourceDefinitions"
+	errFmtParseVersion   = "cannot parse desired API version %q"
+	errFmtNoXRD          = "no CompositeResourceDefinition defines %s"
+	errFmtNoConverter    = "CompositeResourceDefinition %q doesn't configure a converter"
+	errFmtConvert        = "cannot convert %s %q to %s"
+	errFmtGroupMismatch  = "cannot convert %s to a different API group %q"
+	errFmtUnknownVersion = "CompositeResourceDefinition %q doesn't define version %q"
+)
+
+// SetupWebhookWithManager sets up the webhook with the manager.
+func SetupWebhookWithManager(mgr ctrl.Manager, r FunctionRunner, options controller.Options) {
+	h := NewHandler(mgr.GetClient(), NewPipelineConverter(r), WithLogger(options.Logger.WithValues("webhook", "composite-conversion")))
+	mgr.GetWebhookServer().Register(Path, h)
+}
+
+// Handler converts composite resources and claims between the versions of
+// their CompositeResourceDefinition.
+type Handler struct {
+	client    client.Reader
+	converter Converter
+	log       logging.Logger
+}
+
+// HandlerOption is used to configure the Handler.
+type HandlerOption func(*Handler)
+
+// WithLogger configures the logger for the Handler.
+func WithLogger(l logging.Logger) HandlerOption {
+	return func(h *Handler) {
+		h.log = l
+	}
+}
+
+// NewHandler returns a new Handler.
+func NewHandler(c client.Reader, cv Converter, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		client:    c,
+		converter: cv,
+		log:       logging.NewNopLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// ServeHTTP handles a ConversionReview.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &extv1.ConversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+		http.Error(w, errors.Wrap(err, errDecodeReview).Error(), http.StatusBadRequest)
+		return
+	}
+
+	if review.Request == nil {
+		http.Error(w, errNoRequest, http.StatusBadRequest)
+		return
+	}
+
+	rsp := &extv1.ConversionResponse{UID: review.Request.UID}
+
+	objs, err := h.Convert(r.Context(), review.Request.Objects, review.Request.DesiredAPIVersion)
+	if err != nil {
+		h.log.Debug("Cannot convert resources", "error", err, "desiredAPIVersion", review.Request.DesiredAPIVersion)
+		rsp.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+	} else {
+		rsp.ConvertedObjects = objs
+		rsp.Result = metav1.Status{Status: metav1.StatusSuccess}
+	}
+
+	out := &extv1.ConversionReview{
+		TypeMeta: review.TypeMeta,
+		Response: rsp,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.log.Info("Cannot encode conversion review response", "error", err)
+	}
+}
+
+// Convert the supplied objects to the supplied API version.
+func (h *Handler) Convert(ctx context.Context, objs []runtime.RawExtension, apiVersion string) ([]runtime.RawExtension, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtParseVersion, apiVersion)
+	}
+
+	out := make([]runtime.RawExtension, len(objs))
+
+	for i, raw := range objs {
+		u := &kunstructured.Unstructured{}
+		if err := u.UnmarshalJSON(raw.Raw); err != nil {
+			return nil, errors.Wrap(err, errDecodeObject)
+		}
+
+		gvk := u.GroupVersionKind()
+		if gvk.Group != gv.Group {
+			return nil, errors.Errorf(errFmtGroupMismatch, gvk, gv.Group)
+		}
+
+		// Nothing to do.
+		if gvk.Version == gv.Version {
+			out[i] = raw
+			continue
+		}
+
+		xrd, err := h.definitionFor(ctx, gvk.GroupKind())
+		if err != nil {
+			return nil, err
+		}
+
+		if !defines(xrd, gv.Version) {
+			return nil, errors.Errorf(errFmtUnknownVersion, xrd.GetName(), gv.Version)
+		}
+
+		c, err := h.converter.Convert(ctx, xrd.Spec.Converter, u, gv.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtConvert, gvk.Kind, u.GetName(), gv.Version)
+		}
+
+		b, err := c.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrap(err, errEncodeObject)
+		}
+
+		out[i] = runtime.RawExtension{Raw: b}
+	}
+
+	return out, nil
+}
+
+// definitionFor returns the CompositeResourceDefinition that defines the
+// supplied kind of composite resource or claim.
+func (h *Handler) definitionFor(ctx context.Context, gk schema.GroupKind) (*v1.CompositeResourceDefinition, error) {
+	l := &v1.CompositeResourceDefinitionList{}
+	if err := h.client.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errListXRDs)
+	}
+
+	for i := range l.Items {
+		xrd := &l.Items[i]
+		if xrd.Spec.Group != gk.Group {
+			continue
+		}
+
+		if xrd.Spec.Names.Kind != gk.Kind && (!xrd.OffersClaim() || xrd.Spec.ClaimNames.Kind != gk.Kind) {
+			continue
+		}
+
+		if xrd.Spec.Converter == nil {
+			return nil, errors.Errorf(errFmtNoConverter, xrd.GetName())
+		}
+
+		return xrd, nil
+	}
+
+	return nil, errors.Errorf(errFmtNoXRD, gk)
+}
+
+func defines(xrd *v1.CompositeResourceDefinition, version string) bool {
+	for _, v := range xrd.Spec.Versions {
+		if v.Name == version {
+			return true
+		}
+	}
+
+	return false
+}
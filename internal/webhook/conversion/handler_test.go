@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+func raw(t *testing.T, u *kunstructured.Unstructured) runtime.RawExtension {
+	t.Helper()
+
+	b, err := u.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return runtime.RawExtension{Raw: b}
+}
+
+func TestConvert(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	xrd := v1.CompositeResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "xdatabases.example.org"},
+		Spec: v1.CompositeResourceDefinitionSpec{
+			Group:      "example.org",
+			Names:      extv1.CustomResourceDefinitionNames{Kind: "XDatabase"},
+			ClaimNames: &extv1.CustomResourceDefinitionNames{Kind: "Database"},
+			Versions:   []v1.CompositeResourceDefinitionVersion{{Name: "v1"}, {Name: "v2"}},
+			Converter: &v1.CompositeResourceConverter{
+				FieldMappings: []v1.ConversionFieldMapping{
+					{FromVersion: "v1", FromFieldPath: "spec.size", ToVersion: "v2", ToFieldPath: "spec.parameters.size"},
+				},
+			},
+		},
+	}
+
+	noConverter := xrd.DeepCopy()
+	noConverter.Spec.Converter = nil
+
+	list := func(xrds ...v1.CompositeResourceDefinition) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			obj.(*v1.CompositeResourceDefinitionList).Items = xrds
+			return nil
+		}
+	}
+
+	claim := xr("example.org/v1", map[string]any{"size": "large"})
+	claim.SetKind("Database")
+
+	convertedClaim := xr("example.org/v2", map[string]any{"parameters": map[string]any{"size": "large"}})
+	convertedClaim.SetKind("Database")
+
+	type params struct {
+		client client.Reader
+	}
+
+	type args struct {
+		objs       []runtime.RawExtension
+		apiVersion string
+	}
+
+	type want struct {
+		objs []runtime.RawExtension
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		params params
+		args   args
+		want   want
+	}{
+		"SameVersion": {
+			reason: "We shouldn't convert objects that are already the desired version.",
+			params: params{
+				client: &test.MockClient{},
+			},
+			args: args{
+				objs:       []runtime.RawExtension{raw(t, xr("example.org/v2", map[string]any{"size": "large"}))},
+				apiVersion: "example.org/v2",
+			},
+			want: want{
+				objs: []runtime.RawExtension{raw(t, xr("example.org/v2", map[string]any{"size": "large"}))},
+			},
+		},
+		"ListError": {
+			reason: "We should return any error encountered listing XRDs.",
+			params: params{
+				client: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			},
+			args: args{
+				objs:       []runtime.RawExtension{raw(t, xr("example.org/v1", map[string]any{"size": "large"}))},
+				apiVersion: "example.org/v2",
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListXRDs),
+			},
+		},
+		"NoXRD": {
+			reason: "We should return an error if no XRD defines the object's kind.",
+			params: params{
+				client: &test.MockClient{MockList: list()},
+			},
+			args: args{
+				objs:       []runtime.RawExtension{raw(t, xr("example.org/v1", map[string]any{"size": "large"}))},
+				apiVersion: "example.org/v2",
+			},
+			want: want{
+				err: errors.Errorf(errFmtNoXRD, "XDatabase.example.org"),
+			},
+		},
+		"NoConverter": {
+			reason: "We should return an error if the object's XRD doesn't configure a converter.",
+			params: params{
+				client: &test.MockClient{MockList: list(*noConverter)},
+			},
+			args: args{
+				objs:       []runtime.RawExtension{raw(t, xr("example.org/v1", map[string]any{"size": "large"}))},
+				apiVersion: "example.org/v2",
+			},
+			want: want{
+				err: errors.Errorf(errFmtNoConverter, "xdatabases.example.org"),
+			},
+		},
+		"UnknownVersion": {
+			reason: "We should return an error if the XRD doesn't define the desired version.",
+			params: params{
+				client: &test.MockClient{MockList: list(xrd)},
+			},
+			args: args{
+				objs:       []runtime.RawExtension{raw(t, xr("example.org/v1", map[string]any{"size": "large"}))},
+				apiVersion: "example.org/v3",
+			},
+			want: want{
+				err: errors.Errorf(errFmtUnknownVersion, "xdatabases.example.org", "v3"),
+			},
+		},
+		"ConvertXRAndClaim": {
+			reason: "We should convert composite resources and claims using their XRD's converter.",
+			params: params{
+				client: &test.MockClient{MockList: list(xrd)},
+			},
+			args: args{
+				objs: []runtime.RawExtension{
+					raw(t, xr("example.org/v1", map[string]any{"size": "large"})),
+					raw(t, claim),
+				},
+				apiVersion: "example.org/v2",
+			},
+			want: want{
+				objs: []runtime.RawExtension{
+					raw(t, xr("example.org/v2", map[string]any{"parameters": map[string]any{"size": "large"}})),
+					raw(t, convertedClaim),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := NewHandler(tc.params.client, NewPipelineConverter(nil))
+
+			got, err := h.Convert(context.Background(), tc.args.objs, tc.args.apiVersion)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.objs, got); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		body   []byte
+		code   int
+		want   *extv1.ConversionResponse
+	}{
+		"MalformedReview": {
+			reason: "We should return a bad request if the conversion review can't be decoded.",
+			body:   []byte("{"),
+			code:   http.StatusBadRequest,
+		},
+		"NoRequest": {
+			reason: "We should return a bad request if the conversion review has no request.",
+			body:   []byte("{}"),
+			code:   http.StatusBadRequest,
+		},
+		"ConversionFailed": {
+			reason: "We should return a failed response if we can't convert the objects.",
+			body: func() []byte {
+				b, _ := json.Marshal(&extv1.ConversionReview{Request: &extv1.ConversionRequest{UID: types.UID("cool"), DesiredAPIVersion: "example.org/v2/extra"}})
+				return b
+			}(),
+			code: http.StatusOK,
+			want: &extv1.ConversionResponse{
+				UID:    types.UID("cool"),
+				Result: metav1.Status{Status: metav1.StatusFailure, Message: `cannot parse desired API version "example.org/v2/extra": unexpected GroupVersion string: example.org/v2/extra`},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := NewHandler(&test.MockClient{}, NewPipelineConverter(nil))
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(tc.body)))
+
+			if diff := cmp.Diff(tc.code, w.Code); diff != "" {
+				t.Errorf("\n%s\nServeHTTP(...): -want code, +got code:\n%s", tc.reason, diff)
+			}
+
+			if tc.want == nil {
+				return
+			}
+
+			got := &extv1.ConversionReview{}
+			if err := json.Unmarshal(w.Body.Bytes(), got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want, got.Response); diff != "" {
+				t.Errorf("\n%s\nServeHTTP(...): -want response, +got response:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
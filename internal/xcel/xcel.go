@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+// Package xcel evaluates the CEL expressions embedded in Crossplane's APIs.
+package xcel
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"k8s.io/utils/lru"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+const (
+	// DefaultCacheSize is the default number of compiled expressions an
+	// Evaluator caches.
+	DefaultCacheSize = 256
+
+	// DefaultCostLimit is the default runtime cost limit of an expression.
+	// It's the same limit the API server uses for CRD validation rules.
+	DefaultCostLimit = celconfig.PerCallLimit
+
+	// DefaultTimeout is the default time an expression may take to evaluate.
+	DefaultTimeout = 1 * time.Second
+)
+
+const (
+	errNewEnv  = "cannot create CEL environment"
+	errCompile = "cannot compile expression"
+	errProgram = "cannot create program for expression"
+	errNotBool = "expression must evaluate to a bool"
+)
+
+// An Evaluator evaluates CEL expressions in a particular environment. It
+// caches compiled expressions, and bounds how much work evaluating an
+// expression may do. An Evaluator is safe for concurrent use.
+type Evaluator struct {
+	env      func() (*cel.Env, error)
+	programs *lru.Cache
+
+	costLimit uint64
+	timeout   time.Duration
+}
+
+// An EvaluatorOption configures an Evaluator.
+type EvaluatorOption func(e *Evaluator)
+
+// WithCacheSize configures how many compiled expressions an Evaluator caches.
+func WithCacheSize(n int) EvaluatorOption {
+	return func(e *Evaluator) {
+		e.programs = lru.New(n)
+	}
+}
+
+// WithCostLimit configures the runtime cost limit of an expression.
+func WithCostLimit(l uint64) EvaluatorOption {
+	return func(e *Evaluator) {
+		e.costLimit = l
+	}
+}
+
+// WithTimeout configures how long an expression may take to evaluate.
+func WithTimeout(t time.Duration) EvaluatorOption {
+	return func(e *Evaluator) {
+		e.timeout = t
+	}
+}
+
+// NewEvaluator returns an Evaluator that evaluates expressions in a CEL
+// environment built from the supplied options. The environment is built the
+// first time an expression is evaluated.
+func NewEvaluator(env []cel.EnvOption, o ...EvaluatorOption) *Evaluator {
+	e := &Evaluator{
+		env:       sync.OnceValues(func() (*cel.Env, error) { return cel.NewEnv(env...) }),
+		programs:  lru.New(DefaultCacheSize),
+		costLimit: DefaultCostLimit,
+		timeout:   DefaultTimeout,
+	}
+
+	for _, fn := range o {
+		fn(e)
+	}
+
+	return e
+}
+
+// A compiled expression, or the error encountered compiling it.
+type compiled struct {
+	prg cel.Program
+	err error
+}
+
+// EvalBool evaluates the supplied expression with the supplied variables. The
+// expression must evaluate to a bool. EvalBool returns an error if the
+// expression exceeds its cost limit or timeout.
+func (e *Evaluator) EvalBool(expr string, vars map[string]any) (bool, error) {
+	prg, err := e.program(expr)
+	if err != nil {
+		return false, err
+	}
+
+	// The interrupt check frequency only takes effect when the program is
+	// evaluated with a context.
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	out, _, err := prg.ContextEval(ctx, vars)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.New(errNotBool)
+	}
+
+	return b, nil
+}
+
+// program returns the program for the supplied expression, compiling it if it
+// isn't cached. Expressions that fail to compile are cached too, so they
+// aren't compiled again every time they're evaluated.
+func (e *Evaluator) program(expr string) (cel.Program, error) {
+	if c, ok := e.programs.Get(expr); ok {
+		cc := c.(compiled) //nolint:forcetypeassert // We only add compiled values.
+		return cc.prg, cc.err
+	}
+
+	env, err := e.env()
+	if err != nil {
+		// Don't cache this error. It's not specific to the expression.
+		return nil, errors.Wrap(err, errNewEnv)
+	}
+
+	prg, err := e.compile(env, expr)
+	e.programs.Add(expr, compiled{prg: prg, err: err})
+
+	return prg, err
+}
+
+func (e *Evaluator) compile(env *cel.Env, expr string) (cel.Program, error) {
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, errors.Wrap(iss.Err(), errCompile)
+	}
+
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, errors.New(errNotBool)
+	}
+
+	prg, err := env.Program(ast,
+		cel.CostLimit(e.costLimit),
+		cel.InterruptCheckFrequency(celconfig.CheckFrequency),
+	)
+
+	return prg, errors.Wrap(err, errProgram)
+}
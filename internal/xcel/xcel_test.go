@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xcel
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestEvalBool(t *testing.T) {
+	env := []cel.EnvOption{cel.Variable("x", cel.IntType), cel.Variable("l", cel.ListType(cel.IntType))}
+
+	type args struct {
+		expr string
+		vars map[string]any
+	}
+
+	type want struct {
+		ok  bool
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      []EvaluatorOption
+		args   args
+		want   want
+	}{
+		"True": {
+			reason: "We should return true if the expression evaluates to true.",
+			args: args{
+				expr: "x > 1",
+				vars: map[string]any{"x": 2},
+			},
+			want: want{ok: true},
+		},
+		"False": {
+			reason: "We should return false if the expression evaluates to false.",
+			args: args{
+				expr: "x > 1",
+				vars: map[string]any{"x": 0},
+			},
+			want: want{ok: false},
+		},
+		"CompileError": {
+			reason: "We should return an error if the expression doesn't compile.",
+			args: args{
+				expr: "x >",
+			},
+			want: want{err: cmpopts.AnyError},
+		},
+		"NotBool": {
+			reason: "We should return an error if the expression doesn't evaluate to a bool.",
+			args: args{
+				expr: "x + 1",
+				vars: map[string]any{"x": 2},
+			},
+			want: want{err: cmpopts.AnyError},
+		},
+		"CostLimitExceeded": {
+			reason: "We should return an error if evaluating the expression exceeds the cost limit.",
+			o:      []EvaluatorOption{WithCostLimit(10)},
+			args: args{
+				expr: "l.all(a, l.all(b, a != b || a == b))",
+				vars: map[string]any{"l": []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+			},
+			want: want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := NewEvaluator(env, tc.o...)
+
+			// Evaluate twice to make sure cached programs behave the same.
+			for range 2 {
+				ok, err := e.EvalBool(tc.args.expr, tc.args.vars)
+
+				if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+					t.Errorf("\n%s\ne.EvalBool(...): -want, +got:\n%s", tc.reason, diff)
+				}
+
+				if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+					t.Errorf("\n%s\ne.EvalBool(...): -want error, +got error:\n%s", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestEvalBoolCache(t *testing.T) {
+	e := NewEvaluator([]cel.EnvOption{cel.Variable("x", cel.IntType)}, WithCacheSize(1))
+
+	for _, expr := range []string{"x > 1", "x > 2", "x > 1"} {
+		if _, err := e.EvalBool(expr, map[string]any{"x": 2}); err != nil {
+			t.Fatalf("e.EvalBool(%q): %v", expr, err)
+		}
+	}
+
+	if got := e.programs.Len(); got != 1 {
+		t.Errorf("e.programs.Len(): want 1, got %d", got)
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package xcrd
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -26,6 +28,16 @@ import (
 const (
 	// AnnotationKeyCompositionResourceName is the name of the composite resource as described from a composition.
 	AnnotationKeyCompositionResourceName = "crossplane.io/composition-resource-name"
+
+	// AnnotationKeyDependsOn is a comma separated list of the composition
+	// resource names of the composed resources a composed resource depends
+	// on.
+	AnnotationKeyDependsOn = "crossplane.io/depends-on"
+
+	// AnnotationKeyComposedDeletePolicy determines what happens to a
+	// composed resource when it's no longer composed. It may be Delete,
+	// Orphan, or Retain.
+	AnnotationKeyComposedDeletePolicy = "crossplane.io/composed-delete-policy"
 )
 
 // SetCompositionResourceName sets the name of the composition template used to
@@ -39,3 +51,34 @@ func SetCompositionResourceName(o metav1.Object, n string) {
 func GetCompositionResourceName(o metav1.Object) string {
 	return o.GetAnnotations()[AnnotationKeyCompositionResourceName]
 }
+
+// GetDependsOn gets the composition resource names of the composed resources
+// a composed resource depends on from its annotations.
+func GetDependsOn(o metav1.Object) []string {
+	v := o.GetAnnotations()[AnnotationKeyDependsOn]
+	if v == "" {
+		return nil
+	}
+
+	out := make([]string, 0, strings.Count(v, ",")+1)
+
+	for n := range strings.SplitSeq(v, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			out = append(out, n)
+		}
+	}
+
+	return out
+}
+
+// SetComposedDeletePolicy sets the delete policy of a composed resource as an
+// annotation.
+func SetComposedDeletePolicy(o metav1.Object, p string) {
+	meta.AddAnnotations(o, map[string]string{AnnotationKeyComposedDeletePolicy: p})
+}
+
+// GetComposedDeletePolicy gets the delete policy of a composed resource from
+// its annotations.
+func GetComposedDeletePolicy(o metav1.Object) string {
+	return o.GetAnnotations()[AnnotationKeyComposedDeletePolicy]
+}
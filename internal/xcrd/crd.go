@@ -24,12 +24,16 @@ package xcrd
 
 import (
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
 
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 
 	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
@@ -50,9 +54,190 @@ const (
 	errCustomResourceValidationNil = "custom resource validation cannot be nil"
 )
 
+// A CRDOption configures a CustomResourceDefinition derived from a
+// CompositeResourceDefinition.
+type CRDOption func(crd *extv1.CustomResourceDefinition, xrd *v1.CompositeResourceDefinition)
+
+// WithConversionWebhook configures the CustomResourceDefinition to use the
+// supplied conversion webhook if the CompositeResourceDefinition configures a
+// converter.
+func WithConversionWebhook(cc extv1.WebhookClientConfig) CRDOption {
+	return func(crd *extv1.CustomResourceDefinition, xrd *v1.CompositeResourceDefinition) {
+		if xrd.Spec.Converter == nil {
+			return
+		}
+
+		crd.Spec.Conversion = &extv1.CustomResourceConversion{
+			Strategy: extv1.WebhookConverter,
+			Webhook: &extv1.WebhookConversion{
+				ClientConfig:             cc.DeepCopy(),
+				ConversionReviewVersions: []string{"v1"},
+			},
+		}
+	}
+}
+
+// WithValidationRules adds the CompositeResourceDefinition's validation rules
+// to the root of the schema of every version of the CustomResourceDefinition.
+// It's intended for composite resource CRDs; the rules may reference fields
+// that claims don't have.
+func WithValidationRules() CRDOption {
+	return func(crd *extv1.CustomResourceDefinition, xrd *v1.CompositeResourceDefinition) {
+		if len(xrd.Spec.ValidationRules) == 0 {
+			return
+		}
+
+		for i := range crd.Spec.Versions {
+			s := crd.Spec.Versions[i].Schema
+			if s == nil || s.OpenAPIV3Schema == nil {
+				continue
+			}
+
+			for _, r := range xrd.Spec.ValidationRules {
+				s.OpenAPIV3Schema.XValidations = append(s.OpenAPIV3Schema.XValidations, *r.DeepCopy())
+			}
+		}
+	}
+}
+
+// WithImmutableFields adds CEL transition rules to the schema of every version
+// of the CustomResourceDefinition that prevent the CompositeResourceDefinition's
+// immutable fields from being changed or removed once they're set. Fields a
+// version's schema doesn't define are ignored. The CompositeResourceDefinition's
+// schema rejects paths that can't be parsed or that are within arrays, but
+// they're ignored too in case an older CompositeResourceDefinition has them.
+func WithImmutableFields() CRDOption {
+	return func(crd *extv1.CustomResourceDefinition, xrd *v1.CompositeResourceDefinition) {
+		for _, p := range xrd.Spec.ImmutableFields {
+			segments, err := fieldpath.Parse(p)
+			if err != nil {
+				continue
+			}
+
+			path := make([]string, 0, len(segments))
+			for _, s := range segments {
+				if s.Type != fieldpath.SegmentField {
+					break
+				}
+
+				path = append(path, s.Field)
+			}
+
+			// Transition rules aren't supported within arrays.
+			if len(path) == 0 || len(path) != len(segments) {
+				continue
+			}
+
+			for i := range crd.Spec.Versions {
+				s := crd.Spec.Versions[i].Schema
+				if s == nil || s.OpenAPIV3Schema == nil {
+					continue
+				}
+
+				if props, ok := withImmutableField(*s.OpenAPIV3Schema, path, p); ok {
+					s.OpenAPIV3Schema = &props
+				}
+			}
+		}
+	}
+}
+
+// withImmutableField makes the field at the supplied path of the supplied
+// schema immutable. It returns false if the schema doesn't define the field.
+// The supplied name of the field is used in validation messages.
+func withImmutableField(s extv1.JSONSchemaProps, path []string, name string) (extv1.JSONSchemaProps, bool) {
+	if len(path) == 0 {
+		s.XValidations = append(s.XValidations, extv1.ValidationRule{Rule: "self == oldSelf", Message: "Value is immutable"})
+		return s, true
+	}
+
+	child, ok := s.Properties[path[0]]
+	if !ok {
+		return s, false
+	}
+
+	child, ok = withImmutableField(child, path[1:], name)
+	if !ok {
+		return s, false
+	}
+
+	s.Properties[path[0]] = child
+
+	// A transition rule only applies when both the old and new objects set
+	// the field it's on. Removing the immutable field, or any of its
+	// ancestors, would skip the rules below it. So every ancestor must
+	// prevent the immutable field being removed.
+	if r, ok := celPresent(path); ok {
+		s.XValidations = append(s.XValidations, extv1.ValidationRule{
+			Rule:    r,
+			Message: fmt.Sprintf("%s is immutable once set", name),
+		})
+	}
+
+	return s, true
+}
+
+// celPresent returns a CEL transition rule that requires the field at the
+// supplied path to be present if it was present before. It returns false if
+// any of the path's fields can't be selected in a CEL expression.
+func celPresent(path []string) (string, bool) {
+	fields := make([]string, len(path))
+	for i, p := range path {
+		f, ok := celField(p)
+		if !ok {
+			return "", false
+		}
+
+		fields[i] = f
+	}
+
+	// Selecting a field of an absent field is an error, so check each field
+	// along the path is present in turn.
+	has := func(root string) string {
+		checks := make([]string, len(fields))
+		for i := range fields {
+			checks[i] = fmt.Sprintf("has(%s.%s)", root, strings.Join(fields[:i+1], "."))
+		}
+
+		return strings.Join(checks, " && ")
+	}
+
+	if len(fields) == 1 {
+		return fmt.Sprintf("!%s || %s", has("oldSelf"), has("self")), true
+	}
+
+	return fmt.Sprintf("!(%s) || (%s)", has("oldSelf"), has("self")), true
+}
+
+// celReserved are CEL keywords that must be escaped when used as field names.
+var celReserved = map[string]bool{ //nolint:gochecknoglobals // We treat this as a constant.
+	"true": true, "false": true, "null": true, "in": true, "as": true, "break": true,
+	"const": true, "continue": true, "else": true, "for": true, "function": true, "if": true,
+	"import": true, "let": true, "loop": true, "package": true, "namespace": true, "return": true,
+}
+
+// celAccessible matches field names that can be selected in a CEL expression.
+var celAccessible = regexp.MustCompile(`^[a-zA-Z_.\-/][a-zA-Z0-9_.\-/]*$`) //nolint:gochecknoglobals // We treat this as a constant.
+
+// celField returns the supplied field name escaped so that it can be selected
+// in a CEL expression. It returns false if the field can't be selected.
+func celField(name string) (string, bool) {
+	if !celAccessible.MatchString(name) {
+		return "", false
+	}
+
+	if celReserved[name] {
+		return "__" + name + "__", true
+	}
+
+	r := strings.NewReplacer("__", "__underscores__", ".", "__dot__", "-", "__dash__", "/", "__slash__")
+
+	return r.Replace(name), true
+}
+
 // ForCompositeResource derives the CustomResourceDefinition for a composite
 // resource from the supplied CompositeResourceDefinition.
-func ForCompositeResource(xrd *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+func ForCompositeResource(xrd *v1.CompositeResourceDefinition, opts ...CRDOption) (*extv1.CustomResourceDefinition, error) {
 	crd := &extv1.CustomResourceDefinition{
 		Spec: extv1.CustomResourceDefinitionSpec{
 			Group:      xrd.Spec.Group,
@@ -105,12 +290,18 @@ func ForCompositeResource(xrd *v1.CompositeResourceDefinition) (*extv1.CustomRes
 		crd.Spec.Versions[i] = *crdv
 	}
 
+	for _, fn := range opts {
+		fn(crd, xrd)
+	}
+
 	return crd, nil
 }
 
 // ForCompositeResourceClaim derives the CustomResourceDefinition for a
 // composite resource claim from the supplied CompositeResourceDefinition.
-func ForCompositeResourceClaim(xrd *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+// Claims use the same schema as composite resources, even when the
+// CompositeResourceDefinition maps claim fields.
+func ForCompositeResourceClaim(xrd *v1.CompositeResourceDefinition, opts ...CRDOption) (*extv1.CustomResourceDefinition, error) {
 	if err := validateClaimNames(xrd); err != nil {
 		return nil, errors.Wrap(err, errInvalidClaimNames)
 	}
@@ -160,6 +351,10 @@ func ForCompositeResourceClaim(xrd *v1.CompositeResourceDefinition) (*extv1.Cust
 		crd.Spec.Versions[i] = *crdv
 	}
 
+	for _, fn := range opts {
+		fn(crd, xrd)
+	}
+
 	return crd, nil
 }
 
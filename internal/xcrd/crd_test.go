@@ -431,6 +431,18 @@ func TestForCompositeResource(t *testing.T) {
 														},
 													},
 												},
+												"pendingCompositionRevision": {
+													Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+													Type:        "object",
+													Required:    []string{"name"},
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name":             {Type: "string"},
+														"resourcesAdded":   {Type: "integer", Format: "int64"},
+														"resourcesRemoved": {Type: "integer", Format: "int64"},
+														"resourcesChanged": {Type: "integer", Format: "int64"},
+														"previewTime":      {Type: "string", Format: "date-time"},
+													},
+												},
 											},
 											XValidations: extv1.ValidationRules{
 												{
@@ -674,6 +686,18 @@ func TestForCompositeResource(t *testing.T) {
 														},
 													},
 												},
+												"pendingCompositionRevision": {
+													Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+													Type:        "object",
+													Required:    []string{"name"},
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name":             {Type: "string"},
+														"resourcesAdded":   {Type: "integer", Format: "int64"},
+														"resourcesRemoved": {Type: "integer", Format: "int64"},
+														"resourcesChanged": {Type: "integer", Format: "int64"},
+														"previewTime":      {Type: "string", Format: "date-time"},
+													},
+												},
 												"claimConditionTypes": {
 													Type:      "array",
 													XListType: ptr.To("set"),
@@ -956,6 +980,18 @@ func TestForCompositeResource(t *testing.T) {
 														},
 													},
 												},
+												"pendingCompositionRevision": {
+													Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+													Type:        "object",
+													Required:    []string{"name"},
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name":             {Type: "string"},
+														"resourcesAdded":   {Type: "integer", Format: "int64"},
+														"resourcesRemoved": {Type: "integer", Format: "int64"},
+														"resourcesChanged": {Type: "integer", Format: "int64"},
+														"previewTime":      {Type: "string", Format: "date-time"},
+													},
+												},
 												"claimConditionTypes": {
 													Type:      "array",
 													XListType: ptr.To("set"),
@@ -1188,6 +1224,18 @@ func TestForCompositeResource(t *testing.T) {
 														},
 													},
 												},
+												"pendingCompositionRevision": {
+													Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+													Type:        "object",
+													Required:    []string{"name"},
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name":             {Type: "string"},
+														"resourcesAdded":   {Type: "integer", Format: "int64"},
+														"resourcesRemoved": {Type: "integer", Format: "int64"},
+														"resourcesChanged": {Type: "integer", Format: "int64"},
+														"previewTime":      {Type: "string", Format: "date-time"},
+													},
+												},
 												"claimConditionTypes": {
 													Type:      "array",
 													XListType: ptr.To("set"),
@@ -1436,6 +1484,18 @@ func TestForCompositeResource(t *testing.T) {
 														},
 													},
 												},
+												"pendingCompositionRevision": {
+													Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+													Type:        "object",
+													Required:    []string{"name"},
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name":             {Type: "string"},
+														"resourcesAdded":   {Type: "integer", Format: "int64"},
+														"resourcesRemoved": {Type: "integer", Format: "int64"},
+														"resourcesChanged": {Type: "integer", Format: "int64"},
+														"previewTime":      {Type: "string", Format: "date-time"},
+													},
+												},
 												"claimConditionTypes": {
 													Type:      "array",
 													XListType: ptr.To("set"),
@@ -1694,6 +1754,18 @@ func TestForCompositeResource(t *testing.T) {
 														},
 													},
 												},
+												"pendingCompositionRevision": {
+													Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+													Type:        "object",
+													Required:    []string{"name"},
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name":             {Type: "string"},
+														"resourcesAdded":   {Type: "integer", Format: "int64"},
+														"resourcesRemoved": {Type: "integer", Format: "int64"},
+														"resourcesChanged": {Type: "integer", Format: "int64"},
+														"previewTime":      {Type: "string", Format: "date-time"},
+													},
+												},
 												"claimConditionTypes": {
 													Type:      "array",
 													XListType: ptr.To("set"),
@@ -1963,6 +2035,18 @@ func TestForCompositeResource(t *testing.T) {
 														},
 													},
 												},
+												"pendingCompositionRevision": {
+													Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+													Type:        "object",
+													Required:    []string{"name"},
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name":             {Type: "string"},
+														"resourcesAdded":   {Type: "integer", Format: "int64"},
+														"resourcesRemoved": {Type: "integer", Format: "int64"},
+														"resourcesChanged": {Type: "integer", Format: "int64"},
+														"previewTime":      {Type: "string", Format: "date-time"},
+													},
+												},
 												"claimConditionTypes": {
 													Type:      "array",
 													XListType: ptr.To("set"),
@@ -2423,6 +2507,18 @@ func TestForCompositeResourceClaim(t *testing.T) {
 														},
 													},
 												},
+												"pendingCompositionRevision": {
+													Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+													Type:        "object",
+													Required:    []string{"name"},
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name":             {Type: "string"},
+														"resourcesAdded":   {Type: "integer", Format: "int64"},
+														"resourcesRemoved": {Type: "integer", Format: "int64"},
+														"resourcesChanged": {Type: "integer", Format: "int64"},
+														"previewTime":      {Type: "string", Format: "date-time"},
+													},
+												},
 												"claimConditionTypes": {
 													Type:      "array",
 													XListType: ptr.To("set"),
@@ -2681,6 +2777,18 @@ func TestForCompositeResourceClaim(t *testing.T) {
 														},
 													},
 												},
+												"pendingCompositionRevision": {
+													Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+													Type:        "object",
+													Required:    []string{"name"},
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name":             {Type: "string"},
+														"resourcesAdded":   {Type: "integer", Format: "int64"},
+														"resourcesRemoved": {Type: "integer", Format: "int64"},
+														"resourcesChanged": {Type: "integer", Format: "int64"},
+														"previewTime":      {Type: "string", Format: "date-time"},
+													},
+												},
 												"claimConditionTypes": {
 													Type:      "array",
 													XListType: ptr.To("set"),
@@ -2953,6 +3061,18 @@ func TestForCompositeResourceClaimEmptyXrd(t *testing.T) {
 												},
 											},
 										},
+										"pendingCompositionRevision": {
+											Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+											Type:        "object",
+											Required:    []string{"name"},
+											Properties: map[string]extv1.JSONSchemaProps{
+												"name":             {Type: "string"},
+												"resourcesAdded":   {Type: "integer", Format: "int64"},
+												"resourcesRemoved": {Type: "integer", Format: "int64"},
+												"resourcesChanged": {Type: "integer", Format: "int64"},
+												"previewTime":      {Type: "string", Format: "date-time"},
+											},
+										},
 										"claimConditionTypes": {
 											Type:      "array",
 											XListType: ptr.To("set"),
@@ -3174,3 +3294,258 @@ func TestSetCrdMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestWithConversionWebhook(t *testing.T) {
+	cc := extv1.WebhookClientConfig{
+		Service: &extv1.ServiceReference{
+			Name:      "crossplane-webhooks",
+			Namespace: "crossplane-system",
+			Path:      ptr.To("/convert-composites"),
+		},
+		CABundle: []byte("ca"),
+	}
+
+	passthrough := &extv1.CustomResourceConversion{Strategy: extv1.NoneConverter}
+
+	type args struct {
+		crd *extv1.CustomResourceDefinition
+		xrd *v1.CompositeResourceDefinition
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *extv1.CustomResourceConversion
+	}{
+		"NoConverter": {
+			reason: "We should leave the CRD's conversion alone if the XRD doesn't configure a converter.",
+			args: args{
+				crd: &extv1.CustomResourceDefinition{Spec: extv1.CustomResourceDefinitionSpec{Conversion: passthrough}},
+				xrd: &v1.CompositeResourceDefinition{},
+			},
+			want: passthrough,
+		},
+		"Converter": {
+			reason: "We should configure the CRD to use the conversion webhook if the XRD configures a converter.",
+			args: args{
+				crd: &extv1.CustomResourceDefinition{Spec: extv1.CustomResourceDefinitionSpec{Conversion: passthrough}},
+				xrd: &v1.CompositeResourceDefinition{
+					Spec: v1.CompositeResourceDefinitionSpec{
+						Converter: &v1.CompositeResourceConverter{},
+					},
+				},
+			},
+			want: &extv1.CustomResourceConversion{
+				Strategy: extv1.WebhookConverter,
+				Webhook: &extv1.WebhookConversion{
+					ClientConfig:             &cc,
+					ConversionReviewVersions: []string{"v1"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			WithConversionWebhook(cc)(tc.args.crd, tc.args.xrd)
+
+			if diff := cmp.Diff(tc.want, tc.args.crd.Spec.Conversion); diff != "" {
+				t.Errorf("\n%s\nWithConversionWebhook(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithValidationRules(t *testing.T) {
+	rule := extv1.ValidationRule{Rule: "has(self.spec.crossplane.compositionRef)", Message: "A Composition must be selected"}
+	existing := extv1.ValidationRule{Rule: "self.metadata.name.size() < 20"}
+
+	crd := func(rules ...extv1.ValidationRule) *extv1.CustomResourceDefinition {
+		return &extv1.CustomResourceDefinition{
+			Spec: extv1.CustomResourceDefinitionSpec{
+				Versions: []extv1.CustomResourceDefinitionVersion{
+					{Name: "v1", Schema: &extv1.CustomResourceValidation{OpenAPIV3Schema: &extv1.JSONSchemaProps{XValidations: rules}}},
+					{Name: "v2", Schema: &extv1.CustomResourceValidation{OpenAPIV3Schema: &extv1.JSONSchemaProps{XValidations: rules}}},
+				},
+			},
+		}
+	}
+
+	type args struct {
+		crd *extv1.CustomResourceDefinition
+		xrd *v1.CompositeResourceDefinition
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *extv1.CustomResourceDefinition
+	}{
+		"NoRules": {
+			reason: "We should leave the CRD alone if the XRD doesn't configure validation rules.",
+			args: args{
+				crd: crd(existing),
+				xrd: &v1.CompositeResourceDefinition{},
+			},
+			want: crd(existing),
+		},
+		"Rules": {
+			reason: "We should add the XRD's validation rules to the root of every version's schema.",
+			args: args{
+				crd: crd(existing),
+				xrd: &v1.CompositeResourceDefinition{
+					Spec: v1.CompositeResourceDefinitionSpec{
+						ValidationRules: []extv1.ValidationRule{rule},
+					},
+				},
+			},
+			want: crd(existing, rule),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			WithValidationRules()(tc.args.crd, tc.args.xrd)
+
+			if diff := cmp.Diff(tc.want, tc.args.crd); diff != "" {
+				t.Errorf("\n%s\nWithValidationRules(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithImmutableFields(t *testing.T) {
+	// Validation rules at each level of the schema.
+	type rules struct {
+		root       []extv1.ValidationRule
+		spec       []extv1.ValidationRule
+		parameters []extv1.ValidationRule
+	}
+
+	schema := func(region, zone extv1.JSONSchemaProps, r rules) *extv1.JSONSchemaProps {
+		return &extv1.JSONSchemaProps{
+			Type:         "object",
+			XValidations: r.root,
+			Properties: map[string]extv1.JSONSchemaProps{
+				"spec": {
+					Type:         "object",
+					XValidations: r.spec,
+					Properties: map[string]extv1.JSONSchemaProps{
+						"parameters": {
+							Type: "object",
+							Properties: map[string]extv1.JSONSchemaProps{
+								"region":       region,
+								"cloud-zone":   zone,
+								"environments": {Type: "array", Items: &extv1.JSONSchemaPropsOrArray{Schema: &extv1.JSONSchemaProps{Type: "string"}}},
+							},
+							XValidations: r.parameters,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	str := extv1.JSONSchemaProps{Type: "string"}
+	immutable := extv1.JSONSchemaProps{
+		Type:         "string",
+		XValidations: []extv1.ValidationRule{{Rule: "self == oldSelf", Message: "Value is immutable"}},
+	}
+
+	crd := func(schemas ...*extv1.JSONSchemaProps) *extv1.CustomResourceDefinition {
+		c := &extv1.CustomResourceDefinition{}
+		for _, s := range schemas {
+			c.Spec.Versions = append(c.Spec.Versions, extv1.CustomResourceDefinitionVersion{
+				Schema: &extv1.CustomResourceValidation{OpenAPIV3Schema: s},
+			})
+		}
+		return c
+	}
+
+	xrd := func(paths ...string) *v1.CompositeResourceDefinition {
+		return &v1.CompositeResourceDefinition{Spec: v1.CompositeResourceDefinitionSpec{ImmutableFields: paths}}
+	}
+
+	type args struct {
+		crd *extv1.CustomResourceDefinition
+		xrd *v1.CompositeResourceDefinition
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *extv1.CustomResourceDefinition
+	}{
+		"NoImmutableFields": {
+			reason: "We should leave the CRD alone if the XRD doesn't configure immutable fields.",
+			args: args{
+				crd: crd(schema(str, str, rules{})),
+				xrd: xrd(),
+			},
+			want: crd(schema(str, str, rules{})),
+		},
+		"ImmutableField": {
+			reason: "We should prevent an immutable field being changed or removed in every version that defines it.",
+			args: args{
+				crd: crd(schema(str, str, rules{}), &extv1.JSONSchemaProps{Type: "object"}),
+				xrd: xrd("spec.parameters.region"),
+			},
+			want: crd(
+				schema(immutable, str, rules{
+					root: []extv1.ValidationRule{{
+						Rule:    "!(has(oldSelf.spec) && has(oldSelf.spec.parameters) && has(oldSelf.spec.parameters.region)) || (has(self.spec) && has(self.spec.parameters) && has(self.spec.parameters.region))",
+						Message: "spec.parameters.region is immutable once set",
+					}},
+					spec: []extv1.ValidationRule{{
+						Rule:    "!(has(oldSelf.parameters) && has(oldSelf.parameters.region)) || (has(self.parameters) && has(self.parameters.region))",
+						Message: "spec.parameters.region is immutable once set",
+					}},
+					parameters: []extv1.ValidationRule{{
+						Rule:    "!has(oldSelf.region) || has(self.region)",
+						Message: "spec.parameters.region is immutable once set",
+					}},
+				}),
+				&extv1.JSONSchemaProps{Type: "object"},
+			),
+		},
+		"EscapedImmutableField": {
+			reason: "We should escape field names that aren't valid CEL identifiers.",
+			args: args{
+				crd: crd(schema(str, str, rules{})),
+				xrd: xrd("spec.parameters[cloud-zone]"),
+			},
+			want: crd(schema(str, immutable, rules{
+				root: []extv1.ValidationRule{{
+					Rule:    "!(has(oldSelf.spec) && has(oldSelf.spec.parameters) && has(oldSelf.spec.parameters.cloud__dash__zone)) || (has(self.spec) && has(self.spec.parameters) && has(self.spec.parameters.cloud__dash__zone))",
+					Message: "spec.parameters[cloud-zone] is immutable once set",
+				}},
+				spec: []extv1.ValidationRule{{
+					Rule:    "!(has(oldSelf.parameters) && has(oldSelf.parameters.cloud__dash__zone)) || (has(self.parameters) && has(self.parameters.cloud__dash__zone))",
+					Message: "spec.parameters[cloud-zone] is immutable once set",
+				}},
+				parameters: []extv1.ValidationRule{{
+					Rule:    "!has(oldSelf.cloud__dash__zone) || has(self.cloud__dash__zone)",
+					Message: "spec.parameters[cloud-zone] is immutable once set",
+				}},
+			})),
+		},
+		"UnsupportedFields": {
+			reason: "We should ignore invalid paths, paths within arrays, and paths the schema doesn't define.",
+			args: args{
+				crd: crd(schema(str, str, rules{})),
+				xrd: xrd("spec.[", "spec.parameters.environments[0]", "spec.parameters.size"),
+			},
+			want: crd(schema(str, str, rules{})),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			WithImmutableFields()(tc.args.crd, tc.args.xrd)
+
+			if diff := cmp.Diff(tc.want, tc.args.crd); diff != "" {
+				t.Errorf("\n%s\nWithImmutableFields(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -316,6 +316,18 @@ func CompositeResourceStatusProps(s v1.CompositeResourceScope) map[string]extv1.
 				},
 			},
 		},
+		"pendingCompositionRevision": {
+			Description: "A composition revision this resource hasn't adopted yet, because its composition rolls out revisions in stages.",
+			Type:        "object",
+			Required:    []string{"name"},
+			Properties: map[string]extv1.JSONSchemaProps{
+				"name":             {Type: "string"},
+				"resourcesAdded":   {Type: "integer", Format: "int64"},
+				"resourcesRemoved": {Type: "integer", Format: "int64"},
+				"resourcesChanged": {Type: "integer", Format: "int64"},
+				"previewTime":      {Type: "string", Format: "date-time"},
+			},
+		},
 	}
 
 	switch s {
@@ -18,7 +18,6 @@ package cached
 
 import (
 	"context"
-	"io/fs"
 	"path/filepath"
 	"time"
 
@@ -74,6 +73,47 @@ type Metrics interface { //nolint:interfacebloat // Only a little bit bloated. :
 	WriteDuration(name string, d time.Duration)
 }
 
+// A Cache stores serialized CachedRunFunctionResponses.
+type Cache interface {
+	// Get returns the cached response to the named function's request with
+	// the supplied tag. It returns an error that satisfies IsNotCached if
+	// there is no such response.
+	Get(ctx context.Context, name, tag string) ([]byte, error)
+
+	// Set caches the supplied response to the named function's request with
+	// the supplied tag. The cache may discard the response once the supplied
+	// deadline has passed.
+	Set(ctx context.Context, name, tag string, rsp []byte, deadline time.Time) error
+}
+
+// A GarbageCollector garbage collects expired responses from a Cache. Caches
+// that don't expire responses themselves should implement GarbageCollector.
+type GarbageCollector interface {
+	// GarbageCollect deletes cached responses with expired deadlines. It
+	// returns the number of responses it deleted.
+	GarbageCollect(ctx context.Context) (int, error)
+}
+
+type notCachedError struct {
+	key string
+}
+
+func (e notCachedError) Error() string {
+	return "no cached response for " + e.key
+}
+
+// NotCached returns an error indicating there's no cached response to the
+// named function's request with the supplied tag.
+func NotCached(name, tag string) error {
+	return notCachedError{key: filepath.Join(name, tag)}
+}
+
+// IsNotCached returns true if the supplied error indicates there's no cached
+// response.
+func IsNotCached(err error) bool {
+	return errors.As(err, &notCachedError{})
+}
+
 // A FunctionRunner runs a composition function.
 type FunctionRunner interface {
 	// RunFunction runs the named composition function.
@@ -81,15 +121,21 @@ type FunctionRunner interface {
 }
 
 // A FileBackedRunner wraps another function runner. It caches responses
-// returned by that runner to the filesystem. It only caches responses that
-// specify a TTL. Requests are served from cache if there's a cached response
-// for an identical request with an unexpired TTL.
+// returned by that runner. It only caches responses that specify a TTL.
+// Requests are served from cache if there's a cached response for an identical
+// request with an unexpired TTL.
+//
+// By default a FileBackedRunner caches responses to the filesystem. Use
+// WithCache to cache responses somewhere else, for example in memory or in a
+// cache shared by several Crossplane replicas.
 type FileBackedRunner struct {
-	wrapped FunctionRunner
-	fs      afero.Afero
-	maxTTL  time.Duration
-	log     logging.Logger
-	metrics Metrics
+	wrapped   FunctionRunner
+	fs        afero.Fs
+	cache     Cache
+	canonical Canonicalizer
+	maxTTL    time.Duration
+	log       logging.Logger
+	metrics   Metrics
 }
 
 // A FileBackedRunnerOption configures a FileBackedRunner.
@@ -121,10 +167,28 @@ func WithMaxTTL(ttl time.Duration) FileBackedRunnerOption {
 // WithFilesystem specifies which filesystem implementation the FileBackedRunner
 // should use. The runner will ignore its path argument and cache files at the
 // root of this filesystem. Wrap your desired filesystem with afero.BasePathFS
-// to cache files under a specific path.
+// to cache files under a specific path. WithFilesystem has no effect if the
+// runner is configured to use a different Cache.
 func WithFilesystem(fs afero.Fs) FileBackedRunnerOption {
 	return func(r *FileBackedRunner) {
-		r.fs = afero.Afero{Fs: fs}
+		r.fs = fs
+	}
+}
+
+// WithCache specifies which Cache the FileBackedRunner should store responses
+// in. The runner will ignore its path argument.
+func WithCache(c Cache) FileBackedRunnerOption {
+	return func(r *FileBackedRunner) {
+		r.cache = c
+	}
+}
+
+// WithCanonicalizer specifies how the FileBackedRunner should canonicalize
+// requests before using them as cache keys. By default requests are used as
+// is, so any change to a request is a cache miss.
+func WithCanonicalizer(c Canonicalizer) FileBackedRunnerOption {
+	return func(r *FileBackedRunner) {
+		r.canonical = c
 	}
 }
 
@@ -133,7 +197,7 @@ func WithFilesystem(fs afero.Fs) FileBackedRunnerOption {
 func NewFileBackedRunner(wrap FunctionRunner, path string, o ...FileBackedRunnerOption) *FileBackedRunner {
 	r := &FileBackedRunner{
 		wrapped: wrap,
-		fs:      afero.Afero{Fs: afero.NewBasePathFs(afero.NewOsFs(), path)},
+		fs:      afero.NewBasePathFs(afero.NewOsFs(), path),
 		log:     logging.NewNopLogger(),
 		metrics: &NopMetrics{},
 	}
@@ -142,6 +206,10 @@ func NewFileBackedRunner(wrap FunctionRunner, path string, o ...FileBackedRunner
 		fn(r)
 	}
 
+	if r.cache == nil {
+		r.cache = NewFileCache(r.fs, WithFileCacheLogger(r.log), WithFileCacheMetrics(r.metrics))
+	}
+
 	return r
 }
 
@@ -160,23 +228,23 @@ func (r *FileBackedRunner) RunFunction(ctx context.Context, name string, req *fn
 		return r.wrapped.RunFunction(ctx, name, req)
 	}
 
-	key := filepath.Join(name, req.GetMeta().GetTag())
+	t := r.tag(ctx, name, req)
+	key := filepath.Join(name, t)
 	log = log.WithValues("cache-key", key)
 
-	b, err := r.fs.ReadFile(key)
-	if errors.Is(err, fs.ErrNotExist) {
+	b, err := r.cache.Get(ctx, name, t)
+	if IsNotCached(err) {
 		log.Debug("RunFunctionResponse cache miss", "reason", ReasonNotCached)
 		r.metrics.Miss(name)
 
-		return r.CacheFunction(ctx, name, req)
+		return r.cacheFunction(ctx, name, t, req)
 	}
-
 	if err != nil {
 		log.Info("RunFunctionResponse cache miss", "reason", ReasonError, "err", err)
 		r.metrics.Miss(name)
 		r.metrics.Error(name)
 
-		return r.CacheFunction(ctx, name, req)
+		return r.cacheFunction(ctx, name, t, req)
 	}
 
 	crsp := &v1alpha1.CachedRunFunctionResponse{}
@@ -185,7 +253,7 @@ func (r *FileBackedRunner) RunFunction(ctx context.Context, name string, req *fn
 		r.metrics.Miss(name)
 		r.metrics.Error(name)
 
-		return r.CacheFunction(ctx, name, req)
+		return r.cacheFunction(ctx, name, t, req)
 	}
 
 	deadline := crsp.GetDeadline().AsTime()
@@ -196,7 +264,7 @@ func (r *FileBackedRunner) RunFunction(ctx context.Context, name string, req *fn
 		log.Debug("RunFunctionResponse cache miss", "reason", ReasonDeadlineExpired, "deadline", deadline)
 		r.metrics.Miss(name)
 
-		return r.CacheFunction(ctx, name, req)
+		return r.cacheFunction(ctx, name, t, req)
 	}
 
 	log.Debug("RunFunctionResponse cache hit")
@@ -214,7 +282,11 @@ func (r *FileBackedRunner) CacheFunction(ctx context.Context, name string, req *
 		return r.wrapped.RunFunction(ctx, name, req)
 	}
 
-	key := filepath.Join(name, req.GetMeta().GetTag())
+	return r.cacheFunction(ctx, name, r.tag(ctx, name, req), req)
+}
+
+func (r *FileBackedRunner) cacheFunction(ctx context.Context, name, tag string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	key := filepath.Join(name, tag)
 	log := r.log.WithValues("name", name, "cache-key", key)
 
 	rsp, err := r.wrapped.RunFunction(ctx, name, req)
@@ -250,52 +322,40 @@ func (r *FileBackedRunner) CacheFunction(ctx context.Context, name string, req *
 		return rsp, nil
 	}
 
-	if err := r.fs.MkdirAll(name, 0o700); err != nil {
+	if err := r.cache.Set(ctx, name, tag, msg, deadline); err != nil {
 		log.Info("RunFunctionResponse cache write error", "err", err)
 		r.metrics.Error(name)
 
 		return rsp, nil
 	}
 
-	// Write and rename a temp file to make our write 'atomic'. This ensure
-	// we won't overwrite a cache file that we're currently reading.
-	tmp, err := r.fs.TempFile(name, "")
-	if err != nil {
-		log.Info("RunFunctionResponse cache write error", "err", err)
-		r.metrics.Error(name)
-
-		return rsp, nil
-	}
-
-	if _, err := tmp.Write(msg); err != nil {
-		_ = tmp.Close()
+	log.Debug("RunFunctionResponse cache write", "deadline", deadline, "bytes", len(msg))
+	r.metrics.Write(name)
+	r.metrics.WriteDuration(name, time.Since(start))
+	r.metrics.WroteBytes(name, len(msg))
 
-		log.Info("RunFunctionResponse cache write error", "err", err)
-		r.metrics.Error(name)
+	return rsp, nil
+}
 
-		return rsp, nil
+// tag returns the tag used to cache the response to the supplied request. It's
+// the request's own tag, unless the runner canonicalizes requests.
+func (r *FileBackedRunner) tag(ctx context.Context, name string, req *fnv1.RunFunctionRequest) string {
+	if r.canonical == nil {
+		return req.GetMeta().GetTag()
 	}
 
-	if err := tmp.Close(); err != nil {
-		log.Info("RunFunctionResponse cache write error", "err", err)
-		r.metrics.Error(name)
-
-		return rsp, nil
+	creq, err := r.canonical.Canonicalize(ctx, name, req)
+	if err != nil {
+		r.log.Info("Cannot canonicalize RunFunctionRequest - using its tag as the cache key", "name", name, "error", err)
+		return req.GetMeta().GetTag()
 	}
 
-	if err := r.fs.Rename(tmp.Name(), key); err != nil {
-		log.Info("RunFunctionResponse cache write error", "err", err)
-		r.metrics.Error(name)
-
-		return rsp, nil
+	// The request had nothing to canonicalize.
+	if creq == req {
+		return req.GetMeta().GetTag()
 	}
 
-	log.Debug("RunFunctionResponse cache write", "deadline", deadline, "bytes", len(msg))
-	r.metrics.Write(name)
-	r.metrics.WriteDuration(name, time.Since(start))
-	r.metrics.WroteBytes(name, len(msg))
-
-	return rsp, nil
+	return tag(creq)
 }
 
 // GarbageCollectFiles runs every interval until the supplied context is
@@ -318,103 +378,13 @@ func (r *FileBackedRunner) GarbageCollectFiles(ctx context.Context, interval tim
 }
 
 // GarbageCollectFilesNow immediately garbage collects any cached responses with
-// expired deadlines.
+// expired deadlines. It does nothing if the runner's Cache expires responses
+// itself.
 func (r *FileBackedRunner) GarbageCollectFilesNow(ctx context.Context) (int, error) {
-	collected := 0
-	iofs := afero.NewIOFS(r.fs)
-	err := fs.WalkDir(iofs, "/", func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Stop walking if our context is cancelled.
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
-		}
-
-		if d.IsDir() {
-			// Don't try to garbage collect the root of the cache.
-			if path == "/" {
-				return nil
-			}
-
-			entries, err := r.fs.ReadDir(path)
-			if err != nil {
-				r.log.Info("RunFunctionResponse cache error", "path", path, "error", err)
-				return nil
-			}
-
-			if len(entries) > 0 {
-				return nil
-			}
-
-			// Cleanup empty directories. We don't count these as a
-			// garbage collected cache entry.
-			if err := r.fs.Remove(path); err != nil {
-				r.log.Info("RunFunctionResponse cache error", "path", path, "error", err)
-			}
-
-			return nil
-		}
-
-		// The cache layout is like /cache/function-name/request-hash,
-		// so the directory name is our function name.
-		name := filepath.Base(filepath.Dir(path))
-		log := r.log.WithValues("name", name, "cache-key", path)
-
-		b, err := r.fs.ReadFile(path)
-		if err != nil {
-			log.Info("RunFunctionResponse cache error", "error", err)
-			r.metrics.Error(name)
-
-			return nil
-		}
-
-		crsp := &v1alpha1.CachedRunFunctionResponse{}
-		if err := proto.Unmarshal(b, crsp); err != nil {
-			log.Info("RunFunctionResponse cache error", "error", err)
-			r.metrics.Error(name)
-
-			return nil
-		}
-
-		deadline := crsp.GetDeadline().AsTime()
-
-		// Cached response is still valid.
-		if time.Now().Before(deadline) {
-			return nil
-		}
-
-		info, err := d.Info()
-		if err != nil {
-			log.Info("RunFunctionResponse cache error", "error", err)
-			r.metrics.Error(name)
-		}
-
-		// There's a race here. It's possible CacheFunction will write a
-		// new cache entry with a deadline in the future between where
-		// we read the file and here where we remove it. We're okay with
-		// this - it'll just mean we don't cache one response.
-		//
-		// There's no race with reading files. The file content won't
-		// actually be deleted until ReadFile closes the fd.
-		if err := r.fs.Remove(path); err != nil {
-			log.Info("RunFunctionResponse cache error", "error", err)
-			r.metrics.Error(name)
-
-			return nil
-		}
-
-		collected++
-
-		log.Debug("RunFunctionResponse cache delete", "deadline", deadline, "bytes", info.Size())
-		r.metrics.Delete(name)
-		r.metrics.DeletedBytes(name, int(info.Size()))
-
-		return nil
-	})
+	gc, ok := r.cache.(GarbageCollector)
+	if !ok {
+		return 0, nil
+	}
 
-	return collected, err
+	return gc.GarbageCollect(ctx)
 }
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+
+	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// Error strings.
+const (
+	errGetFunction       = "cannot get Function"
+	errFmtExpandField    = "cannot expand field path %q"
+	errFmtDeleteField    = "cannot delete field %q"
+	errCanonicalResource = "cannot canonicalize resource"
+)
+
+// A Canonicalizer returns the canonical form of a request. Requests with the
+// same canonical form share a cached response.
+type Canonicalizer interface {
+	// Canonicalize returns the canonical form of the supplied request to the
+	// named function. It must not modify the supplied request.
+	Canonicalize(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionRequest, error)
+}
+
+// A CanonicalizerFn is a function that canonicalizes a request.
+type CanonicalizerFn func(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionRequest, error)
+
+// Canonicalize calls the function.
+func (fn CanonicalizerFn) Canonicalize(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionRequest, error) {
+	return fn(ctx, name, req)
+}
+
+// VolatileFields returns the paths of resource fields that change without a
+// meaningful change to the resource.
+func VolatileFields() []string {
+	return []string{
+		"metadata.resourceVersion",
+		"metadata.generation",
+		"metadata.managedFields",
+		"status.conditions[*].lastTransitionTime",
+	}
+}
+
+// A FunctionCanonicalizer canonicalizes a request by stripping the resource
+// fields that the response cache configuration of the Function it's sent to
+// says to ignore.
+type FunctionCanonicalizer struct {
+	client client.Reader
+}
+
+// NewFunctionCanonicalizer returns a Canonicalizer that canonicalizes requests
+// according to the response cache configuration of each Function.
+func NewFunctionCanonicalizer(c client.Reader) *FunctionCanonicalizer {
+	return &FunctionCanonicalizer{client: c}
+}
+
+// Canonicalize returns the canonical form of the supplied request to the named
+// Function. It returns the request unchanged if the Function doesn't configure
+// any fields to ignore.
+func (c *FunctionCanonicalizer) Canonicalize(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionRequest, error) {
+	fn := &pkgv1.Function{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name}, fn); err != nil {
+		if kerrors.IsNotFound(err) {
+			return req, nil
+		}
+		return nil, errors.Wrap(err, errGetFunction)
+	}
+
+	rc := fn.Spec.ResponseCache
+	if rc == nil {
+		return req, nil
+	}
+
+	paths := rc.IgnoreFields
+	if rc.IgnoreVolatileFields {
+		paths = append(VolatileFields(), paths...)
+	}
+
+	if len(paths) == 0 {
+		return req, nil
+	}
+
+	return StripFields(req, paths...)
+}
+
+// StripFields returns a copy of the supplied request with the supplied field
+// paths removed from every observed, desired, and required resource. The
+// copy's tag is cleared, because it's derived from the uncanonicalized
+// request.
+func StripFields(req *fnv1.RunFunctionRequest, paths ...string) (*fnv1.RunFunctionRequest, error) {
+	out := proto.CloneOf(req)
+	out.Meta = nil
+
+	resources := make([]*structpb.Struct, 0)
+	for _, s := range []*fnv1.State{out.GetObserved(), out.GetDesired()} {
+		resources = append(resources, s.GetComposite().GetResource())
+		for _, r := range s.GetResources() {
+			resources = append(resources, r.GetResource())
+		}
+	}
+	for _, m := range []map[string]*fnv1.Resources{out.GetExtraResources(), out.GetRequiredResources()} {
+		for _, rs := range m {
+			for _, r := range rs.GetItems() {
+				resources = append(resources, r.GetResource())
+			}
+		}
+	}
+
+	for _, r := range resources {
+		if r == nil {
+			continue
+		}
+		if err := stripFields(r, paths...); err != nil {
+			return nil, errors.Wrap(err, errCanonicalResource)
+		}
+	}
+
+	return out, nil
+}
+
+func stripFields(s *structpb.Struct, paths ...string) error {
+	p := fieldpath.Pave(s.AsMap())
+
+	for _, path := range paths {
+		expanded, err := p.ExpandWildcards(path)
+		if err != nil {
+			return errors.Wrapf(err, errFmtExpandField, path)
+		}
+		for _, e := range expanded {
+			if err := p.DeleteField(e); err != nil {
+				return errors.Wrapf(err, errFmtDeleteField, e)
+			}
+		}
+	}
+
+	ns, err := structpb.NewStruct(p.UnstructuredContent())
+	if err != nil {
+		return err
+	}
+
+	s.Fields = ns.GetFields()
+
+	return nil
+}
+
+// tag uniquely identifies a request. It's computed the same way as the tag
+// Crossplane sends with each request.
+func tag(req *fnv1.RunFunctionRequest) string {
+	m := proto.MarshalOptions{Deterministic: true}
+
+	b, err := m.Marshal(req)
+	if err != nil {
+		return ""
+	}
+
+	h := sha256.Sum256(b)
+
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func MustStruct(v map[string]any) *structpb.Struct {
+	s, err := structpb.NewStruct(v)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Request returns a request whose composite and composed resource have the
+// supplied resourceVersion and condition lastTransitionTime.
+func Request(rv, ltt string) *fnv1.RunFunctionRequest {
+	r := func() *structpb.Struct {
+		return MustStruct(map[string]any{
+			"apiVersion": "example.org/v1",
+			"kind":       "Cool",
+			"metadata": map[string]any{
+				"name":            "cool",
+				"resourceVersion": rv,
+			},
+			"status": map[string]any{
+				"conditions": []any{
+					map[string]any{"type": "Ready", "status": "True", "lastTransitionTime": ltt},
+				},
+			},
+		})
+	}
+
+	return &fnv1.RunFunctionRequest{
+		Meta: &fnv1.RequestMeta{Tag: rv + ltt},
+		Observed: &fnv1.State{
+			Composite: &fnv1.Resource{Resource: r()},
+			Resources: map[string]*fnv1.Resource{"a": {Resource: r()}},
+		},
+	}
+}
+
+func TestStripFields(t *testing.T) {
+	type args struct {
+		req   *fnv1.RunFunctionRequest
+		paths []string
+	}
+
+	type want struct {
+		req *fnv1.RunFunctionRequest
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"VolatileFields": {
+			reason: "Requests that differ only in volatile fields should have the same canonical form.",
+			args: args{
+				req:   Request("42", "2025-01-01T00:00:00Z"),
+				paths: VolatileFields(),
+			},
+			want: want{
+				req: func() *fnv1.RunFunctionRequest {
+					r, _ := StripFields(Request("7", "2024-01-01T00:00:00Z"), VolatileFields()...)
+					return r
+				}(),
+			},
+		},
+		"MissingFields": {
+			reason: "We should ignore field paths that don't exist.",
+			args: args{
+				req:   &fnv1.RunFunctionRequest{Observed: &fnv1.State{Composite: &fnv1.Resource{Resource: MustStruct(map[string]any{"spec": map[string]any{}})}}},
+				paths: []string{"metadata.labels[cool]", "status.conditions[*].lastTransitionTime"},
+			},
+			want: want{
+				req: &fnv1.RunFunctionRequest{Observed: &fnv1.State{Composite: &fnv1.Resource{Resource: MustStruct(map[string]any{"spec": map[string]any{}})}}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := StripFields(tc.args.req, tc.args.paths...)
+
+			if diff := cmp.Diff(tc.want.req, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nStripFields(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nStripFields(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFunctionCanonicalizer(t *testing.T) {
+	req := Request("42", "2025-01-01T00:00:00Z")
+
+	type want struct {
+		req *fnv1.RunFunctionRequest
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		want   want
+	}{
+		"GetError": {
+			reason: "We should return an error if we can't get the Function.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(errors.New("boom")),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"NotFound": {
+			reason: "We should return the request unchanged if the Function doesn't exist.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "cool-fn")),
+			},
+			want: want{
+				req: req,
+			},
+		},
+		"NoResponseCacheConfig": {
+			reason: "We should return the request unchanged if the Function doesn't configure its response cache.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			want: want{
+				req: req,
+			},
+		},
+		"IgnoreVolatileFields": {
+			reason: "We should strip volatile fields if the Function asks us to.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*pkgv1.Function).Spec.ResponseCache = &pkgv1.FunctionResponseCache{IgnoreVolatileFields: true}
+					return nil
+				}),
+			},
+			want: want{
+				req: func() *fnv1.RunFunctionRequest {
+					r, _ := StripFields(req, VolatileFields()...)
+					return r
+				}(),
+			},
+		},
+		"IgnoreFields": {
+			reason: "We should strip the fields the Function asks us to.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*pkgv1.Function).Spec.ResponseCache = &pkgv1.FunctionResponseCache{IgnoreFields: []string{"metadata.resourceVersion"}}
+					return nil
+				}),
+			},
+			want: want{
+				req: func() *fnv1.RunFunctionRequest {
+					r, _ := StripFields(req, "metadata.resourceVersion")
+					return r
+				}(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewFunctionCanonicalizer(tc.c)
+			got, err := c.Canonicalize(context.Background(), "cool-fn", req)
+
+			if diff := cmp.Diff(tc.want.req, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nCanonicalize(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCanonicalize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFileBackedRunnerCanonicalizer(t *testing.T) {
+	calls := 0
+	wrapped := FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+		calls++
+		return &fnv1.RunFunctionResponse{Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(time.Minute)}}, nil
+	})
+
+	c := CanonicalizerFn(func(_ context.Context, _ string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionRequest, error) {
+		return StripFields(req, VolatileFields()...)
+	})
+
+	r := NewFileBackedRunner(wrapped, "", WithLogger(&TestLogger{t: t}), WithCache(NewLRUCache(1024*1024)), WithCanonicalizer(c))
+
+	// These requests have different tags, but only differ in volatile fields.
+	for _, req := range []*fnv1.RunFunctionRequest{Request("1", "2025-01-01T00:00:00Z"), Request("2", "2025-01-02T00:00:00Z")} {
+		if _, err := r.RunFunction(context.Background(), "cool-fn", req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if diff := cmp.Diff(1, calls); diff != "" {
+		t.Errorf("\nRunFunction(...): -want calls to wrapped runner, +got calls:\n%s", diff)
+	}
+}
@@ -0,0 +1,518 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/crossplane/v2/internal/xfn/cached/proto/v1alpha1"
+)
+
+// Error strings.
+const (
+	errFmtReadEntry  = "cannot read cached response %q"
+	errFmtPurgeEntry = "cannot delete cached response %q"
+)
+
+var (
+	_ Cache            = &FileCache{}
+	_ GarbageCollector = &FileCache{}
+)
+
+// A FileCache caches responses to the filesystem. Each response is a file
+// named for its request tag, in a directory named for its function.
+//
+// A FileCache can be bounded by the total size of the responses it caches, and
+// by the size of the responses it caches for each function. When a bound is
+// exceeded it evicts the least recently used responses to make room for new
+// ones. It keeps an index of cached responses in memory to do so. The index is
+// rebuilt from the filesystem after each garbage collection, so it tolerates
+// other processes deleting cached responses.
+type FileCache struct {
+	fs      afero.Afero
+	log     logging.Logger
+	metrics Metrics
+
+	maxBytes         int64
+	maxFunctionBytes int64
+
+	mx      sync.Mutex
+	indexed bool
+	entries map[string]*fileEntry
+	size    int64
+	sizes   map[string]int64
+
+	now func() time.Time
+}
+
+type fileEntry struct {
+	name  string
+	key   string
+	bytes int64
+	used  time.Time
+}
+
+// A FileCacheOption configures a FileCache.
+type FileCacheOption func(c *FileCache)
+
+// WithFileCacheLogger specifies which logger the FileCache should use.
+func WithFileCacheLogger(l logging.Logger) FileCacheOption {
+	return func(c *FileCache) {
+		c.log = l
+	}
+}
+
+// WithFileCacheMetrics specifies which metrics the FileCache should use to
+// record garbage collected and evicted responses.
+func WithFileCacheMetrics(m Metrics) FileCacheOption {
+	return func(c *FileCache) {
+		c.metrics = m
+	}
+}
+
+// WithFileCacheMaxBytes bounds the total size of the responses the FileCache
+// caches. Zero means unbounded.
+func WithFileCacheMaxBytes(n int64) FileCacheOption {
+	return func(c *FileCache) {
+		c.maxBytes = n
+	}
+}
+
+// WithFileCacheMaxFunctionBytes bounds the size of the responses the
+// FileCache caches for each function. Zero means unbounded.
+func WithFileCacheMaxFunctionBytes(n int64) FileCacheOption {
+	return func(c *FileCache) {
+		c.maxFunctionBytes = n
+	}
+}
+
+// NewFileCache returns a Cache that caches responses at the root of the
+// supplied filesystem.
+func NewFileCache(fs afero.Fs, o ...FileCacheOption) *FileCache {
+	c := &FileCache{
+		fs:      afero.Afero{Fs: fs},
+		log:     logging.NewNopLogger(),
+		metrics: &NopMetrics{},
+		now:     time.Now,
+	}
+
+	for _, fn := range o {
+		fn(c)
+	}
+
+	return c
+}
+
+// Get returns the cached response to the named function's request with the
+// supplied tag.
+func (c *FileCache) Get(_ context.Context, name, tag string) ([]byte, error) {
+	key := filepath.Join(name, tag)
+
+	b, err := c.fs.ReadFile(key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, NotCached(name, tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	now := c.now()
+	c.index()
+	c.upsert(name, key, int64(len(b)), now)
+
+	// Persist when the response was last used, so that we evict the right
+	// responses after a restart.
+	_ = c.fs.Chtimes(key, now, now)
+
+	return b, nil
+}
+
+// Set caches the supplied response to the named function's request with the
+// supplied tag. It evicts the least recently used responses if necessary to
+// stay within its bounds. It returns an error if the response is larger than
+// either bound. It doesn't delete the response at the deadline - use
+// GarbageCollect to do that.
+func (c *FileCache) Set(_ context.Context, name, tag string, rsp []byte, _ time.Time) error {
+	if c.maxBytes > 0 && int64(len(rsp)) > c.maxBytes {
+		return errors.Errorf(errFmtTooLarge, len(rsp), c.maxBytes)
+	}
+	if c.maxFunctionBytes > 0 && int64(len(rsp)) > c.maxFunctionBytes {
+		return errors.Errorf(errFmtTooLarge, len(rsp), c.maxFunctionBytes)
+	}
+
+	if err := c.fs.MkdirAll(name, 0o700); err != nil {
+		return err
+	}
+
+	// Write and rename a temp file to make our write 'atomic'. This ensure
+	// we won't overwrite a cache file that we're currently reading.
+	tmp, err := c.fs.TempFile(name, "")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(rsp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	key := filepath.Join(name, tag)
+	if err := c.fs.Rename(tmp.Name(), key); err != nil {
+		return err
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.index()
+	c.upsert(name, key, int64(len(rsp)), c.now())
+
+	for c.maxFunctionBytes > 0 && c.sizes[name] > c.maxFunctionBytes {
+		if !c.evict(name) {
+			break
+		}
+	}
+
+	for c.maxBytes > 0 && c.size > c.maxBytes {
+		if !c.evict("") {
+			break
+		}
+	}
+
+	return nil
+}
+
+// index builds the index of cached responses from the filesystem, if it
+// hasn't been built already. It must be called with the lock held.
+func (c *FileCache) index() {
+	if c.indexed {
+		return
+	}
+
+	c.entries = make(map[string]*fileEntry)
+	c.sizes = make(map[string]int64)
+	c.size = 0
+
+	err := fs.WalkDir(afero.NewIOFS(c.fs), "/", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			// The file was probably deleted since we started walking.
+			return nil //nolint:nilerr // We want to keep walking.
+		}
+
+		name := filepath.Base(filepath.Dir(path))
+		c.upsert(name, filepath.Join(name, filepath.Base(path)), info.Size(), info.ModTime())
+
+		return nil
+	})
+	// The cache directory won't exist until we cache our first response.
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		c.log.Info("Cannot index RunFunctionResponse cache", "error", err)
+		return
+	}
+
+	c.indexed = true
+}
+
+// upsert adds or updates a cached response in the index. It must be called
+// with the lock held.
+func (c *FileCache) upsert(name, key string, bytes int64, used time.Time) {
+	e, ok := c.entries[key]
+	if !ok {
+		e = &fileEntry{name: name, key: key}
+		c.entries[key] = e
+	}
+
+	c.size += bytes - e.bytes
+	c.sizes[name] += bytes - e.bytes
+	e.bytes = bytes
+	e.used = used
+}
+
+// evict deletes the least recently used response. It only considers the named
+// function's responses, unless the name is empty. It returns false if there
+// was nothing to evict. It must be called with the lock held.
+func (c *FileCache) evict(name string) bool {
+	var lru *fileEntry
+	for _, e := range c.entries {
+		if name != "" && e.name != name {
+			continue
+		}
+		if lru == nil || e.used.Before(lru.used) {
+			lru = e
+		}
+	}
+
+	if lru == nil {
+		return false
+	}
+
+	delete(c.entries, lru.key)
+	c.size -= lru.bytes
+	c.sizes[lru.name] -= lru.bytes
+
+	log := c.log.WithValues("name", lru.name, "cache-key", lru.key)
+
+	if err := c.fs.Remove(lru.key); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Info("RunFunctionResponse cache error", "error", err)
+		c.metrics.Error(lru.name)
+
+		return true
+	}
+
+	log.Debug("RunFunctionResponse cache evict", "last-used", lru.used, "bytes", lru.bytes)
+	c.metrics.Delete(lru.name)
+	c.metrics.DeletedBytes(lru.name, int(lru.bytes))
+
+	return true
+}
+
+// GarbageCollect deletes any cached responses with expired deadlines.
+func (c *FileCache) GarbageCollect(ctx context.Context) (int, error) {
+	collected := 0
+	iofs := afero.NewIOFS(c.fs)
+	err := fs.WalkDir(iofs, "/", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Stop walking if our context is cancelled.
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		if d.IsDir() {
+			// Don't try to garbage collect the root of the cache.
+			if path == "/" {
+				return nil
+			}
+
+			entries, err := c.fs.ReadDir(path)
+			if err != nil {
+				c.log.Info("RunFunctionResponse cache error", "path", path, "error", err)
+				return nil
+			}
+
+			if len(entries) > 0 {
+				return nil
+			}
+
+			// Cleanup empty directories. We don't count these as a
+			// garbage collected cache entry.
+			if err := c.fs.Remove(path); err != nil {
+				c.log.Info("RunFunctionResponse cache error", "path", path, "error", err)
+			}
+
+			return nil
+		}
+
+		// The cache layout is like /cache/function-name/request-hash,
+		// so the directory name is our function name.
+		name := filepath.Base(filepath.Dir(path))
+		log := c.log.WithValues("name", name, "cache-key", path)
+
+		b, err := c.fs.ReadFile(path)
+		if err != nil {
+			log.Info("RunFunctionResponse cache error", "error", err)
+			c.metrics.Error(name)
+
+			return nil
+		}
+
+		crsp := &v1alpha1.CachedRunFunctionResponse{}
+		if err := proto.Unmarshal(b, crsp); err != nil {
+			log.Info("RunFunctionResponse cache error", "error", err)
+			c.metrics.Error(name)
+
+			return nil
+		}
+
+		deadline := crsp.GetDeadline().AsTime()
+
+		// Cached response is still valid.
+		if time.Now().Before(deadline) {
+			return nil
+		}
+
+		// There's a race here. It's possible Set will write a new cache
+		// entry with a deadline in the future between where we read the
+		// file and here where we remove it. We're okay with this - it'll
+		// just mean we don't cache one response.
+		//
+		// There's no race with reading files. The file content won't
+		// actually be deleted until ReadFile closes the fd.
+		if err := c.fs.Remove(path); err != nil {
+			log.Info("RunFunctionResponse cache error", "error", err)
+			c.metrics.Error(name)
+
+			return nil
+		}
+
+		collected++
+
+		log.Debug("RunFunctionResponse cache delete", "deadline", deadline, "bytes", len(b))
+		c.metrics.Delete(name)
+		c.metrics.DeletedBytes(name, len(b))
+
+		return nil
+	})
+
+	// Rebuild the index next time we need it, in case anything changed.
+	c.mx.Lock()
+	c.indexed = false
+	c.mx.Unlock()
+
+	return collected, err
+}
+
+// A CacheEntry describes a cached response.
+type CacheEntry struct {
+	// Function that returned the response.
+	Function string
+
+	// Tag of the request the response was returned for.
+	Tag string
+
+	// Bytes is the size of the cached response.
+	Bytes int64
+
+	// Deadline after which the cached response won't be used.
+	Deadline time.Time
+
+	// LastUsed is when the cached response was last written or read.
+	LastUsed time.Time
+}
+
+// List returns the cached responses of the named function. It returns all
+// cached responses if the name is empty.
+func (c *FileCache) List(ctx context.Context, name string) ([]CacheEntry, error) {
+	entries := make([]CacheEntry, 0)
+
+	err := c.walk(ctx, name, func(path string, info fs.FileInfo) error {
+		e := CacheEntry{
+			Function: filepath.Base(filepath.Dir(path)),
+			Tag:      filepath.Base(path),
+			Bytes:    info.Size(),
+			LastUsed: info.ModTime(),
+		}
+
+		b, err := c.fs.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, errFmtReadEntry, path)
+		}
+
+		crsp := &v1alpha1.CachedRunFunctionResponse{}
+		if err := proto.Unmarshal(b, crsp); err == nil {
+			e.Deadline = crsp.GetDeadline().AsTime()
+		}
+
+		entries = append(entries, e)
+
+		return nil
+	})
+
+	return entries, err
+}
+
+// Purge deletes the cached responses of the named function. It deletes all
+// cached responses if the name is empty. It returns the number of responses
+// it deleted.
+func (c *FileCache) Purge(ctx context.Context, name string) (int, error) {
+	purged := 0
+
+	err := c.walk(ctx, name, func(path string, info fs.FileInfo) error {
+		fn := filepath.Base(filepath.Dir(path))
+
+		if err := c.fs.Remove(path); err != nil {
+			return errors.Wrapf(err, errFmtPurgeEntry, path)
+		}
+
+		purged++
+
+		c.log.Debug("RunFunctionResponse cache purge", "name", fn, "cache-key", path, "bytes", info.Size())
+		c.metrics.Delete(fn)
+		c.metrics.DeletedBytes(fn, int(info.Size()))
+
+		return nil
+	})
+
+	// Rebuild the index next time we need it.
+	c.mx.Lock()
+	c.indexed = false
+	c.mx.Unlock()
+
+	return purged, err
+}
+
+// walk calls the supplied function for each of the named function's cached
+// responses, or for all cached responses if the name is empty.
+func (c *FileCache) walk(ctx context.Context, name string, fn func(path string, info fs.FileInfo) error) error {
+	root := "/"
+	if name != "" {
+		root = filepath.Join("/", name)
+	}
+
+	err := fs.WalkDir(afero.NewIOFS(c.fs), root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return errors.Wrapf(err, errFmtReadEntry, path)
+		}
+
+		return fn(path, info)
+	})
+
+	// There's nothing cached yet.
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
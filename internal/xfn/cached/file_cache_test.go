@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/afero"
+)
+
+func TestFileCacheEviction(t *testing.T) {
+	type entry struct {
+		name string
+		tag  string
+	}
+
+	type op struct {
+		// Get instead of Set.
+		get bool
+		entry
+		bytes int
+	}
+
+	type want struct {
+		cached []entry
+		err    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      []FileCacheOption
+		ops    []op
+		want   want
+	}{
+		"Unbounded": {
+			reason: "We shouldn't evict anything if the cache is unbounded.",
+			ops: []op{
+				{entry: entry{"coolfn", "a"}, bytes: 100},
+				{entry: entry{"coolfn", "b"}, bytes: 100},
+				{entry: entry{"otherfn", "c"}, bytes: 100},
+			},
+			want: want{
+				cached: []entry{{"coolfn", "a"}, {"coolfn", "b"}, {"otherfn", "c"}},
+			},
+		},
+		"MaxBytes": {
+			reason: "We should evict the least recently used response when the cache is full.",
+			o:      []FileCacheOption{WithFileCacheMaxBytes(10)},
+			ops: []op{
+				{entry: entry{"coolfn", "a"}, bytes: 4},
+				{entry: entry{"otherfn", "b"}, bytes: 4},
+				{get: true, entry: entry{"coolfn", "a"}},
+				{entry: entry{"coolfn", "c"}, bytes: 4},
+			},
+			want: want{
+				cached: []entry{{"coolfn", "a"}, {"coolfn", "c"}},
+			},
+		},
+		"MaxFunctionBytes": {
+			reason: "We should evict the function's least recently used response when it exceeds its quota.",
+			o:      []FileCacheOption{WithFileCacheMaxFunctionBytes(5)},
+			ops: []op{
+				{entry: entry{"coolfn", "a"}, bytes: 4},
+				{entry: entry{"otherfn", "b"}, bytes: 4},
+				{entry: entry{"coolfn", "c"}, bytes: 4},
+			},
+			want: want{
+				cached: []entry{{"otherfn", "b"}, {"coolfn", "c"}},
+			},
+		},
+		"TooLarge": {
+			reason: "We should return an error if a response exceeds the function's quota.",
+			o:      []FileCacheOption{WithFileCacheMaxFunctionBytes(5)},
+			ops: []op{
+				{entry: entry{"coolfn", "a"}, bytes: 6},
+			},
+			want: want{
+				cached: []entry{},
+				err:    true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+
+			c := NewFileCache(afero.NewMemMapFs(), tc.o...)
+			c.now = func() time.Time {
+				// Each operation happens a second after the last.
+				now = now.Add(time.Second)
+				return now
+			}
+
+			var err error
+			for _, o := range tc.ops {
+				if o.get {
+					_, err = c.Get(context.Background(), o.name, o.tag)
+					continue
+				}
+				err = c.Set(context.Background(), o.name, o.tag, make([]byte, o.bytes), now.Add(time.Hour))
+			}
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nc.Set(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			cached := make([]entry, 0)
+			for _, e := range []entry{{"coolfn", "a"}, {"otherfn", "b"}, {"coolfn", "b"}, {"otherfn", "c"}, {"coolfn", "c"}} {
+				if _, err := c.fs.Stat(e.name + "/" + e.tag); err == nil {
+					cached = append(cached, e)
+				}
+			}
+
+			less := func(a, b entry) bool { return a.name+a.tag < b.name+b.tag }
+			if diff := cmp.Diff(tc.want.cached, cached, cmp.AllowUnexported(entry{}), cmpopts.SortSlices(less)); diff != "" {
+				t.Errorf("\n%s\nc.Set(...): -want cached, +got cached:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFileCacheListAndPurge(t *testing.T) {
+	// Like the real cache, which is rooted at the cache directory.
+	c := NewFileCache(afero.NewBasePathFs(afero.NewMemMapFs(), "/cache"))
+
+	for _, e := range []struct{ name, tag string }{{"coolfn", "a"}, {"coolfn", "b"}, {"otherfn", "c"}} {
+		if err := c.Set(context.Background(), e.name, e.tag, []byte("hi"), time.Now().Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := c.List(context.Background(), "coolfn")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(2, len(entries)); diff != "" {
+		t.Errorf("\nc.List(...): -want entries, +got entries:\n%s", diff)
+	}
+
+	purged, err := c.Purge(context.Background(), "coolfn")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(2, purged); diff != "" {
+		t.Errorf("\nc.Purge(...): -want purged, +got purged:\n%s", diff)
+	}
+
+	entries, err = c.List(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(1, len(entries)); diff != "" {
+		t.Errorf("\nc.List(...): -want entries, +got entries:\n%s", diff)
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"container/list"
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+const errFmtTooLarge = "response is %d bytes, which is larger than the cache's maximum of %d bytes"
+
+var (
+	_ Cache            = &LRUCache{}
+	_ GarbageCollector = &LRUCache{}
+)
+
+// An LRUCache caches responses in memory. It's optionally bounded by the total
+// size of the responses it caches, and by the size of the responses it caches
+// for each function. When a bound is exceeded it evicts the least
+// recently used responses to make room for new ones.
+type LRUCache struct {
+	max         int64
+	maxFunction int64
+	metrics     Metrics
+
+	mx      sync.Mutex
+	size    int64
+	sizes   map[string]int64
+	order   *list.List
+	entries map[string]*list.Element
+
+	now func() time.Time
+}
+
+type lruEntry struct {
+	name     string
+	key      string
+	rsp      []byte
+	deadline time.Time
+}
+
+// An LRUCacheOption configures an LRUCache.
+type LRUCacheOption func(c *LRUCache)
+
+// WithLRUCacheMetrics specifies which metrics the LRUCache should use to
+// record evicted and expired responses.
+func WithLRUCacheMetrics(m Metrics) LRUCacheOption {
+	return func(c *LRUCache) {
+		c.metrics = m
+	}
+}
+
+// WithLRUCacheMaxFunctionBytes bounds the size of the responses the LRUCache
+// caches for each function. Zero means the function is only bounded by the
+// total size of the cache.
+func WithLRUCacheMaxFunctionBytes(n int64) LRUCacheOption {
+	return func(c *LRUCache) {
+		c.maxFunction = n
+	}
+}
+
+// NewLRUCache returns a Cache that caches up to the supplied number of bytes of
+// responses in memory. Zero means the cache is unbounded.
+func NewLRUCache(maxBytes int64, o ...LRUCacheOption) *LRUCache {
+	c := &LRUCache{
+		max:     maxBytes,
+		metrics: &NopMetrics{},
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		sizes:   make(map[string]int64),
+		now:     time.Now,
+	}
+
+	for _, fn := range o {
+		fn(c)
+	}
+
+	return c
+}
+
+// Get returns the cached response to the named function's request with the
+// supplied tag.
+func (c *LRUCache) Get(_ context.Context, name, tag string) ([]byte, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	el, ok := c.entries[filepath.Join(name, tag)]
+	if !ok {
+		return nil, NotCached(name, tag)
+	}
+
+	e := el.Value.(*lruEntry) //nolint:forcetypeassert // We only store *lruEntry.
+	if c.now().After(e.deadline) {
+		c.remove(el)
+		return nil, NotCached(name, tag)
+	}
+
+	c.order.MoveToFront(el)
+
+	return e.rsp, nil
+}
+
+// Set caches the supplied response to the named function's request with the
+// supplied tag. It evicts the least recently used responses if necessary to
+// make room. It returns an error if the response is larger than the cache.
+func (c *LRUCache) Set(_ context.Context, name, tag string, rsp []byte, deadline time.Time) error {
+	if c.max > 0 && int64(len(rsp)) > c.max {
+		return errors.Errorf(errFmtTooLarge, len(rsp), c.max)
+	}
+	if c.maxFunction > 0 && int64(len(rsp)) > c.maxFunction {
+		return errors.Errorf(errFmtTooLarge, len(rsp), c.maxFunction)
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	key := filepath.Join(name, tag)
+
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*lruEntry) //nolint:forcetypeassert // We only store *lruEntry.
+		c.size += int64(len(rsp) - len(e.rsp))
+		c.sizes[name] += int64(len(rsp) - len(e.rsp))
+		e.rsp = rsp
+		e.deadline = deadline
+		c.order.MoveToFront(el)
+	} else {
+		c.entries[key] = c.order.PushFront(&lruEntry{name: name, key: key, rsp: rsp, deadline: deadline})
+		c.size += int64(len(rsp))
+		c.sizes[name] += int64(len(rsp))
+	}
+
+	for c.maxFunction > 0 && c.sizes[name] > c.maxFunction {
+		c.remove(c.leastRecentlyUsed(name))
+	}
+
+	for c.max > 0 && c.size > c.max {
+		c.remove(c.order.Back())
+	}
+
+	return nil
+}
+
+// GarbageCollect deletes any cached responses with expired deadlines.
+func (c *LRUCache) GarbageCollect(_ context.Context) (int, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	collected := 0
+	now := c.now()
+
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if e := el.Value.(*lruEntry); now.After(e.deadline) { //nolint:forcetypeassert // We only store *lruEntry.
+			c.remove(el)
+			collected++
+		}
+		el = next
+	}
+
+	return collected, nil
+}
+
+// Size returns the total size in bytes of the cached responses.
+func (c *LRUCache) Size() int64 {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return c.size
+}
+
+// leastRecentlyUsed returns the named function's least recently used
+// response. It must be called with the lock held.
+func (c *LRUCache) leastRecentlyUsed(name string) *list.Element {
+	for el := c.order.Back(); el != nil; el = el.Prev() {
+		if el.Value.(*lruEntry).name == name { //nolint:forcetypeassert // We only store *lruEntry.
+			return el
+		}
+	}
+
+	return nil
+}
+
+// remove must be called with the lock held.
+func (c *LRUCache) remove(el *list.Element) {
+	e := c.order.Remove(el).(*lruEntry) //nolint:forcetypeassert // We only store *lruEntry.
+	delete(c.entries, e.key)
+	c.size -= int64(len(e.rsp))
+	c.sizes[e.name] -= int64(len(e.rsp))
+
+	c.metrics.Delete(e.name)
+	c.metrics.DeletedBytes(e.name, len(e.rsp))
+}
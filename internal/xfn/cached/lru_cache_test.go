@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLRUCache(t *testing.T) {
+	future := time.Now().Add(time.Minute)
+	past := time.Now().Add(-time.Minute)
+
+	type set struct {
+		tag      string
+		rsp      string
+		deadline time.Time
+	}
+
+	type want struct {
+		cached []string
+		size   int64
+		err    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		max    int64
+		sets   []set
+		want   want
+	}{
+		"FitsInCache": {
+			reason: "We should cache responses while they fit in the cache.",
+			max:    10,
+			sets: []set{
+				{tag: "a", rsp: "aaaa", deadline: future},
+				{tag: "b", rsp: "bbbb", deadline: future},
+			},
+			want: want{
+				cached: []string{"a", "b"},
+				size:   8,
+			},
+		},
+		"EvictLeastRecentlyUsed": {
+			reason: "We should evict the least recently used responses to make room for new ones.",
+			max:    10,
+			sets: []set{
+				{tag: "a", rsp: "aaaa", deadline: future},
+				{tag: "b", rsp: "bbbb", deadline: future},
+				{tag: "c", rsp: "cccc", deadline: future},
+			},
+			want: want{
+				cached: []string{"b", "c"},
+				size:   8,
+			},
+		},
+		"Replace": {
+			reason: "We should replace an existing response, accounting for its new size.",
+			max:    10,
+			sets: []set{
+				{tag: "a", rsp: "aaaa", deadline: future},
+				{tag: "a", rsp: "aa", deadline: future},
+			},
+			want: want{
+				cached: []string{"a"},
+				size:   2,
+			},
+		},
+		"TooLarge": {
+			reason: "We should return an error if a response is larger than the cache.",
+			max:    2,
+			sets: []set{
+				{tag: "a", rsp: "aaaa", deadline: future},
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"Unbounded": {
+			reason: "We should never evict responses from a cache with no maximum size.",
+			max:    0,
+			sets: []set{
+				{tag: "a", rsp: "aaaa", deadline: future},
+				{tag: "b", rsp: "bbbb", deadline: future},
+				{tag: "c", rsp: "cccc", deadline: future},
+			},
+			want: want{
+				cached: []string{"a", "b", "c"},
+				size:   12,
+			},
+		},
+		"Expired": {
+			reason: "We shouldn't return expired responses.",
+			max:    10,
+			sets: []set{
+				{tag: "a", rsp: "aaaa", deadline: past},
+				{tag: "b", rsp: "bbbb", deadline: future},
+			},
+			want: want{
+				cached: []string{"b"},
+				size:   4,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewLRUCache(tc.max)
+
+			var err error
+			for _, s := range tc.sets {
+				err = c.Set(context.Background(), "coolfn", s.tag, []byte(s.rsp), s.deadline)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nc.Set(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			cached := make([]string, 0)
+			for _, tag := range []string{"a", "b", "c"} {
+				_, err := c.Get(context.Background(), "coolfn", tag)
+				if IsNotCached(err) {
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				cached = append(cached, tag)
+			}
+
+			if len(tc.want.cached) == 0 {
+				tc.want.cached = []string{}
+			}
+
+			if diff := cmp.Diff(tc.want.cached, cached); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want cached, +got cached:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.size, c.Size()); diff != "" {
+				t.Errorf("\n%s\nc.Size(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLRUCacheGarbageCollect(t *testing.T) {
+	c := NewLRUCache(100)
+
+	_ = c.Set(context.Background(), "coolfn", "expired", []byte("a"), time.Now().Add(-time.Minute))
+	_ = c.Set(context.Background(), "coolfn", "valid", []byte("b"), time.Now().Add(time.Minute))
+	_ = c.Set(context.Background(), "prettycoolfn", "expired", []byte("c"), time.Now().Add(-time.Minute))
+
+	collected, err := c.GarbageCollect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(2, collected); diff != "" {
+		t.Errorf("\nc.GarbageCollect(...): -want collected, +got collected:\n%s", diff)
+	}
+
+	if diff := cmp.Diff(int64(1), c.Size()); diff != "" {
+		t.Errorf("\nc.Size(): -want, +got:\n%s", diff)
+	}
+}
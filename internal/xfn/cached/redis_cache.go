@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+// Error strings.
+const (
+	errGetRedis = "cannot GET cached response from Redis"
+	errSetRedis = "cannot SET cached response in Redis"
+)
+
+const (
+	// DefaultRedisKeyPrefix is the default prefix of keys used to store cached
+	// responses in Redis.
+	DefaultRedisKeyPrefix = "crossplane:xfn:"
+
+	// DefaultRedisTimeout is the default timeout for Redis operations.
+	DefaultRedisTimeout = 2 * time.Second
+
+	// DefaultRedisMaxIdleConns is the default number of idle connections to
+	// Redis that will be kept open for reuse.
+	DefaultRedisMaxIdleConns = 10
+)
+
+var _ Cache = &RedisCache{}
+
+// A RedisCache caches responses in a key-value store that speaks the Redis
+// protocol. Several Crossplane replicas can share a RedisCache. It relies on
+// the store to expire responses, so it doesn't need to be garbage collected.
+type RedisCache struct {
+	client  *redis.Client
+	opts    *redis.Options
+	prefix  string
+	timeout time.Duration
+}
+
+// A RedisCacheOption configures a RedisCache.
+type RedisCacheOption func(c *RedisCache)
+
+// WithRedisPassword specifies the password the RedisCache should authenticate
+// with.
+func WithRedisPassword(p string) RedisCacheOption {
+	return func(c *RedisCache) {
+		c.opts.Password = p
+	}
+}
+
+// WithRedisDB specifies which Redis logical database the RedisCache should
+// use.
+func WithRedisDB(db int) RedisCacheOption {
+	return func(c *RedisCache) {
+		c.opts.DB = db
+	}
+}
+
+// WithRedisKeyPrefix specifies the prefix of the keys the RedisCache stores
+// responses under.
+func WithRedisKeyPrefix(p string) RedisCacheOption {
+	return func(c *RedisCache) {
+		c.prefix = p
+	}
+}
+
+// WithRedisTimeout specifies the timeout for each Redis operation.
+func WithRedisTimeout(t time.Duration) RedisCacheOption {
+	return func(c *RedisCache) {
+		c.timeout = t
+	}
+}
+
+// WithRedisTLSConfig specifies the TLS config the RedisCache should use to
+// connect to Redis. The RedisCache doesn't use TLS by default.
+func WithRedisTLSConfig(cfg *tls.Config) RedisCacheOption {
+	return func(c *RedisCache) {
+		c.opts.TLSConfig = cfg
+	}
+}
+
+// WithRedisMaxIdleConns specifies how many idle connections to Redis the
+// RedisCache should keep open for reuse.
+func WithRedisMaxIdleConns(n int) RedisCacheOption {
+	return func(c *RedisCache) {
+		c.opts.MaxIdleConns = n
+	}
+}
+
+// NewRedisCache returns a Cache that caches responses in the Redis server at
+// the supplied address.
+func NewRedisCache(addr string, o ...RedisCacheOption) *RedisCache {
+	c := &RedisCache{
+		opts: &redis.Options{
+			Addr:         addr,
+			MaxIdleConns: DefaultRedisMaxIdleConns,
+
+			// Respect the deadline of each operation's context, which
+			// is bounded by the RedisCache's timeout.
+			ContextTimeoutEnabled: true,
+		},
+		prefix:  DefaultRedisKeyPrefix,
+		timeout: DefaultRedisTimeout,
+	}
+
+	for _, fn := range o {
+		fn(c)
+	}
+
+	c.client = redis.NewClient(c.opts)
+
+	return c
+}
+
+// Get returns the cached response to the named function's request with the
+// supplied tag.
+func (c *RedisCache) Get(ctx context.Context, name, tag string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	b, err := c.client.Get(ctx, c.key(name, tag)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, NotCached(name, tag)
+	}
+
+	return b, errors.Wrap(err, errGetRedis)
+}
+
+// Set caches the supplied response to the named function's request with the
+// supplied tag. Redis expires the response at the supplied deadline.
+func (c *RedisCache) Set(ctx context.Context, name, tag string, rsp []byte, deadline time.Time) error {
+	// Redis expires keys with millisecond precision.
+	ttl := time.Until(deadline).Truncate(time.Millisecond)
+
+	// The response would expire immediately. Don't bother caching it.
+	if ttl <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return errors.Wrap(c.client.Set(ctx, c.key(name, tag), rsp, ttl).Err(), errSetRedis)
+}
+
+func (c *RedisCache) key(name, tag string) string {
+	return c.prefix + name + "/" + tag
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestRedisCache(t *testing.T) {
+	srv := miniredis.RunT(t)
+	srv.RequireAuth("secret")
+
+	c := NewRedisCache(srv.Addr(), WithRedisPassword("secret"), WithRedisDB(1))
+
+	// Nothing is cached yet.
+	if _, err := c.Get(context.Background(), "coolfn", "req"); !IsNotCached(err) {
+		t.Errorf("\nc.Get(...): want not cached error, got: %v", err)
+	}
+
+	rsp := []byte("a\r\nbinary\x00response")
+	if err := c.Set(context.Background(), "coolfn", "req", rsp, time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.Get(context.Background(), "coolfn", "req")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(rsp, got); diff != "" {
+		t.Errorf("\nc.Get(...): -want, +got:\n%s", diff)
+	}
+
+	// The response should expire at its deadline.
+	if ttl := srv.DB(1).TTL(DefaultRedisKeyPrefix + "coolfn/req"); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("\nc.Set(...): want TTL of up to a minute, got %s", ttl)
+	}
+
+	srv.FastForward(time.Minute)
+
+	if _, err := c.Get(context.Background(), "coolfn", "req"); !IsNotCached(err) {
+		t.Errorf("\nc.Get(...): want not cached error after the response expired, got: %v", err)
+	}
+}
+
+func TestRedisCacheWrongPassword(t *testing.T) {
+	srv := miniredis.RunT(t)
+	srv.RequireAuth("secret")
+
+	c := NewRedisCache(srv.Addr(), WithRedisPassword("wrong"))
+
+	_, err := c.Get(context.Background(), "coolfn", "req")
+	if err == nil || IsNotCached(err) {
+		t.Errorf("\nc.Get(...): want authentication error, got: %v", err)
+	}
+}
+
+// Make sure replicas sharing a RedisCache share cached responses.
+func TestFileBackedRunnerWithRedisCache(t *testing.T) {
+	srv := miniredis.RunT(t)
+
+	rsp := &fnv1.RunFunctionResponse{
+		Meta: &fnv1.ResponseMeta{
+			Tag: "wrapped",
+			Ttl: durationpb.New(1 * time.Minute),
+		},
+	}
+
+	wrapped := FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+		return rsp, nil
+	})
+
+	r := NewFileBackedRunner(wrapped, "", WithLogger(&TestLogger{t: t}), WithCache(NewRedisCache(srv.Addr())))
+	if _, err := r.RunFunction(context.Background(), "coolfn", &fnv1.RunFunctionRequest{Meta: &fnv1.RequestMeta{Tag: "req"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another replica, with a nil wrapped runner that would panic if called.
+	r = NewFileBackedRunner(nil, "", WithLogger(&TestLogger{t: t}), WithCache(NewRedisCache(srv.Addr())))
+
+	got, err := r.RunFunction(context.Background(), "coolfn", &fnv1.RunFunctionRequest{Meta: &fnv1.RequestMeta{Tag: "req"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(rsp, got, protocmp.Transform()); diff != "" {
+		t.Errorf("\nr.RunFunction(...): -want rsp, +got rsp:\n%s", diff)
+	}
+}
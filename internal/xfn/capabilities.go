@@ -24,6 +24,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 
 	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
 )
 
 // A CapabilityChecker checks whether the named functions have all the required
@@ -43,12 +44,31 @@ func (fn CapabilityCheckerFn) CheckCapabilities(ctx context.Context, caps []stri
 // A RevisionCapabilityChecker is a CapabilityChecker that uses FunctionRevisions
 // to check capabilities.
 type RevisionCapabilityChecker struct {
-	client client.Reader
+	client   client.Reader
+	external bool
+}
+
+// A RevisionCapabilityCheckerOption configures a RevisionCapabilityChecker.
+type RevisionCapabilityCheckerOption func(c *RevisionCapabilityChecker)
+
+// WithExternalFunctionCapabilities configures the RevisionCapabilityChecker to
+// check the capabilities of ExternalFunctions. It checks the ExternalFunction
+// of the same name when a function has no active FunctionRevision.
+func WithExternalFunctionCapabilities() RevisionCapabilityCheckerOption {
+	return func(c *RevisionCapabilityChecker) {
+		c.external = true
+	}
 }
 
 // NewRevisionCapabilityChecker returns a new RevisionCapabilityChecker.
-func NewRevisionCapabilityChecker(c client.Reader) *RevisionCapabilityChecker {
-	return &RevisionCapabilityChecker{client: c}
+func NewRevisionCapabilityChecker(c client.Reader, o ...RevisionCapabilityCheckerOption) *RevisionCapabilityChecker {
+	cc := &RevisionCapabilityChecker{client: c}
+
+	for _, fn := range o {
+		fn(cc)
+	}
+
+	return cc
 }
 
 // CheckCapabilities returns nil if all the named functions have all the
@@ -64,6 +84,10 @@ func (c *RevisionCapabilityChecker) CheckCapabilities(ctx context.Context, caps
 		check[name] = true
 	}
 
+	// Functions with an active revision. Any other function might be an
+	// ExternalFunction.
+	checked := map[string]bool{}
+
 	for _, rev := range l.Items {
 		// We only want to check the active revision.
 		if rev.Spec.DesiredState != pkgv1.PackageRevisionActive {
@@ -76,22 +100,48 @@ func (c *RevisionCapabilityChecker) CheckCapabilities(ctx context.Context, caps
 			continue
 		}
 
-		has := map[string]bool{}
-		for _, cap := range rev.GetCapabilities() {
-			has[cap] = true
+		checked[pkgName] = true
+
+		if missing := missingCapabilities(caps, rev.GetCapabilities()); len(missing) > 0 {
+			return errors.Errorf("function %q (active revision %q) is missing required capabilities: %s", pkgName, rev.GetName(), strings.Join(missing, ", "))
 		}
+	}
+
+	if !c.external || len(checked) == len(check) {
+		return nil
+	}
+
+	xl := &pkgv1beta1.ExternalFunctionList{}
+	if err := c.client.List(ctx, xl); err != nil {
+		return errors.Wrap(err, "cannot list ExternalFunctions")
+	}
 
-		missing := make([]string, 0)
-		for _, cap := range caps {
-			if !has[cap] {
-				missing = append(missing, cap)
-			}
+	for _, xf := range xl.Items {
+		// Functions with an active revision take precedence.
+		if !check[xf.GetName()] || checked[xf.GetName()] {
+			continue
 		}
 
-		if len(missing) > 0 {
-			return errors.Errorf("function %q (active revision %q) is missing required capabilities: %s", pkgName, rev.GetName(), strings.Join(missing, ", "))
+		if missing := missingCapabilities(caps, xf.GetCapabilities()); len(missing) > 0 {
+			return errors.Errorf("external function %q is missing required capabilities: %s", xf.GetName(), strings.Join(missing, ", "))
 		}
 	}
 
 	return nil
 }
+
+func missingCapabilities(want, have []string) []string {
+	has := map[string]bool{}
+	for _, cap := range have {
+		has[cap] = true
+	}
+
+	missing := make([]string, 0)
+	for _, cap := range want {
+		if !has[cap] {
+			missing = append(missing, cap)
+		}
+	}
+
+	return missing
+}
@@ -27,7 +27,9 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
 	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
 )
 
 func TestRevisionCapabilityChecker(t *testing.T) {
@@ -44,6 +46,7 @@ func TestRevisionCapabilityChecker(t *testing.T) {
 	cases := map[string]struct {
 		reason string
 		c      client.Reader
+		o      []RevisionCapabilityCheckerOption
 		args   args
 		want   want
 	}{
@@ -310,13 +313,63 @@ func TestRevisionCapabilityChecker(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ExternalFunctionHasAllCapabilities": {
+			reason: "We should return nil if an ExternalFunction has all required capabilities",
+			c: &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					if l, ok := obj.(*pkgv1beta1.ExternalFunctionList); ok {
+						l.Items = []pkgv1beta1.ExternalFunction{
+							{
+								// No capabilities implies composition.
+								ObjectMeta: metav1.ObjectMeta{Name: "test-fn"},
+							},
+						}
+					}
+					return nil
+				}),
+			},
+			o: []RevisionCapabilityCheckerOption{WithExternalFunctionCapabilities()},
+			args: args{
+				ctx:   context.Background(),
+				caps:  []string{pkgmetav1.FunctionCapabilityComposition},
+				names: []string{"test-fn"},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"ExternalFunctionMissingCapabilities": {
+			reason: "We should return an error if an ExternalFunction is missing required capabilities",
+			c: &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					if l, ok := obj.(*pkgv1beta1.ExternalFunctionList); ok {
+						l.Items = []pkgv1beta1.ExternalFunction{
+							{
+								ObjectMeta: metav1.ObjectMeta{Name: "test-fn"},
+								Spec: pkgv1beta1.ExternalFunctionSpec{
+									Capabilities: []string{"cap1"},
+								},
+							},
+						}
+					}
+					return nil
+				}),
+			},
+			o: []RevisionCapabilityCheckerOption{WithExternalFunctionCapabilities()},
+			args: args{
+				ctx:   context.Background(),
+				caps:  []string{"cap1", "cap2"},
+				names: []string{"test-fn"},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			c := &RevisionCapabilityChecker{
-				client: tc.c,
-			}
+			c := NewRevisionCapabilityChecker(tc.c, tc.o...)
 
 			err := c.CheckCapabilities(tc.args.ctx, tc.args.caps, tc.args.names...)
 
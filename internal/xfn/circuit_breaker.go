@@ -0,0 +1,423 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// Circuit breaker defaults.
+const (
+	// DefaultCircuitFailureThreshold is the default number of consecutive
+	// failed calls to a function that open its circuit breaker.
+	DefaultCircuitFailureThreshold = 5
+
+	// DefaultCircuitOpenDuration is the default time a function's circuit
+	// breaker stays open before it lets a call through to probe the function.
+	DefaultCircuitOpenDuration = 30 * time.Second
+)
+
+// A CircuitState is the state of a function's circuit breaker.
+type CircuitState string
+
+// Circuit breaker states.
+const (
+	// CircuitClosed means calls to the function are allowed.
+	CircuitClosed CircuitState = "Closed"
+
+	// CircuitOpen means calls to the function fail fast.
+	CircuitOpen CircuitState = "Open"
+
+	// CircuitHalfOpen means one call is allowed through to probe whether the
+	// function has recovered.
+	CircuitHalfOpen CircuitState = "HalfOpen"
+)
+
+// A CircuitOpenError is returned when a call to a function fails fast because
+// its circuit breaker is open.
+type CircuitOpenError struct {
+	// Function whose circuit breaker is open.
+	Function string
+
+	// Failures is the number of consecutive failed calls that opened the
+	// circuit breaker.
+	Failures int
+}
+
+// Error returns a description of the error.
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("function %q is unavailable: its circuit breaker opened after %d consecutive failed calls", e.Function, e.Failures)
+}
+
+// IsCircuitOpen returns true if the supplied error indicates that a call to a
+// function failed fast because its circuit breaker is open.
+func IsCircuitOpen(err error) bool {
+	e := &CircuitOpenError{}
+	return errors.As(err, &e)
+}
+
+// CircuitMetrics records metrics about function circuit breakers.
+type CircuitMetrics interface {
+	// CircuitStateChanged records that the named function's circuit breaker
+	// changed state.
+	CircuitStateChanged(name string, s CircuitState)
+
+	// CircuitRejected records that a call to the named function failed fast
+	// because its circuit breaker was open.
+	CircuitRejected(name string)
+}
+
+// A CircuitObserver is notified when a function's circuit breaker opens or
+// closes.
+type CircuitObserver interface {
+	// CircuitStateChanged is called when the named function's circuit breaker
+	// changes state. The supplied error is the error that caused the state
+	// change, if any.
+	CircuitStateChanged(ctx context.Context, name string, s CircuitState, err error)
+}
+
+// A CircuitObserverFn is a function that observes circuit breaker state
+// changes.
+type CircuitObserverFn func(ctx context.Context, name string, s CircuitState, err error)
+
+// CircuitStateChanged calls the function.
+func (fn CircuitObserverFn) CircuitStateChanged(ctx context.Context, name string, s CircuitState, err error) {
+	fn(ctx, name, s, err)
+}
+
+// A CircuitBreakingFunctionRunner wraps another function runner. It tracks
+// consecutive failed calls to each function. Once a function has failed too
+// many times in a row its circuit breaker opens, and calls to it fail fast
+// without reaching the wrapped runner. After a while the circuit breaker
+// half-opens, letting one call through. If that call succeeds the circuit
+// breaker closes. If it fails the circuit breaker opens again.
+//
+// Only failures that suggest the function is unhealthy count toward opening
+// the circuit breaker - for example being unable to connect to the function,
+// or the function timing out. Errors returned by a healthy function, like
+// invalid argument errors, don't.
+type CircuitBreakingFunctionRunner struct {
+	wrapped FunctionRunner
+
+	threshold int
+	open      time.Duration
+
+	mx       sync.Mutex
+	circuits map[string]*circuit
+
+	metrics  CircuitMetrics
+	observer CircuitObserver
+	log      logging.Logger
+
+	now func() time.Time
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+}
+
+// A CircuitBreakingFunctionRunnerOption configures a
+// CircuitBreakingFunctionRunner.
+type CircuitBreakingFunctionRunnerOption func(r *CircuitBreakingFunctionRunner)
+
+// WithFailureThreshold configures how many consecutive failed calls to a
+// function open its circuit breaker.
+func WithFailureThreshold(n int) CircuitBreakingFunctionRunnerOption {
+	return func(r *CircuitBreakingFunctionRunner) {
+		r.threshold = n
+	}
+}
+
+// WithOpenDuration configures how long a function's circuit breaker stays open
+// before it lets a call through to probe the function.
+func WithOpenDuration(d time.Duration) CircuitBreakingFunctionRunnerOption {
+	return func(r *CircuitBreakingFunctionRunner) {
+		r.open = d
+	}
+}
+
+// WithCircuitMetrics configures the metrics the CircuitBreakingFunctionRunner
+// should use.
+func WithCircuitMetrics(m CircuitMetrics) CircuitBreakingFunctionRunnerOption {
+	return func(r *CircuitBreakingFunctionRunner) {
+		r.metrics = m
+	}
+}
+
+// WithCircuitObserver configures what the CircuitBreakingFunctionRunner should
+// notify when a function's circuit breaker changes state.
+func WithCircuitObserver(o CircuitObserver) CircuitBreakingFunctionRunnerOption {
+	return func(r *CircuitBreakingFunctionRunner) {
+		r.observer = o
+	}
+}
+
+// WithCircuitLogger configures the logger the CircuitBreakingFunctionRunner
+// should use.
+func WithCircuitLogger(l logging.Logger) CircuitBreakingFunctionRunnerOption {
+	return func(r *CircuitBreakingFunctionRunner) {
+		r.log = l
+	}
+}
+
+// NewCircuitBreakingFunctionRunner returns a FunctionRunner that wraps the
+// supplied FunctionRunner with a circuit breaker per function.
+func NewCircuitBreakingFunctionRunner(wrapped FunctionRunner, o ...CircuitBreakingFunctionRunnerOption) *CircuitBreakingFunctionRunner {
+	r := &CircuitBreakingFunctionRunner{
+		wrapped:   wrapped,
+		threshold: DefaultCircuitFailureThreshold,
+		open:      DefaultCircuitOpenDuration,
+		circuits:  make(map[string]*circuit),
+		metrics:   &NopCircuitMetrics{},
+		observer:  CircuitObserverFn(func(_ context.Context, _ string, _ CircuitState, _ error) {}),
+		log:       logging.NewNopLogger(),
+		now:       time.Now,
+	}
+
+	for _, fn := range o {
+		fn(r)
+	}
+
+	return r
+}
+
+// RunFunction runs the named function using the wrapped runner, unless the
+// function's circuit breaker is open.
+func (r *CircuitBreakingFunctionRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	if err := r.allow(ctx, name); err != nil {
+		r.metrics.CircuitRejected(name)
+		return nil, err
+	}
+
+	rsp, err := r.wrapped.RunFunction(ctx, name, req)
+
+	// Don't hold the caller's own cancellation against the function.
+	if ctx.Err() != nil {
+		r.abandon(name)
+		return rsp, err
+	}
+
+	r.record(ctx, name, err)
+
+	return rsp, err
+}
+
+// State returns the state of the named function's circuit breaker.
+func (r *CircuitBreakingFunctionRunner) State(name string) CircuitState {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	c, ok := r.circuits[name]
+	if !ok {
+		return CircuitClosed
+	}
+
+	return c.state
+}
+
+// allow returns an error if a call to the named function should fail fast.
+func (r *CircuitBreakingFunctionRunner) allow(ctx context.Context, name string) error {
+	r.mx.Lock()
+
+	c, ok := r.circuits[name]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		r.circuits[name] = c
+	}
+
+	if c.state == CircuitClosed {
+		r.mx.Unlock()
+		return nil
+	}
+
+	// Either another call is already probing the function, or it's not yet
+	// time to probe it.
+	if c.state == CircuitHalfOpen || r.now().Sub(c.openedAt) < r.open {
+		r.mx.Unlock()
+		return &CircuitOpenError{Function: name, Failures: c.failures}
+	}
+
+	// Let this call through to probe the function.
+	c.state = CircuitHalfOpen
+	r.mx.Unlock()
+
+	r.changed(ctx, name, CircuitHalfOpen, nil)
+
+	return nil
+}
+
+// abandon records that a call to the named function was cancelled by the
+// caller before we could tell whether it succeeded. If the call was probing
+// the function the circuit breaker reopens for another open duration, after
+// which the next call probes it again.
+func (r *CircuitBreakingFunctionRunner) abandon(name string) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if c := r.circuits[name]; c.state == CircuitHalfOpen {
+		c.state = CircuitOpen
+		c.openedAt = r.now()
+	}
+}
+
+// record records the result of a call to the named function.
+func (r *CircuitBreakingFunctionRunner) record(ctx context.Context, name string, err error) {
+	r.mx.Lock()
+
+	c := r.circuits[name]
+	from := c.state
+
+	if !Unhealthy(err) {
+		c.state = CircuitClosed
+		c.failures = 0
+		r.mx.Unlock()
+
+		if from != CircuitClosed {
+			r.changed(ctx, name, CircuitClosed, nil)
+		}
+
+		return
+	}
+
+	c.failures++
+
+	// A failed probe reopens the circuit. Otherwise it opens once we hit
+	// the threshold.
+	if from == CircuitHalfOpen || (from == CircuitClosed && c.failures >= r.threshold) {
+		c.state = CircuitOpen
+		c.openedAt = r.now()
+	}
+
+	to := c.state
+	r.mx.Unlock()
+
+	if to != from {
+		r.changed(ctx, name, to, err)
+	}
+}
+
+func (r *CircuitBreakingFunctionRunner) changed(ctx context.Context, name string, s CircuitState, err error) {
+	r.log.Debug("Function circuit breaker changed state", "function", name, "state", s, "error", err)
+	r.metrics.CircuitStateChanged(name, s)
+	r.observer.CircuitStateChanged(ctx, name, s, err)
+}
+
+// Unhealthy returns true if the supplied error returned by a function runner
+// suggests the function is unhealthy. Only gRPC Unavailable, DeadlineExceeded,
+// and ResourceExhausted errors are. Errors that don't have a gRPC status come
+// from the runner rather than the function, for example because the function
+// has no active revision yet, so they're not considered unhealthy.
+func Unhealthy(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	s, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+
+	switch s.Code() { //nolint:exhaustive // Most codes don't suggest the function is unhealthy.
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// A RevisionCircuitObserver reflects the state of a function's circuit
+// breaker in a status condition on its active FunctionRevision, or on its
+// ExternalFunction if it has no active FunctionRevision.
+type RevisionCircuitObserver struct {
+	client client.Client
+	log    logging.Logger
+}
+
+// NewRevisionCircuitObserver returns a CircuitObserver that sets a status
+// condition on a function's active FunctionRevision.
+func NewRevisionCircuitObserver(c client.Client, l logging.Logger) *RevisionCircuitObserver {
+	return &RevisionCircuitObserver{client: c, log: l}
+}
+
+// CircuitStateChanged updates the FunctionResponsive status condition of the
+// named function's active FunctionRevision or ExternalFunction. It ignores the
+// half-open state.
+func (o *RevisionCircuitObserver) CircuitStateChanged(ctx context.Context, name string, s CircuitState, err error) {
+	c := pkgv1.FunctionResponsive()
+
+	switch s {
+	case CircuitHalfOpen:
+		return
+	case CircuitOpen:
+		c = pkgv1.FunctionUnresponsive(fmt.Sprintf("Calls to the function are failing fast. Its circuit breaker opened after consecutive failed calls. Last error: %s", err))
+	case CircuitClosed:
+	}
+
+	l := &pkgv1.FunctionRevisionList{}
+	if err := o.client.List(ctx, l, client.MatchingLabels{pkgv1.LabelParentPackage: name}); err != nil {
+		o.log.Info("Cannot list FunctionRevisions to update circuit breaker condition", "function", name, "error", err)
+		return
+	}
+
+	for i := range l.Items {
+		rev := &l.Items[i]
+		if rev.GetDesiredState() != pkgv1.PackageRevisionActive {
+			continue
+		}
+
+		rev.SetConditions(c)
+
+		if err := o.client.Status().Update(ctx, rev); err != nil {
+			o.log.Info("Cannot update FunctionRevision circuit breaker condition", "function", name, "revision", rev.GetName(), "error", err)
+		}
+
+		return
+	}
+
+	// The function has no active revision. It might be an ExternalFunction.
+	xf := &pkgv1beta1.ExternalFunction{}
+	if err := o.client.Get(ctx, types.NamespacedName{Name: name}, xf); err != nil {
+		if !kerrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			o.log.Info("Cannot get ExternalFunction to update circuit breaker condition", "function", name, "error", err)
+		}
+		return
+	}
+
+	xf.SetConditions(c)
+
+	if err := o.client.Status().Update(ctx, xf); err != nil {
+		o.log.Info("Cannot update ExternalFunction circuit breaker condition", "function", name, "error", err)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestCircuitBreakingFunctionRunner(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "boom")
+	invalid := status.Error(codes.InvalidArgument, "bad input")
+
+	// A call to make to the runner, and what we expect afterward.
+	type call struct {
+		// advance is how far to advance the clock before the call.
+		advance time.Duration
+
+		// err is what the wrapped runner returns, if it's called.
+		err error
+
+		// cancel is whether the caller cancels the call.
+		cancel bool
+
+		wantCalled bool
+		wantOpen   bool
+		wantState  CircuitState
+	}
+
+	cases := map[string]struct {
+		reason string
+		calls  []call
+	}{
+		"TripsAfterThreshold": {
+			reason: "The circuit should open after the threshold of consecutive unhealthy calls, then fail fast.",
+			calls: []call{
+				{err: unavailable, wantCalled: true, wantState: CircuitClosed},
+				{err: unavailable, wantCalled: true, wantState: CircuitOpen},
+				{err: unavailable, wantCalled: false, wantOpen: true, wantState: CircuitOpen},
+			},
+		},
+		"SuccessResetsFailures": {
+			reason: "A successful call should reset the count of consecutive failures.",
+			calls: []call{
+				{err: unavailable, wantCalled: true, wantState: CircuitClosed},
+				{wantCalled: true, wantState: CircuitClosed},
+				{err: unavailable, wantCalled: true, wantState: CircuitClosed},
+			},
+		},
+		"HealthyErrorsDontCount": {
+			reason: "Errors that don't suggest the function is unhealthy shouldn't open the circuit.",
+			calls: []call{
+				{err: invalid, wantCalled: true, wantState: CircuitClosed},
+				{err: invalid, wantCalled: true, wantState: CircuitClosed},
+				{err: invalid, wantCalled: true, wantState: CircuitClosed},
+			},
+		},
+		"HalfOpenProbeSucceeds": {
+			reason: "A successful probe after the open duration should close the circuit.",
+			calls: []call{
+				{err: unavailable, wantCalled: true, wantState: CircuitClosed},
+				{err: unavailable, wantCalled: true, wantState: CircuitOpen},
+				{advance: time.Minute, wantCalled: true, wantState: CircuitClosed},
+				{wantCalled: true, wantState: CircuitClosed},
+			},
+		},
+		"HalfOpenProbeFails": {
+			reason: "A failed probe after the open duration should reopen the circuit.",
+			calls: []call{
+				{err: unavailable, wantCalled: true, wantState: CircuitClosed},
+				{err: unavailable, wantCalled: true, wantState: CircuitOpen},
+				{advance: time.Minute, err: unavailable, wantCalled: true, wantState: CircuitOpen},
+				{wantCalled: false, wantOpen: true, wantState: CircuitOpen},
+			},
+		},
+		"HalfOpenProbeCancelled": {
+			reason: "A probe the caller cancels should reopen the circuit for another open duration.",
+			calls: []call{
+				{err: unavailable, wantCalled: true, wantState: CircuitClosed},
+				{err: unavailable, wantCalled: true, wantState: CircuitOpen},
+				{advance: time.Minute, cancel: true, wantCalled: true, wantState: CircuitOpen},
+				{advance: 10 * time.Second, wantCalled: false, wantOpen: true, wantState: CircuitOpen},
+				{advance: time.Minute, wantCalled: true, wantState: CircuitClosed},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+
+			var err error
+			called := false
+			wrapped := FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+				called = true
+				return &fnv1.RunFunctionResponse{}, err
+			})
+
+			r := NewCircuitBreakingFunctionRunner(wrapped,
+				WithFailureThreshold(2),
+				WithOpenDuration(30*time.Second),
+			)
+			r.now = func() time.Time { return now }
+
+			for i, c := range tc.calls {
+				now = now.Add(c.advance)
+				err = c.err
+				called = false
+
+				ctx, cancel := context.WithCancel(context.Background())
+				if c.cancel {
+					cancel()
+				}
+
+				_, got := r.RunFunction(ctx, "cool-fn", &fnv1.RunFunctionRequest{})
+				cancel()
+
+				if diff := cmp.Diff(c.wantCalled, called); diff != "" {
+					t.Errorf("\n%s\ncall %d: RunFunction(...): -want called, +got called:\n%s", tc.reason, i, diff)
+				}
+				if diff := cmp.Diff(c.wantOpen, IsCircuitOpen(got)); diff != "" {
+					t.Errorf("\n%s\ncall %d: IsCircuitOpen(...): -want, +got:\n%s", tc.reason, i, diff)
+				}
+				if diff := cmp.Diff(c.wantState, r.State("cool-fn")); diff != "" {
+					t.Errorf("\n%s\ncall %d: State(...): -want, +got:\n%s", tc.reason, i, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUnhealthy(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"NoError": {
+			reason: "A successful call isn't unhealthy.",
+			want:   false,
+		},
+		"NoStatus": {
+			reason: "An error without a gRPC status comes from the runner, not the function, so isn't unhealthy.",
+			err:    errors.New("no active FunctionRevision"),
+			want:   false,
+		},
+		"Unavailable": {
+			reason: "An unavailable function is unhealthy.",
+			err:    errors.Wrap(status.Error(codes.Unavailable, "boom"), "cannot run function"),
+			want:   true,
+		},
+		"DeadlineExceeded": {
+			reason: "A function that doesn't respond in time is unhealthy.",
+			err:    status.Error(codes.DeadlineExceeded, "too slow"),
+			want:   true,
+		},
+		"InvalidArgument": {
+			reason: "A function that rejects its input isn't unhealthy.",
+			err:    status.Error(codes.InvalidArgument, "bad input"),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Unhealthy(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUnhealthy(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
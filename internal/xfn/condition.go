@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"github.com/google/cel-go/cel"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/internal/xcel"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// Variables that may be used in a pipeline step condition.
+const (
+	// ConditionVarObserved is the observed state sent to the step.
+	ConditionVarObserved = "observed"
+
+	// ConditionVarDesired is the desired state sent to the step.
+	ConditionVarDesired = "desired"
+
+	// ConditionVarContext is the function pipeline context sent to the step.
+	ConditionVarContext = "context"
+)
+
+const errEvalCondition = "cannot evaluate condition"
+
+var conditions = xcel.NewEvaluator([]cel.EnvOption{ //nolint:gochecknoglobals // We want to share compiled conditions.
+	cel.Types(&fnv1.State{}),
+	cel.Variable(ConditionVarObserved, cel.ObjectType("apiextensions.fn.proto.v1.State")),
+	cel.Variable(ConditionVarDesired, cel.ObjectType("apiextensions.fn.proto.v1.State")),
+	cel.Variable(ConditionVarContext, cel.ObjectType("google.protobuf.Struct")),
+})
+
+// EvaluateCondition evaluates the supplied CEL expression against the supplied
+// observed state, desired state, and function pipeline context. The expression
+// may refer to them as observed, desired, and context. It must evaluate to a
+// bool. An empty expression always evaluates to true.
+//
+// Observed and desired state are RunFunctionRequest State messages, so fields
+// use their protobuf names, for example
+// observed.composite.resource.spec.replicas or
+// has(desired.resources.bucket).
+func EvaluateCondition(expr string, o, d *fnv1.State, fctx *structpb.Struct) (bool, error) {
+	if expr == "" {
+		return true, nil
+	}
+
+	if o == nil {
+		o = &fnv1.State{}
+	}
+
+	if d == nil {
+		d = &fnv1.State{}
+	}
+
+	if fctx == nil {
+		fctx = &structpb.Struct{}
+	}
+
+	ok, err := conditions.EvalBool(expr, map[string]any{
+		ConditionVarObserved: o,
+		ConditionVarDesired:  d,
+		ConditionVarContext:  fctx,
+	})
+
+	return ok, errors.Wrap(err, errEvalCondition)
+}
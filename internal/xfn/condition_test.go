@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	o := &fnv1.State{
+		Composite: &fnv1.Resource{
+			Resource: MustStruct(map[string]any{
+				"apiVersion": "example.org/v1",
+				"kind":       "XR",
+				"spec": map[string]any{
+					"replicas": 3,
+					"region":   "us-east-1",
+				},
+			}),
+		},
+	}
+	d := &fnv1.State{
+		Resources: map[string]*fnv1.Resource{
+			"bucket": {Resource: MustStruct(map[string]any{"apiVersion": "example.org/v1", "kind": "Bucket"})},
+		},
+	}
+	fctx := MustStruct(map[string]any{"environment": "production"})
+
+	type args struct {
+		expr string
+		o    *fnv1.State
+		d    *fnv1.State
+		fctx *structpb.Struct
+	}
+
+	type want struct {
+		ok  bool
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"EmptyExpression": {
+			reason: "An empty expression should always evaluate to true.",
+			args:   args{},
+			want:   want{ok: true},
+		},
+		"ObservedCompositeTrue": {
+			reason: "It should be possible to refer to the observed composite resource.",
+			args: args{
+				expr: "observed.composite.resource.spec.replicas > 2",
+				o:    o,
+			},
+			want: want{ok: true},
+		},
+		"ObservedCompositeFalse": {
+			reason: "An expression that isn't met should evaluate to false.",
+			args: args{
+				expr: "observed.composite.resource.spec.region == 'eu-west-1'",
+				o:    o,
+			},
+			want: want{ok: false},
+		},
+		"DesiredResource": {
+			reason: "It should be possible to test whether a desired resource exists.",
+			args: args{
+				expr: "'bucket' in desired.resources && !('queue' in desired.resources)",
+				d:    d,
+			},
+			want: want{ok: true},
+		},
+		"Context": {
+			reason: "It should be possible to refer to the function pipeline context.",
+			args: args{
+				expr: "context.environment == 'production'",
+				fctx: fctx,
+			},
+			want: want{ok: true},
+		},
+		"NilState": {
+			reason: "Nil state should be treated as empty.",
+			args: args{
+				expr: "size(desired.resources) == 0 && !has(context.environment)",
+			},
+			want: want{ok: true},
+		},
+		"InvalidExpression": {
+			reason: "We should return an error if the expression doesn't compile.",
+			args: args{
+				expr: "observed.",
+			},
+			want: want{err: cmpopts.AnyError},
+		},
+		"NotBool": {
+			reason: "We should return an error if the expression doesn't evaluate to a bool.",
+			args: args{
+				expr: "size(desired.resources)",
+			},
+			want: want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ok, err := EvaluateCondition(tc.args.expr, tc.args.o, tc.args.d, tc.args.fctx)
+
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nEvaluateCondition(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nEvaluateCondition(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// Error strings.
+const (
+	errGetCredentialsSecret    = "cannot get credentials Secret"
+	errGetCredentialsConfigMap = "cannot get credentials ConfigMap"
+	errCreateTokenRequest      = "cannot create ServiceAccount TokenRequest"
+	errNoTokenAudiences        = "at least one token audience is required"
+)
+
+// CredentialsKeyToken is the key under which a ServiceAccount token is
+// supplied to a function.
+const CredentialsKeyToken = "token"
+
+// DefaultTokenExpirationSeconds is the lifetime of a requested ServiceAccount
+// token, if none is specified.
+const DefaultTokenExpirationSeconds int64 = 3600
+
+// A cached ServiceAccount token is refreshed once this fraction of its
+// lifetime has passed. This is the same fraction the kubelet uses for
+// projected ServiceAccount token volumes.
+const tokenRefreshFraction = 0.8
+
+// A CredentialsFetcher fetches the credentials a function needs to run.
+type CredentialsFetcher interface {
+	// FetchSecret fetches credentials from the supplied Secret.
+	FetchSecret(ctx context.Context, ref types.NamespacedName) (*fnv1.Credentials, error)
+
+	// FetchConfigMap fetches credentials from the supplied ConfigMap.
+	FetchConfigMap(ctx context.Context, ref types.NamespacedName) (*fnv1.Credentials, error)
+
+	// FetchServiceAccountToken fetches a short-lived token for the supplied
+	// ServiceAccount and audiences. At least one audience is required. An
+	// expiration of zero uses the default expiration.
+	FetchServiceAccountToken(ctx context.Context, ref types.NamespacedName, audiences []string, expirationSeconds int64) (*fnv1.Credentials, error)
+}
+
+// An ExistingCredentialsFetcher fetches function credentials from existing
+// Secrets and ConfigMaps, and by requesting ServiceAccount tokens from the
+// API server.
+type ExistingCredentialsFetcher struct {
+	reader client.Reader
+	tokens client.SubResourceClientConstructor
+
+	now func() time.Time
+
+	mx     sync.Mutex
+	cached map[string]token
+}
+
+type token struct {
+	token     string
+	refreshAt time.Time
+}
+
+// An ExistingCredentialsFetcherOption configures an
+// ExistingCredentialsFetcher.
+type ExistingCredentialsFetcherOption func(f *ExistingCredentialsFetcher)
+
+// WithClock configures the function an ExistingCredentialsFetcher uses to
+// get the current time.
+func WithClock(now func() time.Time) ExistingCredentialsFetcherOption {
+	return func(f *ExistingCredentialsFetcher) {
+		f.now = now
+	}
+}
+
+// NewExistingCredentialsFetcher returns a CredentialsFetcher that reads
+// Secrets and ConfigMaps using the supplied reader, and requests tokens using
+// the supplied subresource client. Tokens are cached until most of their
+// lifetime has passed.
+func NewExistingCredentialsFetcher(r client.Reader, c client.SubResourceClientConstructor, o ...ExistingCredentialsFetcherOption) *ExistingCredentialsFetcher {
+	f := &ExistingCredentialsFetcher{
+		reader: r,
+		tokens: c,
+		now:    time.Now,
+		cached: make(map[string]token),
+	}
+
+	for _, fn := range o {
+		fn(f)
+	}
+
+	return f
+}
+
+// FetchSecret fetches credentials from the supplied Secret.
+func (f *ExistingCredentialsFetcher) FetchSecret(ctx context.Context, ref types.NamespacedName) (*fnv1.Credentials, error) {
+	s := &corev1.Secret{}
+	if err := f.reader.Get(ctx, ref, s); err != nil {
+		return nil, errors.Wrap(err, errGetCredentialsSecret)
+	}
+
+	return CredentialsFromData(s.Data), nil
+}
+
+// FetchConfigMap fetches credentials from the supplied ConfigMap. Both its
+// data and binary data are supplied to the function.
+func (f *ExistingCredentialsFetcher) FetchConfigMap(ctx context.Context, ref types.NamespacedName) (*fnv1.Credentials, error) {
+	cm := &corev1.ConfigMap{}
+	if err := f.reader.Get(ctx, ref, cm); err != nil {
+		return nil, errors.Wrap(err, errGetCredentialsConfigMap)
+	}
+
+	return CredentialsFromConfigMap(cm), nil
+}
+
+// FetchServiceAccountToken returns a cached token for the supplied
+// ServiceAccount, or requests a new one if there's no cached token or the
+// cached token is due to be refreshed. It doesn't request a token without
+// audiences, because the API server would default them to its own, making the
+// token valid for the Kubernetes API.
+func (f *ExistingCredentialsFetcher) FetchServiceAccountToken(ctx context.Context, ref types.NamespacedName, audiences []string, expirationSeconds int64) (*fnv1.Credentials, error) {
+	if len(audiences) == 0 {
+		return nil, errors.New(errNoTokenAudiences)
+	}
+
+	if expirationSeconds == 0 {
+		expirationSeconds = DefaultTokenExpirationSeconds
+	}
+
+	aud := slices.Sorted(slices.Values(audiences))
+	key := strings.Join([]string{ref.Namespace, ref.Name, strconv.FormatInt(expirationSeconds, 10), strings.Join(aud, ",")}, "/")
+
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	now := f.now()
+
+	if t, ok := f.cached[key]; ok && now.Before(t.refreshAt) {
+		return CredentialsFromData(map[string][]byte{CredentialsKeyToken: []byte(t.token)}), nil
+	}
+
+	sa := &corev1.ServiceAccount{}
+	sa.SetName(ref.Name)
+	sa.SetNamespace(ref.Namespace)
+
+	tr := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         aud,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+
+	if err := f.tokens.SubResource("token").Create(ctx, sa, tr); err != nil {
+		delete(f.cached, key)
+		return nil, errors.Wrap(err, errCreateTokenRequest)
+	}
+
+	// The API server may issue a token with a different lifetime than the
+	// one we asked for, so we refresh based on its actual expiry.
+	lifetime := tr.Status.ExpirationTimestamp.Sub(now)
+	f.cached[key] = token{
+		token:     tr.Status.Token,
+		refreshAt: now.Add(time.Duration(float64(lifetime) * tokenRefreshFraction)),
+	}
+
+	return CredentialsFromData(map[string][]byte{CredentialsKeyToken: []byte(tr.Status.Token)}), nil
+}
+
+// CredentialsFromData returns function credentials containing the supplied
+// data.
+func CredentialsFromData(data map[string][]byte) *fnv1.Credentials {
+	return &fnv1.Credentials{
+		Source: &fnv1.Credentials_CredentialData{
+			CredentialData: &fnv1.CredentialData{
+				Data: data,
+			},
+		},
+	}
+}
+
+// CredentialsFromConfigMap returns function credentials containing the data
+// and binary data of the supplied ConfigMap.
+func CredentialsFromConfigMap(cm *corev1.ConfigMap) *fnv1.Credentials {
+	data := make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+	for k, v := range cm.Data {
+		data[k] = []byte(v)
+	}
+
+	maps.Copy(data, cm.BinaryData)
+
+	return CredentialsFromData(data)
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestFetchConfigMap(t *testing.T) {
+	type want struct {
+		creds *fnv1.Credentials
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		want   want
+	}{
+		"GetError": {
+			reason: "We should return an error if we can't get the ConfigMap.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(errors.New("boom")),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"Success": {
+			reason: "We should return the ConfigMap's data and binary data.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					cm := obj.(*corev1.ConfigMap)
+					cm.Data = map[string]string{"url": "https://example.org"}
+					cm.BinaryData = map[string][]byte{"ca.crt": []byte("cool-ca")}
+					return nil
+				}),
+			},
+			want: want{
+				creds: CredentialsFromData(map[string][]byte{
+					"url":    []byte("https://example.org"),
+					"ca.crt": []byte("cool-ca"),
+				}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := NewExistingCredentialsFetcher(tc.c, nil)
+			got, err := f.FetchConfigMap(context.Background(), types.NamespacedName{Namespace: "default", Name: "cool-cm"})
+
+			if diff := cmp.Diff(tc.want.creds, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nFetchConfigMap(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nFetchConfigMap(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFetchServiceAccountToken(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ref := types.NamespacedName{Namespace: "default", Name: "cool-sa"}
+
+	type call struct {
+		at        time.Time
+		audiences []string
+	}
+
+	type want struct {
+		tokens   []string
+		requests []authenticationv1.TokenRequestSpec
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		create test.MockSubResourceCreateFn
+		calls  []call
+		want   want
+	}{
+		"NoAudiences": {
+			reason: "We should return an error rather than request a token for the API server's audiences.",
+			calls:  []call{{at: now}},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"CreateError": {
+			reason: "We should return an error if we can't request a token.",
+			create: test.NewMockSubResourceCreateFn(errors.New("boom")),
+			calls:  []call{{at: now, audiences: []string{"a"}}},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"CachedToken": {
+			reason: "We should reuse a token until most of its lifetime has passed, then request a new one.",
+			calls: []call{
+				{at: now, audiences: []string{"b", "a"}},
+				{at: now.Add(30 * time.Minute), audiences: []string{"a", "b"}},
+				{at: now.Add(50 * time.Minute), audiences: []string{"a", "b"}},
+			},
+			want: want{
+				tokens: []string{"token-1", "token-1", "token-2"},
+				requests: []authenticationv1.TokenRequestSpec{
+					{Audiences: []string{"a", "b"}, ExpirationSeconds: ptr.To[int64](3600)},
+					{Audiences: []string{"a", "b"}, ExpirationSeconds: ptr.To[int64](3600)},
+				},
+			},
+		},
+		"DifferentAudiences": {
+			reason: "We shouldn't reuse a token requested for different audiences.",
+			calls: []call{
+				{at: now, audiences: []string{"a"}},
+				{at: now, audiences: []string{"b"}},
+			},
+			want: want{
+				tokens: []string{"token-1", "token-2"},
+				requests: []authenticationv1.TokenRequestSpec{
+					{Audiences: []string{"a"}, ExpirationSeconds: ptr.To[int64](3600)},
+					{Audiences: []string{"b"}, ExpirationSeconds: ptr.To[int64](3600)},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clock := now
+			got := want{}
+
+			create := tc.create
+			if create == nil {
+				create = func(_ context.Context, obj, sub client.Object, _ ...client.SubResourceCreateOption) error {
+					if obj.GetName() != ref.Name || obj.GetNamespace() != ref.Namespace {
+						t.Errorf("TokenRequest for unexpected ServiceAccount %s/%s", obj.GetNamespace(), obj.GetName())
+					}
+					tr := sub.(*authenticationv1.TokenRequest)
+					got.requests = append(got.requests, tr.Spec)
+					tr.Status.Token = fmt.Sprintf("token-%d", len(got.requests))
+					tr.Status.ExpirationTimestamp = metav1.NewTime(clock.Add(time.Duration(*tr.Spec.ExpirationSeconds) * time.Second))
+					return nil
+				}
+			}
+
+			f := NewExistingCredentialsFetcher(nil, &test.MockClient{MockSubResourceCreate: create}, WithClock(func() time.Time { return clock }))
+
+			for _, c := range tc.calls {
+				clock = c.at
+
+				creds, err := f.FetchServiceAccountToken(context.Background(), ref, c.audiences, 0)
+				if err != nil {
+					got.err = err
+					break
+				}
+
+				got.tokens = append(got.tokens, string(creds.GetCredentialData().GetData()[CredentialsKeyToken]))
+			}
+
+			if diff := cmp.Diff(tc.want.tokens, got.tokens); diff != "" {
+				t.Errorf("\n%s\nFetchServiceAccountToken(...): -want tokens, +got tokens:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.requests, got.requests); diff != "" {
+				t.Errorf("\n%s\nFetchServiceAccountToken(...): -want requests, +got requests:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, got.err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nFetchServiceAccountToken(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
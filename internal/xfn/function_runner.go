@@ -19,6 +19,10 @@ package xfn
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,14 +30,23 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health" // Enables client side health checking.
+	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
 	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/tracing"
 	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
 	fnv1beta1 "github.com/crossplane/crossplane/v2/proto/fn/v1beta1"
 )
@@ -48,6 +61,15 @@ const (
 	errFmtRunFunction   = "cannot run Function %q"
 	errFmtEmptyEndpoint = "cannot determine gRPC target: active FunctionRevision %q has an empty status.endpoint"
 	errFmtDialFunction  = "cannot gRPC dial target %q from status.endpoint of active FunctionRevision %q"
+
+	errNoActiveRevisionsOrExternal = "cannot find an active FunctionRevision (a FunctionRevision with spec.desiredState: Active) or an ExternalFunction"
+	errGetExternalFunction         = "cannot get ExternalFunction"
+	errListExternalFunctions       = "cannot List ExternalFunctions to determine which gRPC client connections to garbage collect."
+
+	errFmtDialExternalFunction = "cannot gRPC dial target %q from spec.endpoint of ExternalFunction %q"
+	errFmtGetTLSSecret         = "cannot get TLS Secret %q"
+	errFmtParseCACert          = "cannot parse CA certificate bundle from the ca.crt key of Secret %q"
+	errFmtParseClientCert      = "cannot parse client certificate from Secret %q"
 )
 
 // This configures a gRPC client to use round robin load balancing. This means
@@ -55,6 +77,11 @@ const (
 // Service is headless, requests will be spread across each Pod.
 // See https://github.com/grpc/grpc/blob/v1.58.0/doc/load-balancing.md#load-balancing-policies
 //
+// It also configures the gRPC client to use the gRPC health checking protocol
+// to avoid sending requests to Pods that report they're not serving. Functions
+// that don't implement the protocol are assumed to be healthy.
+// See https://grpc.io/docs/guides/health-checking/
+//
 // It also configures the gRPC client to wait for the server to be ready before
 // sending RPCs. Notably this gives Functions time to start before we make a
 // request. See https://grpc.io/docs/guides/wait-for-ready/
@@ -65,6 +92,9 @@ const svcConfig = `
 			"round_robin":{}
 		}
 	],
+	"healthCheckConfig": {
+		"serviceName": ""
+	},
 	"methodConfig": [
 		{
 			"name": [{}],
@@ -90,25 +120,43 @@ func (fn FunctionRunnerFn) RunFunction(ctx context.Context, name string, req *fn
 // A PackagedFunctionRunner runs a Function by making a gRPC call to a Function
 // package's runtime. It creates a gRPC client connection for each Function. The
 // Function's endpoint is determined by reading the status.endpoint of the
-// active FunctionRevision. You must call GarbageCollectClientConnections in
-// order to ensure connections are properly closed.
+// active FunctionRevision, or optionally the spec.endpoint of an
+// ExternalFunction. You must call GarbageCollectClientConnections in order to
+// ensure connections are properly closed.
 type PackagedFunctionRunner struct {
 	client       client.Reader
 	creds        credentials.TransportCredentials
 	interceptors []InterceptorCreator
+	metrics      StepMetrics
+
+	external  bool
+	namespace string
+
+	resolver resolver.Builder
 
 	connsMx sync.RWMutex
-	conns   map[string]*grpc.ClientConn
+	conns   map[string]*clientConn
 
 	log logging.Logger
 }
 
-// An InterceptorCreator creates gRPC UnaryClientInterceptors for functions.
+// An InterceptorCreator creates gRPC client interceptors for functions.
 type InterceptorCreator interface {
 	// CreateInterceptor creates an interceptor for the named function. It also
 	// accepts the function's package OCI reference, which may be used by the
 	// interceptor (e.g. to label metrics).
 	CreateInterceptor(name, pkg string) grpc.UnaryClientInterceptor
+
+	// CreateStreamInterceptor creates an interceptor for the named
+	// function's streaming RPCs, like RunFunctionStream.
+	CreateStreamInterceptor(name, pkg string) grpc.StreamClientInterceptor
+}
+
+// StepMetrics records metrics about calls made to run pipeline steps.
+type StepMetrics interface {
+	// RunStep records that the named function was called the supplied
+	// number of times to run the supplied pipeline step.
+	RunStep(name, step string, attempts int, d time.Duration, err error)
 }
 
 // A PackagedFunctionRunnerOption configures a PackagedFunctionRunner.
@@ -136,14 +184,43 @@ func WithInterceptorCreators(ics ...InterceptorCreator) PackagedFunctionRunnerOp
 	}
 }
 
+// WithStepMetrics configures the metrics the PackagedFunctionRunner should use
+// to record pipeline step runs.
+func WithStepMetrics(m StepMetrics) PackagedFunctionRunnerOption {
+	return func(r *PackagedFunctionRunner) {
+		r.metrics = m
+	}
+}
+
+// WithExternalFunctions configures the PackagedFunctionRunner to run
+// ExternalFunctions. It reads the Secrets referenced by an ExternalFunction's
+// TLS configuration from the supplied namespace.
+func WithExternalFunctions(namespace string) PackagedFunctionRunnerOption {
+	return func(r *PackagedFunctionRunner) {
+		r.external = true
+		r.namespace = namespace
+	}
+}
+
+// WithEndpointSliceResolver configures the PackagedFunctionRunner to resolve
+// the Service endpoints of Function packages using the supplied resolver,
+// rather than DNS. Endpoints that aren't of the form
+// dns:///name.namespace:port are still resolved using DNS.
+func WithEndpointSliceResolver(b *EndpointSliceResolverBuilder) PackagedFunctionRunnerOption {
+	return func(r *PackagedFunctionRunner) {
+		r.resolver = b
+	}
+}
+
 // NewPackagedFunctionRunner returns a FunctionRunner that runs a Function by
 // making a gRPC call to a Function package's runtime.
 func NewPackagedFunctionRunner(c client.Reader, o ...PackagedFunctionRunnerOption) *PackagedFunctionRunner {
 	r := &PackagedFunctionRunner{
-		client: c,
-		creds:  insecure.NewCredentials(),
-		conns:  make(map[string]*grpc.ClientConn),
-		log:    logging.NewNopLogger(),
+		client:  c,
+		creds:   insecure.NewCredentials(),
+		conns:   make(map[string]*clientConn),
+		metrics: &NopStepMetrics{},
+		log:     logging.NewNopLogger(),
 	}
 
 	for _, fn := range o {
@@ -155,13 +232,42 @@ func NewPackagedFunctionRunner(c client.Reader, o ...PackagedFunctionRunnerOptio
 
 // RunFunction sends the supplied RunFunctionRequest to the named Function. The
 // function is expected to be an installed Function.pkg.crossplane.io package.
+// If the supplied context carries a StepPolicy, RunFunction applies its
+// timeout and retry policy. If the supplied context carries a ProgressHandler
+// and the Function has the streaming capability, RunFunction streams progress
+// updates to the handler.
 func (r *PackagedFunctionRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
-	conn, err := r.getClientConn(ctx, name)
+	conn, caps, err := r.getClientConn(ctx, name)
 	if err != nil {
 		return nil, errors.Wrapf(err, errFmtGetClientConn, name)
 	}
 
-	rsp, err := NewBetaFallBackFunctionRunnerServiceClient(conn).RunFunction(ctx, req)
+	c := NewBetaFallBackFunctionRunnerServiceClient(conn)
+	call := func(ctx context.Context) (*fnv1.RunFunctionResponse, error) {
+		return c.RunFunction(ctx, req)
+	}
+
+	// Only stream if the caller wants progress updates, and the function
+	// says it can send them.
+	if h, ok := GetProgressHandler(ctx); ok && slices.Contains(caps, pkgmetav1.FunctionCapabilityStreaming) {
+		call = func(ctx context.Context) (*fnv1.RunFunctionResponse, error) {
+			return RunFunctionStream(ctx, c, req, h)
+		}
+	}
+
+	p, ok := GetStepPolicy(ctx)
+	if !ok {
+		rsp, err := call(ctx)
+		return rsp, errors.Wrapf(err, errFmtRunFunction, name)
+	}
+
+	start := time.Now()
+	rsp, attempts, err := p.Run(ctx, call)
+	r.metrics.RunStep(name, p.Step, attempts, time.Since(start), err)
+
+	if attempts > 1 {
+		r.log.Debug("Retried pipeline step function call", "function", name, "step", p.Step, "attempts", attempts, "error", err)
+	}
 
 	return rsp, errors.Wrapf(err, errFmtRunFunction, name)
 }
@@ -189,37 +295,24 @@ func (r *PackagedFunctionRunner) RunFunction(ctx context.Context, name string, r
 // cost of listing and iterating over FunctionRevisions from cache. The default
 // RevisionHistoryLimit is 1, so for most Functions we'd expect there to be two
 // revisions in the cache (one active, and one previously active).
-func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string) (*grpc.ClientConn, error) {
+//
+// If external functions are enabled and the Function has no active
+// FunctionRevision, we fall back to the ExternalFunction of the same name.
+func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string) (*grpc.ClientConn, []string, error) {
 	log := r.log.WithValues("function", name)
 
-	l := &pkgv1.FunctionRevisionList{}
-	if err := r.client.List(ctx, l, client.MatchingLabels{pkgv1.LabelParentPackage: name}); err != nil {
-		return nil, errors.Wrapf(err, errListFunctionRevisions)
-	}
-
-	var active *pkgv1.FunctionRevision
-	for i := range l.Items {
-		if l.Items[i].GetDesiredState() == pkgv1.PackageRevisionActive {
-			active = &l.Items[i]
-			break
-		}
-	}
-
-	if active == nil {
-		return nil, errors.New(errNoActiveRevisions)
-	}
-
-	if active.Status.Endpoint == "" {
-		return nil, errors.Errorf(errFmtEmptyEndpoint, active.GetName())
+	t, err := r.getTarget(ctx, name)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// If we have a connection for the up-to-date endpoint, return it.
+	// If we have a connection for the up-to-date target, return it.
 	r.connsMx.RLock()
 
 	conn, ok := r.conns[name]
-	if ok && conn.Target() == active.Status.Endpoint {
+	if ok && conn.config == t.config {
 		defer r.connsMx.RUnlock()
-		return conn, nil
+		return conn.ClientConn, t.caps, nil
 	}
 
 	r.connsMx.RUnlock()
@@ -232,38 +325,191 @@ func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string)
 	// released the read lock and took the write lock, so check again.
 	conn, ok = r.conns[name]
 	if ok {
-		// We now have a connection for the up-to-date endpoint.
-		if conn.Target() == active.Status.Endpoint {
-			return conn, nil
+		// We now have a connection for the up-to-date target.
+		if conn.config == t.config {
+			return conn.ClientConn, t.caps, nil
 		}
 
-		// This connection is to an old endpoint. We need to close it and create
+		// This connection is to an old target. We need to close it and create
 		// a new connection. Close only returns an error is if the connection is
 		// already closed or in the process of closing.
-		log.Debug("Closing gRPC client connection with stale target", "old-target", conn.Target(), "new-target", active.Status.Endpoint)
+		log.Debug("Closing gRPC client connection with stale target", "old-target", conn.Target(), "new-target", t.endpoint)
 		_ = conn.Close()
 
 		delete(r.conns, name)
 	}
 
+	creds, err := t.creds(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	is := make([]grpc.UnaryClientInterceptor, len(r.interceptors))
+	sis := make([]grpc.StreamClientInterceptor, len(r.interceptors))
 	for i := range r.interceptors {
-		is[i] = r.interceptors[i].CreateInterceptor(name, active.Spec.Package)
+		is[i] = r.interceptors[i].CreateInterceptor(name, t.pkg)
+		sis[i] = r.interceptors[i].CreateStreamInterceptor(name, t.pkg)
 	}
 
-	conn, err := grpc.NewClient(active.Status.Endpoint,
-		grpc.WithTransportCredentials(r.creds),
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultServiceConfig(svcConfig),
-		grpc.WithChainUnaryInterceptor(is...))
+		grpc.WithChainUnaryInterceptor(is...),
+		grpc.WithChainStreamInterceptor(sis...),
+		grpc.WithStatsHandler(tracing.ClientStatsHandler()),
+	}
+
+	dial := t.endpoint
+	if t.service && r.resolver != nil {
+		if svc, ns, port, ok := ParseServiceEndpoint(t.endpoint); ok && strings.HasPrefix(t.endpoint, "dns:") {
+			dial = ServiceTarget(svc, ns, port)
+			opts = append(opts, grpc.WithResolvers(r.resolver))
+		}
+	}
+
+	cc, err := grpc.NewClient(dial, opts...)
 	if err != nil {
-		return nil, errors.Wrapf(err, errFmtDialFunction, active.Status.Endpoint, active.GetName())
+		return nil, nil, errors.Wrapf(err, t.errFmtDial, dial, t.source)
+	}
+
+	r.conns[name] = &clientConn{ClientConn: cc, config: t.config}
+
+	log.Debug("Created new gRPC client connection", "target", dial)
+
+	return cc, t.caps, nil
+}
+
+// A clientConn is a gRPC client connection to a function.
+type clientConn struct {
+	*grpc.ClientConn
+
+	// config identifies the configuration the connection was created with.
+	// The connection is stale if the function's configuration changes.
+	config string
+}
+
+// A target is where and how to call a function.
+type target struct {
+	// service is true if the endpoint is the Service of a Function package.
+	service bool
+
+	endpoint string
+	pkg      string
+	caps     []string
+	config   string
+	creds    func(ctx context.Context) (credentials.TransportCredentials, error)
+
+	// source is the name of the resource the target was read from, and
+	// errFmtDial describes it when we can't dial the target.
+	source     string
+	errFmtDial string
+}
+
+func (r *PackagedFunctionRunner) getTarget(ctx context.Context, name string) (*target, error) {
+	l := &pkgv1.FunctionRevisionList{}
+	if err := r.client.List(ctx, l, client.MatchingLabels{pkgv1.LabelParentPackage: name}); err != nil {
+		return nil, errors.Wrapf(err, errListFunctionRevisions)
+	}
+
+	var active *pkgv1.FunctionRevision
+	for i := range l.Items {
+		if l.Items[i].GetDesiredState() == pkgv1.PackageRevisionActive {
+			active = &l.Items[i]
+			break
+		}
+	}
+
+	if active != nil {
+		if active.Status.Endpoint == "" {
+			return nil, errors.Errorf(errFmtEmptyEndpoint, active.GetName())
+		}
+
+		return &target{
+			service:  true,
+			endpoint: active.Status.Endpoint,
+			pkg:      active.Spec.Package,
+			caps:     active.GetCapabilities(),
+			config:   active.Status.Endpoint,
+			creds: func(_ context.Context) (credentials.TransportCredentials, error) {
+				return r.creds, nil
+			},
+			source:     active.GetName(),
+			errFmtDial: errFmtDialFunction,
+		}, nil
+	}
+
+	if !r.external {
+		return nil, errors.New(errNoActiveRevisions)
 	}
 
-	r.conns[name] = conn
+	xf := &pkgv1beta1.ExternalFunction{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name}, xf); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, errors.New(errNoActiveRevisionsOrExternal)
+		}
+
+		return nil, errors.Wrap(err, errGetExternalFunction)
+	}
+
+	return &target{
+		endpoint: xf.Spec.Endpoint,
+		caps:     xf.GetCapabilities(),
+		// Recreate the connection if the ExternalFunction is recreated or
+		// its spec changes.
+		config: fmt.Sprintf("%s/%d", xf.GetUID(), xf.GetGeneration()),
+		creds: func(ctx context.Context) (credentials.TransportCredentials, error) {
+			return r.externalCredentials(ctx, xf)
+		},
+		source:     xf.GetName(),
+		errFmtDial: errFmtDialExternalFunction,
+	}, nil
+}
+
+// externalCredentials returns the transport credentials used to call the
+// supplied ExternalFunction. It reads any Secrets referenced by the
+// ExternalFunction's TLS configuration, so Crossplane only picks up changes to
+// these Secrets when it creates a new connection.
+func (r *PackagedFunctionRunner) externalCredentials(ctx context.Context, xf *pkgv1beta1.ExternalFunction) (credentials.TransportCredentials, error) {
+	t := ptr.Deref(xf.Spec.TLS, pkgv1beta1.ExternalFunctionTLS{})
+
+	if t.Mode == pkgv1beta1.ExternalFunctionTLSModeInsecure {
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: t.ServerName,
+	}
+
+	if ref := t.CASecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: r.namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrapf(err, errFmtGetTLSSecret, ref.Name)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(s.Data[pkgv1beta1.ExternalFunctionSecretKeyCACert]) {
+			return nil, errors.Errorf(errFmtParseCACert, ref.Name)
+		}
 
-	log.Debug("Created new gRPC client connection", "target", active.Status.Endpoint)
+		cfg.RootCAs = pool
+	}
+
+	if ref := t.ClientCertSecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: r.namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrapf(err, errFmtGetTLSSecret, ref.Name)
+		}
+
+		cert, err := tls.X509KeyPair(s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtParseClientCert, ref.Name)
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
 
-	return conn, nil
+	return credentials.NewTLS(cfg), nil
 }
 
 // GarbageCollectConnections runs every interval until the supplied context is
@@ -318,6 +564,17 @@ func (r *PackagedFunctionRunner) GarbageCollectConnectionsNow(ctx context.Contex
 		functionExists[f.GetName()] = true
 	}
 
+	if r.external {
+		xl := &pkgv1beta1.ExternalFunctionList{}
+		if err := r.client.List(ctx, xl); err != nil {
+			return 0, errors.Wrap(err, errListExternalFunctions)
+		}
+
+		for _, f := range xl.Items {
+			functionExists[f.GetName()] = true
+		}
+	}
+
 	// Garbage collect connections.
 	closed := 0
 
@@ -411,3 +668,9 @@ func fromBeta(rsp *fnv1beta1.RunFunctionResponse) (*fnv1.RunFunctionResponse, er
 
 	return out, errors.Wrapf(err, "cannot unmarshal %T protobuf bytes into %T", rsp, out)
 }
+
+// RunFunctionStream sends a v1 RunFunctionRequest using the RunFunctionStream
+// RPC. It never falls back to v1beta1, which doesn't support streaming.
+func (c *BetaFallBackFunctionRunnerServiceClient) RunFunctionStream(ctx context.Context, req *fnv1.RunFunctionRequest, opts ...grpc.CallOption) (fnv1.FunctionRunnerService_RunFunctionStreamClient, error) {
+	return fnv1.NewFunctionRunnerServiceClient(c.cc).RunFunctionStream(ctx, req, opts...)
+}
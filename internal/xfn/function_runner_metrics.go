@@ -17,21 +17,53 @@ package xfn
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
 	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
 )
 
+var (
+	_ StepMetrics    = &NopStepMetrics{}
+	_ StepMetrics    = &PrometheusMetrics{}
+	_ CircuitMetrics = &NopCircuitMetrics{}
+	_ CircuitMetrics = &PrometheusMetrics{}
+)
+
+// NopStepMetrics does nothing.
+type NopStepMetrics struct{}
+
+// RunStep does nothing.
+func (m *NopStepMetrics) RunStep(_, _ string, _ int, _ time.Duration, _ error) {}
+
+// NopCircuitMetrics does nothing.
+type NopCircuitMetrics struct{}
+
+// CircuitStateChanged does nothing.
+func (m *NopCircuitMetrics) CircuitStateChanged(_ string, _ CircuitState) {}
+
+// CircuitRejected does nothing.
+func (m *NopCircuitMetrics) CircuitRejected(_ string) {}
+
 // PrometheusMetrics are requests, errors, and duration (RED) metrics for
 // function runs.
 type PrometheusMetrics struct {
 	requests  *prometheus.CounterVec
 	responses *prometheus.CounterVec
 	duration  *prometheus.HistogramVec
+
+	stepDuration *prometheus.HistogramVec
+	stepRetries  *prometheus.CounterVec
+
+	circuitOpen     *prometheus.GaugeVec
+	circuitTrips    *prometheus.CounterVec
+	circuitRejected *prometheus.CounterVec
 }
 
 // NewPrometheusMetrics creates metrics for function runs.
@@ -55,6 +87,37 @@ func NewPrometheusMetrics() *PrometheusMetrics {
 			Help:      "Histogram of RunFunctionResponse latency (seconds).",
 			Buckets:   prometheus.DefBuckets,
 		}, []string{"function_name", "function_package", "grpc_target", "grpc_method", "grpc_code", "result_severity"}),
+
+		stepDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Subsystem: "function",
+			Name:      "run_function_step_seconds",
+			Help:      "Histogram of pipeline step latency (seconds), including any retries.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"function_name", "step", "grpc_code"}),
+
+		stepRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "function",
+			Name:      "run_function_step_retries_total",
+			Help:      "Total number of times a pipeline step's function call was retried.",
+		}, []string{"function_name", "step"}),
+
+		circuitOpen: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: "function",
+			Name:      "circuit_breaker_open",
+			Help:      "Whether a function's circuit breaker is open (1), half-open (0.5), or closed (0).",
+		}, []string{"function_name"}),
+
+		circuitTrips: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "function",
+			Name:      "circuit_breaker_trips_total",
+			Help:      "Total number of times a function's circuit breaker opened.",
+		}, []string{"function_name"}),
+
+		circuitRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "function",
+			Name:      "circuit_breaker_rejected_total",
+			Help:      "Total number of function calls that failed fast because the function's circuit breaker was open.",
+		}, []string{"function_name"}),
 	}
 }
 
@@ -65,6 +128,11 @@ func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
 	m.requests.Describe(ch)
 	m.responses.Describe(ch)
 	m.duration.Describe(ch)
+	m.stepDuration.Describe(ch)
+	m.stepRetries.Describe(ch)
+	m.circuitOpen.Describe(ch)
+	m.circuitTrips.Describe(ch)
+	m.circuitRejected.Describe(ch)
 }
 
 // Collect is called by the Prometheus registry when collecting
@@ -74,6 +142,46 @@ func (m *PrometheusMetrics) Collect(ch chan<- prometheus.Metric) {
 	m.requests.Collect(ch)
 	m.responses.Collect(ch)
 	m.duration.Collect(ch)
+	m.stepDuration.Collect(ch)
+	m.stepRetries.Collect(ch)
+	m.circuitOpen.Collect(ch)
+	m.circuitTrips.Collect(ch)
+	m.circuitRejected.Collect(ch)
+}
+
+// RunStep records that the named function was called the supplied number of
+// times to run the supplied pipeline step. The duration should include all
+// attempts, and the error should be that of the final attempt.
+func (m *PrometheusMetrics) RunStep(name, step string, attempts int, d time.Duration, err error) {
+	s, _ := status.FromError(err)
+
+	m.stepDuration.With(prometheus.Labels{"function_name": name, "step": step, "grpc_code": s.Code().String()}).Observe(d.Seconds())
+
+	if attempts > 1 {
+		m.stepRetries.With(prometheus.Labels{"function_name": name, "step": step}).Add(float64(attempts - 1))
+	}
+}
+
+// CircuitStateChanged records that the named function's circuit breaker
+// changed state.
+func (m *PrometheusMetrics) CircuitStateChanged(name string, s CircuitState) {
+	l := prometheus.Labels{"function_name": name}
+
+	switch s {
+	case CircuitOpen:
+		m.circuitOpen.With(l).Set(1)
+		m.circuitTrips.With(l).Inc()
+	case CircuitHalfOpen:
+		m.circuitOpen.With(l).Set(0.5)
+	case CircuitClosed:
+		m.circuitOpen.With(l).Set(0)
+	}
+}
+
+// CircuitRejected records that a call to the named function failed fast
+// because its circuit breaker was open.
+func (m *PrometheusMetrics) CircuitRejected(name string) {
+	m.circuitRejected.With(prometheus.Labels{"function_name": name}).Inc()
 }
 
 // CreateInterceptor returns a gRPC UnaryClientInterceptor for the named
@@ -88,35 +196,96 @@ func (m *PrometheusMetrics) CreateInterceptor(name, pkg string) grpc.UnaryClient
 		err := invoker(ctx, method, req, reply, cc, opts...)
 		duration := time.Since(start)
 
-		s, _ := status.FromError(err)
-		l["grpc_code"] = s.Code().String()
-
-		// We consider the 'severity' of the response to be that of the most
-		// severe result in the response. A response with no results, or only
-		// normal results, has severity "Normal". A response with warnings, but
-		// no fatal results, has severity "Warning". A response with fatal
-		// results has severity "Fatal".
-		l["result_severity"] = "Normal"
-
-		if rsp, ok := reply.(*fnv1.RunFunctionResponse); ok {
-			for _, r := range rsp.GetResults() {
-				// Keep iterating if we see a warning result - we might still
-				// see a fatal result.
-				if r.GetSeverity() == fnv1.Severity_SEVERITY_WARNING {
-					l["result_severity"] = "Warning"
-				}
-				// Break if we see a fatal result, to ensure we don't downgrade
-				// the severity to warning.
-				if r.GetSeverity() == fnv1.Severity_SEVERITY_FATAL {
-					l["result_severity"] = "Fatal"
-					break
-				}
-			}
+		rsp, _ := reply.(*fnv1.RunFunctionResponse)
+		m.observe(l, duration, rsp, err)
+
+		return err
+	}
+}
+
+// CreateStreamInterceptor returns a gRPC StreamClientInterceptor for the named
+// function. The supplied package (pkg) should be the package's OCI reference.
+// It records a streaming RPC's response when the function sends its final
+// response, or when the stream fails.
+func (m *PrometheusMetrics) CreateStreamInterceptor(name, pkg string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		l := prometheus.Labels{"function_name": name, "function_package": pkg, "grpc_target": cc.Target(), "grpc_method": method}
+
+		m.requests.With(l).Inc()
+
+		start := time.Now()
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			m.observe(l, time.Since(start), nil, err)
+			return nil, err
+		}
+
+		return &observedClientStream{ClientStream: s, observe: func(rsp *fnv1.RunFunctionResponse, err error) {
+			m.observe(l, time.Since(start), rsp, err)
+		}}, nil
+	}
+}
+
+// observe records a response with the supplied labels.
+func (m *PrometheusMetrics) observe(l prometheus.Labels, d time.Duration, rsp *fnv1.RunFunctionResponse, err error) {
+	s, _ := status.FromError(err)
+	l["grpc_code"] = s.Code().String()
+
+	// We consider the 'severity' of the response to be that of the most
+	// severe result in the response. A response with no results, or only
+	// normal results, has severity "Normal". A response with warnings, but
+	// no fatal results, has severity "Warning". A response with fatal
+	// results has severity "Fatal".
+	l["result_severity"] = "Normal"
+
+	for _, r := range rsp.GetResults() {
+		// Keep iterating if we see a warning result - we might still see a
+		// fatal result.
+		if r.GetSeverity() == fnv1.Severity_SEVERITY_WARNING {
+			l["result_severity"] = "Warning"
 		}
+		// Break if we see a fatal result, to ensure we don't downgrade the
+		// severity to warning.
+		if r.GetSeverity() == fnv1.Severity_SEVERITY_FATAL {
+			l["result_severity"] = "Fatal"
+			break
+		}
+	}
+
+	m.responses.With(l).Inc()
+	m.duration.With(l).Observe(d.Seconds())
+}
 
-		m.responses.With(l).Inc()
-		m.duration.With(l).Observe(duration.Seconds())
+// An observedClientStream calls observe once, when the function sends its
+// final response or the stream fails. A stream that ends without a final
+// response is observed as OK, without results.
+type observedClientStream struct {
+	grpc.ClientStream
 
+	observe  func(rsp *fnv1.RunFunctionResponse, err error)
+	observed bool
+}
+
+func (s *observedClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if s.observed {
 		return err
 	}
+
+	switch {
+	case errors.Is(err, io.EOF):
+		s.observe(nil, nil)
+	case err != nil:
+		s.observe(nil, err)
+	default:
+		rsp, ok := m.(*fnv1.RunFunctionStreamResponse)
+		if !ok || rsp.GetFinal() == nil {
+			return nil
+		}
+		s.observe(rsp.GetFinal(), nil)
+	}
+
+	s.observed = true
+
+	return err
 }
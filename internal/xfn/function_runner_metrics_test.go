@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestCreateStreamInterceptor(t *testing.T) {
+	progress := []*fnv1.Progress{{Message: "Migrating"}}
+
+	type want struct {
+		requests  float64
+		responses float64
+		severity  string
+	}
+
+	cases := map[string]struct {
+		reason string
+		server fnv1.FunctionRunnerServiceServer
+		want   want
+	}{
+		"FinalResponse": {
+			reason: "We should record the final response's severity once the function sends it.",
+			server: &MockStreamingFunctionServer{
+				progress: progress,
+				final: &fnv1.RunFunctionResponse{Results: []*fnv1.Result{
+					{Severity: fnv1.Severity_SEVERITY_WARNING},
+				}},
+			},
+			want: want{
+				requests:  1,
+				responses: 1,
+				severity:  "Warning",
+			},
+		},
+		"NoFinalResponse": {
+			reason: "We should record a response if the function closes the stream without a final response.",
+			server: &MockStreamingFunctionServer{progress: progress},
+			want: want{
+				requests:  1,
+				responses: 1,
+				severity:  "Normal",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			lis := NewGRPCServer(t, tc.server)
+			defer lis.Close()
+
+			m := NewPrometheusMetrics()
+
+			conn, err := grpc.NewClient(lis.Addr().String(),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithStreamInterceptor(m.CreateStreamInterceptor("cool-fn", "cool-pkg")),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			_, _ = RunFunctionStream(context.Background(), fnv1.NewFunctionRunnerServiceClient(conn), &fnv1.RunFunctionRequest{}, func(_ *fnv1.Progress) {})
+
+			l := prometheus.Labels{
+				"function_name":    "cool-fn",
+				"function_package": "cool-pkg",
+				"grpc_target":      conn.Target(),
+				"grpc_method":      fnv1.FunctionRunnerService_RunFunctionStream_FullMethodName,
+			}
+
+			if diff := cmp.Diff(tc.want.requests, testutil.ToFloat64(m.requests.With(l))); diff != "" {
+				t.Errorf("\n%s\nrequests: -want, +got:\n%s", tc.reason, diff)
+			}
+
+			l["grpc_code"] = "OK"
+			l["result_severity"] = tc.want.severity
+
+			if diff := cmp.Diff(tc.want.responses, testutil.ToFloat64(m.responses.With(l))); diff != "" {
+				t.Errorf("\n%s\nresponses: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
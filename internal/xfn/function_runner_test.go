@@ -32,6 +32,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 
 	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
 	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
 	fnv1beta1 "github.com/crossplane/crossplane/v2/proto/fn/v1beta1"
 )
@@ -285,7 +286,7 @@ func TestGetClientConn(t *testing.T) {
 
 	// We should be able to create a new connection.
 	t.Run("CreateNewConnection", func(t *testing.T) {
-		conn, err := r.getClientConn(context.Background(), "cool-fn")
+		conn, _, err := r.getClientConn(context.Background(), "cool-fn")
 
 		if diff := cmp.Diff(target, conn.Target()); diff != "" {
 			t.Errorf("\nr.getClientConn(...): -want, +got:\n%s", diff)
@@ -299,7 +300,7 @@ func TestGetClientConn(t *testing.T) {
 	// If we're called again and our FunctionRevision's endpoint hasn't changed,
 	// we should return our cached connection.
 	t.Run("ReuseExistingConnection", func(t *testing.T) {
-		conn, err := r.getClientConn(context.Background(), "cool-fn")
+		conn, _, err := r.getClientConn(context.Background(), "cool-fn")
 
 		if diff := cmp.Diff(target, conn.Target()); diff != "" {
 			t.Errorf("\nr.getClientConn(...): -want, +got:\n%s", diff)
@@ -322,7 +323,7 @@ func TestGetClientConn(t *testing.T) {
 	// If we're called again and our FunctionRevision's endpoint _has_ changed,
 	// we should close our cached connection and create a new one.
 	t.Run("ReplaceExistingConnection", func(t *testing.T) {
-		conn, err := r.getClientConn(context.Background(), "cool-fn")
+		conn, _, err := r.getClientConn(context.Background(), "cool-fn")
 
 		if diff := cmp.Diff(target, conn.Target()); diff != "" {
 			t.Errorf("\nr.getClientConn(...): -want, +got:\n%s", diff)
@@ -339,6 +340,117 @@ func TestGetClientConn(t *testing.T) {
 	}
 }
 
+func TestGetClientConnExternalFunction(t *testing.T) {
+	// Start a gRPC server.
+	lis := NewGRPCServer(t, &MockFunctionServer{rsp: &fnv1.RunFunctionResponse{
+		Meta: &fnv1.ResponseMeta{Tag: "hi!"},
+	}})
+	defer lis.Close()
+
+	target := strings.Replace(lis.Addr().String(), "127.0.0.1", "dns:///localhost", 1)
+
+	xf := &pkgv1beta1.ExternalFunction{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-fn", UID: "cool-uid", Generation: 1},
+		Spec: pkgv1beta1.ExternalFunctionSpec{
+			Endpoint:     target,
+			TLS:          &pkgv1beta1.ExternalFunctionTLS{Mode: pkgv1beta1.ExternalFunctionTLSModeInsecure},
+			Capabilities: []string{"composition", "streaming"},
+		},
+	}
+
+	c := &test.MockClient{
+		// No FunctionRevisions.
+		MockList: test.NewMockListFn(nil),
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			xf.DeepCopyInto(obj.(*pkgv1beta1.ExternalFunction))
+			return nil
+		}),
+	}
+
+	t.Run("ExternalFunctionsDisabled", func(t *testing.T) {
+		r := NewPackagedFunctionRunner(c)
+		_, _, err := r.getClientConn(context.Background(), "cool-fn")
+
+		if diff := cmp.Diff(errors.New(errNoActiveRevisions), err, test.EquateErrors()); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want error, +got error:\n%s", diff)
+		}
+	})
+
+	r := NewPackagedFunctionRunner(c, WithExternalFunctions("crossplane-system"))
+
+	var first *grpc.ClientConn
+
+	t.Run("CreateNewConnection", func(t *testing.T) {
+		conn, caps, err := r.getClientConn(context.Background(), "cool-fn")
+		first = conn
+
+		if diff := cmp.Diff(target, conn.Target()); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want, +got:\n%s", diff)
+		}
+
+		if diff := cmp.Diff([]string{"composition", "streaming"}, caps); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want capabilities, +got capabilities:\n%s", diff)
+		}
+
+		if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want error, +got error:\n%s", diff)
+		}
+	})
+
+	t.Run("ReuseExistingConnection", func(t *testing.T) {
+		conn, _, err := r.getClientConn(context.Background(), "cool-fn")
+
+		if conn != first {
+			t.Errorf("\nr.getClientConn(...): want the existing connection, got a new one")
+		}
+
+		if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want error, +got error:\n%s", diff)
+		}
+	})
+
+	// If the ExternalFunction's spec changes we should create a new connection,
+	// even if its endpoint didn't change.
+	xf.SetGeneration(2)
+
+	t.Run("ReplaceExistingConnection", func(t *testing.T) {
+		conn, _, err := r.getClientConn(context.Background(), "cool-fn")
+
+		if conn == first {
+			t.Errorf("\nr.getClientConn(...): want a new connection, got the existing one")
+		}
+
+		if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want error, +got error:\n%s", diff)
+		}
+	})
+
+	t.Run("ExternalFunctionStillExistsDoNotGarbageCollect", func(t *testing.T) {
+		c.MockList = test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			if l, ok := obj.(*pkgv1beta1.ExternalFunctionList); ok {
+				l.Items = []pkgv1beta1.ExternalFunction{*xf}
+			}
+			return nil
+		})
+
+		i, err := r.GarbageCollectConnectionsNow(context.Background())
+
+		if diff := cmp.Diff(0, i); diff != "" {
+			t.Errorf("\nr.GarbageCollectConnectionsNow(...): -want, +got:\n%s", diff)
+		}
+
+		if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+			t.Errorf("\nr.GarbageCollectConnectionsNow(...): -want error, +got error:\n%s", diff)
+		}
+	})
+
+	// Close any gRPC clients.
+	c.MockList = test.NewMockListFn(nil)
+	if _, err := r.GarbageCollectConnectionsNow(context.Background()); err != nil {
+		t.Logf("Error closing client connections: %s", err)
+	}
+}
+
 func TestGarbageCollectConnectionsNow(t *testing.T) {
 	// TestRunFunction exercises most of the GarbageCollectConnectionsNow code.
 	// Here we just test some cases that don't fit well in our usual
@@ -362,7 +474,7 @@ func TestGarbageCollectConnectionsNow(t *testing.T) {
 
 	// Add our connection to our pool.
 	r.connsMx.Lock()
-	r.conns["cool-fn"] = conn
+	r.conns["cool-fn"] = &clientConn{ClientConn: conn, config: target}
 	r.connsMx.Unlock()
 
 	ctx := context.Background()
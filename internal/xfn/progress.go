@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+const (
+	errOpenStream    = "cannot open RunFunctionStream stream"
+	errRecvStream    = "cannot receive from RunFunctionStream stream"
+	errNoFinalStream = "function closed RunFunctionStream stream without sending a final response"
+)
+
+// A ProgressHandler handles progress updates streamed by a function.
+type ProgressHandler func(p *fnv1.Progress)
+
+type progressHandlerKey struct{}
+
+// WithProgressHandler returns a copy of the supplied context that carries the
+// supplied progress handler. Function runners that support streaming call it
+// for each progress update streamed by a function called with the returned
+// context. Function runners fall back to calling functions that don't support
+// streaming without progress updates.
+func WithProgressHandler(ctx context.Context, h ProgressHandler) context.Context {
+	return context.WithValue(ctx, progressHandlerKey{}, h)
+}
+
+// GetProgressHandler returns the progress handler carried by the supplied
+// context, if any.
+func GetProgressHandler(ctx context.Context) (ProgressHandler, bool) {
+	h, ok := ctx.Value(progressHandlerKey{}).(ProgressHandler)
+	return h, ok
+}
+
+// RunFunctionStream runs a function using the RunFunctionStream RPC. It calls
+// the supplied handler for each progress update, and returns the function's
+// final response. It falls back to the unary RunFunction RPC if the function
+// doesn't implement RunFunctionStream.
+func RunFunctionStream(ctx context.Context, c fnv1.FunctionRunnerServiceClient, req *fnv1.RunFunctionRequest, h ProgressHandler) (*fnv1.RunFunctionResponse, error) {
+	// Make sure the stream is cleaned up if we return before it's done.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.RunFunctionStream(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return c.RunFunction(ctx, req)
+		}
+
+		return nil, errors.Wrap(err, errOpenStream)
+	}
+
+	for first := true; ; first = false {
+		rsp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New(errNoFinalStream)
+		}
+
+		// gRPC doesn't report an unimplemented streaming RPC until we try
+		// to receive the first message.
+		if first && status.Code(err) == codes.Unimplemented {
+			return c.RunFunction(ctx, req)
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, errRecvStream)
+		}
+
+		if f := rsp.GetFinal(); f != nil {
+			return f, nil
+		}
+
+		if p := rsp.GetProgress(); p != nil {
+			h(p)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/testing/protocmp"
+	"k8s.io/utils/ptr"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestRunFunctionStream(t *testing.T) {
+	final := &fnv1.RunFunctionResponse{Meta: &fnv1.ResponseMeta{Tag: "hi"}}
+	progress := []*fnv1.Progress{
+		{Message: "Migrating", PercentComplete: ptr.To[int32](50)},
+		{Message: "Almost done", PercentComplete: ptr.To[int32](90)},
+	}
+
+	type want struct {
+		rsp      *fnv1.RunFunctionResponse
+		progress []*fnv1.Progress
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		server fnv1.FunctionRunnerServiceServer
+		want   want
+	}{
+		"Streaming": {
+			reason: "We should call the handler for each progress update, and return the final response.",
+			server: &MockStreamingFunctionServer{progress: progress, final: final},
+			want: want{
+				rsp:      final,
+				progress: progress,
+			},
+		},
+		"FallBackToUnary": {
+			reason: "We should fall back to RunFunction if RunFunctionStream is unimplemented.",
+			server: &MockFunctionServer{rsp: final},
+			want: want{
+				rsp: final,
+			},
+		},
+		"NoFinalResponse": {
+			reason: "We should return an error if the function closes the stream without a final response.",
+			server: &MockStreamingFunctionServer{progress: progress},
+			want: want{
+				progress: progress,
+				err:      cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			lis := NewGRPCServer(t, tc.server)
+			defer lis.Close()
+
+			conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			got := make([]*fnv1.Progress, 0)
+			h := func(p *fnv1.Progress) { got = append(got, p) }
+
+			rsp, err := RunFunctionStream(context.Background(), fnv1.NewFunctionRunnerServiceClient(conn), &fnv1.RunFunctionRequest{}, h)
+
+			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nRunFunctionStream(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.progress, got, protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nRunFunctionStream(...): -want progress, +got progress:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRunFunctionStream(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type MockStreamingFunctionServer struct {
+	fnv1.UnimplementedFunctionRunnerServiceServer
+
+	progress []*fnv1.Progress
+	final    *fnv1.RunFunctionResponse
+}
+
+func (s *MockStreamingFunctionServer) RunFunctionStream(_ *fnv1.RunFunctionRequest, stream fnv1.FunctionRunnerService_RunFunctionStreamServer) error {
+	for _, p := range s.progress {
+		if err := stream.Send(&fnv1.RunFunctionStreamResponse{Response: &fnv1.RunFunctionStreamResponse_Progress{Progress: p}}); err != nil {
+			return err
+		}
+	}
+
+	if s.final == nil {
+		return nil
+	}
+
+	return stream.Send(&fnv1.RunFunctionStreamResponse{Response: &fnv1.RunFunctionStreamResponse_Final{Final: s.final}})
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 
+	"github.com/crossplane/crossplane/v2/internal/tracing"
 	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
 )
 
@@ -60,19 +61,41 @@ func NewFetchingFunctionRunner(r FunctionRunner, f RequiredResourcesFetcher) *Fe
 	return &FetchingFunctionRunner{wrapped: r, resources: f}
 }
 
+// A RunObserver observes each time a FetchingFunctionRunner runs a function,
+// for example to record how many times it took for the function's
+// requirements to stabilize.
+type RunObserver func(req *fnv1.RunFunctionRequest, rsp *fnv1.RunFunctionResponse)
+
+type runObserverKey struct{}
+
+// WithRunObserver returns a context that tells a FetchingFunctionRunner to
+// call the supplied observer each time it runs a function.
+func WithRunObserver(ctx context.Context, o RunObserver) context.Context {
+	return context.WithValue(ctx, runObserverKey{}, o)
+}
+
 // RunFunction runs a function, repeatedly fetching any required resources it asks
 // for. The function may be run up to MaxRequirementsIterations times.
 func (c *FetchingFunctionRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
 	// Used to store the requirements returned at the previous iteration.
 	var requirements *fnv1.Requirements
 
+	observe, _ := ctx.Value(runObserverKey{}).(RunObserver)
+
 	for i := int64(0); i <= MaxRequirementsIterations; i++ {
-		rsp, err := c.wrapped.RunFunction(ctx, name, req)
+		rctx, span := tracing.Start(ctx, "RunFunction", tracing.AttrKeyFunction.String(name), tracing.AttrKeyIteration.Int64(i))
+		rsp, err := c.wrapped.RunFunction(rctx, name, req)
+		tracing.End(span, err)
+
 		if err != nil {
 			// I can't think of any useful info to wrap this error with.
 			return nil, err
 		}
 
+		if observe != nil {
+			observe(req, rsp)
+		}
+
 		for _, rs := range rsp.GetResults() {
 			if rs.GetSeverity() == fnv1.Severity_SEVERITY_FATAL {
 				// We won't iterate if the function returned a fatal result.
@@ -96,7 +119,7 @@ func (c *FetchingFunctionRunner) RunFunction(ctx context.Context, name string, r
 		// Fetch the requested resources and add them to the desired state.
 		// Support both old (extra_resources) and new (resources) field names.
 		for name, selector := range newRequirements.GetExtraResources() { //nolint:staticcheck // Supporting deprecated field for backward compatibility
-			resources, err := c.resources.Fetch(ctx, selector)
+			resources, err := c.fetch(ctx, name, i, selector)
 			if err != nil {
 				return nil, errors.Wrapf(err, "fetching resources for %s", name)
 			}
@@ -106,7 +129,7 @@ func (c *FetchingFunctionRunner) RunFunction(ctx context.Context, name string, r
 		}
 
 		for name, selector := range newRequirements.GetResources() {
-			resources, err := c.resources.Fetch(ctx, selector)
+			resources, err := c.fetch(ctx, name, i, selector)
 			if err != nil {
 				return nil, errors.Wrapf(err, "fetching resources for %s", name)
 			}
@@ -122,6 +145,14 @@ func (c *FetchingFunctionRunner) RunFunction(ctx context.Context, name string, r
 	return nil, errors.Errorf("requirements didn't stabilize after the maximum number of iterations (%d)", MaxRequirementsIterations)
 }
 
+func (c *FetchingFunctionRunner) fetch(ctx context.Context, name string, iteration int64, selector *fnv1.ResourceSelector) (*fnv1.Resources, error) {
+	ctx, span := tracing.Start(ctx, "FetchRequiredResources", tracing.AttrKeyRequirement.String(name), tracing.AttrKeyIteration.Int64(iteration))
+	resources, err := c.resources.Fetch(ctx, selector)
+	tracing.End(span, err)
+
+	return resources, err
+}
+
 // ExistingRequiredResourcesFetcher fetches required resources requested by
 // functions using the provided client.Reader.
 type ExistingRequiredResourcesFetcher struct {
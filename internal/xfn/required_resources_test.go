@@ -287,7 +287,9 @@ func TestFetchingFunctionRunner(t *testing.T) {
 					return nil, errors.New("boom")
 				}),
 			},
-			args: args{},
+			args: args{
+				ctx: context.Background(),
+			},
 			want: want{
 				err: cmpopts.AnyError,
 			},
@@ -306,7 +308,9 @@ func TestFetchingFunctionRunner(t *testing.T) {
 					return rsp, nil
 				}),
 			},
-			args: args{},
+			args: args{
+				ctx: context.Background(),
+			},
 			want: want{
 				rsp: &fnv1.RunFunctionResponse{
 					Results: []*fnv1.Result{
@@ -332,7 +336,9 @@ func TestFetchingFunctionRunner(t *testing.T) {
 					return rsp, nil
 				}),
 			},
-			args: args{},
+			args: args{
+				ctx: context.Background(),
+			},
 			want: want{
 				rsp: &fnv1.RunFunctionResponse{
 					Results: []*fnv1.Result{
@@ -365,6 +371,7 @@ func TestFetchingFunctionRunner(t *testing.T) {
 				}),
 			},
 			args: args{
+				ctx: context.Background(),
 				req: &fnv1.RunFunctionRequest{},
 			},
 			want: want{
@@ -394,6 +401,7 @@ func TestFetchingFunctionRunner(t *testing.T) {
 				}),
 			},
 			args: args{
+				ctx: context.Background(),
 				req: &fnv1.RunFunctionRequest{},
 			},
 			want: want{
@@ -443,6 +451,7 @@ func TestFetchingFunctionRunner(t *testing.T) {
 				}),
 			},
 			args: args{
+				ctx: context.Background(),
 				req: &fnv1.RunFunctionRequest{},
 			},
 			want: want{
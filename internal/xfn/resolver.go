@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+)
+
+// EndpointSliceScheme is the gRPC target scheme resolved by reading the
+// EndpointSlices of a Kubernetes Service, e.g. kubernetes:///name.namespace:port.
+const EndpointSliceScheme = "kubernetes"
+
+// DefaultResolveInterval is the default interval at which an EndpointSlice
+// resolver re-reads a Service's EndpointSlices.
+const DefaultResolveInterval = 5 * time.Second
+
+// Error strings.
+const (
+	errListEndpointSlices = "cannot list EndpointSlices"
+	errNoReadyEndpoints   = "cannot find any ready endpoints in the Service's EndpointSlices"
+
+	errFmtInvalidServiceTarget = "invalid target %q: must be of the form name.namespace:port"
+)
+
+var _ resolver.Builder = &EndpointSliceResolverBuilder{}
+
+// An EndpointSliceResolverBuilder builds gRPC resolvers that resolve a
+// Kubernetes Service to the addresses of its ready endpoints. It reads the
+// Service's EndpointSlices, rather than resolving its DNS name. This means
+// calls are spread across new endpoints as soon as they're ready, and never
+// sent to endpoints that aren't ready.
+type EndpointSliceResolverBuilder struct {
+	client   client.Reader
+	interval time.Duration
+	log      logging.Logger
+}
+
+// An EndpointSliceResolverBuilderOption configures an
+// EndpointSliceResolverBuilder.
+type EndpointSliceResolverBuilderOption func(b *EndpointSliceResolverBuilder)
+
+// WithResolveInterval configures how often resolvers re-read EndpointSlices.
+func WithResolveInterval(d time.Duration) EndpointSliceResolverBuilderOption {
+	return func(b *EndpointSliceResolverBuilder) {
+		b.interval = d
+	}
+}
+
+// WithResolverLogger configures the logger resolvers should use.
+func WithResolverLogger(l logging.Logger) EndpointSliceResolverBuilderOption {
+	return func(b *EndpointSliceResolverBuilder) {
+		b.log = l
+	}
+}
+
+// NewEndpointSliceResolverBuilder returns a gRPC resolver builder that reads
+// EndpointSlices using the supplied client. The client should be backed by a
+// cache, because each resolver reads EndpointSlices frequently.
+func NewEndpointSliceResolverBuilder(c client.Reader, o ...EndpointSliceResolverBuilderOption) *EndpointSliceResolverBuilder {
+	b := &EndpointSliceResolverBuilder{
+		client:   c,
+		interval: DefaultResolveInterval,
+		log:      logging.NewNopLogger(),
+	}
+
+	for _, fn := range o {
+		fn(b)
+	}
+
+	return b
+}
+
+// Scheme returns the scheme resolved by this builder's resolvers.
+func (b *EndpointSliceResolverBuilder) Scheme() string {
+	return EndpointSliceScheme
+}
+
+// Build a resolver for the supplied target. The target's endpoint must be of
+// the form name.namespace:port.
+func (b *EndpointSliceResolverBuilder) Build(t resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	name, namespace, port, ok := ParseServiceEndpoint(t.Endpoint())
+	if !ok {
+		return nil, errors.Errorf(errFmtInvalidServiceTarget, t.Endpoint())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := &endpointSliceResolver{
+		client:     b.client,
+		cc:         cc,
+		name:       name,
+		namespace:  namespace,
+		port:       port,
+		interval:   b.interval,
+		log:        b.log.WithValues("service", name, "namespace", namespace),
+		resolveNow: make(chan struct{}, 1),
+		cancel:     cancel,
+	}
+
+	r.wg.Add(1)
+
+	go r.watch(ctx)
+
+	return r, nil
+}
+
+// ServiceTarget returns the gRPC target of the supplied Service endpoint
+// using the EndpointSlice scheme.
+func ServiceTarget(name, namespace string, port int32) string {
+	return fmt.Sprintf("%s:///%s.%s:%d", EndpointSliceScheme, name, namespace, port)
+}
+
+// ParseServiceEndpoint parses an endpoint of the form name.namespace:port, with
+// an optional scheme. It returns false if the endpoint isn't of this form.
+func ParseServiceEndpoint(endpoint string) (name, namespace string, port int32, ok bool) {
+	// Strip the scheme and authority, e.g. dns://authority/.
+	if _, rest, ok := strings.Cut(endpoint, "://"); ok {
+		_, endpoint, _ = strings.Cut(rest, "/")
+	}
+
+	host, p, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", "", 0, false
+	}
+
+	pn, err := strconv.ParseInt(p, 10, 32)
+	if err != nil {
+		return "", "", 0, false
+	}
+
+	// Service and namespace names can't contain dots.
+	name, namespace, ok = strings.Cut(host, ".")
+	if !ok || name == "" || namespace == "" || strings.Contains(namespace, ".") {
+		return "", "", 0, false
+	}
+
+	return name, namespace, int32(pn), true
+}
+
+type endpointSliceResolver struct {
+	client    client.Reader
+	cc        resolver.ClientConn
+	name      string
+	namespace string
+	port      int32
+	interval  time.Duration
+	log       logging.Logger
+
+	resolveNow chan struct{}
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
+	// Only accessed by the watch goroutine.
+	last []string
+}
+
+// ResolveNow asks the resolver to re-read EndpointSlices. gRPC calls it
+// when a connection to an endpoint fails.
+func (r *endpointSliceResolver) ResolveNow(_ resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+		// A resolve is already pending.
+	}
+}
+
+// Close stops the resolver.
+func (r *endpointSliceResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *endpointSliceResolver) watch(ctx context.Context) {
+	defer r.wg.Done()
+
+	t := time.NewTicker(r.interval)
+	defer t.Stop()
+
+	for {
+		r.resolve(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		case <-r.resolveNow:
+		}
+	}
+}
+
+func (r *endpointSliceResolver) resolve(ctx context.Context) {
+	addrs, err := r.endpoints(ctx)
+	if err != nil {
+		r.last = nil
+		r.cc.ReportError(err)
+		return
+	}
+
+	// Don't churn the load balancer if nothing changed.
+	if slices.Equal(addrs, r.last) {
+		return
+	}
+
+	s := resolver.State{Addresses: make([]resolver.Address, len(addrs))}
+	for i, a := range addrs {
+		s.Addresses[i] = resolver.Address{Addr: a}
+	}
+
+	if err := r.cc.UpdateState(s); err != nil {
+		r.log.Debug("Cannot update gRPC client connection with resolved endpoints", "error", err)
+		r.last = nil
+		return
+	}
+
+	r.log.Debug("Resolved Service endpoints", "addresses", addrs)
+	r.last = addrs
+}
+
+// endpoints returns the sorted addresses of the Service's ready endpoints.
+func (r *endpointSliceResolver) endpoints(ctx context.Context) ([]string, error) {
+	l := &discoveryv1.EndpointSliceList{}
+	if err := r.client.List(ctx, l, client.InNamespace(r.namespace), client.MatchingLabels{discoveryv1.LabelServiceName: r.name}); err != nil {
+		return nil, errors.Wrap(err, errListEndpointSlices)
+	}
+
+	addrs := make([]string, 0)
+
+	for _, s := range l.Items {
+		port := r.port
+
+		// EndpointSlice ports are the ports of the endpoints, which may differ
+		// from the Service port. We can't tell which port corresponds to our
+		// Service port unless there's only one.
+		if len(s.Ports) == 1 && s.Ports[0].Port != nil {
+			port = *s.Ports[0].Port
+		}
+
+		for _, e := range s.Endpoints {
+			// A nil ready condition should be interpreted as ready.
+			if e.Conditions.Ready != nil && !*e.Conditions.Ready {
+				continue
+			}
+
+			for _, a := range e.Addresses {
+				addrs = append(addrs, net.JoinHostPort(a, strconv.Itoa(int(port))))
+			}
+		}
+	}
+
+	if len(addrs) == 0 {
+		return nil, errors.New(errNoReadyEndpoints)
+	}
+
+	slices.Sort(addrs)
+
+	return slices.Compact(addrs), nil
+}
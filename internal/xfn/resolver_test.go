@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/resolver"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+)
+
+func TestParseServiceEndpoint(t *testing.T) {
+	type want struct {
+		name      string
+		namespace string
+		port      int32
+		ok        bool
+	}
+
+	cases := map[string]struct {
+		reason   string
+		endpoint string
+		want     want
+	}{
+		"DNSTarget": {
+			reason:   "We should parse a DNS target like the one the package manager writes to a FunctionRevision's status.",
+			endpoint: "dns:///function-cool.crossplane-system:9443",
+			want:     want{name: "function-cool", namespace: "crossplane-system", port: 9443, ok: true},
+		},
+		"NoScheme": {
+			reason:   "We should parse an endpoint with no scheme.",
+			endpoint: "function-cool.crossplane-system:9443",
+			want:     want{name: "function-cool", namespace: "crossplane-system", port: 9443, ok: true},
+		},
+		"FullyQualified": {
+			reason:   "We shouldn't parse a fully qualified name, which might not be a Service.",
+			endpoint: "dns:///function-cool.example.org:9443",
+			want:     want{ok: false},
+		},
+		"NoNamespace": {
+			reason:   "We shouldn't parse an endpoint with no namespace.",
+			endpoint: "dns:///localhost:9443",
+			want:     want{ok: false},
+		},
+		"NoPort": {
+			reason:   "We shouldn't parse an endpoint with no port.",
+			endpoint: "dns:///function-cool.crossplane-system",
+			want:     want{ok: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n, ns, port, ok := ParseServiceEndpoint(tc.endpoint)
+			got := want{name: n, namespace: ns, port: port, ok: ok}
+
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nParseServiceEndpoint(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type MockResolverClientConn struct {
+	resolver.ClientConn
+
+	states chan resolver.State
+	errs   chan error
+}
+
+func (cc *MockResolverClientConn) UpdateState(s resolver.State) error {
+	cc.states <- s
+	return nil
+}
+
+func (cc *MockResolverClientConn) ReportError(err error) {
+	cc.errs <- err
+}
+
+func TestEndpointSliceResolver(t *testing.T) {
+	type want struct {
+		addrs []string
+		err   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		want   want
+	}{
+		"ListError": {
+			reason: "We should report an error if we can't list EndpointSlices.",
+			c: &test.MockClient{
+				MockList: test.NewMockListFn(errors.New("boom")),
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"NoReadyEndpoints": {
+			reason: "We should report an error if the Service has no ready endpoints.",
+			c: &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					obj.(*discoveryv1.EndpointSliceList).Items = []discoveryv1.EndpointSlice{
+						{
+							Endpoints: []discoveryv1.Endpoint{
+								{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+							},
+						},
+					}
+					return nil
+				}),
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"ReadyEndpoints": {
+			reason: "We should resolve the addresses of the Service's ready endpoints, using the EndpointSlice's port.",
+			c: &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					obj.(*discoveryv1.EndpointSliceList).Items = []discoveryv1.EndpointSlice{
+						{
+							Ports: []discoveryv1.EndpointPort{{Port: ptr.To[int32](8443)}},
+							Endpoints: []discoveryv1.Endpoint{
+								{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}},
+								{Addresses: []string{"10.0.0.1"}},
+								{Addresses: []string{"10.0.0.3"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+							},
+						},
+						{
+							// No ports, so we should use the target's port.
+							Endpoints: []discoveryv1.Endpoint{
+								{Addresses: []string{"fd00::1"}},
+							},
+						},
+					}
+					return nil
+				}),
+			},
+			want: want{
+				addrs: []string{"10.0.0.1:8443", "10.0.0.2:8443", "[fd00::1]:9443"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cc := &MockResolverClientConn{states: make(chan resolver.State, 1), errs: make(chan error, 1)}
+
+			b := NewEndpointSliceResolverBuilder(tc.c, WithResolveInterval(time.Hour))
+			r, err := b.Build(resolver.Target{URL: url.URL{Scheme: EndpointSliceScheme, Path: "/function-cool.crossplane-system:9443"}}, cc, resolver.BuildOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			got := want{}
+
+			select {
+			case s := <-cc.states:
+				for _, a := range s.Addresses {
+					got.addrs = append(got.addrs, a.Addr)
+				}
+			case <-cc.errs:
+				got.err = true
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the resolver to resolve")
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nBuild(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
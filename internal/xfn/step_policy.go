@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// Retry defaults.
+const (
+	// DefaultRetryMaxAttempts is the default maximum number of times a
+	// pipeline step with a retry policy calls its function.
+	DefaultRetryMaxAttempts = 3
+
+	// DefaultRetryBackoff is the default time to wait before the first retry.
+	DefaultRetryBackoff = 1 * time.Second
+
+	// DefaultRetryMaxBackoff is the default longest time to wait between
+	// retries.
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// DefaultRetryCodes are the gRPC status codes that are retried by default.
+var DefaultRetryCodes = []string{ //nolint:gochecknoglobals // We treat this as a constant.
+	codes.Unavailable.String(),
+	codes.DeadlineExceeded.String(),
+	codes.ResourceExhausted.String(),
+}
+
+// A StepPolicy configures how a function runner calls the function of a
+// pipeline step.
+type StepPolicy struct {
+	// Step is the name of the pipeline step.
+	Step string
+
+	// Timeout for each call to the function. Zero means calls are bound only
+	// by the caller's context.
+	Timeout time.Duration
+
+	// Retry configures how failed calls to the function are retried.
+	Retry RetryPolicy
+}
+
+// A RetryPolicy configures how failed function calls are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times to call the function,
+	// including the first call. Zero means calls aren't retried.
+	MaxAttempts int
+
+	// Backoff is how long to wait before the first retry. It doubles for
+	// each subsequent retry. Zero means DefaultRetryBackoff.
+	Backoff time.Duration
+
+	// MaxBackoff is the longest time to wait between retries. Zero means
+	// DefaultRetryMaxBackoff.
+	MaxBackoff time.Duration
+
+	// Codes are the names of the gRPC status codes that should be retried.
+	// Nil means DefaultRetryCodes.
+	Codes []string
+}
+
+type stepPolicyKey struct{}
+
+// WithStepPolicy returns a copy of the supplied context that carries the
+// supplied pipeline step policy. Function runners that support step policies
+// apply it to function calls made with the returned context.
+func WithStepPolicy(ctx context.Context, p StepPolicy) context.Context {
+	return context.WithValue(ctx, stepPolicyKey{}, p)
+}
+
+// GetStepPolicy returns the pipeline step policy carried by the supplied
+// context, if any.
+func GetStepPolicy(ctx context.Context) (StepPolicy, bool) {
+	p, ok := ctx.Value(stepPolicyKey{}).(StepPolicy)
+	return p, ok
+}
+
+// Run calls the supplied function until it succeeds, returns an error that
+// shouldn't be retried, or the policy's maximum attempts are exhausted. Each
+// call is subject to the policy's timeout. Run returns the number of calls it
+// made.
+func (p StepPolicy) Run(ctx context.Context, fn func(ctx context.Context) (*fnv1.RunFunctionResponse, error)) (*fnv1.RunFunctionResponse, int, error) {
+	for attempt := 1; ; attempt++ {
+		rsp, err := p.call(ctx, fn)
+		if err == nil || attempt >= p.Retry.MaxAttempts || !p.Retry.Retryable(err) {
+			return rsp, attempt, err
+		}
+
+		t := time.NewTimer(p.Retry.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return rsp, attempt, err
+		case <-t.C:
+		}
+	}
+}
+
+func (p StepPolicy) call(ctx context.Context, fn func(ctx context.Context) (*fnv1.RunFunctionResponse, error)) (*fnv1.RunFunctionResponse, error) {
+	if p.Timeout <= 0 {
+		return fn(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	return fn(ctx)
+}
+
+// Retryable returns true if the supplied error should be retried.
+func (p RetryPolicy) Retryable(err error) bool {
+	s, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+
+	if p.Codes == nil {
+		return slices.Contains(DefaultRetryCodes, s.Code().String())
+	}
+
+	return slices.Contains(p.Codes, s.Code().String())
+}
+
+// backoff returns how long to wait before the supplied retry. The first retry
+// is retry 1.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	b := p.Backoff
+	if b <= 0 {
+		b = DefaultRetryBackoff
+	}
+
+	maxb := p.MaxBackoff
+	if maxb <= 0 {
+		maxb = DefaultRetryMaxBackoff
+	}
+
+	for range retry - 1 {
+		b *= 2
+		if b >= maxb {
+			return maxb
+		}
+	}
+
+	return min(b, maxb)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestStepPolicyRun(t *testing.T) {
+	rsp := &fnv1.RunFunctionResponse{Meta: &fnv1.ResponseMeta{Tag: "hi"}}
+
+	// failFor returns a function that fails with the supplied code the
+	// supplied number of times, then succeeds.
+	failFor := func(n int, c codes.Code) func(context.Context) (*fnv1.RunFunctionResponse, error) {
+		calls := 0
+		return func(_ context.Context) (*fnv1.RunFunctionResponse, error) {
+			calls++
+			if calls <= n {
+				return nil, status.Error(c, "boom")
+			}
+			return rsp, nil
+		}
+	}
+
+	type args struct {
+		p  StepPolicy
+		fn func(context.Context) (*fnv1.RunFunctionResponse, error)
+	}
+
+	type want struct {
+		rsp      *fnv1.RunFunctionResponse
+		attempts int
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoRetryPolicy": {
+			reason: "We should call the function once if there's no retry policy.",
+			args: args{
+				p:  StepPolicy{Step: "cool"},
+				fn: failFor(1, codes.Unavailable),
+			},
+			want: want{
+				attempts: 1,
+				err:      cmpopts.AnyError,
+			},
+		},
+		"RetryUntilSuccess": {
+			reason: "We should retry retryable errors until the function succeeds.",
+			args: args{
+				p:  StepPolicy{Step: "cool", Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}},
+				fn: failFor(2, codes.Unavailable),
+			},
+			want: want{
+				rsp:      rsp,
+				attempts: 3,
+			},
+		},
+		"MaxAttemptsExhausted": {
+			reason: "We should return the last error if we exhaust our attempts.",
+			args: args{
+				p:  StepPolicy{Step: "cool", Retry: RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}},
+				fn: failFor(5, codes.Unavailable),
+			},
+			want: want{
+				attempts: 2,
+				err:      cmpopts.AnyError,
+			},
+		},
+		"NotRetryable": {
+			reason: "We shouldn't retry errors with codes that aren't retryable.",
+			args: args{
+				p:  StepPolicy{Step: "cool", Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}},
+				fn: failFor(1, codes.InvalidArgument),
+			},
+			want: want{
+				attempts: 1,
+				err:      cmpopts.AnyError,
+			},
+		},
+		"CustomCodes": {
+			reason: "We should retry errors with the configured codes.",
+			args: args{
+				p:  StepPolicy{Step: "cool", Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, Codes: []string{codes.Internal.String()}}},
+				fn: failFor(1, codes.Internal),
+			},
+			want: want{
+				rsp:      rsp,
+				attempts: 2,
+			},
+		},
+		"Timeout": {
+			reason: "We should apply the policy's timeout to each call.",
+			args: args{
+				p: StepPolicy{Step: "cool", Timeout: time.Millisecond},
+				fn: func(ctx context.Context) (*fnv1.RunFunctionResponse, error) {
+					<-ctx.Done()
+					return nil, status.FromContextError(ctx.Err()).Err()
+				},
+			},
+			want: want{
+				attempts: 1,
+				err:      cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rsp, attempts, err := tc.args.p.Run(context.Background(), tc.args.fn)
+
+			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.attempts, attempts); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want attempts, +got attempts:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      RetryPolicy
+		retry  int
+		want   time.Duration
+	}{
+		"FirstRetryDefault": {
+			reason: "The first retry should wait the default backoff.",
+			retry:  1,
+			want:   DefaultRetryBackoff,
+		},
+		"Doubles": {
+			reason: "The backoff should double with each retry.",
+			p:      RetryPolicy{Backoff: time.Second},
+			retry:  3,
+			want:   4 * time.Second,
+		},
+		"Capped": {
+			reason: "The backoff should not exceed the max backoff.",
+			p:      RetryPolicy{Backoff: time.Second, MaxBackoff: 3 * time.Second},
+			retry:  5,
+			want:   3 * time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.p.backoff(tc.retry)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nbackoff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
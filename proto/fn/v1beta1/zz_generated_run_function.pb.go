@@ -685,6 +685,170 @@ func (x *RunFunctionResponse) GetOutput() *structpb.Struct {
 	return nil
 }
 
+// A RunFunctionStreamResponse is sent by a function that is streaming progress
+// updates. A function sends zero or more progress updates, followed by exactly
+// one final response.
+type RunFunctionStreamResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*RunFunctionStreamResponse_Progress
+	//	*RunFunctionStreamResponse_Final
+	Response      isRunFunctionStreamResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunFunctionStreamResponse) Reset() {
+	*x = RunFunctionStreamResponse{}
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunFunctionStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunFunctionStreamResponse) ProtoMessage() {}
+
+func (x *RunFunctionStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunFunctionStreamResponse.ProtoReflect.Descriptor instead.
+func (*RunFunctionStreamResponse) Descriptor() ([]byte, []int) {
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RunFunctionStreamResponse) GetResponse() isRunFunctionStreamResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *RunFunctionStreamResponse) GetProgress() *Progress {
+	if x != nil {
+		if x, ok := x.Response.(*RunFunctionStreamResponse_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *RunFunctionStreamResponse) GetFinal() *RunFunctionResponse {
+	if x != nil {
+		if x, ok := x.Response.(*RunFunctionStreamResponse_Final); ok {
+			return x.Final
+		}
+	}
+	return nil
+}
+
+type isRunFunctionStreamResponse_Response interface {
+	isRunFunctionStreamResponse_Response()
+}
+
+type RunFunctionStreamResponse_Progress struct {
+	// Progress of the function run so far.
+	Progress *Progress `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type RunFunctionStreamResponse_Final struct {
+	// The final response. The function must close the stream after sending
+	// it.
+	Final *RunFunctionResponse `protobuf:"bytes,2,opt,name=final,proto3,oneof"`
+}
+
+func (*RunFunctionStreamResponse_Progress) isRunFunctionStreamResponse_Response() {}
+
+func (*RunFunctionStreamResponse_Final) isRunFunctionStreamResponse_Response() {}
+
+// Progress of a long-running function.
+type Progress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Human-readable details about the function's progress.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Optional estimate of how complete the function run is, as a percentage
+	// between 0 and 100.
+	PercentComplete *int32 `protobuf:"varint,2,opt,name=percent_complete,json=percentComplete,proto3,oneof" json:"percent_complete,omitempty"`
+	// Results produced so far. Progress results are informational. Results of
+	// fatal severity only stop the pipeline when included in the final
+	// response.
+	Results []*Result `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	// Status conditions observed so far.
+	Conditions    []*Condition `protobuf:"bytes,4,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Progress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Progress) GetPercentComplete() int32 {
+	if x != nil && x.PercentComplete != nil {
+		return *x.PercentComplete
+	}
+	return 0
+}
+
+func (x *Progress) GetResults() []*Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *Progress) GetConditions() []*Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
 // RequestMeta contains metadata pertaining to a RunFunctionRequest.
 type RequestMeta struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -697,7 +861,7 @@ type RequestMeta struct {
 
 func (x *RequestMeta) Reset() {
 	*x = RequestMeta{}
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[5]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -709,7 +873,7 @@ func (x *RequestMeta) String() string {
 func (*RequestMeta) ProtoMessage() {}
 
 func (x *RequestMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[5]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -722,7 +886,7 @@ func (x *RequestMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestMeta.ProtoReflect.Descriptor instead.
 func (*RequestMeta) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{5}
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RequestMeta) GetTag() string {
@@ -751,7 +915,7 @@ type Requirements struct {
 
 func (x *Requirements) Reset() {
 	*x = Requirements{}
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[6]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -763,7 +927,7 @@ func (x *Requirements) String() string {
 func (*Requirements) ProtoMessage() {}
 
 func (x *Requirements) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[6]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -776,7 +940,7 @@ func (x *Requirements) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Requirements.ProtoReflect.Descriptor instead.
 func (*Requirements) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{6}
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{8}
 }
 
 // Deprecated: Marked as deprecated in proto/fn/v1beta1/zz_generated_run_function.proto.
@@ -818,7 +982,7 @@ type ResourceSelector struct {
 
 func (x *ResourceSelector) Reset() {
 	*x = ResourceSelector{}
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[7]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -830,7 +994,7 @@ func (x *ResourceSelector) String() string {
 func (*ResourceSelector) ProtoMessage() {}
 
 func (x *ResourceSelector) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[7]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,7 +1007,7 @@ func (x *ResourceSelector) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceSelector.ProtoReflect.Descriptor instead.
 func (*ResourceSelector) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{7}
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ResourceSelector) GetApiVersion() string {
@@ -920,7 +1084,7 @@ type MatchLabels struct {
 
 func (x *MatchLabels) Reset() {
 	*x = MatchLabels{}
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[8]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -932,7 +1096,7 @@ func (x *MatchLabels) String() string {
 func (*MatchLabels) ProtoMessage() {}
 
 func (x *MatchLabels) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[8]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -945,7 +1109,7 @@ func (x *MatchLabels) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchLabels.ProtoReflect.Descriptor instead.
 func (*MatchLabels) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{8}
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *MatchLabels) GetLabels() map[string]string {
@@ -971,7 +1135,7 @@ type ResponseMeta struct {
 
 func (x *ResponseMeta) Reset() {
 	*x = ResponseMeta{}
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[9]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -983,7 +1147,7 @@ func (x *ResponseMeta) String() string {
 func (*ResponseMeta) ProtoMessage() {}
 
 func (x *ResponseMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[9]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -996,7 +1160,7 @@ func (x *ResponseMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseMeta.ProtoReflect.Descriptor instead.
 func (*ResponseMeta) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{9}
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ResponseMeta) GetTag() string {
@@ -1028,7 +1192,7 @@ type State struct {
 
 func (x *State) Reset() {
 	*x = State{}
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[10]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1040,7 +1204,7 @@ func (x *State) String() string {
 func (*State) ProtoMessage() {}
 
 func (x *State) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[10]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1053,7 +1217,7 @@ func (x *State) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use State.ProtoReflect.Descriptor instead.
 func (*State) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{10}
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *State) GetComposite() *Resource {
@@ -1124,7 +1288,7 @@ type Resource struct {
 
 func (x *Resource) Reset() {
 	*x = Resource{}
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[11]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1136,7 +1300,7 @@ func (x *Resource) String() string {
 func (*Resource) ProtoMessage() {}
 
 func (x *Resource) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[11]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1149,7 +1313,7 @@ func (x *Resource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Resource.ProtoReflect.Descriptor instead.
 func (*Resource) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{11}
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Resource) GetResource() *structpb.Struct {
@@ -1191,7 +1355,7 @@ type Result struct {
 
 func (x *Result) Reset() {
 	*x = Result{}
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[12]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1203,7 +1367,7 @@ func (x *Result) String() string {
 func (*Result) ProtoMessage() {}
 
 func (x *Result) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[12]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1216,7 +1380,7 @@ func (x *Result) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Result.ProtoReflect.Descriptor instead.
 func (*Result) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{12}
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Result) GetSeverity() Severity {
@@ -1274,7 +1438,7 @@ type Condition struct {
 
 func (x *Condition) Reset() {
 	*x = Condition{}
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[13]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1286,7 +1450,7 @@ func (x *Condition) String() string {
 func (*Condition) ProtoMessage() {}
 
 func (x *Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[13]
+	mi := &file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1299,7 +1463,7 @@ func (x *Condition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Condition.ProtoReflect.Descriptor instead.
 func (*Condition) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{13}
+	return file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Condition) GetType() string {
@@ -1385,7 +1549,20 @@ const file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDesc = "" +
 	"\x06output\x18\a \x01(\v2\x17.google.protobuf.StructH\x01R\x06output\x88\x01\x01B\n" +
 	"\n" +
 	"\b_contextB\t\n" +
-	"\a_output\"\x1f\n" +
+	"\a_output\"\xbc\x01\n" +
+	"\x19RunFunctionStreamResponse\x12F\n" +
+	"\bprogress\x18\x01 \x01(\v2(.apiextensions.fn.proto.v1beta1.ProgressH\x00R\bprogress\x12K\n" +
+	"\x05final\x18\x02 \x01(\v23.apiextensions.fn.proto.v1beta1.RunFunctionResponseH\x00R\x05finalB\n" +
+	"\n" +
+	"\bresponse\"\xf6\x01\n" +
+	"\bProgress\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12.\n" +
+	"\x10percent_complete\x18\x02 \x01(\x05H\x00R\x0fpercentComplete\x88\x01\x01\x12@\n" +
+	"\aresults\x18\x03 \x03(\v2&.apiextensions.fn.proto.v1beta1.ResultR\aresults\x12I\n" +
+	"\n" +
+	"conditions\x18\x04 \x03(\v2).apiextensions.fn.proto.v1beta1.ConditionR\n" +
+	"conditionsB\x13\n" +
+	"\x11_percent_complete\"\x1f\n" +
 	"\vRequestMeta\x12\x10\n" +
 	"\x03tag\x18\x01 \x01(\tR\x03tag\"\xbd\x03\n" +
 	"\fRequirements\x12m\n" +
@@ -1464,9 +1641,10 @@ const file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDesc = "" +
 	"\x1cSTATUS_CONDITION_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18STATUS_CONDITION_UNKNOWN\x10\x01\x12\x19\n" +
 	"\x15STATUS_CONDITION_TRUE\x10\x02\x12\x1a\n" +
-	"\x16STATUS_CONDITION_FALSE\x10\x032\x91\x01\n" +
+	"\x16STATUS_CONDITION_FALSE\x10\x032\x9a\x02\n" +
 	"\x15FunctionRunnerService\x12x\n" +
-	"\vRunFunction\x122.apiextensions.fn.proto.v1beta1.RunFunctionRequest\x1a3.apiextensions.fn.proto.v1beta1.RunFunctionResponse\"\x00B6Z4github.com/crossplane/crossplane/v2/proto/fn/v1beta1b\x06proto3"
+	"\vRunFunction\x122.apiextensions.fn.proto.v1beta1.RunFunctionRequest\x1a3.apiextensions.fn.proto.v1beta1.RunFunctionResponse\"\x00\x12\x86\x01\n" +
+	"\x11RunFunctionStream\x122.apiextensions.fn.proto.v1beta1.RunFunctionRequest\x1a9.apiextensions.fn.proto.v1beta1.RunFunctionStreamResponse\"\x000\x01B6Z4github.com/crossplane/crossplane/v2/proto/fn/v1beta1b\x06proto3"
 
 var (
 	file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescOnce sync.Once
@@ -1481,84 +1659,92 @@ func file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDescGZIP() []byte
 }
 
 var file_proto_fn_v1beta1_zz_generated_run_function_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_proto_fn_v1beta1_zz_generated_run_function_proto_goTypes = []any{
-	(Ready)(0),                  // 0: apiextensions.fn.proto.v1beta1.Ready
-	(Severity)(0),               // 1: apiextensions.fn.proto.v1beta1.Severity
-	(Target)(0),                 // 2: apiextensions.fn.proto.v1beta1.Target
-	(Status)(0),                 // 3: apiextensions.fn.proto.v1beta1.Status
-	(*RunFunctionRequest)(nil),  // 4: apiextensions.fn.proto.v1beta1.RunFunctionRequest
-	(*Credentials)(nil),         // 5: apiextensions.fn.proto.v1beta1.Credentials
-	(*CredentialData)(nil),      // 6: apiextensions.fn.proto.v1beta1.CredentialData
-	(*Resources)(nil),           // 7: apiextensions.fn.proto.v1beta1.Resources
-	(*RunFunctionResponse)(nil), // 8: apiextensions.fn.proto.v1beta1.RunFunctionResponse
-	(*RequestMeta)(nil),         // 9: apiextensions.fn.proto.v1beta1.RequestMeta
-	(*Requirements)(nil),        // 10: apiextensions.fn.proto.v1beta1.Requirements
-	(*ResourceSelector)(nil),    // 11: apiextensions.fn.proto.v1beta1.ResourceSelector
-	(*MatchLabels)(nil),         // 12: apiextensions.fn.proto.v1beta1.MatchLabels
-	(*ResponseMeta)(nil),        // 13: apiextensions.fn.proto.v1beta1.ResponseMeta
-	(*State)(nil),               // 14: apiextensions.fn.proto.v1beta1.State
-	(*Resource)(nil),            // 15: apiextensions.fn.proto.v1beta1.Resource
-	(*Result)(nil),              // 16: apiextensions.fn.proto.v1beta1.Result
-	(*Condition)(nil),           // 17: apiextensions.fn.proto.v1beta1.Condition
-	nil,                         // 18: apiextensions.fn.proto.v1beta1.RunFunctionRequest.ExtraResourcesEntry
-	nil,                         // 19: apiextensions.fn.proto.v1beta1.RunFunctionRequest.CredentialsEntry
-	nil,                         // 20: apiextensions.fn.proto.v1beta1.RunFunctionRequest.RequiredResourcesEntry
-	nil,                         // 21: apiextensions.fn.proto.v1beta1.CredentialData.DataEntry
-	nil,                         // 22: apiextensions.fn.proto.v1beta1.Requirements.ExtraResourcesEntry
-	nil,                         // 23: apiextensions.fn.proto.v1beta1.Requirements.ResourcesEntry
-	nil,                         // 24: apiextensions.fn.proto.v1beta1.MatchLabels.LabelsEntry
-	nil,                         // 25: apiextensions.fn.proto.v1beta1.State.ResourcesEntry
-	nil,                         // 26: apiextensions.fn.proto.v1beta1.Resource.ConnectionDetailsEntry
-	(*structpb.Struct)(nil),     // 27: google.protobuf.Struct
-	(*durationpb.Duration)(nil), // 28: google.protobuf.Duration
+	(Ready)(0),                        // 0: apiextensions.fn.proto.v1beta1.Ready
+	(Severity)(0),                     // 1: apiextensions.fn.proto.v1beta1.Severity
+	(Target)(0),                       // 2: apiextensions.fn.proto.v1beta1.Target
+	(Status)(0),                       // 3: apiextensions.fn.proto.v1beta1.Status
+	(*RunFunctionRequest)(nil),        // 4: apiextensions.fn.proto.v1beta1.RunFunctionRequest
+	(*Credentials)(nil),               // 5: apiextensions.fn.proto.v1beta1.Credentials
+	(*CredentialData)(nil),            // 6: apiextensions.fn.proto.v1beta1.CredentialData
+	(*Resources)(nil),                 // 7: apiextensions.fn.proto.v1beta1.Resources
+	(*RunFunctionResponse)(nil),       // 8: apiextensions.fn.proto.v1beta1.RunFunctionResponse
+	(*RunFunctionStreamResponse)(nil), // 9: apiextensions.fn.proto.v1beta1.RunFunctionStreamResponse
+	(*Progress)(nil),                  // 10: apiextensions.fn.proto.v1beta1.Progress
+	(*RequestMeta)(nil),               // 11: apiextensions.fn.proto.v1beta1.RequestMeta
+	(*Requirements)(nil),              // 12: apiextensions.fn.proto.v1beta1.Requirements
+	(*ResourceSelector)(nil),          // 13: apiextensions.fn.proto.v1beta1.ResourceSelector
+	(*MatchLabels)(nil),               // 14: apiextensions.fn.proto.v1beta1.MatchLabels
+	(*ResponseMeta)(nil),              // 15: apiextensions.fn.proto.v1beta1.ResponseMeta
+	(*State)(nil),                     // 16: apiextensions.fn.proto.v1beta1.State
+	(*Resource)(nil),                  // 17: apiextensions.fn.proto.v1beta1.Resource
+	(*Result)(nil),                    // 18: apiextensions.fn.proto.v1beta1.Result
+	(*Condition)(nil),                 // 19: apiextensions.fn.proto.v1beta1.Condition
+	nil,                               // 20: apiextensions.fn.proto.v1beta1.RunFunctionRequest.ExtraResourcesEntry
+	nil,                               // 21: apiextensions.fn.proto.v1beta1.RunFunctionRequest.CredentialsEntry
+	nil,                               // 22: apiextensions.fn.proto.v1beta1.RunFunctionRequest.RequiredResourcesEntry
+	nil,                               // 23: apiextensions.fn.proto.v1beta1.CredentialData.DataEntry
+	nil,                               // 24: apiextensions.fn.proto.v1beta1.Requirements.ExtraResourcesEntry
+	nil,                               // 25: apiextensions.fn.proto.v1beta1.Requirements.ResourcesEntry
+	nil,                               // 26: apiextensions.fn.proto.v1beta1.MatchLabels.LabelsEntry
+	nil,                               // 27: apiextensions.fn.proto.v1beta1.State.ResourcesEntry
+	nil,                               // 28: apiextensions.fn.proto.v1beta1.Resource.ConnectionDetailsEntry
+	(*structpb.Struct)(nil),           // 29: google.protobuf.Struct
+	(*durationpb.Duration)(nil),       // 30: google.protobuf.Duration
 }
 var file_proto_fn_v1beta1_zz_generated_run_function_proto_depIdxs = []int32{
-	9,  // 0: apiextensions.fn.proto.v1beta1.RunFunctionRequest.meta:type_name -> apiextensions.fn.proto.v1beta1.RequestMeta
-	14, // 1: apiextensions.fn.proto.v1beta1.RunFunctionRequest.observed:type_name -> apiextensions.fn.proto.v1beta1.State
-	14, // 2: apiextensions.fn.proto.v1beta1.RunFunctionRequest.desired:type_name -> apiextensions.fn.proto.v1beta1.State
-	27, // 3: apiextensions.fn.proto.v1beta1.RunFunctionRequest.input:type_name -> google.protobuf.Struct
-	27, // 4: apiextensions.fn.proto.v1beta1.RunFunctionRequest.context:type_name -> google.protobuf.Struct
-	18, // 5: apiextensions.fn.proto.v1beta1.RunFunctionRequest.extra_resources:type_name -> apiextensions.fn.proto.v1beta1.RunFunctionRequest.ExtraResourcesEntry
-	19, // 6: apiextensions.fn.proto.v1beta1.RunFunctionRequest.credentials:type_name -> apiextensions.fn.proto.v1beta1.RunFunctionRequest.CredentialsEntry
-	20, // 7: apiextensions.fn.proto.v1beta1.RunFunctionRequest.required_resources:type_name -> apiextensions.fn.proto.v1beta1.RunFunctionRequest.RequiredResourcesEntry
+	11, // 0: apiextensions.fn.proto.v1beta1.RunFunctionRequest.meta:type_name -> apiextensions.fn.proto.v1beta1.RequestMeta
+	16, // 1: apiextensions.fn.proto.v1beta1.RunFunctionRequest.observed:type_name -> apiextensions.fn.proto.v1beta1.State
+	16, // 2: apiextensions.fn.proto.v1beta1.RunFunctionRequest.desired:type_name -> apiextensions.fn.proto.v1beta1.State
+	29, // 3: apiextensions.fn.proto.v1beta1.RunFunctionRequest.input:type_name -> google.protobuf.Struct
+	29, // 4: apiextensions.fn.proto.v1beta1.RunFunctionRequest.context:type_name -> google.protobuf.Struct
+	20, // 5: apiextensions.fn.proto.v1beta1.RunFunctionRequest.extra_resources:type_name -> apiextensions.fn.proto.v1beta1.RunFunctionRequest.ExtraResourcesEntry
+	21, // 6: apiextensions.fn.proto.v1beta1.RunFunctionRequest.credentials:type_name -> apiextensions.fn.proto.v1beta1.RunFunctionRequest.CredentialsEntry
+	22, // 7: apiextensions.fn.proto.v1beta1.RunFunctionRequest.required_resources:type_name -> apiextensions.fn.proto.v1beta1.RunFunctionRequest.RequiredResourcesEntry
 	6,  // 8: apiextensions.fn.proto.v1beta1.Credentials.credential_data:type_name -> apiextensions.fn.proto.v1beta1.CredentialData
-	21, // 9: apiextensions.fn.proto.v1beta1.CredentialData.data:type_name -> apiextensions.fn.proto.v1beta1.CredentialData.DataEntry
-	15, // 10: apiextensions.fn.proto.v1beta1.Resources.items:type_name -> apiextensions.fn.proto.v1beta1.Resource
-	13, // 11: apiextensions.fn.proto.v1beta1.RunFunctionResponse.meta:type_name -> apiextensions.fn.proto.v1beta1.ResponseMeta
-	14, // 12: apiextensions.fn.proto.v1beta1.RunFunctionResponse.desired:type_name -> apiextensions.fn.proto.v1beta1.State
-	16, // 13: apiextensions.fn.proto.v1beta1.RunFunctionResponse.results:type_name -> apiextensions.fn.proto.v1beta1.Result
-	27, // 14: apiextensions.fn.proto.v1beta1.RunFunctionResponse.context:type_name -> google.protobuf.Struct
-	10, // 15: apiextensions.fn.proto.v1beta1.RunFunctionResponse.requirements:type_name -> apiextensions.fn.proto.v1beta1.Requirements
-	17, // 16: apiextensions.fn.proto.v1beta1.RunFunctionResponse.conditions:type_name -> apiextensions.fn.proto.v1beta1.Condition
-	27, // 17: apiextensions.fn.proto.v1beta1.RunFunctionResponse.output:type_name -> google.protobuf.Struct
-	22, // 18: apiextensions.fn.proto.v1beta1.Requirements.extra_resources:type_name -> apiextensions.fn.proto.v1beta1.Requirements.ExtraResourcesEntry
-	23, // 19: apiextensions.fn.proto.v1beta1.Requirements.resources:type_name -> apiextensions.fn.proto.v1beta1.Requirements.ResourcesEntry
-	12, // 20: apiextensions.fn.proto.v1beta1.ResourceSelector.match_labels:type_name -> apiextensions.fn.proto.v1beta1.MatchLabels
-	24, // 21: apiextensions.fn.proto.v1beta1.MatchLabels.labels:type_name -> apiextensions.fn.proto.v1beta1.MatchLabels.LabelsEntry
-	28, // 22: apiextensions.fn.proto.v1beta1.ResponseMeta.ttl:type_name -> google.protobuf.Duration
-	15, // 23: apiextensions.fn.proto.v1beta1.State.composite:type_name -> apiextensions.fn.proto.v1beta1.Resource
-	25, // 24: apiextensions.fn.proto.v1beta1.State.resources:type_name -> apiextensions.fn.proto.v1beta1.State.ResourcesEntry
-	27, // 25: apiextensions.fn.proto.v1beta1.Resource.resource:type_name -> google.protobuf.Struct
-	26, // 26: apiextensions.fn.proto.v1beta1.Resource.connection_details:type_name -> apiextensions.fn.proto.v1beta1.Resource.ConnectionDetailsEntry
-	0,  // 27: apiextensions.fn.proto.v1beta1.Resource.ready:type_name -> apiextensions.fn.proto.v1beta1.Ready
-	1,  // 28: apiextensions.fn.proto.v1beta1.Result.severity:type_name -> apiextensions.fn.proto.v1beta1.Severity
-	2,  // 29: apiextensions.fn.proto.v1beta1.Result.target:type_name -> apiextensions.fn.proto.v1beta1.Target
-	3,  // 30: apiextensions.fn.proto.v1beta1.Condition.status:type_name -> apiextensions.fn.proto.v1beta1.Status
-	2,  // 31: apiextensions.fn.proto.v1beta1.Condition.target:type_name -> apiextensions.fn.proto.v1beta1.Target
-	7,  // 32: apiextensions.fn.proto.v1beta1.RunFunctionRequest.ExtraResourcesEntry.value:type_name -> apiextensions.fn.proto.v1beta1.Resources
-	5,  // 33: apiextensions.fn.proto.v1beta1.RunFunctionRequest.CredentialsEntry.value:type_name -> apiextensions.fn.proto.v1beta1.Credentials
-	7,  // 34: apiextensions.fn.proto.v1beta1.RunFunctionRequest.RequiredResourcesEntry.value:type_name -> apiextensions.fn.proto.v1beta1.Resources
-	11, // 35: apiextensions.fn.proto.v1beta1.Requirements.ExtraResourcesEntry.value:type_name -> apiextensions.fn.proto.v1beta1.ResourceSelector
-	11, // 36: apiextensions.fn.proto.v1beta1.Requirements.ResourcesEntry.value:type_name -> apiextensions.fn.proto.v1beta1.ResourceSelector
-	15, // 37: apiextensions.fn.proto.v1beta1.State.ResourcesEntry.value:type_name -> apiextensions.fn.proto.v1beta1.Resource
-	4,  // 38: apiextensions.fn.proto.v1beta1.FunctionRunnerService.RunFunction:input_type -> apiextensions.fn.proto.v1beta1.RunFunctionRequest
-	8,  // 39: apiextensions.fn.proto.v1beta1.FunctionRunnerService.RunFunction:output_type -> apiextensions.fn.proto.v1beta1.RunFunctionResponse
-	39, // [39:40] is the sub-list for method output_type
-	38, // [38:39] is the sub-list for method input_type
-	38, // [38:38] is the sub-list for extension type_name
-	38, // [38:38] is the sub-list for extension extendee
-	0,  // [0:38] is the sub-list for field type_name
+	23, // 9: apiextensions.fn.proto.v1beta1.CredentialData.data:type_name -> apiextensions.fn.proto.v1beta1.CredentialData.DataEntry
+	17, // 10: apiextensions.fn.proto.v1beta1.Resources.items:type_name -> apiextensions.fn.proto.v1beta1.Resource
+	15, // 11: apiextensions.fn.proto.v1beta1.RunFunctionResponse.meta:type_name -> apiextensions.fn.proto.v1beta1.ResponseMeta
+	16, // 12: apiextensions.fn.proto.v1beta1.RunFunctionResponse.desired:type_name -> apiextensions.fn.proto.v1beta1.State
+	18, // 13: apiextensions.fn.proto.v1beta1.RunFunctionResponse.results:type_name -> apiextensions.fn.proto.v1beta1.Result
+	29, // 14: apiextensions.fn.proto.v1beta1.RunFunctionResponse.context:type_name -> google.protobuf.Struct
+	12, // 15: apiextensions.fn.proto.v1beta1.RunFunctionResponse.requirements:type_name -> apiextensions.fn.proto.v1beta1.Requirements
+	19, // 16: apiextensions.fn.proto.v1beta1.RunFunctionResponse.conditions:type_name -> apiextensions.fn.proto.v1beta1.Condition
+	29, // 17: apiextensions.fn.proto.v1beta1.RunFunctionResponse.output:type_name -> google.protobuf.Struct
+	10, // 18: apiextensions.fn.proto.v1beta1.RunFunctionStreamResponse.progress:type_name -> apiextensions.fn.proto.v1beta1.Progress
+	8,  // 19: apiextensions.fn.proto.v1beta1.RunFunctionStreamResponse.final:type_name -> apiextensions.fn.proto.v1beta1.RunFunctionResponse
+	18, // 20: apiextensions.fn.proto.v1beta1.Progress.results:type_name -> apiextensions.fn.proto.v1beta1.Result
+	19, // 21: apiextensions.fn.proto.v1beta1.Progress.conditions:type_name -> apiextensions.fn.proto.v1beta1.Condition
+	24, // 22: apiextensions.fn.proto.v1beta1.Requirements.extra_resources:type_name -> apiextensions.fn.proto.v1beta1.Requirements.ExtraResourcesEntry
+	25, // 23: apiextensions.fn.proto.v1beta1.Requirements.resources:type_name -> apiextensions.fn.proto.v1beta1.Requirements.ResourcesEntry
+	14, // 24: apiextensions.fn.proto.v1beta1.ResourceSelector.match_labels:type_name -> apiextensions.fn.proto.v1beta1.MatchLabels
+	26, // 25: apiextensions.fn.proto.v1beta1.MatchLabels.labels:type_name -> apiextensions.fn.proto.v1beta1.MatchLabels.LabelsEntry
+	30, // 26: apiextensions.fn.proto.v1beta1.ResponseMeta.ttl:type_name -> google.protobuf.Duration
+	17, // 27: apiextensions.fn.proto.v1beta1.State.composite:type_name -> apiextensions.fn.proto.v1beta1.Resource
+	27, // 28: apiextensions.fn.proto.v1beta1.State.resources:type_name -> apiextensions.fn.proto.v1beta1.State.ResourcesEntry
+	29, // 29: apiextensions.fn.proto.v1beta1.Resource.resource:type_name -> google.protobuf.Struct
+	28, // 30: apiextensions.fn.proto.v1beta1.Resource.connection_details:type_name -> apiextensions.fn.proto.v1beta1.Resource.ConnectionDetailsEntry
+	0,  // 31: apiextensions.fn.proto.v1beta1.Resource.ready:type_name -> apiextensions.fn.proto.v1beta1.Ready
+	1,  // 32: apiextensions.fn.proto.v1beta1.Result.severity:type_name -> apiextensions.fn.proto.v1beta1.Severity
+	2,  // 33: apiextensions.fn.proto.v1beta1.Result.target:type_name -> apiextensions.fn.proto.v1beta1.Target
+	3,  // 34: apiextensions.fn.proto.v1beta1.Condition.status:type_name -> apiextensions.fn.proto.v1beta1.Status
+	2,  // 35: apiextensions.fn.proto.v1beta1.Condition.target:type_name -> apiextensions.fn.proto.v1beta1.Target
+	7,  // 36: apiextensions.fn.proto.v1beta1.RunFunctionRequest.ExtraResourcesEntry.value:type_name -> apiextensions.fn.proto.v1beta1.Resources
+	5,  // 37: apiextensions.fn.proto.v1beta1.RunFunctionRequest.CredentialsEntry.value:type_name -> apiextensions.fn.proto.v1beta1.Credentials
+	7,  // 38: apiextensions.fn.proto.v1beta1.RunFunctionRequest.RequiredResourcesEntry.value:type_name -> apiextensions.fn.proto.v1beta1.Resources
+	13, // 39: apiextensions.fn.proto.v1beta1.Requirements.ExtraResourcesEntry.value:type_name -> apiextensions.fn.proto.v1beta1.ResourceSelector
+	13, // 40: apiextensions.fn.proto.v1beta1.Requirements.ResourcesEntry.value:type_name -> apiextensions.fn.proto.v1beta1.ResourceSelector
+	17, // 41: apiextensions.fn.proto.v1beta1.State.ResourcesEntry.value:type_name -> apiextensions.fn.proto.v1beta1.Resource
+	4,  // 42: apiextensions.fn.proto.v1beta1.FunctionRunnerService.RunFunction:input_type -> apiextensions.fn.proto.v1beta1.RunFunctionRequest
+	4,  // 43: apiextensions.fn.proto.v1beta1.FunctionRunnerService.RunFunctionStream:input_type -> apiextensions.fn.proto.v1beta1.RunFunctionRequest
+	8,  // 44: apiextensions.fn.proto.v1beta1.FunctionRunnerService.RunFunction:output_type -> apiextensions.fn.proto.v1beta1.RunFunctionResponse
+	9,  // 45: apiextensions.fn.proto.v1beta1.FunctionRunnerService.RunFunctionStream:output_type -> apiextensions.fn.proto.v1beta1.RunFunctionStreamResponse
+	44, // [44:46] is the sub-list for method output_type
+	42, // [42:44] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_proto_fn_v1beta1_zz_generated_run_function_proto_init() }
@@ -1571,20 +1757,25 @@ func file_proto_fn_v1beta1_zz_generated_run_function_proto_init() {
 		(*Credentials_CredentialData)(nil),
 	}
 	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[4].OneofWrappers = []any{}
-	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[7].OneofWrappers = []any{
+	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[5].OneofWrappers = []any{
+		(*RunFunctionStreamResponse_Progress)(nil),
+		(*RunFunctionStreamResponse_Final)(nil),
+	}
+	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[9].OneofWrappers = []any{
 		(*ResourceSelector_MatchName)(nil),
 		(*ResourceSelector_MatchLabels)(nil),
 	}
-	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[9].OneofWrappers = []any{}
-	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[12].OneofWrappers = []any{}
-	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[11].OneofWrappers = []any{}
+	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[14].OneofWrappers = []any{}
+	file_proto_fn_v1beta1_zz_generated_run_function_proto_msgTypes[15].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDesc), len(file_proto_fn_v1beta1_zz_generated_run_function_proto_rawDesc)),
 			NumEnums:      4,
-			NumMessages:   23,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
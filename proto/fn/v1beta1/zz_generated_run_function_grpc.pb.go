@@ -43,7 +43,8 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	FunctionRunnerService_RunFunction_FullMethodName = "/apiextensions.fn.proto.v1beta1.FunctionRunnerService/RunFunction"
+	FunctionRunnerService_RunFunction_FullMethodName       = "/apiextensions.fn.proto.v1beta1.FunctionRunnerService/RunFunction"
+	FunctionRunnerService_RunFunctionStream_FullMethodName = "/apiextensions.fn.proto.v1beta1.FunctionRunnerService/RunFunctionStream"
 )
 
 // FunctionRunnerServiceClient is the client API for FunctionRunnerService service.
@@ -52,6 +53,11 @@ const (
 type FunctionRunnerServiceClient interface {
 	// RunFunction runs the function.
 	RunFunction(ctx context.Context, in *RunFunctionRequest, opts ...grpc.CallOption) (*RunFunctionResponse, error)
+	// RunFunctionStream runs the function, streaming progress updates while it
+	// runs. The last message in the stream must be the final response. Only
+	// functions with the 'streaming' capability need implement this RPC.
+	// Crossplane falls back to RunFunction if it's unimplemented.
+	RunFunctionStream(ctx context.Context, in *RunFunctionRequest, opts ...grpc.CallOption) (FunctionRunnerService_RunFunctionStreamClient, error)
 }
 
 type functionRunnerServiceClient struct {
@@ -71,12 +77,49 @@ func (c *functionRunnerServiceClient) RunFunction(ctx context.Context, in *RunFu
 	return out, nil
 }
 
+func (c *functionRunnerServiceClient) RunFunctionStream(ctx context.Context, in *RunFunctionRequest, opts ...grpc.CallOption) (FunctionRunnerService_RunFunctionStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FunctionRunnerService_ServiceDesc.Streams[0], FunctionRunnerService_RunFunctionStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &functionRunnerServiceRunFunctionStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FunctionRunnerService_RunFunctionStreamClient interface {
+	Recv() (*RunFunctionStreamResponse, error)
+	grpc.ClientStream
+}
+
+type functionRunnerServiceRunFunctionStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *functionRunnerServiceRunFunctionStreamClient) Recv() (*RunFunctionStreamResponse, error) {
+	m := new(RunFunctionStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // FunctionRunnerServiceServer is the server API for FunctionRunnerService service.
 // All implementations must embed UnimplementedFunctionRunnerServiceServer
 // for forward compatibility
 type FunctionRunnerServiceServer interface {
 	// RunFunction runs the function.
 	RunFunction(context.Context, *RunFunctionRequest) (*RunFunctionResponse, error)
+	// RunFunctionStream runs the function, streaming progress updates while it
+	// runs. The last message in the stream must be the final response. Only
+	// functions with the 'streaming' capability need implement this RPC.
+	// Crossplane falls back to RunFunction if it's unimplemented.
+	RunFunctionStream(*RunFunctionRequest, FunctionRunnerService_RunFunctionStreamServer) error
 	mustEmbedUnimplementedFunctionRunnerServiceServer()
 }
 
@@ -87,6 +130,9 @@ type UnimplementedFunctionRunnerServiceServer struct {
 func (UnimplementedFunctionRunnerServiceServer) RunFunction(context.Context, *RunFunctionRequest) (*RunFunctionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RunFunction not implemented")
 }
+func (UnimplementedFunctionRunnerServiceServer) RunFunctionStream(*RunFunctionRequest, FunctionRunnerService_RunFunctionStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunFunctionStream not implemented")
+}
 func (UnimplementedFunctionRunnerServiceServer) mustEmbedUnimplementedFunctionRunnerServiceServer() {}
 
 // UnsafeFunctionRunnerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -118,6 +164,27 @@ func _FunctionRunnerService_RunFunction_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FunctionRunnerService_RunFunctionStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunFunctionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FunctionRunnerServiceServer).RunFunctionStream(m, &functionRunnerServiceRunFunctionStreamServer{stream})
+}
+
+type FunctionRunnerService_RunFunctionStreamServer interface {
+	Send(*RunFunctionStreamResponse) error
+	grpc.ServerStream
+}
+
+type functionRunnerServiceRunFunctionStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *functionRunnerServiceRunFunctionStreamServer) Send(m *RunFunctionStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // FunctionRunnerService_ServiceDesc is the grpc.ServiceDesc for FunctionRunnerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -130,6 +197,12 @@ var FunctionRunnerService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _FunctionRunnerService_RunFunction_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunFunctionStream",
+			Handler:       _FunctionRunnerService_RunFunctionStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "proto/fn/v1beta1/zz_generated_run_function.proto",
 }